@@ -7,6 +7,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/signal"
 	"syscall"
@@ -50,6 +51,11 @@ func main() {
 	case err := <-done:
 		if err != nil {
 			log.Printf("CLI execution failed: %v", err)
+			// Commands such as scan report their worst finding through the exit code
+			var exitErr *cli.ExitCodeError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.Code)
+			}
 			os.Exit(1)
 		}
 		// CLI completed successfully
@@ -37,6 +37,11 @@
 //   - batch_resolve_cert_chain: Resolve multiple certificates in a single call
 //   - fetch_remote_cert: Retrieve chains directly from TLS endpoints
 //   - visualize_cert_chain: Visualize certificate chains in ASCII tree, table, or JSON formats
+//   - inspect_csr: Inspect a certificate signing request and run pre-issuance checks
+//   - inspect_certificate: Fully decode certificates like openssl x509 -text, as text or JSON
+//   - lint_certificate: Lint certificates offline against the CA/Browser Forum Baseline Requirements
+//   - diff_cert_chains: Compare two certificates or chains and report per-field differences
+//   - scan_certificate_inventory: Inventory the certificates under directories and Kubernetes secret manifests
 //   - analyze_certificate_with_ai: Delegate structured certificate analysis to a configured LLM
 //   - get_resource_usage: Monitor server resource usage (memory, GC, system info)
 //
@@ -0,0 +1,132 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ctHost       string // Remote host to connect to for TLS-delivered SCTs
+	ctPort       int    // Remote port
+	ctLogList    string // Path to the CT log list SCTs are verified against
+	ctJSONFormat bool   // JSON output for the ct command
+)
+
+var (
+	// ErrCTNotCompliant is returned when the leaf does not satisfy every browser CT policy.
+	ErrCTNotCompliant = errors.New("certificate does not satisfy CT policy")
+)
+
+// ctRemoteTimeout bounds the TLS handshake when reading SCTs from a server.
+const ctRemoteTimeout = 10 * time.Second
+
+// newCTCmd creates the ct subcommand.
+//
+// The command verifies the Signed Certificate Timestamps of a leaf, either
+// embedded in a certificate file or, with --host, embedded in the served
+// certificate and delivered in the TLS handshake. Each SCT is checked against
+// the CT log list given with --log-list, and the represented logs and operators are compared with the
+// Chrome and Apple CT policies.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured ct command
+func newCTCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ct [CERT_FILE]",
+		Short: "Verify Certificate Transparency SCTs against a log list and browser CT policy",
+		Example: fmt.Sprintf(`  %s ct test-leaf.cer --log-list log_list.json
+  %s ct --host example.com --log-list log_list.json --json`, exeName, exeName),
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if (len(args) == 1) == (ctHost != "") {
+				return errors.New("specify either CERT_FILE or --host")
+			}
+			path := ""
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return execCT(ctx, path, cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().StringVar(&ctHost, "host", "", "read SCTs from a TLS server instead of a file")
+	cmd.Flags().IntVar(&ctPort, "port", 443, "port of the TLS server")
+	cmd.Flags().StringVar(&ctLogList, "log-list", "", "CT log list in the published v3 JSON format (download it with \"make update-ct-log-list\")")
+	cmd.Flags().BoolVarP(&ctJSONFormat, "json", "j", false, "output the report in JSON format")
+	cmd.MarkFlagRequired("log-list")
+
+	return cmd
+}
+
+// execCT resolves the chain of the certificate at path, or of the --host
+// server, and evaluates its SCTs.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - path: Path to a PEM or DER encoded certificate; empty when reading from --host
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Log list, reading, decoding, connection, chain resolution, or output error, or ErrCTNotCompliant
+func execCT(ctx context.Context, path, version string) error {
+	logs, err := x509chain.LoadCTLogList(ctLogList)
+	if err != nil {
+		return err
+	}
+
+	var chain *x509chain.Chain
+	if ctHost != "" {
+		chain, _, err = x509chain.FetchRemoteChain(ctx, ctHost, ctPort, ctRemoteTimeout, version)
+		if err != nil {
+			return err
+		}
+	} else {
+		certData, err := readCertificateFile(path)
+		if err != nil {
+			return err
+		}
+
+		cert, err := decodeCertificate(certData, x509certs.New())
+		if err != nil {
+			return err
+		}
+
+		if chain, err = fetchCertificateChain(ctx, cert, version); err != nil {
+			return err
+		}
+	}
+
+	report := chain.EvaluateCT(logs)
+
+	if ctJSONFormat {
+		outputData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	} else {
+		fmt.Print(report.RenderText())
+	}
+
+	if !report.Compliant {
+		return ErrCTNotCompliant
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_CT(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, _ := writeSelfSignedKeyPair(t, tmpDir)
+	logListPath := filepath.Join(tmpDir, "log_list.json")
+	require.NoError(t, os.WriteFile(logListPath, []byte(`{"version": "test", "operators": []}`), 0644))
+
+	t.Run("No SCTs", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "ct", certPath, "--log-list", logListPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrCTNotCompliant)
+		assert.Contains(t, output, "Subject: CN=bundle.example.com")
+		assert.Contains(t, output, "Signed Certificate Timestamps:\n  None")
+		assert.Contains(t, output, "[FAIL] Chrome:")
+		assert.Contains(t, output, "Result: NOT COMPLIANT")
+	})
+
+	t.Run("JSON Output", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "ct", certPath, "--log-list", logListPath, "--json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrCTNotCompliant)
+
+		var report x509chain.CTReport
+		require.NoError(t, json.Unmarshal([]byte(output), &report))
+		assert.False(t, report.Compliant)
+		assert.Empty(t, report.SCTs)
+		require.Len(t, report.Policies, 2)
+	})
+
+	t.Run("Missing Log List", func(t *testing.T) {
+		// No log list is bundled, so none is assumed
+		os.Args = []string{"cmd", "ct", certPath}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), `required flag(s) "log-list" not set`)
+	})
+
+	t.Run("Invalid Log List", func(t *testing.T) {
+		bad := filepath.Join(tmpDir, "bad.json")
+		require.NoError(t, os.WriteFile(bad, []byte("not json"), 0644))
+		os.Args = []string{"cmd", "ct", certPath, "--log-list", bad}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509chain.ErrInvalidCTLogList)
+	})
+
+	t.Run("File And Host", func(t *testing.T) {
+		os.Args = []string{"cmd", "ct", certPath, "--host", "example.com", "--log-list", logListPath}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), "specify either CERT_FILE or --host")
+
+		os.Args = []string{"cmd", "ct", "--log-list", logListPath}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), "specify either CERT_FILE or --host")
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	diffJSONFormat bool // JSON output for the diff command
+)
+
+// diffResolveTimeout bounds the TLS handshake and each AIA download when resolving a diff input.
+const diffResolveTimeout = 10 * time.Second
+
+// newDiffCmd creates the diff subcommand.
+//
+// The command resolves two chains, each from a certificate file, base64
+// data, or a host:port address, aligns them, and reports per-field
+// differences such as a new issuer, a different intermediate, added or
+// removed SANs, a key algorithm change, or a new validity period.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured diff command
+func newDiffCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "diff OLD NEW",
+		Short: "Compare two certificates or chains and report per-field differences",
+		Example: fmt.Sprintf(`  %s diff current.pem renewed.pem
+  %s diff example.com:443 renewed.pem --json`, exeName, exeName),
+		Args:         cobra.ExactArgs(2),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execDiff(ctx, args[0], args[1], cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&diffJSONFormat, "json", "j", false, "output the diff in JSON format")
+
+	return cmd
+}
+
+// execDiff resolves both inputs and prints their diff.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - oldInput: File path, base64 data, or host:port of the old chain
+//   - newInput: File path, base64 data, or host:port of the new chain
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Input, connection, chain resolution, or output error
+func execDiff(ctx context.Context, oldInput, newInput, version string) error {
+	oldChain, err := x509chain.ResolveInput(ctx, oldInput, diffResolveTimeout, version)
+	if err != nil {
+		return fmt.Errorf("error resolving old chain: %w", err)
+	}
+	newChain, err := x509chain.ResolveInput(ctx, newInput, diffResolveTimeout, version)
+	if err != nil {
+		return fmt.Errorf("error resolving new chain: %w", err)
+	}
+
+	diff := x509chain.DiffChains(oldChain, newChain)
+
+	if diffJSONFormat {
+		outputData, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	} else {
+		fmt.Print(diff.RenderText())
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Diff(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	oldPath, _ := writeSelfSignedKeyPair(t, t.TempDir())
+	newPath, _ := writeSelfSignedKeyPair(t, t.TempDir())
+
+	t.Run("Identical", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "diff", oldPath, oldPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "[unchanged]")
+		assert.Contains(t, output, "Result: IDENTICAL")
+	})
+
+	t.Run("Renewed Key", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "diff", oldPath, newPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "[changed]")
+		assert.Contains(t, output, "~ SPKI SHA-256:")
+		assert.Contains(t, output, "Result: 1 of 1 certificates differ")
+	})
+
+	t.Run("JSON Output With Base64 Input", func(t *testing.T) {
+		data, err := os.ReadFile(newPath)
+		require.NoError(t, err)
+		block, _ := pem.Decode(data)
+		require.NotNil(t, block)
+
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "diff", oldPath, base64.StdEncoding.EncodeToString(block.Bytes), "--json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+
+		var diff x509chain.ChainDiff
+		require.NoError(t, json.Unmarshal([]byte(output), &diff))
+		assert.False(t, diff.Identical)
+		require.Len(t, diff.Certificates, 1)
+		assert.Equal(t, x509chain.DiffChanged, diff.Certificates[0].Status)
+		assert.NotEmpty(t, diff.Certificates[0].Changes)
+	})
+
+	t.Run("Invalid Input", func(t *testing.T) {
+		os.Args = []string{"cmd", "diff", oldPath, "not a certificate!"}
+		err := cli.Execute(context.Background(), version, log)
+		assert.ErrorIs(t, err, x509chain.ErrInvalidInput)
+		assert.ErrorContains(t, err, "error resolving new chain")
+	})
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/spf13/cobra"
+)
+
+var csrJSONFormat bool // JSON output for the inspect-csr command
+
+var (
+	// ErrCSRChecksFailed is returned when a certificate request has at least one error-severity finding.
+	ErrCSRChecksFailed = errors.New("certificate request failed pre-issuance checks")
+)
+
+// newInspectCSRCmd creates the inspect-csr subcommand.
+//
+// The command decodes a PKCS#10 certificate request, prints its subject, SANs,
+// key and signature details together with the pre-issuance findings, and
+// exits non-zero when any finding has error severity so it can gate CI jobs.
+//
+// Parameters:
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured inspect-csr command
+func newInspectCSRCmd(exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inspect-csr CSR_FILE",
+		Short: "Inspect a certificate signing request and run pre-issuance checks",
+		Example: fmt.Sprintf(`  %s inspect-csr request.csr
+  %s inspect-csr request.csr --json`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execInspectCSR(args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&csrJSONFormat, "json", "j", false, "output the report in JSON format")
+
+	return cmd
+}
+
+// execInspectCSR decodes and inspects the certificate request at path.
+//
+// Parameters:
+//   - path: Path to a PEM or DER encoded certificate request
+//
+// Returns:
+//   - error: Reading, decoding, or output error, or ErrCSRChecksFailed
+func execInspectCSR(path string) error {
+	data, err := readCertificateFile(path)
+	if err != nil {
+		return err
+	}
+
+	certManager := x509certs.New()
+	csr, err := certManager.DecodeCSR(data)
+	if err != nil {
+		return fmt.Errorf("error decoding certificate request (%d bytes): %w", len(data), err)
+	}
+
+	report := certManager.InspectCSR(csr)
+
+	if csrJSONFormat {
+		outputData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	} else {
+		fmt.Print(formatCSRReport(report))
+	}
+
+	if !report.Passed {
+		return ErrCSRChecksFailed
+	}
+	return nil
+}
+
+// formatCSRReport renders a CSR report as human-readable text.
+//
+// Parameters:
+//   - report: Report produced by [x509certs.Certificate.InspectCSR]
+//
+// Returns:
+//   - string: Multi-line text report
+func formatCSRReport(report *x509certs.CSRReport) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Subject:             %s\n", report.Subject)
+	writeList := func(label string, values []string) {
+		if len(values) > 0 {
+			fmt.Fprintf(&b, "%-21s%s\n", label+":", strings.Join(values, ", "))
+		}
+	}
+	writeList("DNS Names", report.DNSNames)
+	writeList("IP Addresses", report.IPAddresses)
+	writeList("Email Addresses", report.EmailAddresses)
+	writeList("URIs", report.URIs)
+	fmt.Fprintf(&b, "Public Key:          %s (%d bits, %d-bit security)\n", report.PublicKeyAlgorithm, report.KeyBits, report.SecurityBits)
+	fmt.Fprintf(&b, "Signature Algorithm: %s\n", report.SignatureAlgorithm)
+	fmt.Fprintf(&b, "Signature Valid:     %t\n", report.SignatureValid)
+
+	b.WriteString("\nChecks:\n")
+	if len(report.Findings) == 0 {
+		b.WriteString("  All checks passed.\n")
+	}
+	for _, f := range report.Findings {
+		fmt.Fprintf(&b, "  [%s] %s: %s\n", strings.ToUpper(string(f.Severity)), f.Check, f.Message)
+	}
+
+	result := "PASSED"
+	if !report.Passed {
+		result = "FAILED"
+	}
+	fmt.Fprintf(&b, "\nResult: %s\n", result)
+
+	return b.String()
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCSR writes a PEM encoded certificate request signed by key into dir.
+func writeCSR(t *testing.T, dir string, key crypto.Signer, tmpl *x509.CertificateRequest) string {
+	t.Helper()
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "request.csr")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}), 0644))
+	return path
+}
+
+// captureStdout runs fn while capturing everything written to os.Stdout.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	oldStdout := os.Stdout
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	os.Stdout = w
+
+	outputChan := make(chan string, 1)
+	go func() {
+		var buf strings.Builder
+		io.Copy(&buf, r)
+		outputChan <- buf.String()
+	}()
+
+	fn()
+
+	w.Close()
+	os.Stdout = oldStdout
+	output := <-outputChan
+	r.Close()
+	return output
+}
+
+func TestExecute_InspectCSR(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	t.Run("Passing Request", func(t *testing.T) {
+		path := writeCSR(t, t.TempDir(), ecKey, &x509.CertificateRequest{
+			Subject:  pkix.Name{CommonName: "www.example.com"},
+			DNSNames: []string{"www.example.com"},
+		})
+		os.Args = []string{"cmd", "inspect-csr", path}
+
+		var execErr error
+		output := captureStdout(t, func() { execErr = cli.Execute(context.Background(), version, log) })
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "www.example.com")
+		assert.Contains(t, output, "ECDSA P-256 (256 bits, 128-bit security)")
+		assert.Contains(t, output, "Result: PASSED")
+	})
+
+	t.Run("Failing Request JSON", func(t *testing.T) {
+		path := writeCSR(t, t.TempDir(), weakKey, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: "example.com"},
+		})
+		os.Args = []string{"cmd", "inspect-csr", path, "--json"}
+
+		var execErr error
+		output := captureStdout(t, func() { execErr = cli.Execute(context.Background(), version, log) })
+		assert.ErrorIs(t, execErr, cli.ErrCSRChecksFailed)
+
+		var report x509certs.CSRReport
+		require.NoError(t, json.Unmarshal([]byte(output), &report))
+		assert.False(t, report.Passed)
+		assert.Equal(t, 1024, report.KeyBits)
+
+		var checks []string
+		for _, f := range report.Findings {
+			checks = append(checks, f.Check)
+		}
+		assert.Equal(t, []string{x509certs.CheckKeySize, x509certs.CheckSANPresent}, checks)
+	})
+
+	t.Run("Missing Argument", func(t *testing.T) {
+		os.Args = []string{"cmd", "inspect-csr"}
+		assert.Error(t, cli.Execute(context.Background(), version, log))
+	})
+
+	t.Run("Not A Request", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "leaf.cer")
+		require.NoError(t, os.WriteFile(path, []byte(testCertPEM), 0644))
+		os.Args = []string{"cmd", "inspect-csr", path}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509certs.ErrInvalidBlockType)
+	})
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/spf13/cobra"
+)
+
+var (
+	inventoryFormat   string // Report format for the inventory command: markdown, csv, or json
+	inventoryWarnDays int    // Expiry warning window in days
+)
+
+var (
+	// ErrInventoryUnhealthy is returned when a scanned chain is expired or fails verification.
+	ErrInventoryUnhealthy = errors.New("certificate inventory contains expired or invalid chains")
+)
+
+// newInventoryCmd creates the inventory subcommand.
+//
+// The command walks files and directories, finds every certificate by content
+// (PEM, DER, and Kubernetes TLS secret manifests), resolves and verifies each
+// chain, and prints an inventory of subject, issuer, expiry, key, and chain
+// health. It exits non-zero when any chain is expired or invalid.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured inventory command
+func newInventoryCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "inventory PATH...",
+		Short: "Scan directories and Kubernetes secret manifests for certificates and report chain health",
+		Example: fmt.Sprintf(`  %s inventory /etc/nginx /etc/ssl/private
+  %s inventory ./k8s --format csv --warn-days 14`, exeName, exeName),
+		Args:         cobra.MinimumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execInventory(ctx, args, cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().StringVarP(&inventoryFormat, "format", "F", "markdown", "report format: markdown, csv, or json")
+	cmd.Flags().IntVar(&inventoryWarnDays, "warn-days", x509inventory.DefaultWarnDays, "report chains expiring within this many days")
+
+	return cmd
+}
+
+// execInventory scans paths and prints the inventory report.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - paths: Files and directories to scan
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Format, walking, or output error, or ErrInventoryUnhealthy
+func execInventory(ctx context.Context, paths []string, version string) error {
+	if inventoryFormat != "markdown" && inventoryFormat != "csv" && inventoryFormat != "json" {
+		return fmt.Errorf("unsupported format %q, supported formats: markdown, csv, json", inventoryFormat)
+	}
+
+	report, err := x509inventory.Scan(ctx, paths, x509inventory.Options{
+		Version:  version,
+		WarnDays: inventoryWarnDays,
+	})
+	if err != nil {
+		return fmt.Errorf("error scanning certificates: %w", err)
+	}
+
+	switch inventoryFormat {
+	case "json":
+		outputData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	case "csv":
+		output, err := report.RenderCSV()
+		if err != nil {
+			return err
+		}
+		fmt.Print(output)
+	default:
+		fmt.Print(report.RenderMarkdown())
+	}
+
+	if report.Unhealthy() {
+		return ErrInventoryUnhealthy
+	}
+	return nil
+}
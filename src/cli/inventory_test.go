@@ -0,0 +1,110 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Inventory(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	dir := t.TempDir()
+	writeSelfSignedKeyPair(t, dir)
+
+	t.Run("Markdown", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "inventory", dir}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "# Certificate Inventory")
+		assert.Contains(t, output, "Files scanned: 2")
+		assert.Contains(t, output, "Certificates: 1 (ok: 0, expiring: 1,")
+		assert.Contains(t, output, "CN=bundle.example.com")
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "inventory", dir, "--format", "csv", "--warn-days", "1"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], "Path,Source,Secret,Subject"))
+		assert.Contains(t, lines[1], filepath.Join(dir, "server.crt"))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "inventory", dir, "-F", "json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+
+		var report x509inventory.Report
+		require.NoError(t, json.Unmarshal([]byte(output), &report))
+		require.Len(t, report.Entries, 1)
+		assert.Equal(t, x509inventory.SourceFile, report.Entries[0].Source)
+		assert.Equal(t, 1, report.Summary.Expiring)
+	})
+
+	t.Run("Expired Certificate", func(t *testing.T) {
+		expiredDir := t.TempDir()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "expired.example.com"},
+			NotBefore:    time.Now().AddDate(-1, 0, 0),
+			NotAfter:     time.Now().AddDate(0, 0, -1),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(expiredDir, "expired.der"), der, 0644))
+
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "inventory", dir, expiredDir}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrInventoryUnhealthy)
+		assert.Contains(t, output, "expired: 1)")
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		os.Args = []string{"cmd", "inventory", dir, "--format", "xml"}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), "unsupported format")
+	})
+
+	t.Run("Missing Path", func(t *testing.T) {
+		os.Args = []string{"cmd", "inventory", filepath.Join(dir, "missing")}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), os.ErrNotExist)
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/spf13/cobra"
+)
+
+var lintJSONFormat bool // JSON output for the lint command
+
+var (
+	// ErrLintFailed is returned when any linted certificate has at least one error-severity finding.
+	ErrLintFailed = errors.New("certificate failed lint checks")
+)
+
+// newLintCmd creates the lint subcommand.
+//
+// The command checks every certificate in a PEM bundle or DER file against the
+// built-in CA/Browser Forum Baseline Requirements rules without any network
+// access, prints each finding with its severity and citation, and exits
+// non-zero when any finding has error severity so it can gate CI jobs.
+//
+// Parameters:
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured lint command
+func newLintCmd(exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "lint CERT_FILE",
+		Short: "Lint certificates offline against the CA/Browser Forum Baseline Requirements",
+		Example: fmt.Sprintf(`  %s lint test-leaf.cer
+  %s lint bundle.pem --json`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execLint(args[0])
+		},
+	}
+
+	cmd.Flags().BoolVarP(&lintJSONFormat, "json", "j", false, "output the reports in JSON format")
+
+	return cmd
+}
+
+// execLint lints every certificate in the file at path.
+//
+// Parameters:
+//   - path: Path to a PEM bundle or DER encoded certificate
+//
+// Returns:
+//   - error: Reading, decoding, or output error, or ErrLintFailed
+func execLint(path string) error {
+	data, err := readCertificateFile(path)
+	if err != nil {
+		return err
+	}
+
+	certManager := x509certs.New()
+	certs, err := certManager.DecodeMultiple(data)
+	if err != nil {
+		return fmt.Errorf("error decoding certificate (%d bytes): %w", len(data), err)
+	}
+
+	reports := make([]*x509certs.LintReport, len(certs))
+	passed := true
+	for i, cert := range certs {
+		reports[i] = certManager.Lint(cert)
+		passed = passed && reports[i].Passed
+	}
+
+	if lintJSONFormat {
+		outputData, err := json.MarshalIndent(reports, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	} else {
+		for i, report := range reports {
+			if i > 0 {
+				fmt.Println()
+			}
+			fmt.Print(report.RenderText())
+		}
+	}
+
+	if !passed {
+		return ErrLintFailed
+	}
+	return nil
+}
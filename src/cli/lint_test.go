@@ -0,0 +1,70 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Lint(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	// A self-signed end-entity certificate has no AIA, revocation
+	// information or authority key identifier, so it fails the lint
+	certPath, _ := writeSelfSignedKeyPair(t, t.TempDir())
+
+	t.Run("Text", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "lint", certPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrLintFailed)
+		assert.Contains(t, output, "Type:            subscriber")
+		assert.Contains(t, output, "[ERROR] "+x509certs.LintAuthorityInfoAccess)
+		assert.Contains(t, output, "(CABF BR §7.1.2.11.2)")
+		assert.Contains(t, output, "Result: FAILED")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "lint", certPath, "--json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrLintFailed)
+
+		var reports []x509certs.LintReport
+		require.NoError(t, json.Unmarshal([]byte(output), &reports))
+		require.Len(t, reports, 1)
+		assert.False(t, reports[0].Passed)
+		assert.Contains(t, findingIDs(reports[0].Findings), x509certs.LintRevocationInfo)
+	})
+
+	t.Run("Missing Argument", func(t *testing.T) {
+		os.Args = []string{"cmd", "lint"}
+		assert.Error(t, cli.Execute(context.Background(), version, log))
+	})
+}
+
+// findingIDs returns the rule identifiers of findings in order.
+func findingIDs(findings []x509certs.Finding) []string {
+	ids := make([]string, len(findings))
+	for i, f := range findings {
+		ids[i] = f.Check
+	}
+	return ids
+}
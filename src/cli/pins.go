@@ -0,0 +1,137 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	pinsJSONFormat    bool // JSON output for the pins command
+	pinsIncludeSystem bool // Include the system root CA in the pins output
+)
+
+// pinInfo is a single chain element in the pins JSON output.
+type pinInfo struct {
+	Index   int    `json:"index"`
+	Role    string `json:"role"`
+	Subject string `json:"subject"`
+	x509certs.Fingerprints
+}
+
+// newPinsCmd creates the pins subcommand.
+//
+// The command resolves the chain of a certificate and prints the base64
+// SPKI SHA-256 pin of every element, ready to paste into HPKP-style,
+// mobile, or service-mesh pinning configurations.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured pins command
+func newPinsCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pins CERT_FILE",
+		Short: "Print SPKI SHA-256 pins for every certificate in the resolved chain",
+		Example: fmt.Sprintf(`  %s pins test-leaf.cer
+  %s pins test-leaf.cer --include-system --json`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execPins(ctx, args[0], cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&pinsJSONFormat, "json", "j", false, "output pins, fingerprints and key identifiers in JSON format")
+	cmd.Flags().BoolVarP(&pinsIncludeSystem, "include-system", "s", false, "include root CA from system in output")
+
+	return cmd
+}
+
+// execPins resolves the chain of the certificate at path and prints its pins.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - path: Path to a PEM or DER encoded certificate
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Reading, decoding, chain resolution, or output error
+func execPins(ctx context.Context, path, version string) error {
+	certData, err := readCertificateFile(path)
+	if err != nil {
+		return err
+	}
+
+	cert, err := decodeCertificate(certData, x509certs.New())
+	if err != nil {
+		return err
+	}
+
+	chain, err := fetchCertificateChain(ctx, cert, version)
+	if err != nil {
+		return err
+	}
+
+	if pinsIncludeSystem {
+		if err = chain.AddRootCA(); err != nil {
+			return fmt.Errorf("error adding root CA: %w", err)
+		}
+	}
+
+	pins := collectPins(chain)
+
+	if pinsJSONFormat {
+		outputData, err := json.MarshalIndent(pins, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+		return nil
+	}
+
+	fmt.Print(formatPins(pins))
+	return nil
+}
+
+// collectPins builds the pin entries for every certificate in the chain.
+func collectPins(chain *x509chain.Chain) []pinInfo {
+	pins := make([]pinInfo, len(chain.Certs))
+	for i, cert := range chain.Certs {
+		pins[i] = pinInfo{
+			Index:        i,
+			Role:         chain.GetCertificateRole(i),
+			Subject:      cert.Subject.CommonName,
+			Fingerprints: x509certs.CertificateFingerprints(cert),
+		}
+	}
+	return pins
+}
+
+// formatPins renders pin entries in the pin-sha256 directive syntax,
+// one per line, annotated with the certificate role and subject.
+//
+// Parameters:
+//   - pins: Pin entries produced by collectPins
+//
+// Returns:
+//   - string: Multi-line text output
+func formatPins(pins []pinInfo) string {
+	var b strings.Builder
+	for _, p := range pins {
+		fmt.Fprintf(&b, "pin-sha256=%q # %s: %s\n", p.SPKISHA256, p.Role, p.Subject)
+	}
+	return b.String()
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Pins(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	certPath, _ := writeSelfSignedKeyPair(t, t.TempDir())
+	certData, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+	cert, err := x509certs.New().Decode(certData)
+	require.NoError(t, err)
+	pin := x509certs.SPKIPin(cert)
+
+	t.Run("Text", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "pins", certPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, `pin-sha256="`+pin+`"`)
+		assert.Contains(t, output, "bundle.example.com")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "pins", certPath, "--json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+
+		var pins []struct {
+			Subject string `json:"subject"`
+			x509certs.Fingerprints
+		}
+		require.NoError(t, json.Unmarshal([]byte(output), &pins))
+		require.Len(t, pins, 1)
+		assert.Equal(t, "bundle.example.com", pins[0].Subject)
+		assert.Equal(t, x509certs.CertificateFingerprints(cert), pins[0].Fingerprints)
+	})
+
+	t.Run("Missing Argument", func(t *testing.T) {
+		os.Args = []string{"cmd", "pins"}
+		assert.Error(t, cli.Execute(context.Background(), version, log))
+	})
+}
+
+func TestExecute_JSONFingerprints(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, _ := writeSelfSignedKeyPair(t, tmpDir)
+	outPath := filepath.Join(tmpDir, "chain.json")
+
+	os.Args = []string{"cmd", "-f", certPath, "--json", "-o", outPath}
+	require.NoError(t, cli.Execute(context.Background(), version, log))
+
+	output, err := os.ReadFile(outPath)
+	require.NoError(t, err)
+
+	var result struct {
+		Certificates []x509certs.Fingerprints `json:"listCertificates"`
+	}
+	require.NoError(t, json.Unmarshal(output, &result))
+	require.Len(t, result.Certificates, 1)
+	assert.NotEmpty(t, result.Certificates[0].SHA1)
+	assert.NotEmpty(t, result.Certificates[0].SHA256)
+	assert.NotEmpty(t, result.Certificates[0].SPKISHA256)
+}
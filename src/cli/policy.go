@@ -0,0 +1,115 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyFile          string // Path to the organisational policy file
+	policyJSONFormat    bool   // JSON output for the policy command
+	policyIncludeSystem bool   // Include the system root CA before evaluating the policy
+)
+
+var (
+	// ErrPolicyViolation is returned when a resolved chain fails at least one policy rule.
+	ErrPolicyViolation = errors.New("certificate chain violates policy")
+)
+
+// newPolicyCmd creates the policy subcommand.
+//
+// The command resolves the chain of a certificate, evaluates an organisational
+// policy file (JSON or YAML) against it, prints pass or fail for every rule,
+// and exits non-zero on any violation so it can gate deployments in CI.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured policy command
+func newPolicyCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "policy CERT_FILE",
+		Short: "Evaluate an organisational policy file against the resolved chain",
+		Example: fmt.Sprintf(`  %s policy test-leaf.cer --policy policy.yaml
+  %s policy test-leaf.cer -p policy.json --include-system --json`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execPolicy(ctx, args[0], cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().StringVarP(&policyFile, "policy", "p", "", "policy file (.json, .yaml, or .yml)")
+	cmd.Flags().BoolVarP(&policyJSONFormat, "json", "j", false, "output the report in JSON format")
+	cmd.Flags().BoolVarP(&policyIncludeSystem, "include-system", "s", false, "include root CA from system before evaluating")
+	cmd.MarkFlagRequired("policy")
+
+	return cmd
+}
+
+// execPolicy resolves the chain of the certificate at path and evaluates the policy.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - path: Path to a PEM or DER encoded certificate
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Policy loading, reading, decoding, chain resolution, or output error, or ErrPolicyViolation
+func execPolicy(ctx context.Context, path, version string) error {
+	policy, err := x509chain.LoadPolicy(policyFile)
+	if err != nil {
+		return err
+	}
+
+	certData, err := readCertificateFile(path)
+	if err != nil {
+		return err
+	}
+
+	cert, err := decodeCertificate(certData, x509certs.New())
+	if err != nil {
+		return err
+	}
+
+	chain, err := fetchCertificateChain(ctx, cert, version)
+	if err != nil {
+		return err
+	}
+
+	if policyIncludeSystem {
+		if err = chain.AddRootCA(); err != nil {
+			return fmt.Errorf("error adding root CA: %w", err)
+		}
+	}
+
+	report := chain.EvaluatePolicy(policy)
+
+	if policyJSONFormat {
+		outputData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	} else {
+		fmt.Print(report.RenderText())
+	}
+
+	if !report.Passed {
+		return ErrPolicyViolation
+	}
+	return nil
+}
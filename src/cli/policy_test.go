@@ -0,0 +1,80 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Policy(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, _ := writeSelfSignedKeyPair(t, tmpDir)
+	writePolicy := func(name, content string) string {
+		path := filepath.Join(tmpDir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	passing := writePolicy("pass.yaml", `
+name: house-rules
+allowedIssuers: [bundle.example.com]
+minECDSABits: 256
+forbiddenSignatureAlgorithms: [SHA1, MD5]
+maxLeafValidityDays: 90
+`)
+	failing := writePolicy("fail.json", `{"name": "strict", "minECDSABits": 384}`)
+
+	t.Run("Passing Policy", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "policy", certPath, "--policy", passing}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "Policy:  house-rules")
+		assert.Contains(t, output, "[PASS] "+x509chain.PolicyAllowedIssuers)
+		assert.Contains(t, output, "Result: PASSED")
+	})
+
+	t.Run("Violation", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "policy", certPath, "-p", failing, "--json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrPolicyViolation)
+
+		var report x509chain.PolicyReport
+		require.NoError(t, json.Unmarshal([]byte(output), &report))
+		assert.Equal(t, "strict", report.Policy)
+		assert.False(t, report.Passed)
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, x509chain.PolicyMinECDSABits, report.Results[0].Rule)
+	})
+
+	t.Run("Missing Policy Flag", func(t *testing.T) {
+		os.Args = []string{"cmd", "policy", certPath}
+		assert.Error(t, cli.Execute(context.Background(), version, log))
+	})
+
+	t.Run("Invalid Policy", func(t *testing.T) {
+		os.Args = []string{"cmd", "policy", certPath, "-p", writePolicy("empty.yaml", "name: empty\n")}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509chain.ErrEmptyPolicy)
+	})
+}
@@ -7,11 +7,14 @@ package cli
 
 import (
 	"context"
+	"crypto"
 	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/helper/posix"
 	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
@@ -36,12 +39,27 @@ var (
 	treeFormat       bool          // New flag for ASCII tree visualization
 	tableFormat      bool          // New flag for table visualization
 	inputFile        string        // New variable for input file
+	trustStore       string        // Truststore output format (jks or pkcs12)
+	storePassword    string        // Truststore password
+	keyFile          string        // Private key file matching the leaf certificate
+	keyPassword      string        // Password for an encrypted PKCS#8 private key
+	bundleLayout     string        // Server bundle layout (nginx, apache, or haproxy)
+	inspectFormat    bool          // Full certificate decode, like openssl x509 -text
 	globalLogger     logger.Logger // Global logger instance
 )
 
 var (
 	// ErrInputFileRequired is returned when no input file is specified.
 	ErrInputFileRequired = errors.New("input file must be specified with -f or --file")
+
+	// ErrTrustStoreOutputRequired is returned when truststore output is requested without an output file.
+	ErrTrustStoreOutputRequired = errors.New("truststore output must be written to a file with -o or --output")
+
+	// ErrBundleKeyRequired is returned when a server bundle is requested without a private key.
+	ErrBundleKeyRequired = errors.New("server bundle requires a private key with --key")
+
+	// ErrBundleOutputRequired is returned when a server bundle is requested without an output directory.
+	ErrBundleOutputRequired = errors.New("server bundle must be written to a directory with -o or --output")
 )
 
 // Execute sets up and runs the TLS certificate chain resolver command-line interface.
@@ -76,6 +94,19 @@ var (
 //	<exe> -f cert.pem -o output.pem
 //	<exe> -f cert.pem -t  # tree format
 //	<exe> -f cert.pem -j  # JSON format
+//	<exe> -f cert.pem --inspect  # full decode, like openssl x509 -text
+//	<exe> -f cert.pem -s --truststore jks -o truststore.jks  # Java truststore
+//	<exe> -f cert.pem --key key.pem --bundle nginx -o /etc/nginx/tls  # server bundle
+//	<exe> inspect-csr request.csr  # CSR pre-issuance checks
+//	<exe> pins cert.pem  # SPKI SHA-256 pins for the resolved chain
+//	<exe> lint cert.pem  # offline Baseline Requirements lint
+//	<exe> policy cert.pem -p policy.yaml  # organisational policy gate
+//	<exe> validate cert.pem -s -v  # verify, with name and policy constraints
+//	<exe> ct --host example.com  # verify SCTs against browser CT policy
+//	<exe> diff example.com:443 renewed.pem  # compare two chains
+//	<exe> inventory /etc/nginx ./k8s --format csv  # certificate inventory
+//	<exe> scan targets.txt --format jsonl  # endpoint scan, exit code = worst finding
+//	<exe> watch watch.yaml  # expiry monitoring daemon with alert sinks
 //
 // Where <exe> is the actual executable name (determined dynamically).
 func Execute(ctx context.Context, version string, log logger.Logger) error {
@@ -88,12 +119,23 @@ func Execute(ctx context.Context, version string, log logger.Logger) error {
 		Use:   exeName,
 		Short: "TLS certificate chain resolver",
 		Example: fmt.Sprintf(`  %s -f test-leaf.cer -o test-output-bundle.pem
-  %s -f another-cert.cer -o test-output-bundle.crt --der --include-system`, exeName, exeName),
+  %s -f another-cert.cer -o test-output-bundle.crt --der --include-system
+  %s -f test-leaf.cer -s --truststore pkcs12 --store-password changeit -o truststore.p12
+  %s -f test-leaf.cer --key test-leaf.key --bundle nginx -o ./tls`, exeName, exeName, exeName, exeName),
 		Version: version,
 		Args: func(cmd *cobra.Command, args []string) error {
 			if inputFile == "" {
 				return ErrInputFileRequired
 			}
+			if trustStore != "" && outputFile == "" {
+				return ErrTrustStoreOutputRequired
+			}
+			if bundleLayout != "" && keyFile == "" {
+				return ErrBundleKeyRequired
+			}
+			if bundleLayout != "" && outputFile == "" {
+				return ErrBundleOutputRequired
+			}
 			return nil
 		},
 		// TODO: This execution flow could be improved, but the current implementation
@@ -120,18 +162,36 @@ func Execute(ctx context.Context, version string, log logger.Logger) error {
 	rootCmd.Flags().BoolVarP(&jsonFormat, "json", "j", false, "output in JSON format with PEM-encoded certificates and their chains")
 	rootCmd.Flags().BoolVarP(&treeFormat, "tree", "t", false, "display certificate chain as ASCII tree")
 	rootCmd.Flags().BoolVarP(&tableFormat, "table", "", false, "display certificate chain as formatted table")
+	rootCmd.Flags().StringVar(&trustStore, "truststore", "", "output a Java truststore in the given format (jks or pkcs12)")
+	rootCmd.Flags().StringVar(&storePassword, "store-password", x509certs.DefaultTrustStorePassword, "truststore password used with --truststore")
+	rootCmd.Flags().StringVar(&keyFile, "key", "", "private key file (PKCS#1, PKCS#8, SEC1, or encrypted PKCS#8) to verify against the leaf")
+	rootCmd.Flags().StringVar(&keyPassword, "key-password", "", "password for an encrypted private key")
+	rootCmd.Flags().StringVar(&bundleLayout, "bundle", "", "write a server bundle (nginx, apache, or haproxy) into the -o directory")
+	rootCmd.Flags().BoolVar(&inspectFormat, "inspect", false, "decode every certificate field and extension (text, or JSON with --json)")
+
+	rootCmd.AddCommand(newInspectCSRCmd(exeName))
+	rootCmd.AddCommand(newPinsCmd(ctx, exeName))
+	rootCmd.AddCommand(newLintCmd(exeName))
+	rootCmd.AddCommand(newPolicyCmd(ctx, exeName))
+	rootCmd.AddCommand(newValidateCmd(ctx, exeName))
+	rootCmd.AddCommand(newCTCmd(ctx, exeName))
+	rootCmd.AddCommand(newDiffCmd(ctx, exeName))
+	rootCmd.AddCommand(newInventoryCmd(ctx, exeName))
+	rootCmd.AddCommand(newScanCmd(ctx, exeName))
+	rootCmd.AddCommand(newWatchCmd(ctx, exeName))
 
 	return rootCmd.Execute()
 }
 
 // certificateInfo represents the details of a single certificate,
-// including its subject, issuer, serial number, and PEM-encoded data.
+// including its subject, issuer, serial number, fingerprints, and PEM-encoded data.
 type certificateInfo struct {
 	Subject            string `json:"subject"`
 	Issuer             string `json:"issuer"`
 	Serial             string `json:"serial"`
 	SignatureAlgorithm string `json:"signatureAlgorithm"`
 	PEM                string `json:"pem"`
+	x509certs.Fingerprints
 }
 
 // jsonOutput defines the structure for the JSON output format,
@@ -182,6 +242,15 @@ func execCli(ctx context.Context, cmd *cobra.Command) error {
 		}
 	}
 
+	// Verify the private key against the leaf if provided
+	var key crypto.PrivateKey
+	if keyFile != "" {
+		if key, err = loadPrivateKey(keyFile, chain.Certs[0], certManager); err != nil {
+			return err
+		}
+		globalLogger.Println("Private key matches the leaf certificate public key.")
+	}
+
 	// Filter certificates if needed
 	certsToOutput := filterCertificates(chain)
 
@@ -203,6 +272,18 @@ func execCli(ctx context.Context, cmd *cobra.Command) error {
 		globalLogger.Println("Certificate chain complete. Total", len(chain.Certs), "certificate(s) found.\n")
 	}
 
+	// Output a server bundle if specified
+	if bundleLayout != "" {
+		return outputServerBundle(chain, key)
+	}
+	// Output as a Java truststore if specified
+	if trustStore != "" {
+		return outputTrustStore(certsToOutput, certManager)
+	}
+	// Output the full certificate decode if specified
+	if inspectFormat {
+		return outputInspect(certsToOutput, certManager)
+	}
 	// Output in JSON format if specified
 	if jsonFormat {
 		return outputJSON(certsToOutput, certManager)
@@ -251,6 +332,37 @@ func decodeCertificate(certData []byte, certManager *x509certs.Certificate) (*x5
 	return cert, nil
 }
 
+// loadPrivateKey reads a private key file and verifies it matches the leaf certificate.
+//
+// The key may be PKCS#1, PKCS#8, SEC1, or password-protected PKCS#8 in PEM or
+// DER form. The keyPassword flag is used for encrypted keys.
+//
+// Parameters:
+//   - path: Path to the private key file
+//   - leaf: Leaf certificate whose public key must match
+//   - certManager: Certificate manager for key decoding and matching
+//
+// Returns:
+//   - crypto.PrivateKey: Decoded private key
+//   - error: Reading, decoding, or key mismatch error
+func loadPrivateKey(path string, leaf *x509.Certificate, certManager *x509certs.Certificate) (crypto.PrivateKey, error) {
+	keyData, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading private key file '%s': %w", path, err)
+	}
+
+	key, err := certManager.DecodePrivateKey(keyData, []byte(keyPassword))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding private key: %w", err)
+	}
+
+	if err := certManager.MatchPrivateKey(leaf, key); err != nil {
+		return nil, fmt.Errorf("error verifying private key for '%s': %w", leaf.Subject.CommonName, err)
+	}
+
+	return key, nil
+}
+
 // fetchCertificateChain retrieves the complete certificate chain for the given certificate.
 //
 // It creates a new certificate chain manager, fetches all intermediate certificates
@@ -263,7 +375,8 @@ func decodeCertificate(certData []byte, certManager *x509certs.Certificate) (*x5
 //   - version: Application version string for HTTP User-Agent headers
 //
 // Returns:
-//   - *x509chain.Chain: Fully resolved certificate chain with intermediates
+//   - *x509chain.Chain: Fully resolved certificate chain with intermediates; when fetching or
+//     verification fails, the certificates resolved so far, or nil if the context was cancelled
 //   - error: Any error that occurs during chain fetching or verification
 func fetchCertificateChain(ctx context.Context, cert *x509.Certificate, version string) (*x509chain.Chain, error) {
 	// Create a chain manager
@@ -282,7 +395,7 @@ func fetchCertificateChain(ctx context.Context, cert *x509.Certificate, version
 		return nil, ctx.Err()
 	case err := <-result:
 		if err != nil {
-			return nil, fmt.Errorf("error fetching certificate chain: %w", err)
+			return chain, fmt.Errorf("error fetching certificate chain: %w", err)
 		}
 	}
 
@@ -309,7 +422,7 @@ func filterCertificates(chain *x509chain.Chain) []*x509.Certificate {
 // outputJSON outputs the certificates in structured JSON format.
 //
 // It creates a JSON array containing detailed certificate information
-// including subject, issuer, validity dates, and PEM-encoded data.
+// including subject, issuer, fingerprints, SPKI pins, key identifiers, and PEM-encoded data.
 // The JSON output is written to stdout.
 //
 // Parameters:
@@ -329,6 +442,7 @@ func outputJSON(certsToOutput []*x509.Certificate, certManager *x509certs.Certif
 			Serial:             cert.SerialNumber.String(),
 			SignatureAlgorithm: cert.SignatureAlgorithm.String(),
 			PEM:                string(pemData),
+			Fingerprints:       x509certs.CertificateFingerprints(cert),
 		}
 	}
 
@@ -346,6 +460,38 @@ func outputJSON(certsToOutput []*x509.Certificate, certManager *x509certs.Certif
 	return writeOutput(outputData)
 }
 
+// outputInspect outputs a full decode of each certificate, comparable to `openssl x509 -text`.
+//
+// With the jsonFormat flag the decodes are emitted as a JSON array using the
+// stable [x509certs.CertificateDetails] schema; otherwise they are rendered as text.
+//
+// Parameters:
+//   - certsToOutput: Certificates to decode
+//   - certManager: Certificate manager for decoding operations
+//
+// Returns:
+//   - error: JSON marshaling or output error
+func outputInspect(certsToOutput []*x509.Certificate, certManager *x509certs.Certificate) error {
+	details := make([]*x509certs.CertificateDetails, len(certsToOutput))
+	for i, cert := range certsToOutput {
+		details[i] = certManager.Inspect(cert)
+	}
+
+	if jsonFormat {
+		outputData, err := json.MarshalIndent(details, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		return writeOutput(outputData)
+	}
+
+	var text strings.Builder
+	for _, d := range details {
+		text.WriteString(d.RenderText())
+	}
+	return writeOutput([]byte(text.String()))
+}
+
 // outputCertificates outputs the certificates in the requested format (DER or PEM).
 //
 // It encodes all certificates in the chain using either DER or PEM format
@@ -370,6 +516,110 @@ func outputCertificates(certsToOutput []*x509.Certificate, certManager *x509cert
 	return writeOutput(outputData)
 }
 
+// outputTrustStore outputs the certificates as a Java truststore (JKS or PKCS#12).
+//
+// Each certificate becomes a trusted certificate entry with a stable alias derived
+// from its subject CN and SHA-256 fingerprint. Truststores are binary, so the output
+// is always written to the file given by the outputFile flag.
+//
+// Parameters:
+//   - certsToOutput: Certificates to store as trusted entries
+//   - certManager: Certificate manager for truststore encoding
+//
+// Returns:
+//   - error: Encoding or output error
+func outputTrustStore(certsToOutput []*x509.Certificate, certManager *x509certs.Certificate) error {
+	outputData, err := certManager.EncodeTrustStore(trustStore, certsToOutput, storePassword)
+	if err != nil {
+		return fmt.Errorf("error encoding truststore: %w", err)
+	}
+
+	// Duplicate certificates are stored once, so log the entries actually written
+	entries, err := x509certs.TrustStoreEntries(certsToOutput)
+	if err != nil {
+		return fmt.Errorf("error encoding truststore: %w", err)
+	}
+	for _, entry := range entries {
+		globalLogger.Printf("Truststore entry: %s", entry.Alias)
+	}
+
+	return writeOutput(outputData)
+}
+
+// outputServerBundle writes a ready-to-deploy server bundle into the output directory.
+//
+// The layout is taken from the bundleLayout flag and always uses the full resolved
+// chain (leaf plus intermediates), independent of the filtering flags. Files holding
+// private key material are written with 0600 permissions.
+//
+// Parameters:
+//   - chain: Resolved certificate chain
+//   - key: Private key matching the leaf certificate
+//
+// Returns:
+//   - error: Bundle assembly or file writing error
+func outputServerBundle(chain *x509chain.Chain, key crypto.PrivateKey) error {
+	files, err := chain.ServerBundle(bundleLayout, key)
+	if err != nil {
+		return fmt.Errorf("error building %s bundle: %w", bundleLayout, err)
+	}
+
+	if err := os.MkdirAll(outputFile, 0755); err != nil {
+		return fmt.Errorf("error creating output directory: %w", err)
+	}
+
+	for _, file := range files {
+		perm := os.FileMode(0644)
+		if file.Sensitive {
+			perm = 0600
+		}
+
+		path := filepath.Join(outputFile, file.Name)
+		if err := replaceFile(path, file.Data, perm); err != nil {
+			return fmt.Errorf("error writing bundle file: %w", err)
+		}
+		globalLogger.Printf("Bundle file written to %s.", path)
+	}
+
+	return nil
+}
+
+// replaceFile writes data to path with the given permissions, replacing any existing file.
+//
+// The data is written to a temporary file in the same directory, created with 0600
+// permissions, and renamed over path. Unlike [os.WriteFile], which keeps the mode of
+// an existing file, private key material is never readable under looser permissions,
+// not even briefly.
+//
+// Parameters:
+//   - path: Destination file path
+//   - data: File contents
+//   - perm: Permissions of the written file
+//
+// Returns:
+//   - error: File creation, writing, or rename error
+func replaceFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	// Removing fails harmlessly once the file has been renamed
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // writeOutput writes the certificate data to the specified output file or stdout.
 //
 // If an output file is specified via the outputFile flag, it writes the data
@@ -7,9 +7,16 @@ package cli_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
 	"errors"
 	"io"
+	"math/big"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -18,6 +25,7 @@ import (
 	"time"
 
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -156,6 +164,33 @@ func TestExecute_ValidCertificate(t *testing.T) {
 				assert.True(t, ok, "expected non-empty listCertificates array in JSON output")
 			},
 		},
+		{
+			name:          "JKS Truststore Output",
+			args:          []string{"--truststore", "jks", "--store-password", "secret"},
+			outputFileExt: ".jks",
+			validateOutput: func(t *testing.T, outputData []byte) {
+				entries, err := x509certs.New().DecodeJKS(outputData, "secret")
+				require.NoError(t, err, "failed to decode JKS output")
+				require.NotEmpty(t, entries, "expected truststore entries")
+				assert.Equal(t, x509certs.TrustStoreAlias(entries[0].Certificate), entries[0].Alias)
+			},
+		},
+		{
+			name:          "PKCS12 Truststore Output",
+			args:          []string{"--truststore", "pkcs12"},
+			outputFileExt: ".p12",
+			validateOutput: func(t *testing.T, outputData []byte) {
+				certs, err := x509certs.New().DecodePKCS12TrustStore(outputData, x509certs.DefaultTrustStorePassword)
+				require.NoError(t, err, "failed to decode PKCS#12 output")
+				assert.NotEmpty(t, certs, "expected truststore certificates")
+			},
+		},
+		{
+			name:          "Unsupported Truststore Format",
+			args:          []string{"--truststore", "bks"},
+			outputFileExt: ".bks",
+			expectError:   true,
+		},
 		{
 			name:          "Include System Root CA",
 			args:          []string{"--include-system"},
@@ -226,6 +261,18 @@ func TestExecute_ValidCertificate(t *testing.T) {
 	}
 }
 
+func TestExecute_TrustStoreRequiresOutput(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	inputFile := filepath.Join(t.TempDir(), "google.cer")
+	require.NoError(t, os.WriteFile(inputFile, []byte(testCertPEM), 0644), "Failed to write test certificate file")
+
+	os.Args = []string{"cmd", "-f", inputFile, "--truststore", "jks"}
+
+	err := cli.Execute(context.Background(), version, log)
+	assert.ErrorIs(t, err, cli.ErrTrustStoreOutputRequired)
+}
+
 func TestExecute_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -388,3 +435,163 @@ func TestExecute_WriteError(t *testing.T) {
 
 	assert.Contains(t, err.Error(), "error writing to output file", "expected 'error writing to output file' error, got: %v", err)
 }
+
+// writeSelfSignedKeyPair writes a self-signed server certificate and its
+// PKCS#8 private key into dir, returning both file paths. The certificate has
+// no AIA URLs so chain resolution completes without network access.
+func writeSelfSignedKeyPair(t *testing.T, dir string) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "bundle.example.com"},
+		DNSNames:     []string{"bundle.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certPath = filepath.Join(dir, "server.crt")
+	keyPath = filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+	return certPath, keyPath
+}
+
+func TestExecute_ServerBundle(t *testing.T) {
+	tests := []struct {
+		layout    string
+		wantFiles map[string]os.FileMode
+	}{
+		{layout: "nginx", wantFiles: map[string]os.FileMode{"fullchain.pem": 0644, "privkey.pem": 0600}},
+		{layout: "apache", wantFiles: map[string]os.FileMode{"cert.pem": 0644, "privkey.pem": 0600}},
+		{layout: "haproxy", wantFiles: map[string]os.FileMode{"combined.pem": 0600}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			log := logger.NewMCPLogger(io.Discard, true)
+
+			tmpDir := t.TempDir()
+			certPath, keyPath := writeSelfSignedKeyPair(t, tmpDir)
+			outDir := filepath.Join(tmpDir, "bundle")
+
+			os.Args = []string{"cmd", "-f", certPath, "--key", keyPath, "--bundle", tt.layout, "-o", outDir}
+
+			require.NoError(t, cli.Execute(context.Background(), version, log))
+
+			entries, err := os.ReadDir(outDir)
+			require.NoError(t, err)
+			assert.Len(t, entries, len(tt.wantFiles))
+
+			for name, mode := range tt.wantFiles {
+				info, err := os.Stat(filepath.Join(outDir, name))
+				require.NoError(t, err, "expected bundle file %s", name)
+				if runtime.GOOS != "windows" {
+					assert.Equal(t, mode, info.Mode().Perm(), "unexpected permissions for %s", name)
+				}
+			}
+		})
+	}
+}
+
+func TestExecute_ServerBundle_ReplacesExisting(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, keyPath := writeSelfSignedKeyPair(t, tmpDir)
+	outDir := filepath.Join(tmpDir, "bundle")
+	require.NoError(t, os.Mkdir(outDir, 0755))
+	// A stale, world-readable key file must not keep its permissions
+	keyFile := filepath.Join(outDir, "privkey.pem")
+	require.NoError(t, os.WriteFile(keyFile, []byte("stale"), 0644))
+
+	os.Args = []string{"cmd", "-f", certPath, "--key", keyPath, "--bundle", "nginx", "-o", outDir}
+	require.NoError(t, cli.Execute(context.Background(), version, log))
+
+	data, err := os.ReadFile(keyFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "PRIVATE KEY")
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(keyFile)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	}
+
+	// No temporary files are left behind
+	entries, err := os.ReadDir(outDir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestExecute_ServerBundle_Errors(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, keyPath := writeSelfSignedKeyPair(t, tmpDir)
+	otherDir := t.TempDir()
+	_, otherKeyPath := writeSelfSignedKeyPair(t, otherDir)
+
+	t.Run("Missing Key", func(t *testing.T) {
+		os.Args = []string{"cmd", "-f", certPath, "--bundle", "nginx", "-o", tmpDir}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), cli.ErrBundleKeyRequired)
+	})
+
+	t.Run("Missing Output", func(t *testing.T) {
+		os.Args = []string{"cmd", "-f", certPath, "--key", keyPath, "--bundle", "nginx"}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), cli.ErrBundleOutputRequired)
+	})
+
+	t.Run("Mismatched Key", func(t *testing.T) {
+		os.Args = []string{"cmd", "-f", certPath, "--key", otherKeyPath, "--bundle", "nginx", "-o", filepath.Join(tmpDir, "out")}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509certs.ErrKeyMismatch)
+	})
+
+	t.Run("Unknown Layout", func(t *testing.T) {
+		os.Args = []string{"cmd", "-f", certPath, "--key", keyPath, "--bundle", "caddy", "-o", filepath.Join(tmpDir, "out")}
+		assert.Error(t, cli.Execute(context.Background(), version, log))
+	})
+}
+
+func TestExecute_Inspect(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, _ := writeSelfSignedKeyPair(t, tmpDir)
+
+	t.Run("Text", func(t *testing.T) {
+		outPath := filepath.Join(tmpDir, "inspect.txt")
+		os.Args = []string{"cmd", "-f", certPath, "--inspect", "-o", outPath}
+		require.NoError(t, cli.Execute(context.Background(), version, log))
+
+		output, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.Contains(t, string(output), "Subject: CN=bundle.example.com")
+		assert.Contains(t, string(output), "X509v3 Subject Alternative Name:")
+		assert.Contains(t, string(output), "DNS:bundle.example.com")
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		outPath := filepath.Join(tmpDir, "inspect.json")
+		os.Args = []string{"cmd", "-f", certPath, "--inspect", "--json", "-o", outPath}
+		require.NoError(t, cli.Execute(context.Background(), version, log))
+
+		output, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+
+		var details []x509certs.CertificateDetails
+		require.NoError(t, json.Unmarshal(output, &details))
+		require.Len(t, details, 1)
+		assert.Equal(t, "CN=bundle.example.com", details[0].Subject)
+		assert.NotEmpty(t, details[0].Extensions)
+	})
+}
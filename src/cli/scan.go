@@ -0,0 +1,194 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scanFormat       string        // Output format for the scan command: text, json, or jsonl
+	scanConcurrency  int           // Maximum number of endpoints scanned at once
+	scanHostInterval time.Duration // Minimum delay between connections to the same host
+	scanTimeout      time.Duration // Timeout for each handshake and AIA, OCSP, or CRL download
+	scanWarnDays     int           // Expiry warning window in days
+)
+
+var (
+	// ErrScanFindings is wrapped in the [ExitCodeError] returned when any endpoint is not healthy.
+	ErrScanFindings = errors.New("endpoint scan found unhealthy endpoints")
+)
+
+// ExitCodeError is an error that requests a specific process exit code.
+type ExitCodeError struct {
+	// Code: Process exit code
+	Code int
+	// Err: Underlying error
+	Err error
+}
+
+// Error returns the underlying error message.
+func (e *ExitCodeError) Error() string { return e.Err.Error() }
+
+// Unwrap returns the underlying error.
+func (e *ExitCodeError) Unwrap() error { return e.Err }
+
+// scanExitCode maps the worst health state of a scan to a process exit code.
+// Exit code 1 is reserved for usage and runtime errors, so findings start at 2.
+func scanExitCode(worst string) int {
+	if worst == "" || worst == x509inventory.HealthOK {
+		return 0
+	}
+	return x509inventory.Severity(worst) + 1
+}
+
+// newScanCmd creates the scan subcommand.
+//
+// The command reads host:port targets, one per line, from a file or standard
+// input, fetches each served chain, completes it through AIA, and checks
+// verification, revocation, and expiry with bounded concurrency and a per-host
+// rate limit. It prints one result per target and a summary, and exits with a
+// code reflecting the worst finding so it can run unattended from cron.
+//
+// Parameters:
+//   - ctx: Context for cancellation during scanning
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured scan command
+func newScanCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "scan [TARGETS_FILE]",
+		Short: "Scan a list of TLS endpoints for chain, revocation, and expiry problems",
+		Long: `Scan reads host:port targets (port 443 when omitted), one per line, from
+TARGETS_FILE or standard input when the file is omitted or "-". Blank lines
+and text after "#" are ignored.
+
+Exit codes reflect the worst finding:
+  0  all endpoints ok
+  1  usage or runtime error
+  2  expiring
+  3  unreachable
+  4  incomplete chain
+  5  invalid chain
+  6  expired
+  7  revoked`,
+		Example: fmt.Sprintf(`  %s scan targets.txt
+  echo example.com | %s scan --format jsonl
+  %s scan targets.txt --concurrency 16 --host-interval 2s --warn-days 14`, exeName, exeName, exeName),
+		Args:         cobra.MaximumNArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "-"
+			if len(args) == 1 {
+				path = args[0]
+			}
+			return execScan(ctx, path, cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().StringVarP(&scanFormat, "format", "F", "text", "output format: text, json, or jsonl (one result per line, then the summary)")
+	cmd.Flags().IntVar(&scanConcurrency, "concurrency", x509inventory.DefaultConcurrency, "maximum number of endpoints scanned at once")
+	cmd.Flags().DurationVar(&scanHostInterval, "host-interval", time.Second, "minimum delay between connections to the same host (0 disables)")
+	cmd.Flags().DurationVar(&scanTimeout, "timeout", x509inventory.DefaultTimeout, "timeout for each TLS handshake and AIA, OCSP, or CRL download")
+	cmd.Flags().IntVar(&scanWarnDays, "warn-days", x509inventory.DefaultWarnDays, "report chains expiring within this many days")
+
+	return cmd
+}
+
+// execScan reads the targets, scans them, and prints the results.
+//
+// Parameters:
+//   - ctx: Context for cancellation during scanning
+//   - path: Targets file, or "-" for standard input
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Input, format, or output error, or an [ExitCodeError] wrapping ErrScanFindings
+func execScan(ctx context.Context, path, version string) error {
+	if scanFormat != "text" && scanFormat != "json" && scanFormat != "jsonl" {
+		return fmt.Errorf("unsupported format %q, supported formats: text, json, jsonl", scanFormat)
+	}
+	if scanConcurrency < 1 {
+		return fmt.Errorf("invalid concurrency %d, must be at least 1", scanConcurrency)
+	}
+
+	var input io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("error opening targets file: %w", err)
+		}
+		defer f.Close()
+		input = f
+	}
+	targets, err := x509inventory.ParseTargets(input)
+	if err != nil {
+		return fmt.Errorf("error reading targets: %w", err)
+	}
+	if len(targets) == 0 {
+		return errors.New("no targets to scan")
+	}
+
+	// Stream JSON Lines as each endpoint completes
+	var (
+		emit    func(x509inventory.EndpointResult)
+		emitErr error
+	)
+	encoder := json.NewEncoder(os.Stdout)
+	if scanFormat == "jsonl" {
+		emit = func(result x509inventory.EndpointResult) {
+			if err := encoder.Encode(result); err != nil && emitErr == nil {
+				emitErr = err
+			}
+		}
+	}
+
+	report, err := x509inventory.ScanEndpoints(ctx, targets, x509inventory.EndpointOptions{
+		Version:      version,
+		Timeout:      scanTimeout,
+		WarnDays:     scanWarnDays,
+		Concurrency:  scanConcurrency,
+		HostInterval: scanHostInterval,
+	}, emit)
+	if err != nil {
+		return fmt.Errorf("error scanning endpoints: %w", err)
+	}
+
+	switch scanFormat {
+	case "jsonl":
+		if emitErr == nil {
+			emitErr = encoder.Encode(struct {
+				Summary x509inventory.Summary `json:"summary"`
+			}{report.Summary})
+		}
+		if emitErr != nil {
+			return fmt.Errorf("error encoding JSON: %w", emitErr)
+		}
+	case "json":
+		outputData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return fmt.Errorf("error encoding JSON: %w", err)
+		}
+		fmt.Println(string(outputData))
+	default:
+		fmt.Print(report.RenderText())
+	}
+
+	if code := scanExitCode(report.Summary.Worst); code != 0 {
+		return &ExitCodeError{Code: code, Err: fmt.Errorf("%w: worst finding %s", ErrScanFindings, report.Summary.Worst)}
+	}
+	return nil
+}
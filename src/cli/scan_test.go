@@ -0,0 +1,131 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Scan(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	// The httptest certificate is a long-lived self-signed CA, so the endpoint is healthy
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ErrorLog = newDiscardLogger()
+	server.StartTLS()
+	defer server.Close()
+	healthy := server.Listener.Addr().String()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	dir := t.TempDir()
+	healthyFile := filepath.Join(dir, "healthy.txt")
+	require.NoError(t, os.WriteFile(healthyFile, []byte("# fleet\n"+healthy+"\n"), 0644))
+	mixedFile := filepath.Join(dir, "mixed.txt")
+	require.NoError(t, os.WriteFile(mixedFile, []byte(healthy+"\n"+closed+"\n"), 0644))
+
+	t.Run("Text", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "scan", healthyFile, "--host-interval", "0"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "[ok]")
+		assert.Contains(t, output, healthy)
+		assert.Contains(t, output, "Targets: 1 (ok: 1,")
+		assert.Contains(t, output, "worst: ok")
+	})
+
+	t.Run("JSON Lines With Findings", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "scan", mixedFile, "-F", "jsonl", "--timeout", "2s"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.ErrorIs(t, execErr, cli.ErrScanFindings)
+		var exitErr *cli.ExitCodeError
+		require.ErrorAs(t, execErr, &exitErr)
+		assert.Equal(t, 3, exitErr.Code)
+
+		lines := strings.Split(strings.TrimSpace(output), "\n")
+		require.Len(t, lines, 3)
+		var last struct {
+			Summary x509inventory.Summary `json:"summary"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(lines[2]), &last))
+		assert.Equal(t, x509inventory.Summary{Total: 2, OK: 1, Unreachable: 1, Worst: x509inventory.HealthUnreachable}, last.Summary)
+
+		var result x509inventory.EndpointResult
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &result))
+		assert.NotEmpty(t, result.Target)
+	})
+
+	t.Run("JSON From Stdin", func(t *testing.T) {
+		stdin, err := os.Open(healthyFile)
+		require.NoError(t, err)
+		defer stdin.Close()
+		oldStdin := os.Stdin
+		os.Stdin = stdin
+		defer func() { os.Stdin = oldStdin }()
+
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "scan", "--format", "json"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+
+		var report x509inventory.EndpointReport
+		require.NoError(t, json.Unmarshal([]byte(output), &report))
+		require.Len(t, report.Results, 1)
+		assert.Equal(t, healthy, report.Results[0].Target)
+		assert.Equal(t, x509inventory.HealthOK, report.Results[0].Health)
+	})
+
+	t.Run("Invalid Target", func(t *testing.T) {
+		badFile := filepath.Join(dir, "bad.txt")
+		require.NoError(t, os.WriteFile(badFile, []byte("example.com:99999\n"), 0644))
+		os.Args = []string{"cmd", "scan", badFile}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509inventory.ErrInvalidTarget)
+	})
+
+	t.Run("Invalid Format", func(t *testing.T) {
+		os.Args = []string{"cmd", "scan", healthyFile, "--format", "xml"}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), "unsupported format")
+	})
+
+	t.Run("Empty Targets", func(t *testing.T) {
+		emptyFile := filepath.Join(dir, "empty.txt")
+		require.NoError(t, os.WriteFile(emptyFile, []byte("# nothing\n"), 0644))
+		os.Args = []string{"cmd", "scan", emptyFile}
+		assert.ErrorContains(t, cli.Execute(context.Background(), version, log), "no targets")
+	})
+}
+
+// newDiscardLogger returns a standard logger that drops TLS handshake noise from test servers.
+func newDiscardLogger() *log.Logger {
+	return log.New(io.Discard, "", 0)
+}
@@ -0,0 +1,136 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/spf13/cobra"
+)
+
+var (
+	validateIncludeSystem bool // Include the system root CA before verifying the chain
+	validateVerbose       bool // Append the name and policy constraints report
+)
+
+var (
+	// ErrValidationFailed is returned when the resolved chain does not verify.
+	ErrValidationFailed = errors.New("certificate chain validation failed")
+)
+
+// newValidateCmd creates the validate subcommand.
+//
+// The command resolves the chain of a certificate and verifies it. With
+// --verbose it also reports which name constraints apply at each level of the
+// chain, whether every leaf SAN falls inside them, and the effective
+// certificate policy set, which is printed even when verification fails.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured validate command
+func newValidateCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate CERT_FILE",
+		Short: "Resolve and verify the certificate chain",
+		Example: fmt.Sprintf(`  %s validate test-leaf.cer --include-system
+  %s validate test-leaf.cer -s --verbose`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execValidate(ctx, args[0], cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&validateIncludeSystem, "include-system", "s", false, "include root CA from system before verifying")
+	cmd.Flags().BoolVarP(&validateVerbose, "verbose", "v", false, "report name constraints and certificate policy processing")
+
+	return cmd
+}
+
+// execValidate resolves the chain of the certificate at path and verifies it.
+//
+// Parameters:
+//   - ctx: Context for cancellation during chain resolution
+//   - path: Path to a PEM or DER encoded certificate
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Reading or decoding error, or ErrValidationFailed wrapping the resolution or verification error
+func execValidate(ctx context.Context, path, version string) error {
+	certData, err := readCertificateFile(path)
+	if err != nil {
+		return err
+	}
+
+	cert, err := decodeCertificate(certData, x509certs.New())
+	if err != nil {
+		return err
+	}
+
+	// Chain resolution ends with verification, so a failure here still leaves
+	// the resolved certificates available for the report.
+	chain, verifyErr := fetchCertificateChain(ctx, cert, version)
+	if chain == nil {
+		return verifyErr
+	}
+
+	if verifyErr == nil && validateIncludeSystem {
+		if err = chain.AddRootCA(); err != nil {
+			return fmt.Errorf("error adding root CA: %w", err)
+		}
+		verifyErr = chain.VerifyChain()
+	}
+
+	printValidation(chain, verifyErr)
+
+	if verifyErr != nil {
+		return fmt.Errorf("%w: %w", ErrValidationFailed, verifyErr)
+	}
+	return nil
+}
+
+// printValidation prints the chain details, the verification result, and in
+// verbose mode the constraints report.
+//
+// Parameters:
+//   - chain: Resolved certificate chain
+//   - verifyErr: Result of chain verification, nil when the chain verified
+func printValidation(chain *x509chain.Chain, verifyErr error) {
+	fmt.Println("Chain Details:")
+	for i, c := range chain.Certs {
+		fmt.Printf("%d: %s\n", i+1, c.Subject.CommonName)
+		fmt.Printf("   Valid: %s to %s\n", c.NotBefore.Format("2006-01-02"), c.NotAfter.Format("2006-01-02"))
+		switch {
+		case chain.IsRootNode(c):
+			fmt.Println("   Type: Root CA")
+		case chain.IsSelfSigned(c):
+			fmt.Println("   Type: Self-signed")
+		case i == 0:
+			fmt.Println("   Type: Leaf")
+		default:
+			fmt.Println("   Type: Intermediate")
+		}
+	}
+	fmt.Printf("\nTotal certificates: %d\n", len(chain.Certs))
+
+	if verifyErr != nil {
+		fmt.Printf("Validation: FAILED (%v)\n", verifyErr)
+	} else {
+		fmt.Println("Validation: PASSED")
+	}
+
+	if validateVerbose {
+		fmt.Println("\nConstraints Evaluation:")
+		fmt.Print(chain.EvaluateConstraints().RenderText())
+	}
+}
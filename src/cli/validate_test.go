@@ -0,0 +1,124 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConstrainedLeaf writes a leaf issued by a CA that only permits
+// example.com. The CA is served over AIA from a local test server, and the
+// leaf also names www.example.org, so chain verification fails.
+func writeConstrainedLeaf(t *testing.T, dir string) string {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Constrained CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		PermittedDNSDomains:   []string{"example.com"},
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pkix-cert")
+		w.Write(caDER)
+	}))
+	t.Cleanup(server.Close)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "www.example.com"},
+		DNSNames:              []string{"www.example.com", "www.example.org"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		IssuingCertificateURL: []string{server.URL + "/ca.der"},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, caTmpl, &key.PublicKey, caKey)
+	require.NoError(t, err)
+
+	path := filepath.Join(dir, "constrained.crt")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	return path
+}
+
+func TestExecute_Validate(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+
+	tmpDir := t.TempDir()
+	certPath, _ := writeSelfSignedKeyPair(t, tmpDir)
+
+	t.Run("Valid Chain", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "validate", certPath}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "1: bundle.example.com")
+		assert.Contains(t, output, "Type: Leaf")
+		assert.Contains(t, output, "Validation: PASSED")
+		assert.NotContains(t, output, "Constraints Evaluation:")
+	})
+
+	t.Run("Verbose", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "validate", certPath, "--verbose"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		require.NoError(t, execErr)
+		assert.Contains(t, output, "Constraints Evaluation:")
+		assert.Contains(t, output, "No CA in the chain carries name constraints.")
+		assert.Contains(t, output, "Policy Processing: VALID")
+	})
+
+	t.Run("Verification Failure Still Reports Constraints", func(t *testing.T) {
+		var execErr error
+		output := captureStdout(t, func() {
+			os.Args = []string{"cmd", "validate", writeConstrainedLeaf(t, tmpDir), "-v"}
+			execErr = cli.Execute(context.Background(), version, log)
+		})
+		assert.ErrorIs(t, execErr, cli.ErrValidationFailed)
+		var invalid x509.CertificateInvalidError
+		require.ErrorAs(t, execErr, &invalid)
+		assert.Equal(t, x509.CANotAuthorizedForThisName, invalid.Reason)
+		assert.Contains(t, output, "Validation: FAILED")
+		assert.Contains(t, output, "Type: Root CA")
+		assert.Contains(t, output, "Permitted DNS:   example.com")
+		assert.Contains(t, output, "DNS:www.example.org — NOT ALLOWED")
+		assert.Contains(t, output, "Name Constraints: VIOLATED")
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	x509watch "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/watch"
+	"github.com/spf13/cobra"
+)
+
+var (
+	watchOnce bool // Run a single check and exit instead of watching continuously
+)
+
+// newWatchCmd creates the watch subcommand.
+//
+// The command loads a JSON or YAML watch configuration listing certificate
+// files, directories, and TLS endpoints, re-checks them on an interval, and
+// notifies webhook, command, file, or syslog sinks when a certificate crosses
+// an expiry threshold, expires, or is renewed. Alert state is kept in a state
+// file so that restarts and --once runs from cron do not repeat alerts.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the watcher
+//   - exeName: Executable name used in usage examples
+//
+// Returns:
+//   - *cobra.Command: Configured watch command
+func newWatchCmd(ctx context.Context, exeName string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch CONFIG",
+		Short: "Continuously monitor certificate expiry and send threshold alerts to webhooks, commands, files, or syslog",
+		Long: `Watch re-checks the files, directories, and endpoints listed in CONFIG
+(JSON, or YAML with a .yaml/.yml extension) on an interval, and notifies
+every sink when a certificate enters a nearer expiry threshold (default
+30, 14, 7, and 1 days), expires, or is renewed after an alert.
+
+Example configuration:
+
+  interval: 1h
+  thresholds: [30, 14, 7, 1]
+  stateFile: /var/lib/cert-watch/state.json
+  files: [/etc/nginx/tls, ./k8s]
+  endpoints: [example.com, mail.example.com:993]
+  sinks:
+    - type: webhook
+      url: https://hooks.example.com/tls
+      headers: {Authorization: "Bearer TOKEN"}
+    - type: command
+      command: [/usr/local/bin/notify-oncall]
+    - type: file
+      path: /var/log/cert-watch.jsonl
+    - type: syslog
+      tag: cert-watch`,
+		Example: fmt.Sprintf(`  %s watch watch.yaml
+  %s watch watch.json --once  # single check, e.g. from cron`, exeName, exeName),
+		Args:         cobra.ExactArgs(1),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return execWatch(ctx, args[0], cmd.Root().Version)
+		},
+	}
+
+	cmd.Flags().BoolVar(&watchOnce, "once", false, "run a single check and exit")
+
+	return cmd
+}
+
+// execWatch loads the configuration and runs the watcher.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the watcher
+//   - configPath: Path to the watch configuration
+//   - version: Application version string for HTTP User-Agent headers
+//
+// Returns:
+//   - error: Configuration or state error, or with --once any check error
+func execWatch(ctx context.Context, configPath, version string) error {
+	cfg, err := x509watch.LoadConfig(configPath)
+	if err != nil {
+		return err
+	}
+	watcher, err := x509watch.New(cfg, version, globalLogger)
+	if err != nil {
+		return err
+	}
+
+	if watchOnce {
+		events, err := watcher.Check(ctx)
+		for _, event := range events {
+			globalLogger.Println(event.Message)
+		}
+		if err != nil {
+			return fmt.Errorf("watch check failed: %w", err)
+		}
+		return nil
+	}
+
+	globalLogger.Printf("Watching %d file path(s) and %d endpoint(s) from %s", len(cfg.Files), len(cfg.Endpoints), configPath)
+	return watcher.Run(ctx)
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/cli"
+	x509watch "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/watch"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecute_Watch(t *testing.T) {
+	var logs bytes.Buffer
+	log := logger.NewMCPLogger(&logs, false)
+
+	certDir := t.TempDir()
+	writeSelfSignedKeyPair(t, certDir)
+
+	dir := t.TempDir()
+	eventLog := filepath.Join(dir, "events.jsonl")
+	configPath := filepath.Join(dir, "watch.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`interval: 50ms
+stateFile: `+filepath.Join(dir, "state.json")+`
+files: [`+certDir+`]
+sinks:
+  - type: file
+    path: `+eventLog+`
+`), 0644))
+
+	t.Run("Once", func(t *testing.T) {
+		os.Args = []string{"cmd", "watch", configPath, "--once"}
+		require.NoError(t, cli.Execute(context.Background(), version, log))
+
+		data, err := os.ReadFile(eventLog)
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+		require.Len(t, lines, 1)
+		var event x509watch.Event
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), &event))
+		assert.Equal(t, x509watch.EventThreshold, event.Kind)
+		assert.Equal(t, 1, event.Threshold)
+		assert.Equal(t, "CN=bundle.example.com", event.Subject)
+		assert.Contains(t, logs.String(), "within the 1-day threshold")
+	})
+
+	t.Run("Continuous Until Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+		defer cancel()
+
+		os.Args = []string{"cmd", "watch", configPath}
+		require.NoError(t, cli.Execute(ctx, version, log))
+		assert.Contains(t, logs.String(), "Watch check complete: 0 event(s)")
+
+		// The alert from the previous run is not repeated
+		data, err := os.ReadFile(eventLog)
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(string(data), "\n"))
+	})
+
+	t.Run("Invalid Config", func(t *testing.T) {
+		badPath := filepath.Join(dir, "bad.yaml")
+		require.NoError(t, os.WriteFile(badPath, []byte("files: [/tmp]\n"), 0644))
+		os.Args = []string{"cmd", "watch", badPath}
+		assert.ErrorIs(t, cli.Execute(context.Background(), version, log), x509watch.ErrNoSinks)
+	})
+}
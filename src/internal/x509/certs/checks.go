@@ -0,0 +1,236 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// Severity classifies how serious a [Finding] is.
+type Severity string
+
+const (
+	// SeverityError marks a problem a publicly-trusted CA would reject or clients would fail on.
+	SeverityError Severity = "error"
+	// SeverityWarning marks a problem that is allowed but discouraged.
+	SeverityWarning Severity = "warning"
+	// SeverityInfo marks an informational observation.
+	SeverityInfo Severity = "info"
+)
+
+// Check identifiers reported in [Finding.Check].
+const (
+	// CheckKeySize validates public key algorithm and strength.
+	CheckKeySize = "key-size"
+	// CheckSignatureAlgorithm validates the signature hash algorithm.
+	CheckSignatureAlgorithm = "signature-algorithm"
+	// CheckSANPresent requires at least one Subject Alternative Name.
+	CheckSANPresent = "san-present"
+	// CheckSANSyntax validates DNS names in the Subject Alternative Name extension.
+	CheckSANSyntax = "san-syntax"
+	// CheckCommonNameInSAN requires the subject common name to be repeated in the SANs.
+	CheckCommonNameInSAN = "cn-in-san"
+	// CheckCSRSignature validates the self-signature of a certificate request.
+	CheckCSRSignature = "csr-signature"
+)
+
+const (
+	// minRSABits: Smallest RSA modulus accepted by the CA/Browser Forum Baseline Requirements
+	minRSABits = 2048
+	// minECDSABits: Smallest ECDSA curve accepted by the CA/Browser Forum Baseline Requirements
+	minECDSABits = 256
+)
+
+// Finding is the outcome of a single check.
+type Finding struct {
+	// Check: Identifier of the check that produced the finding
+	Check string `json:"check" yaml:"check"`
+	// Severity: Finding severity (error, warning, or info)
+	Severity Severity `json:"severity" yaml:"severity"`
+	// Message: Human-readable explanation
+	Message string `json:"message" yaml:"message"`
+	// Citation: Requirement the finding relates to, set by lint rules
+	Citation string `json:"citation,omitempty" yaml:"citation,omitempty"`
+}
+
+// subjectNames groups the identity fields shared by certificates and certificate requests.
+type subjectNames struct {
+	// commonName: Subject common name
+	commonName string
+	// dnsNames: DNS Subject Alternative Names
+	dnsNames []string
+	// ipAddresses: IP address Subject Alternative Names
+	ipAddresses []net.IP
+	// emailAddresses: Email Subject Alternative Names
+	emailAddresses []string
+	// uris: URI Subject Alternative Names
+	uris []*url.URL
+}
+
+// HasErrors reports whether any finding has error severity.
+//
+// Parameters:
+//   - findings: Findings to inspect
+//
+// Returns:
+//   - bool: true if at least one finding is an error
+func HasErrors(findings []Finding) bool {
+	return slices.ContainsFunc(findings, func(f Finding) bool { return f.Severity == SeverityError })
+}
+
+// PublicKeyInfo describes a public key's algorithm and size.
+//
+// It is a shorthand for [DescribePublicKey] when only the name and size are needed.
+//
+// Parameters:
+//   - pub: Public key from a certificate or certificate request
+//
+// Returns:
+//   - algorithm: Algorithm name (e.g. "RSA", "ECDSA P-256", "Ed25519")
+//   - bits: Key size in bits, or 0 when unknown
+func PublicKeyInfo(pub any) (algorithm string, bits int) {
+	desc := DescribePublicKey(pub)
+	return desc.Algorithm, desc.Bits
+}
+
+// CheckCertificate runs the key-size, signature algorithm and SAN checks against a certificate.
+//
+// The same checks back [Certificate.InspectCSR], so a request that passes here
+// will not be rejected for these reasons once issued.
+//
+// Parameters:
+//   - cert: Certificate to check
+//
+// Returns:
+//   - []Finding: Findings in check order (empty when everything passes)
+func (c *Certificate) CheckCertificate(cert *x509.Certificate) []Finding {
+	var findings []Finding
+	findings = append(findings, checkPublicKey(cert.PublicKey, DescribeKey(cert))...)
+	findings = append(findings, checkSignatureAlgorithm(cert.SignatureAlgorithm)...)
+	if !cert.IsCA {
+		findings = append(findings, checkSubjectNames(subjectNames{
+			commonName:     cert.Subject.CommonName,
+			dnsNames:       cert.DNSNames,
+			ipAddresses:    cert.IPAddresses,
+			emailAddresses: cert.EmailAddresses,
+			uris:           cert.URIs,
+		})...)
+	}
+	return findings
+}
+
+// checkPublicKey validates the public key algorithm and size.
+//
+// Keys the standard library cannot parse are judged from their description.
+func checkPublicKey(pub any, desc KeyDescription) []Finding {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		if desc.Bits < minRSABits {
+			return []Finding{{Check: CheckKeySize, Severity: SeverityError, Message: fmt.Sprintf("RSA key is %d bits; at least %d bits are required", desc.Bits, minRSABits)}}
+		}
+		if key.E < 65537 {
+			return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("RSA public exponent %d is smaller than 65537", key.E)}}
+		}
+		return nil
+	case *ecdsa.PublicKey:
+		if desc.Bits < minECDSABits {
+			return []Finding{{Check: CheckKeySize, Severity: SeverityError, Message: fmt.Sprintf("%s key is %d bits; P-256 or stronger is required", desc.Algorithm, desc.Bits)}}
+		}
+		return nil
+	}
+
+	switch desc.Family {
+	case KeyFamilyEdDSA:
+		return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("%s keys are not accepted by most publicly-trusted CAs", desc.Algorithm)}}
+	case KeyFamilyDSA:
+		return []Finding{{Check: CheckKeySize, Severity: SeverityError, Message: "DSA keys are deprecated and not accepted by publicly-trusted CAs"}}
+	case KeyFamilyMLDSA, KeyFamilySLHDSA, KeyFamilyComposite:
+		return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("post-quantum %s keys are not yet accepted by publicly-trusted CAs", desc.Algorithm)}}
+	case KeyFamilyUnknown:
+		if desc.OID != "" {
+			return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("unrecognized public key algorithm %s", desc.OID)}}
+		}
+		return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("unrecognized public key type %T", pub)}}
+	default:
+		return []Finding{{Check: CheckKeySize, Severity: SeverityWarning, Message: fmt.Sprintf("%s keys cannot be used for signatures", desc.Algorithm)}}
+	}
+}
+
+// checkSignatureAlgorithm rejects MD2, MD5 and SHA-1 based signatures.
+func checkSignatureAlgorithm(alg x509.SignatureAlgorithm) []Finding {
+	switch alg {
+	case x509.MD2WithRSA, x509.MD5WithRSA, x509.SHA1WithRSA, x509.DSAWithSHA1, x509.ECDSAWithSHA1:
+		return []Finding{{Check: CheckSignatureAlgorithm, Severity: SeverityError, Message: fmt.Sprintf("%s uses a broken hash algorithm", alg)}}
+	case x509.DSAWithSHA256:
+		return []Finding{{Check: CheckSignatureAlgorithm, Severity: SeverityError, Message: fmt.Sprintf("%s is deprecated", alg)}}
+	case x509.UnknownSignatureAlgorithm:
+		return []Finding{{Check: CheckSignatureAlgorithm, Severity: SeverityWarning, Message: "unrecognized signature algorithm"}}
+	}
+	return nil
+}
+
+// checkSubjectNames validates the Subject Alternative Names and common name.
+func checkSubjectNames(names subjectNames) []Finding {
+	if len(names.dnsNames)+len(names.ipAddresses)+len(names.emailAddresses)+len(names.uris) == 0 {
+		return []Finding{{Check: CheckSANPresent, Severity: SeverityError, Message: "no Subject Alternative Names; modern clients ignore the common name"}}
+	}
+
+	var findings []Finding
+	for _, name := range names.dnsNames {
+		if err := validateDNSName(name); err != nil {
+			findings = append(findings, Finding{Check: CheckSANSyntax, Severity: SeverityError, Message: fmt.Sprintf("DNS name %q: %v", name, err)})
+		}
+	}
+
+	if cn := names.commonName; cn != "" {
+		inSAN := slices.ContainsFunc(names.dnsNames, func(n string) bool { return strings.EqualFold(n, cn) }) ||
+			slices.ContainsFunc(names.ipAddresses, func(ip net.IP) bool { return ip.String() == cn })
+		if !inSAN {
+			findings = append(findings, Finding{Check: CheckCommonNameInSAN, Severity: SeverityWarning, Message: fmt.Sprintf("common name %q is not listed in the Subject Alternative Names", cn)})
+		}
+	}
+
+	return findings
+}
+
+// validateDNSName checks a SAN DNS name for basic hostname syntax.
+//
+// A single leading "*." wildcard label is accepted.
+func validateDNSName(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty name")
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("longer than 253 characters")
+	}
+
+	labels := strings.Split(strings.TrimSuffix(name, "."), ".")
+	for i, label := range labels {
+		if i == 0 && label == "*" && len(labels) > 2 {
+			continue
+		}
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("label %q must be 1-63 characters", label)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return fmt.Errorf("label %q must not start or end with a hyphen", label)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("label %q contains invalid character %q", label, r)
+			}
+		}
+	}
+
+	return nil
+}
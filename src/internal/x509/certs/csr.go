@@ -0,0 +1,129 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrParseCSR indicates a failure to parse a PKCS#10 certificate request from the provided data.
+	ErrParseCSR = errors.New("x509certs: failed to parse certificate request")
+)
+
+// CSRReport summarizes a [PKCS #10] certificate signing request and its pre-issuance findings.
+//
+// [PKCS #10]: https://grokipedia.com/page/Certificate_signing_request
+type CSRReport struct {
+	// Subject: Full subject distinguished name
+	Subject string `json:"subject"`
+	// CommonName: Subject common name
+	CommonName string `json:"commonName,omitempty"`
+	// DNSNames: Requested DNS Subject Alternative Names
+	DNSNames []string `json:"dnsNames,omitempty"`
+	// IPAddresses: Requested IP address Subject Alternative Names
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	// EmailAddresses: Requested email Subject Alternative Names
+	EmailAddresses []string `json:"emailAddresses,omitempty"`
+	// URIs: Requested URI Subject Alternative Names
+	URIs []string `json:"uris,omitempty"`
+	// PublicKeyAlgorithm: Public key algorithm (e.g. "RSA", "ECDSA P-256")
+	PublicKeyAlgorithm string `json:"publicKeyAlgorithm"`
+	// KeyBits: Public key size in bits
+	KeyBits int `json:"keyBits"`
+	// SecurityBits: Equivalent security strength of the public key in bits
+	SecurityBits int `json:"securityBits"`
+	// SignatureAlgorithm: Algorithm used for the request self-signature
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	// SignatureValid: Whether the request self-signature verifies
+	SignatureValid bool `json:"signatureValid"`
+	// Passed: True when no finding has error severity
+	Passed bool `json:"passed"`
+	// Findings: Results of the key-size, signature algorithm and SAN checks
+	Findings []Finding `json:"findings"`
+}
+
+// DecodeCSR decodes a PKCS#10 certificate request from PEM or DER data.
+//
+// PEM input may use either the "CERTIFICATE REQUEST" or the legacy
+// "NEW CERTIFICATE REQUEST" block type.
+//
+// Parameters:
+//   - data: Raw certificate request data (PEM or DER)
+//
+// Returns:
+//   - *x509.CertificateRequest: Decoded certificate request
+//   - error: ErrInvalidBlockType for non-CSR PEM blocks, or ErrParseCSR
+func (c *Certificate) DecodeCSR(data []byte) (*x509.CertificateRequest, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		if block.Type != "CERTIFICATE REQUEST" && block.Type != "NEW CERTIFICATE REQUEST" {
+			return nil, ErrInvalidBlockType
+		}
+		data = block.Bytes
+	}
+
+	csr, err := x509.ParseCertificateRequest(data)
+	if err != nil {
+		return nil, ErrParseCSR
+	}
+
+	return csr, nil
+}
+
+// InspectCSR runs pre-issuance checks against a certificate request.
+//
+// It applies the same key-size, signature algorithm and SAN checks used by
+// [Certificate.CheckCertificate], plus verification of the request's self-signature,
+// so weak keys or missing SANs are caught before submission to a CA.
+//
+// Parameters:
+//   - csr: Certificate request to inspect
+//
+// Returns:
+//   - *CSRReport: Request summary with findings
+func (c *Certificate) InspectCSR(csr *x509.CertificateRequest) *CSRReport {
+	key := describeKey(csr.PublicKey, csr.RawSubjectPublicKeyInfo)
+
+	report := &CSRReport{
+		Subject:            csr.Subject.String(),
+		CommonName:         csr.Subject.CommonName,
+		DNSNames:           csr.DNSNames,
+		EmailAddresses:     csr.EmailAddresses,
+		PublicKeyAlgorithm: key.Algorithm,
+		KeyBits:            key.Bits,
+		SecurityBits:       key.SecurityBits,
+		SignatureAlgorithm: csr.SignatureAlgorithm.String(),
+		Findings:           []Finding{},
+	}
+	for _, ip := range csr.IPAddresses {
+		report.IPAddresses = append(report.IPAddresses, ip.String())
+	}
+	for _, uri := range csr.URIs {
+		report.URIs = append(report.URIs, uri.String())
+	}
+
+	if err := csr.CheckSignature(); err != nil {
+		report.Findings = append(report.Findings, Finding{Check: CheckCSRSignature, Severity: SeverityError, Message: fmt.Sprintf("request signature does not verify: %v", err)})
+	} else {
+		report.SignatureValid = true
+	}
+
+	report.Findings = append(report.Findings, checkPublicKey(csr.PublicKey, key)...)
+	report.Findings = append(report.Findings, checkSignatureAlgorithm(csr.SignatureAlgorithm)...)
+	report.Findings = append(report.Findings, checkSubjectNames(subjectNames{
+		commonName:     csr.Subject.CommonName,
+		dnsNames:       csr.DNSNames,
+		ipAddresses:    csr.IPAddresses,
+		emailAddresses: csr.EmailAddresses,
+		uris:           csr.URIs,
+	})...)
+
+	report.Passed = !HasErrors(report.Findings)
+	return report
+}
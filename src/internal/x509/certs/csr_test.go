@@ -0,0 +1,194 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// newTestCSR creates a DER encoded certificate request signed by key.
+func newTestCSR(t *testing.T, key crypto.Signer, tmpl *x509.CertificateRequest) []byte {
+	t.Helper()
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+	require.NoError(t, err)
+	return der
+}
+
+// findingChecks returns the check identifiers of the given findings.
+func findingChecks(findings []x509certs.Finding) []string {
+	checks := make([]string, 0, len(findings))
+	for _, f := range findings {
+		checks = append(checks, f.Check)
+	}
+	return checks
+}
+
+func TestCertificate_DecodeCSR(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der := newTestCSR(t, key, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "example.com"},
+		DNSNames: []string{"example.com"},
+	})
+
+	c := x509certs.New()
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "PEM", data: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})},
+		{name: "Legacy PEM Type", data: pem.EncodeToMemory(&pem.Block{Type: "NEW CERTIFICATE REQUEST", Bytes: der})},
+		{name: "DER", data: der},
+		{name: "Certificate PEM", data: []byte(testCertPEM), wantErr: x509certs.ErrInvalidBlockType},
+		{name: "Garbage", data: []byte("not a certificate request"), wantErr: x509certs.ErrParseCSR},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csr, err := c.DecodeCSR(tt.data)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "example.com", csr.Subject.CommonName)
+		})
+	}
+}
+
+func TestCertificate_InspectCSR(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		key        crypto.Signer
+		tmpl       *x509.CertificateRequest
+		wantPassed bool
+		wantChecks []string
+	}{
+		{
+			name: "Valid Request",
+			key:  ecKey,
+			tmpl: &x509.CertificateRequest{
+				Subject:     pkix.Name{CommonName: "www.example.com"},
+				DNSNames:    []string{"www.example.com", "*.api.example.com"},
+				IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+			},
+			wantPassed: true,
+			wantChecks: []string{},
+		},
+		{
+			name: "Weak RSA Key",
+			key:  weakKey,
+			tmpl: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "example.com"},
+				DNSNames: []string{"example.com"},
+			},
+			wantChecks: []string{x509certs.CheckKeySize},
+		},
+		{
+			name:       "Missing SAN",
+			key:        ecKey,
+			tmpl:       &x509.CertificateRequest{Subject: pkix.Name{CommonName: "example.com"}},
+			wantChecks: []string{x509certs.CheckSANPresent},
+		},
+		{
+			name: "Invalid DNS Name",
+			key:  ecKey,
+			tmpl: &x509.CertificateRequest{
+				DNSNames: []string{"-bad-.example.com"},
+			},
+			wantChecks: []string{x509certs.CheckSANSyntax},
+		},
+		{
+			name: "Common Name Not In SAN",
+			key:  ecKey,
+			tmpl: &x509.CertificateRequest{
+				Subject:  pkix.Name{CommonName: "other.example.com"},
+				DNSNames: []string{"example.com"},
+			},
+			wantPassed: true,
+			wantChecks: []string{x509certs.CheckCommonNameInSAN},
+		},
+	}
+
+	c := x509certs.New()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			csr, err := c.DecodeCSR(newTestCSR(t, tt.key, tt.tmpl))
+			require.NoError(t, err)
+
+			report := c.InspectCSR(csr)
+			assert.True(t, report.SignatureValid)
+			assert.Equal(t, tt.wantPassed, report.Passed)
+			assert.Equal(t, tt.wantChecks, findingChecks(report.Findings))
+			assert.Equal(t, !tt.wantPassed, x509certs.HasErrors(report.Findings))
+		})
+	}
+
+	t.Run("Report Fields", func(t *testing.T) {
+		csr, err := c.DecodeCSR(newTestCSR(t, ecKey, tests[0].tmpl))
+		require.NoError(t, err)
+
+		report := c.InspectCSR(csr)
+		assert.Equal(t, "ECDSA P-256", report.PublicKeyAlgorithm)
+		assert.Equal(t, 256, report.KeyBits)
+		assert.Equal(t, "ECDSA-SHA256", report.SignatureAlgorithm)
+		assert.Equal(t, []string{"192.0.2.1"}, report.IPAddresses)
+	})
+}
+
+func TestCertificate_CheckCertificate(t *testing.T) {
+	c := x509certs.New()
+
+	t.Run("CA Skips SAN Checks", func(t *testing.T) {
+		root := newTestCA(t, pkix.Name{CommonName: "Test Root CA"})
+		assert.Empty(t, c.CheckCertificate(root))
+	})
+
+	t.Run("Leaf Without SAN", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "legacy.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+
+		findings := c.CheckCertificate(cert)
+		assert.Equal(t, []string{x509certs.CheckSANPresent}, findingChecks(findings))
+		assert.True(t, x509certs.HasErrors(findings))
+	})
+}
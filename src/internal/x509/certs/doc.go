@@ -8,7 +8,12 @@
 // utilities for handling certificate blocks and bundles. This package is used
 // by the chain resolver to parse inputs and format outputs.
 //
+// It also exports chains as Java truststores (JKS and [PKCS12]) and decodes
+// server private keys (PKCS#1, PKCS#8, SEC 1, and encrypted PKCS#8) so they can
+// be matched against a leaf certificate.
+//
 // [X.509]: https://grokipedia.com/page/X.509
 // [PKCS7]: https://grokipedia.com/page/PKCS_7
+// [PKCS12]: https://grokipedia.com/page/PKCS_12
 // [PEM]: https://grokipedia.com/page/PEM#privacy-enhanced-mail
 package x509certs
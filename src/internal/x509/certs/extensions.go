@@ -0,0 +1,845 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+// errMalformedExtension indicates that an extension value does not match its ASN.1 definition.
+var errMalformedExtension = errors.New("malformed extension value")
+
+// GeneralName is a single RFC 5280 GeneralName, as used in SANs, name constraints, AIA and CDP.
+type GeneralName struct {
+	// Type: Name form ("DNS", "IP", "email", "URI", "dirName", "otherName", "registeredID", "x400Address", or "ediPartyName")
+	Type string `json:"type"`
+	// Value: Rendered name value (IP ranges use CIDR notation, unknown forms are hex)
+	Value string `json:"value"`
+}
+
+// String renders the name as "Type:Value", matching OpenSSL's notation.
+func (n GeneralName) String() string { return n.Type + ":" + n.Value }
+
+// NameConstraintsDetails lists the permitted and excluded subtrees of a name constraints extension.
+type NameConstraintsDetails struct {
+	// Permitted: Permitted subtree bases
+	Permitted []GeneralName `json:"permitted,omitempty"`
+	// Excluded: Excluded subtree bases
+	Excluded []GeneralName `json:"excluded,omitempty"`
+}
+
+// PolicyDetails is a single certificate policy with its qualifiers.
+type PolicyDetails struct {
+	// OID: Policy identifier in dotted notation
+	OID string `json:"oid"`
+	// Name: Well-known policy name, if any (e.g. "CA/B Domain Validated")
+	Name string `json:"name,omitempty"`
+	// CPS: Certification Practice Statement URIs
+	CPS []string `json:"cps,omitempty"`
+	// UserNotices: User notice explicit texts and notice references
+	UserNotices []string `json:"userNotices,omitempty"`
+}
+
+// PolicyMappingDetails maps an issuer domain policy to an equivalent subject domain policy.
+type PolicyMappingDetails struct {
+	// IssuerDomainPolicy: Policy OID in the issuer's domain
+	IssuerDomainPolicy string `json:"issuerDomainPolicy"`
+	// SubjectDomainPolicy: Equivalent policy OID in the subject's domain
+	SubjectDomainPolicy string `json:"subjectDomainPolicy"`
+}
+
+// AccessDescription is a single entry of an Authority or Subject Information Access extension.
+type AccessDescription struct {
+	// Method: Access method name (e.g. "OCSP", "CA Issuers") or OID
+	Method string `json:"method"`
+	// Location: Where the information can be retrieved
+	Location GeneralName `json:"location"`
+}
+
+// Well-known extension OIDs, keyed by dotted string.
+const (
+	oidSubjectKeyID          = "2.5.29.14"
+	oidKeyUsage              = "2.5.29.15"
+	oidSubjectAltName        = "2.5.29.17"
+	oidIssuerAltName         = "2.5.29.18"
+	oidBasicConstraints      = "2.5.29.19"
+	oidNameConstraints       = "2.5.29.30"
+	oidCRLDistributionPoints = "2.5.29.31"
+	oidCertificatePolicies   = "2.5.29.32"
+	oidPolicyMappings        = "2.5.29.33"
+	oidAuthorityKeyID        = "2.5.29.35"
+	oidPolicyConstraints     = "2.5.29.36"
+	oidExtKeyUsage           = "2.5.29.37"
+	oidFreshestCRL           = "2.5.29.46"
+	oidInhibitAnyPolicy      = "2.5.29.54"
+	oidAuthorityInfoAccess   = "1.3.6.1.5.5.7.1.1"
+	oidSubjectInfoAccess     = "1.3.6.1.5.5.7.1.11"
+	oidTLSFeature            = "1.3.6.1.5.5.7.1.24"
+	oidOCSPNoCheck           = "1.3.6.1.5.5.7.48.1.5"
+	oidSCTList               = "1.3.6.1.4.1.11129.2.4.2"
+	oidCTPoison              = "1.3.6.1.4.1.11129.2.4.3"
+)
+
+// extensionNames maps extension OIDs to the names used by OpenSSL.
+var extensionNames = map[string]string{
+	oidSubjectKeyID:          "X509v3 Subject Key Identifier",
+	oidKeyUsage:              "X509v3 Key Usage",
+	oidSubjectAltName:        "X509v3 Subject Alternative Name",
+	oidIssuerAltName:         "X509v3 Issuer Alternative Name",
+	oidBasicConstraints:      "X509v3 Basic Constraints",
+	oidNameConstraints:       "X509v3 Name Constraints",
+	oidCRLDistributionPoints: "X509v3 CRL Distribution Points",
+	oidCertificatePolicies:   "X509v3 Certificate Policies",
+	oidPolicyMappings:        "X509v3 Policy Mappings",
+	oidAuthorityKeyID:        "X509v3 Authority Key Identifier",
+	oidPolicyConstraints:     "X509v3 Policy Constraints",
+	oidExtKeyUsage:           "X509v3 Extended Key Usage",
+	oidFreshestCRL:           "X509v3 Freshest CRL",
+	oidInhibitAnyPolicy:      "X509v3 Inhibit Any Policy",
+	oidAuthorityInfoAccess:   "Authority Information Access",
+	oidSubjectInfoAccess:     "Subject Information Access",
+	oidTLSFeature:            "TLS Feature",
+	oidOCSPNoCheck:           "OCSP No Check",
+	oidSCTList:               "CT Precertificate SCTs",
+	oidCTPoison:              "CT Precertificate Poison",
+}
+
+// accessMethodNames maps AIA/SIA access method OIDs to names.
+var accessMethodNames = map[string]string{
+	"1.3.6.1.5.5.7.48.1":  "OCSP",
+	"1.3.6.1.5.5.7.48.2":  "CA Issuers",
+	"1.3.6.1.5.5.7.48.3":  "Time Stamping",
+	"1.3.6.1.5.5.7.48.5":  "CA Repository",
+	"1.3.6.1.5.5.7.48.10": "RPKI Manifest",
+	"1.3.6.1.5.5.7.48.13": "Signed Object",
+}
+
+// policyNames maps well-known certificate policy OIDs to names.
+var policyNames = map[string]string{
+	"2.5.29.32.0":    "Any Policy",
+	"2.23.140.1.1":   "CA/B Extended Validation",
+	"2.23.140.1.2.1": "CA/B Domain Validated",
+	"2.23.140.1.2.2": "CA/B Organization Validated",
+	"2.23.140.1.2.3": "CA/B Individual Validated",
+	"2.23.140.1.3":   "CA/B Extended Validation Code Signing",
+	"2.23.140.1.4.1": "CA/B Code Signing",
+	"2.23.140.1.5.1": "CA/B S/MIME Mailbox Validated",
+}
+
+// PolicyName returns the well-known name of a certificate policy OID, or an empty
+// string when the policy is not recognised.
+//
+// Parameters:
+//   - oid: Dotted policy OID, e.g. "2.23.140.1.2.1"
+//
+// Returns:
+//   - string: Policy name such as "CA/B Domain Validated", or ""
+func PolicyName(oid string) string {
+	return policyNames[oid]
+}
+
+// otherNameTypes maps otherName type-id OIDs to names.
+var otherNameTypes = map[string]string{
+	"1.3.6.1.4.1.311.20.2.3": "UPN",
+	"1.3.6.1.5.5.7.8.7":      "SRVName",
+	"1.3.6.1.5.5.7.8.9":      "SmtpUTF8Mailbox",
+}
+
+// tlsFeatureStatusRequest is the status_request TLS extension codepoint (RFC 6066).
+const tlsFeatureStatusRequest = 5
+
+// tlsFeatureNames maps TLS extension codepoints found in the TLS Feature extension to names.
+var tlsFeatureNames = map[int]string{
+	tlsFeatureStatusRequest: "status_request (OCSP Must-Staple)",
+	17:                      "status_request_v2",
+}
+
+// keyUsageNames lists key usage bits in bit order with their OpenSSL names.
+var keyUsageNames = []struct {
+	usage x509.KeyUsage
+	name  string
+}{
+	{x509.KeyUsageDigitalSignature, "Digital Signature"},
+	{x509.KeyUsageContentCommitment, "Non Repudiation"},
+	{x509.KeyUsageKeyEncipherment, "Key Encipherment"},
+	{x509.KeyUsageDataEncipherment, "Data Encipherment"},
+	{x509.KeyUsageKeyAgreement, "Key Agreement"},
+	{x509.KeyUsageCertSign, "Certificate Sign"},
+	{x509.KeyUsageCRLSign, "CRL Sign"},
+	{x509.KeyUsageEncipherOnly, "Encipher Only"},
+	{x509.KeyUsageDecipherOnly, "Decipher Only"},
+}
+
+// extKeyUsageNames maps extended key usages to their OpenSSL names.
+var extKeyUsageNames = map[x509.ExtKeyUsage]string{
+	x509.ExtKeyUsageAny:                            "Any Extended Key Usage",
+	x509.ExtKeyUsageServerAuth:                     "TLS Web Server Authentication",
+	x509.ExtKeyUsageClientAuth:                     "TLS Web Client Authentication",
+	x509.ExtKeyUsageCodeSigning:                    "Code Signing",
+	x509.ExtKeyUsageEmailProtection:                "E-mail Protection",
+	x509.ExtKeyUsageIPSECEndSystem:                 "IPSec End System",
+	x509.ExtKeyUsageIPSECTunnel:                    "IPSec Tunnel",
+	x509.ExtKeyUsageIPSECUser:                      "IPSec User",
+	x509.ExtKeyUsageTimeStamping:                   "Time Stamping",
+	x509.ExtKeyUsageOCSPSigning:                    "OCSP Signing",
+	x509.ExtKeyUsageMicrosoftServerGatedCrypto:     "Microsoft Server Gated Crypto",
+	x509.ExtKeyUsageNetscapeServerGatedCrypto:      "Netscape Server Gated Crypto",
+	x509.ExtKeyUsageMicrosoftCommercialCodeSigning: "Microsoft Commercial Code Signing",
+	x509.ExtKeyUsageMicrosoftKernelCodeSigning:     "Microsoft Kernel Code Signing",
+}
+
+// decodeExtension renders a single certificate extension.
+//
+// Extensions that fail to decode, and extensions this package does not know,
+// are reported with their raw value as hex so no information is lost.
+//
+// Parameters:
+//   - cert: Certificate the extension belongs to (for fields already parsed by crypto/x509)
+//   - ext: Extension to decode
+//
+// Returns:
+//   - ExtensionDetails: Decoded extension
+func decodeExtension(cert *x509.Certificate, ext pkix.Extension) ExtensionDetails {
+	oid := ext.Id.String()
+	details := ExtensionDetails{OID: oid, Name: extensionNames[oid], Critical: ext.Critical}
+	if details.Name == "" {
+		details.Name = oid
+	}
+
+	var err error
+	switch oid {
+	case oidSubjectKeyID:
+		details.Values = []string{colonHex(cert.SubjectKeyId)}
+	case oidAuthorityKeyID:
+		details.Values = []string{colonHex(cert.AuthorityKeyId)}
+	case oidKeyUsage:
+		details.Values = []string{strings.Join(keyUsageStrings(cert.KeyUsage), ", ")}
+	case oidExtKeyUsage:
+		details.Values = []string{strings.Join(extKeyUsageStrings(cert), ", ")}
+	case oidBasicConstraints:
+		details.Values = []string{basicConstraintsString(cert)}
+	case oidSubjectAltName, oidIssuerAltName:
+		if details.GeneralNames, err = parseGeneralNames(ext.Value); err == nil {
+			details.Values = []string{joinGeneralNames(details.GeneralNames)}
+		}
+	case oidNameConstraints:
+		if details.NameConstraints, err = parseNameConstraints(ext.Value); err == nil {
+			details.Values = nameConstraintsStrings(details.NameConstraints)
+		}
+	case oidCertificatePolicies:
+		if details.Policies, err = parsePolicies(ext.Value); err == nil {
+			details.Values = policyStrings(details.Policies)
+		}
+	case oidPolicyMappings:
+		if details.PolicyMappings, err = parsePolicyMappings(ext.Value); err == nil {
+			for _, m := range details.PolicyMappings {
+				details.Values = append(details.Values, m.IssuerDomainPolicy+" -> "+m.SubjectDomainPolicy)
+			}
+		}
+	case oidPolicyConstraints:
+		details.Values, err = parsePolicyConstraints(ext.Value)
+	case oidInhibitAnyPolicy:
+		var skip int64
+		input := cryptobyte.String(ext.Value)
+		if !input.ReadASN1Integer(&skip) {
+			err = errMalformedExtension
+		}
+		details.Values = []string{fmt.Sprintf("%d", skip)}
+	case oidAuthorityInfoAccess, oidSubjectInfoAccess:
+		if details.AccessDescriptions, err = parseAccessDescriptions(ext.Value); err == nil {
+			for _, ad := range details.AccessDescriptions {
+				details.Values = append(details.Values, ad.Method+" - "+ad.Location.String())
+			}
+		}
+	case oidCRLDistributionPoints, oidFreshestCRL:
+		if details.DistributionPoints, err = parseDistributionPoints(ext.Value); err == nil {
+			for _, name := range details.DistributionPoints {
+				details.Values = append(details.Values, "Full Name: "+name.String())
+			}
+		}
+	case oidTLSFeature:
+		details.Values, err = parseTLSFeatures(ext.Value)
+	case oidSCTList:
+		if details.SCTs, err = ParseSCTList(ext.Value); err == nil {
+			details.Values = sctStrings(details.SCTs)
+		}
+	case oidCTPoison, oidOCSPNoCheck:
+		details.Values = []string{"NULL"}
+	default:
+		details.Raw = hex.EncodeToString(ext.Value)
+		details.Values = []string{colonHex(ext.Value)}
+	}
+
+	if err != nil {
+		details.Error = err.Error()
+		details.Raw = hex.EncodeToString(ext.Value)
+		details.Values = []string{colonHex(ext.Value)}
+	}
+
+	return details
+}
+
+// parseGeneralNames parses a DER GeneralNames SEQUENCE.
+func parseGeneralNames(der []byte) ([]GeneralName, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+	return readGeneralNames(seq)
+}
+
+// readGeneralNames reads GeneralName elements until the input is exhausted.
+func readGeneralNames(input cryptobyte.String) ([]GeneralName, error) {
+	var names []GeneralName
+	for !input.Empty() {
+		name, err := readGeneralName(&input)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// readGeneralName reads a single context-tagged GeneralName.
+func readGeneralName(input *cryptobyte.String) (GeneralName, error) {
+	var (
+		content cryptobyte.String
+		tag     cbasn1.Tag
+	)
+	if !input.ReadAnyASN1(&content, &tag) {
+		return GeneralName{}, errMalformedExtension
+	}
+
+	switch tag {
+	case cbasn1.Tag(0).ContextSpecific().Constructed():
+		return GeneralName{Type: "otherName", Value: otherNameString(content)}, nil
+	case cbasn1.Tag(1).ContextSpecific():
+		return GeneralName{Type: "email", Value: string(content)}, nil
+	case cbasn1.Tag(2).ContextSpecific():
+		return GeneralName{Type: "DNS", Value: string(content)}, nil
+	case cbasn1.Tag(3).ContextSpecific().Constructed():
+		return GeneralName{Type: "x400Address", Value: hex.EncodeToString(content)}, nil
+	case cbasn1.Tag(4).ContextSpecific().Constructed():
+		var rdn pkix.RDNSequence
+		if rest, err := asn1.Unmarshal(content, &rdn); err != nil || len(rest) > 0 {
+			return GeneralName{}, errMalformedExtension
+		}
+		var name pkix.Name
+		name.FillFromRDNSequence(&rdn)
+		return GeneralName{Type: "dirName", Value: name.String()}, nil
+	case cbasn1.Tag(5).ContextSpecific().Constructed():
+		return GeneralName{Type: "ediPartyName", Value: hex.EncodeToString(content)}, nil
+	case cbasn1.Tag(6).ContextSpecific():
+		return GeneralName{Type: "URI", Value: string(content)}, nil
+	case cbasn1.Tag(7).ContextSpecific():
+		return GeneralName{Type: "IP", Value: ipString(content)}, nil
+	case cbasn1.Tag(8).ContextSpecific():
+		var oid x509.OID
+		if err := oid.UnmarshalBinary(content); err != nil {
+			return GeneralName{}, errMalformedExtension
+		}
+		return GeneralName{Type: "registeredID", Value: oid.String()}, nil
+	default:
+		return GeneralName{Type: fmt.Sprintf("tag%d", tag&0x1f), Value: hex.EncodeToString(content)}, nil
+	}
+}
+
+// otherNameString renders an otherName as "type-id:value".
+//
+// String values are shown as text; anything else is shown as hex.
+func otherNameString(content cryptobyte.String) string {
+	var (
+		oid   asn1.ObjectIdentifier
+		value cryptobyte.String
+	)
+	if !content.ReadASN1ObjectIdentifier(&oid) || !content.ReadASN1(&value, cbasn1.Tag(0).ContextSpecific().Constructed()) {
+		return hex.EncodeToString(content)
+	}
+
+	typeName := otherNameTypes[oid.String()]
+	if typeName == "" {
+		typeName = oid.String()
+	}
+
+	if text, ok := asn1StringValue(value); ok {
+		return typeName + ":" + text
+	}
+	return typeName + ":" + hex.EncodeToString(value)
+}
+
+// asn1StringValue decodes a single ASN.1 string element (UTF8, IA5, Printable, Visible, or BMP).
+func asn1StringValue(input cryptobyte.String) (string, bool) {
+	var (
+		content cryptobyte.String
+		tag     cbasn1.Tag
+	)
+	if !input.ReadAnyASN1(&content, &tag) {
+		return "", false
+	}
+
+	switch tag {
+	case cbasn1.UTF8String, cbasn1.IA5String, cbasn1.PrintableString, cbasn1.Tag(26): // VisibleString
+		return string(content), true
+	case cbasn1.Tag(30): // BMPString
+		if len(content)%2 != 0 {
+			return "", false
+		}
+		units := make([]uint16, 0, len(content)/2)
+		for i := 0; i < len(content); i += 2 {
+			units = append(units, uint16(content[i])<<8|uint16(content[i+1]))
+		}
+		return string(utf16.Decode(units)), true
+	default:
+		return "", false
+	}
+}
+
+// ipString renders an iPAddress GeneralName; 8 or 32 byte values are address/mask pairs.
+func ipString(b []byte) string {
+	switch len(b) {
+	case net.IPv4len, net.IPv6len:
+		return net.IP(b).String()
+	case 2 * net.IPv4len, 2 * net.IPv6len:
+		n := len(b) / 2
+		return (&net.IPNet{IP: net.IP(b[:n]), Mask: net.IPMask(b[n:])}).String()
+	default:
+		return hex.EncodeToString(b)
+	}
+}
+
+// parseNameConstraints parses the NameConstraints extension (RFC 5280 Section 4.2.1.10).
+func parseNameConstraints(der []byte) (*NameConstraintsDetails, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	readSubtrees := func(tag cbasn1.Tag) ([]GeneralName, error) {
+		var (
+			subtrees cryptobyte.String
+			present  bool
+		)
+		if !seq.ReadOptionalASN1(&subtrees, &present, tag) {
+			return nil, errMalformedExtension
+		}
+
+		var bases []GeneralName
+		for !subtrees.Empty() {
+			var subtree cryptobyte.String
+			if !subtrees.ReadASN1(&subtree, cbasn1.SEQUENCE) {
+				return nil, errMalformedExtension
+			}
+			base, err := readGeneralName(&subtree)
+			if err != nil {
+				return nil, err
+			}
+			bases = append(bases, base)
+		}
+		return bases, nil
+	}
+
+	permitted, err := readSubtrees(cbasn1.Tag(0).ContextSpecific().Constructed())
+	if err != nil {
+		return nil, err
+	}
+	excluded, err := readSubtrees(cbasn1.Tag(1).ContextSpecific().Constructed())
+	if err != nil {
+		return nil, err
+	}
+
+	return &NameConstraintsDetails{Permitted: permitted, Excluded: excluded}, nil
+}
+
+// parsePolicies parses the CertificatePolicies extension including CPS and user notice qualifiers.
+func parsePolicies(der []byte) ([]PolicyDetails, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var policies []PolicyDetails
+	for !seq.Empty() {
+		var (
+			info, oidBytes, qualifiers cryptobyte.String
+			hasQualifiers              bool
+			oid                        x509.OID
+		)
+		if !seq.ReadASN1(&info, cbasn1.SEQUENCE) ||
+			!info.ReadASN1(&oidBytes, cbasn1.OBJECT_IDENTIFIER) ||
+			!info.ReadOptionalASN1(&qualifiers, &hasQualifiers, cbasn1.SEQUENCE) ||
+			oid.UnmarshalBinary(oidBytes) != nil {
+			return nil, errMalformedExtension
+		}
+
+		policy := PolicyDetails{OID: oid.String(), Name: policyNames[oid.String()]}
+		for !qualifiers.Empty() {
+			var (
+				qualifier cryptobyte.String
+				id        asn1.ObjectIdentifier
+			)
+			if !qualifiers.ReadASN1(&qualifier, cbasn1.SEQUENCE) || !qualifier.ReadASN1ObjectIdentifier(&id) {
+				return nil, errMalformedExtension
+			}
+
+			switch id.String() {
+			case "1.3.6.1.5.5.7.2.1": // id-qt-cps
+				if uri, ok := asn1StringValue(qualifier); ok {
+					policy.CPS = append(policy.CPS, uri)
+				}
+			case "1.3.6.1.5.5.7.2.2": // id-qt-unotice
+				policy.UserNotices = append(policy.UserNotices, userNoticeString(qualifier))
+			}
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+// userNoticeString renders a UserNotice qualifier's notice reference and explicit text.
+func userNoticeString(input cryptobyte.String) string {
+	var notice cryptobyte.String
+	if !input.ReadASN1(&notice, cbasn1.SEQUENCE) {
+		return ""
+	}
+
+	var parts []string
+	if notice.PeekASN1Tag(cbasn1.SEQUENCE) {
+		var ref, org, numbers cryptobyte.String
+		if notice.ReadASN1(&ref, cbasn1.SEQUENCE) && ref.ReadAnyASN1Element(&org, nil) && ref.ReadASN1(&numbers, cbasn1.SEQUENCE) {
+			organization, _ := asn1StringValue(org)
+			var nums []string
+			for !numbers.Empty() {
+				var n int64
+				if !numbers.ReadASN1Integer(&n) {
+					break
+				}
+				nums = append(nums, fmt.Sprintf("%d", n))
+			}
+			parts = append(parts, fmt.Sprintf("Organization: %s, Numbers: %s", organization, strings.Join(nums, ",")))
+		}
+	}
+	if !notice.Empty() {
+		if text, ok := asn1StringValue(notice); ok {
+			parts = append(parts, "Explicit Text: "+text)
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
+// parsePolicyMappings parses the PolicyMappings extension.
+func parsePolicyMappings(der []byte) ([]PolicyMappingDetails, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var mappings []PolicyMappingDetails
+	for !seq.Empty() {
+		var (
+			mapping               cryptobyte.String
+			issuerRaw, subjectRaw cryptobyte.String
+			issuerOID, subjectOID x509.OID
+		)
+		if !seq.ReadASN1(&mapping, cbasn1.SEQUENCE) ||
+			!mapping.ReadASN1(&issuerRaw, cbasn1.OBJECT_IDENTIFIER) ||
+			!mapping.ReadASN1(&subjectRaw, cbasn1.OBJECT_IDENTIFIER) ||
+			issuerOID.UnmarshalBinary(issuerRaw) != nil ||
+			subjectOID.UnmarshalBinary(subjectRaw) != nil {
+			return nil, errMalformedExtension
+		}
+		mappings = append(mappings, PolicyMappingDetails{
+			IssuerDomainPolicy:  issuerOID.String(),
+			SubjectDomainPolicy: subjectOID.String(),
+		})
+	}
+
+	return mappings, nil
+}
+
+// parsePolicyConstraints parses the PolicyConstraints extension into display lines.
+func parsePolicyConstraints(der []byte) ([]string, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var (
+		values []string
+		skip   int64
+	)
+	for _, field := range []struct {
+		tag  cbasn1.Tag
+		name string
+	}{
+		{cbasn1.Tag(0).ContextSpecific(), "Require Explicit Policy"},
+		{cbasn1.Tag(1).ContextSpecific(), "Inhibit Policy Mapping"},
+	} {
+		if !seq.PeekASN1Tag(field.tag) {
+			continue
+		}
+		var content cryptobyte.String
+		if !seq.ReadASN1(&content, field.tag) {
+			return nil, errMalformedExtension
+		}
+		skip = 0
+		for _, b := range content {
+			skip = skip<<8 | int64(b)
+		}
+		values = append(values, fmt.Sprintf("%s: %d", field.name, skip))
+	}
+
+	return values, nil
+}
+
+// parseAccessDescriptions parses an Authority or Subject Information Access extension.
+func parseAccessDescriptions(der []byte) ([]AccessDescription, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var descriptions []AccessDescription
+	for !seq.Empty() {
+		var (
+			desc   cryptobyte.String
+			method asn1.ObjectIdentifier
+		)
+		if !seq.ReadASN1(&desc, cbasn1.SEQUENCE) || !desc.ReadASN1ObjectIdentifier(&method) {
+			return nil, errMalformedExtension
+		}
+		location, err := readGeneralName(&desc)
+		if err != nil {
+			return nil, err
+		}
+
+		name := accessMethodNames[method.String()]
+		if name == "" {
+			name = method.String()
+		}
+		descriptions = append(descriptions, AccessDescription{Method: name, Location: location})
+	}
+
+	return descriptions, nil
+}
+
+// parseDistributionPoints parses CRL Distribution Points, returning the full names of every point.
+//
+// Points identified only by a name relative to the CRL issuer are skipped.
+func parseDistributionPoints(der []byte) ([]GeneralName, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var names []GeneralName
+	for !seq.Empty() {
+		var (
+			point, dpName, fullName cryptobyte.String
+			hasName, hasFullName    bool
+		)
+		if !seq.ReadASN1(&point, cbasn1.SEQUENCE) ||
+			!point.ReadOptionalASN1(&dpName, &hasName, cbasn1.Tag(0).ContextSpecific().Constructed()) {
+			return nil, errMalformedExtension
+		}
+		if !hasName {
+			continue
+		}
+		if !dpName.ReadOptionalASN1(&fullName, &hasFullName, cbasn1.Tag(0).ContextSpecific().Constructed()) {
+			return nil, errMalformedExtension
+		}
+		if !hasFullName {
+			continue
+		}
+
+		pointNames, err := readGeneralNames(fullName)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, pointNames...)
+	}
+
+	return names, nil
+}
+
+// HasMustStaple reports whether a certificate carries the TLS Feature extension
+// with status_request, also known as [OCSP Must-Staple] (RFC 7633).
+//
+// Parameters:
+//   - cert: Certificate to check
+//
+// Returns:
+//   - bool: True when clients must require a stapled OCSP response
+//
+// [OCSP Must-Staple]: https://grokipedia.com/page/OCSP_stapling
+func HasMustStaple(cert *x509.Certificate) bool {
+	for _, ext := range cert.Extensions {
+		if ext.Id.String() != oidTLSFeature {
+			continue
+		}
+		features, err := parseTLSFeatures(ext.Value)
+		return err == nil && slices.Contains(features, tlsFeatureNames[tlsFeatureStatusRequest])
+	}
+	return false
+}
+
+// parseTLSFeatures parses the TLS Feature extension (RFC 7633) into feature names.
+func parseTLSFeatures(der []byte) ([]string, error) {
+	var seq cryptobyte.String
+	input := cryptobyte.String(der)
+	if !input.ReadASN1(&seq, cbasn1.SEQUENCE) || !input.Empty() {
+		return nil, errMalformedExtension
+	}
+
+	var features []string
+	for !seq.Empty() {
+		var feature int
+		if !seq.ReadASN1Integer(&feature) {
+			return nil, errMalformedExtension
+		}
+		if name, ok := tlsFeatureNames[feature]; ok {
+			features = append(features, name)
+		} else {
+			features = append(features, fmt.Sprintf("unknown(%d)", feature))
+		}
+	}
+
+	return features, nil
+}
+
+// keyUsageStrings returns the names of the key usage bits that are set.
+func keyUsageStrings(usage x509.KeyUsage) []string {
+	var names []string
+	for _, ku := range keyUsageNames {
+		if usage&ku.usage != 0 {
+			names = append(names, ku.name)
+		}
+	}
+	return names
+}
+
+// extKeyUsageStrings returns the names of the extended key usages, including unknown OIDs.
+func extKeyUsageStrings(cert *x509.Certificate) []string {
+	var names []string
+	for _, eku := range cert.ExtKeyUsage {
+		if name, ok := extKeyUsageNames[eku]; ok {
+			names = append(names, name)
+		}
+	}
+	for _, oid := range cert.UnknownExtKeyUsage {
+		names = append(names, oid.String())
+	}
+	return names
+}
+
+// basicConstraintsString renders the basic constraints extension like OpenSSL.
+func basicConstraintsString(cert *x509.Certificate) string {
+	if !cert.IsCA {
+		return "CA:FALSE"
+	}
+	if cert.MaxPathLen > 0 || cert.MaxPathLenZero {
+		return fmt.Sprintf("CA:TRUE, pathlen:%d", cert.MaxPathLen)
+	}
+	return "CA:TRUE"
+}
+
+// joinGeneralNames renders general names as a comma-separated list.
+func joinGeneralNames(names []GeneralName) string {
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = name.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// nameConstraintsStrings renders name constraints as display lines.
+func nameConstraintsStrings(nc *NameConstraintsDetails) []string {
+	var lines []string
+	if len(nc.Permitted) > 0 {
+		lines = append(lines, "Permitted:")
+		for _, name := range nc.Permitted {
+			lines = append(lines, "  "+name.String())
+		}
+	}
+	if len(nc.Excluded) > 0 {
+		lines = append(lines, "Excluded:")
+		for _, name := range nc.Excluded {
+			lines = append(lines, "  "+name.String())
+		}
+	}
+	return lines
+}
+
+// policyStrings renders certificate policies and their qualifiers as display lines.
+func policyStrings(policies []PolicyDetails) []string {
+	var lines []string
+	for _, p := range policies {
+		line := "Policy: " + p.OID
+		if p.Name != "" {
+			line += " (" + p.Name + ")"
+		}
+		lines = append(lines, line)
+		for _, cps := range p.CPS {
+			lines = append(lines, "  CPS: "+cps)
+		}
+		for _, notice := range p.UserNotices {
+			lines = append(lines, "  User Notice: "+notice)
+		}
+	}
+	return lines
+}
+
+// sctStrings renders embedded SCTs as display lines.
+func sctStrings(scts []SignedCertificateTimestamp) []string {
+	var lines []string
+	for _, sct := range scts {
+		lines = append(lines,
+			"Signed Certificate Timestamp:",
+			fmt.Sprintf("  Version   : v%d", sct.Version+1),
+			"  Log ID    : "+colonHex(sct.LogID),
+			"  Timestamp : "+sct.Timestamp.Format("Jan 02 15:04:05.000 2006 MST"),
+			fmt.Sprintf("  Signature : %s-with-%s", sct.HashAlgorithm, sct.SignatureAlgorithm),
+		)
+	}
+	return lines
+}
+
+// colonHex renders bytes as colon-separated uppercase hex pairs (e.g. "1F:E3:9C").
+func colonHex(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.Grow(len(b) * 3)
+	for i, v := range b {
+		if i > 0 {
+			sb.WriteByte(':')
+		}
+		fmt.Fprintf(&sb, "%02X", v)
+	}
+	return sb.String()
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+)
+
+// Fingerprints holds the identifiers commonly used to pin or look up a certificate.
+//
+// Hex values use uppercase colon-separated bytes, matching `openssl x509 -fingerprint`.
+// The SPKI pin is the base64 SHA-256 digest of the SubjectPublicKeyInfo, as used by
+// [HTTP Public Key Pinning] and most mobile and service-mesh pinning configurations.
+//
+// [HTTP Public Key Pinning]: https://grokipedia.com/page/HTTP_Public_Key_Pinning
+type Fingerprints struct {
+	// SHA1: SHA-1 digest of the DER certificate
+	SHA1 string `json:"sha1Fingerprint"`
+	// SHA256: SHA-256 digest of the DER certificate
+	SHA256 string `json:"sha256Fingerprint"`
+	// SPKISHA256: Base64 SHA-256 digest of the SubjectPublicKeyInfo
+	SPKISHA256 string `json:"spkiSha256"`
+	// SubjectKeyID: Subject Key Identifier, if present
+	SubjectKeyID string `json:"subjectKeyId,omitempty"`
+	// AuthorityKeyID: Authority Key Identifier, if present
+	AuthorityKeyID string `json:"authorityKeyId,omitempty"`
+}
+
+// CertificateFingerprints computes the fingerprints and key identifiers of a certificate.
+//
+// Parameters:
+//   - cert: Certificate to fingerprint
+//
+// Returns:
+//   - Fingerprints: Digests and key identifiers
+func CertificateFingerprints(cert *x509.Certificate) Fingerprints {
+	sha1Sum := sha1.Sum(cert.Raw)
+	sha256Sum := sha256.Sum256(cert.Raw)
+
+	return Fingerprints{
+		SHA1:           colonHex(sha1Sum[:]),
+		SHA256:         colonHex(sha256Sum[:]),
+		SPKISHA256:     SPKIPin(cert),
+		SubjectKeyID:   colonHex(cert.SubjectKeyId),
+		AuthorityKeyID: colonHex(cert.AuthorityKeyId),
+	}
+}
+
+// SPKIPin returns the base64 SHA-256 digest of the certificate's SubjectPublicKeyInfo.
+//
+// The pin survives certificate renewal as long as the key pair is reused, which is
+// why it is preferred over certificate fingerprints for pinning.
+//
+// Parameters:
+//   - cert: Certificate whose public key is pinned
+//
+// Returns:
+//   - string: Standard base64 encoded digest (the pin-sha256 value)
+func SPKIPin(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.RawSubjectPublicKeyInfo)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
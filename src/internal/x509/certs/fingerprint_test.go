@@ -0,0 +1,47 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+func TestCertificateFingerprints(t *testing.T) {
+	cert, err := x509certs.New().Decode([]byte(testCertPEM))
+	require.NoError(t, err)
+
+	// Expected values computed with openssl x509 -fingerprint and the
+	// pin-sha256 recipe (pubkey | pkey -outform der | dgst -sha256 | base64).
+	fp := x509certs.CertificateFingerprints(cert)
+	assert.Equal(t, "D5:5B:91:A8:76:1F:03:C2:6B:E1:A1:25:DF:3A:92:AC:22:6E:CC:16", fp.SHA1)
+	assert.Equal(t, "CF:9B:D9:59:20:BB:B8:2F:42:9E:94:CD:4F:3F:EB:85:61:41:5D:9E:24:17:FE:E2:85:05:E4:62:30:A3:E1:21", fp.SHA256)
+	assert.Equal(t, "iXK48jI+5eMBOyAex+NUO125Sv+Wx0hfOyQHdyuQ2pQ=", fp.SPKISHA256)
+	assert.Equal(t, "1F:E3:9C:BA:51:B5:9E:E2:CD:9A:E3:E6:99:A8:3D:B6:38:42:5A:26", fp.SubjectKeyID)
+	assert.Equal(t, "DE:1B:1E:ED:79:15:D4:3E:37:24:C3:21:BB:EC:34:39:6D:42:B2:30", fp.AuthorityKeyID)
+	assert.Equal(t, fp.SPKISHA256, x509certs.SPKIPin(cert))
+}
+
+func TestCertificateFingerprints_JSON(t *testing.T) {
+	ca := newTestCA(t, pkix.Name{CommonName: "Fingerprint Test CA"})
+	ca.AuthorityKeyId = nil
+
+	data, err := json.Marshal(x509certs.CertificateFingerprints(ca))
+	require.NoError(t, err)
+
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Contains(t, decoded, "sha1Fingerprint")
+	assert.Contains(t, decoded, "sha256Fingerprint")
+	assert.Contains(t, decoded, "spkiSha256")
+	assert.NotContains(t, decoded, "authorityKeyId", "absent identifiers are omitted")
+}
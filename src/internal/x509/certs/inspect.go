@@ -0,0 +1,223 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CertificateDetails is a full decode of an X.509 certificate, comparable to `openssl x509 -text`.
+//
+// The JSON field names are stable and safe to depend on from scripts.
+type CertificateDetails struct {
+	// Version: X.509 version (1, 2 or 3)
+	Version int `json:"version"`
+	// SerialNumber: Serial number as colon-separated hex
+	SerialNumber string `json:"serialNumber"`
+	// SignatureAlgorithm: Algorithm the issuer used to sign the certificate
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	// Issuer: Issuer distinguished name
+	Issuer string `json:"issuer"`
+	// Subject: Subject distinguished name
+	Subject string `json:"subject"`
+	// NotBefore: Start of the validity period
+	NotBefore time.Time `json:"notBefore"`
+	// NotAfter: End of the validity period
+	NotAfter time.Time `json:"notAfter"`
+	// PublicKey: Subject public key information
+	PublicKey PublicKeyDetails `json:"publicKey"`
+	// Extensions: Every extension in certificate order
+	Extensions []ExtensionDetails `json:"extensions"`
+	// Signature: Issuer signature as colon-separated hex
+	Signature string `json:"signature"`
+	// Fingerprints: Certificate digests, SPKI pin and key identifiers
+	Fingerprints
+}
+
+// PublicKeyDetails describes the subject public key.
+type PublicKeyDetails struct {
+	// KeyDescription: Algorithm family, parameter set and security strength
+	KeyDescription
+	// Exponent: RSA public exponent
+	Exponent int `json:"exponent,omitempty"`
+	// Value: RSA modulus, EC point, or raw key as colon-separated hex
+	Value string `json:"value,omitempty"`
+}
+
+// ExtensionDetails is a single decoded certificate extension.
+//
+// Values always holds the human-readable rendering used by [CertificateDetails.RenderText].
+// Depending on the extension, one of the typed fields also carries the structured data.
+type ExtensionDetails struct {
+	// OID: Extension identifier in dotted notation
+	OID string `json:"oid"`
+	// Name: Extension name as shown by OpenSSL, or the OID when unknown
+	Name string `json:"name"`
+	// Critical: Whether the extension is marked critical
+	Critical bool `json:"critical"`
+	// Values: Human-readable lines
+	Values []string `json:"values"`
+	// GeneralNames: Subject or issuer alternative names of every type
+	GeneralNames []GeneralName `json:"generalNames,omitempty"`
+	// NameConstraints: Permitted and excluded subtrees
+	NameConstraints *NameConstraintsDetails `json:"nameConstraints,omitempty"`
+	// Policies: Certificate policies with CPS and user notice qualifiers
+	Policies []PolicyDetails `json:"policies,omitempty"`
+	// PolicyMappings: Issuer to subject domain policy mappings
+	PolicyMappings []PolicyMappingDetails `json:"policyMappings,omitempty"`
+	// AccessDescriptions: Authority or subject information access entries
+	AccessDescriptions []AccessDescription `json:"accessDescriptions,omitempty"`
+	// DistributionPoints: CRL distribution point full names
+	DistributionPoints []GeneralName `json:"distributionPoints,omitempty"`
+	// SCTs: Embedded Certificate Transparency timestamps
+	SCTs []SignedCertificateTimestamp `json:"scts,omitempty"`
+	// Raw: Extension value as hex, set for unknown or undecodable extensions
+	Raw string `json:"raw,omitempty"`
+	// Error: Decoding error, if the value did not match its ASN.1 definition
+	Error string `json:"error,omitempty"`
+}
+
+// Inspect fully decodes a certificate.
+//
+// Every extension is decoded, including all Subject Alternative Name types,
+// name constraints, certificate policies with qualifiers, policy mappings and
+// constraints, AIA, CRL distribution points, embedded SCTs, and the TLS
+// feature (OCSP Must-Staple) extension. Unknown extensions are kept as hex.
+//
+// Parameters:
+//   - cert: Certificate to decode
+//
+// Returns:
+//   - *CertificateDetails: Decoded certificate
+func (c *Certificate) Inspect(cert *x509.Certificate) *CertificateDetails {
+	details := &CertificateDetails{
+		Version:            cert.Version,
+		SerialNumber:       colonHex(cert.SerialNumber.Bytes()),
+		SignatureAlgorithm: cert.SignatureAlgorithm.String(),
+		Issuer:             cert.Issuer.String(),
+		Subject:            cert.Subject.String(),
+		NotBefore:          cert.NotBefore.UTC(),
+		NotAfter:           cert.NotAfter.UTC(),
+		PublicKey:          publicKeyDetails(cert),
+		Extensions:         make([]ExtensionDetails, 0, len(cert.Extensions)),
+		Signature:          colonHex(cert.Signature),
+		Fingerprints:       CertificateFingerprints(cert),
+	}
+	if details.SerialNumber == "" {
+		details.SerialNumber = "00"
+	}
+
+	for _, ext := range cert.Extensions {
+		details.Extensions = append(details.Extensions, decodeExtension(cert, ext))
+	}
+
+	return details
+}
+
+// publicKeyDetails describes a certificate's public key.
+func publicKeyDetails(cert *x509.Certificate) PublicKeyDetails {
+	details := PublicKeyDetails{KeyDescription: DescribeKey(cert)}
+
+	switch key := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		details.Exponent = key.E
+		details.Value = colonHex(key.N.Bytes())
+	case *ecdsa.PublicKey:
+		// crypto/ecdh has no P-224 support, so its point is left out
+		if ecdhKey, err := key.ECDH(); err == nil {
+			details.Value = colonHex(ecdhKey.Bytes())
+		}
+	case ed25519.PublicKey:
+		details.Value = colonHex(key)
+	default:
+		// Other keys (Ed448, ML-DSA, SLH-DSA, composite) are shown raw, whether or
+		// not the toolchain's crypto/x509 can parse them
+		if _, raw, err := parseSPKI(cert.RawSubjectPublicKeyInfo); err == nil {
+			details.Value = colonHex(raw)
+		}
+	}
+
+	return details
+}
+
+// RenderText renders the details in the layout of `openssl x509 -text`.
+//
+// Returns:
+//   - string: Multi-line text rendering
+func (d *CertificateDetails) RenderText() string {
+	var b strings.Builder
+
+	b.WriteString("Certificate:\n")
+	b.WriteString("    Data:\n")
+	fmt.Fprintf(&b, "        Version: %d (0x%x)\n", d.Version, max(d.Version-1, 0))
+	b.WriteString("        Serial Number:\n")
+	writeWrappedHex(&b, d.SerialNumber, 12)
+	fmt.Fprintf(&b, "        Signature Algorithm: %s\n", d.SignatureAlgorithm)
+	fmt.Fprintf(&b, "        Issuer: %s\n", d.Issuer)
+	b.WriteString("        Validity\n")
+	fmt.Fprintf(&b, "            Not Before: %s\n", d.NotBefore.Format(opensslTimeFormat))
+	fmt.Fprintf(&b, "            Not After : %s\n", d.NotAfter.Format(opensslTimeFormat))
+	fmt.Fprintf(&b, "        Subject: %s\n", d.Subject)
+	b.WriteString("        Subject Public Key Info:\n")
+	fmt.Fprintf(&b, "            Public Key Algorithm: %s\n", d.PublicKey.Algorithm)
+	fmt.Fprintf(&b, "                Public-Key: (%d bit)\n", d.PublicKey.Bits)
+	if d.PublicKey.SecurityBits > 0 {
+		fmt.Fprintf(&b, "                Security Strength: %d bit\n", d.PublicKey.SecurityBits)
+	}
+	if d.PublicKey.Value != "" {
+		writeWrappedHex(&b, d.PublicKey.Value, 16)
+	}
+	if d.PublicKey.Exponent != 0 {
+		fmt.Fprintf(&b, "                Exponent: %d (0x%x)\n", d.PublicKey.Exponent, d.PublicKey.Exponent)
+	}
+
+	if len(d.Extensions) > 0 {
+		b.WriteString("        X509v3 extensions:\n")
+		for _, ext := range d.Extensions {
+			b.WriteString("            " + ext.Name + ":")
+			if ext.Critical {
+				b.WriteString(" critical")
+			}
+			b.WriteByte('\n')
+			for _, value := range ext.Values {
+				b.WriteString("                " + value + "\n")
+			}
+		}
+	}
+
+	fmt.Fprintf(&b, "    Signature Algorithm: %s\n", d.SignatureAlgorithm)
+	b.WriteString("    Signature Value:\n")
+	writeWrappedHex(&b, d.Signature, 8)
+	fmt.Fprintf(&b, "SHA1 Fingerprint=%s\n", d.SHA1)
+	fmt.Fprintf(&b, "SHA256 Fingerprint=%s\n", d.SHA256)
+	fmt.Fprintf(&b, "SPKI SHA256 Pin=%s\n", d.SPKISHA256)
+
+	return b.String()
+}
+
+// opensslTimeFormat is the validity time layout used by OpenSSL.
+const opensslTimeFormat = "Jan _2 15:04:05 2006 MST"
+
+// writeWrappedHex writes colon-separated hex wrapped at 18 bytes per line.
+func writeWrappedHex(b *strings.Builder, colonHex string, indent int) {
+	const bytesPerLine = 18
+	pad := strings.Repeat(" ", indent)
+	pairs := strings.Split(colonHex, ":")
+	for i := 0; i < len(pairs); i += bytesPerLine {
+		end := min(i+bytesPerLine, len(pairs))
+		line := strings.Join(pairs[i:end], ":")
+		if end < len(pairs) {
+			line += ":"
+		}
+		b.WriteString(pad + strings.ToLower(line) + "\n")
+	}
+}
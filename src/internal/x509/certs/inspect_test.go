@@ -0,0 +1,323 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// mustBuild returns the builder output or fails the test.
+func mustBuild(t *testing.T, b *cryptobyte.Builder) []byte {
+	t.Helper()
+	out, err := b.Bytes()
+	require.NoError(t, err)
+	return out
+}
+
+// richExtensions returns hand-encoded extensions that crypto/x509 cannot produce on its own.
+func richExtensions(t *testing.T) []pkix.Extension {
+	t.Helper()
+
+	// SAN with otherName (UPN), dirName, registeredID and DNS
+	var san cryptobyte.Builder
+	san.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.Tag(0).ContextSpecific().Constructed(), func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3})
+			b.AddASN1(cbasn1.Tag(0).ContextSpecific().Constructed(), func(b *cryptobyte.Builder) {
+				b.AddASN1(cbasn1.UTF8String, func(b *cryptobyte.Builder) { b.AddBytes([]byte("user@corp.example")) })
+			})
+		})
+		b.AddASN1(cbasn1.Tag(4).ContextSpecific().Constructed(), func(b *cryptobyte.Builder) {
+			dirName, err := asn1.Marshal(pkix.Name{CommonName: "Directory Entry", Organization: []string{"Example"}}.ToRDNSequence())
+			require.NoError(t, err)
+			b.AddBytes(dirName)
+		})
+		b.AddASN1(cbasn1.Tag(8).ContextSpecific(), func(b *cryptobyte.Builder) {
+			oid, err := x509.ParseOID("1.2.3.4")
+			require.NoError(t, err)
+			der, err := oid.MarshalBinary()
+			require.NoError(t, err)
+			b.AddBytes(der)
+		})
+		b.AddASN1(cbasn1.Tag(2).ContextSpecific(), func(b *cryptobyte.Builder) { b.AddBytes([]byte("www.example.com")) })
+	})
+
+	// Certificate policies with a CPS qualifier and a user notice
+	var policies cryptobyte.Builder
+	policies.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1})
+			b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+				b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 1})
+					b.AddASN1(cbasn1.IA5String, func(b *cryptobyte.Builder) { b.AddBytes([]byte("https://cps.example.com")) })
+				})
+				b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 2})
+					b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+						b.AddASN1(cbasn1.UTF8String, func(b *cryptobyte.Builder) { b.AddBytes([]byte("Test notice")) })
+					})
+				})
+			})
+		})
+	})
+
+	// Policy mappings and constraints
+	var mappings cryptobyte.Builder
+	mappings.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{1, 2, 3, 1})
+			b.AddASN1ObjectIdentifier(asn1.ObjectIdentifier{1, 2, 3, 2})
+		})
+	})
+	var constraints cryptobyte.Builder
+	constraints.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.Tag(0).ContextSpecific(), func(b *cryptobyte.Builder) { b.AddUint8(2) })
+	})
+
+	// TLS feature: status_request (OCSP Must-Staple)
+	var tlsFeature cryptobyte.Builder
+	tlsFeature.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) { b.AddASN1Int64(5) })
+
+	// SCT list with a single v1 SCT
+	var sctList cryptobyte.Builder
+	sctList.AddASN1(cbasn1.OCTET_STRING, func(b *cryptobyte.Builder) {
+		b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddUint8(0)
+				b.AddBytes(make([]byte, 32))
+				b.AddUint64(uint64(time.Date(2025, 11, 24, 8, 41, 5, 0, time.UTC).UnixMilli()))
+				b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {})
+				b.AddUint8(4)
+				b.AddUint8(3)
+				b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes([]byte{0x30, 0x00}) })
+			})
+		})
+	})
+
+	return []pkix.Extension{
+		{Id: asn1.ObjectIdentifier{2, 5, 29, 17}, Value: mustBuild(t, &san)},
+		{Id: asn1.ObjectIdentifier{2, 5, 29, 32}, Value: mustBuild(t, &policies)},
+		{Id: asn1.ObjectIdentifier{2, 5, 29, 33}, Value: mustBuild(t, &mappings)},
+		{Id: asn1.ObjectIdentifier{2, 5, 29, 36}, Value: mustBuild(t, &constraints)},
+		{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}, Value: mustBuild(t, &tlsFeature)},
+		{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}, Value: mustBuild(t, &sctList)},
+		{Id: asn1.ObjectIdentifier{1, 2, 3, 4, 5}, Value: []byte{0x05, 0x00}},
+	}
+}
+
+// newRichCertificate creates a CA certificate carrying every extension the decoder handles.
+func newRichCertificate(t *testing.T) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, excluded, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(0x1234),
+		Subject:               pkix.Name{CommonName: "Rich Test CA"},
+		NotBefore:             time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		NotAfter:              time.Date(2035, 1, 1, 0, 0, 0, 0, time.UTC),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		UnknownExtKeyUsage:    []asn1.ObjectIdentifier{{1, 2, 3, 9}},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLen:            0,
+		MaxPathLenZero:        true,
+		PermittedDNSDomains:   []string{".example.com"},
+		ExcludedIPRanges:      []*net.IPNet{excluded},
+		OCSPServer:            []string{"http://ocsp.example.com"},
+		IssuingCertificateURL: []string{"http://ca.example.com/ca.crt"},
+		CRLDistributionPoints: []string{"http://crl.example.com/ca.crl"},
+		ExtraExtensions:       richExtensions(t),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// findExtension returns the decoded extension with the given OID.
+func findExtension(t *testing.T, details *x509certs.CertificateDetails, oid string) x509certs.ExtensionDetails {
+	t.Helper()
+	for _, ext := range details.Extensions {
+		if ext.OID == oid {
+			return ext
+		}
+	}
+	require.Failf(t, "extension not found", "OID %s", oid)
+	return x509certs.ExtensionDetails{}
+}
+
+func TestCertificate_Inspect(t *testing.T) {
+	cert := newRichCertificate(t)
+	details := x509certs.New().Inspect(cert)
+
+	assert.Equal(t, 3, details.Version)
+	assert.Equal(t, "12:34", details.SerialNumber)
+	assert.Equal(t, "CN=Rich Test CA", details.Subject)
+	assert.Equal(t, "ECDSA P-256", details.PublicKey.Algorithm)
+	assert.Equal(t, 256, details.PublicKey.Bits)
+	assert.True(t, strings.HasPrefix(details.PublicKey.Value, "04:"), "uncompressed EC point")
+
+	t.Run("Subject Alternative Name", func(t *testing.T) {
+		ext := findExtension(t, details, "2.5.29.17")
+		assert.Equal(t, []x509certs.GeneralName{
+			{Type: "otherName", Value: "UPN:user@corp.example"},
+			{Type: "dirName", Value: "CN=Directory Entry,O=Example"},
+			{Type: "registeredID", Value: "1.2.3.4"},
+			{Type: "DNS", Value: "www.example.com"},
+		}, ext.GeneralNames)
+	})
+
+	t.Run("Name Constraints", func(t *testing.T) {
+		ext := findExtension(t, details, "2.5.29.30")
+		require.NotNil(t, ext.NameConstraints)
+		assert.Equal(t, []x509certs.GeneralName{{Type: "DNS", Value: ".example.com"}}, ext.NameConstraints.Permitted)
+		assert.Equal(t, []x509certs.GeneralName{{Type: "IP", Value: "10.0.0.0/8"}}, ext.NameConstraints.Excluded)
+	})
+
+	t.Run("Certificate Policies", func(t *testing.T) {
+		ext := findExtension(t, details, "2.5.29.32")
+		require.Len(t, ext.Policies, 1)
+		assert.Equal(t, "2.23.140.1.2.1", ext.Policies[0].OID)
+		assert.Equal(t, "CA/B Domain Validated", ext.Policies[0].Name)
+		assert.Equal(t, []string{"https://cps.example.com"}, ext.Policies[0].CPS)
+		assert.Equal(t, []string{"Explicit Text: Test notice"}, ext.Policies[0].UserNotices)
+	})
+
+	t.Run("Policy Mappings And Constraints", func(t *testing.T) {
+		mappings := findExtension(t, details, "2.5.29.33")
+		assert.Equal(t, []x509certs.PolicyMappingDetails{{IssuerDomainPolicy: "1.2.3.1", SubjectDomainPolicy: "1.2.3.2"}}, mappings.PolicyMappings)
+
+		constraints := findExtension(t, details, "2.5.29.36")
+		assert.Equal(t, []string{"Require Explicit Policy: 2"}, constraints.Values)
+	})
+
+	t.Run("Access And Distribution Points", func(t *testing.T) {
+		aia := findExtension(t, details, "1.3.6.1.5.5.7.1.1")
+		assert.Equal(t, []x509certs.AccessDescription{
+			{Method: "OCSP", Location: x509certs.GeneralName{Type: "URI", Value: "http://ocsp.example.com"}},
+			{Method: "CA Issuers", Location: x509certs.GeneralName{Type: "URI", Value: "http://ca.example.com/ca.crt"}},
+		}, aia.AccessDescriptions)
+
+		cdp := findExtension(t, details, "2.5.29.31")
+		assert.Equal(t, []x509certs.GeneralName{{Type: "URI", Value: "http://crl.example.com/ca.crl"}}, cdp.DistributionPoints)
+	})
+
+	t.Run("Usage And Constraints", func(t *testing.T) {
+		assert.Equal(t, []string{"Certificate Sign, CRL Sign"}, findExtension(t, details, "2.5.29.15").Values)
+		assert.Equal(t, []string{"TLS Web Server Authentication, 1.2.3.9"}, findExtension(t, details, "2.5.29.37").Values)
+		assert.Equal(t, []string{"CA:TRUE, pathlen:0"}, findExtension(t, details, "2.5.29.19").Values)
+	})
+
+	t.Run("TLS Feature", func(t *testing.T) {
+		assert.Equal(t, []string{"status_request (OCSP Must-Staple)"}, findExtension(t, details, "1.3.6.1.5.5.7.1.24").Values)
+	})
+
+	t.Run("SCT List", func(t *testing.T) {
+		ext := findExtension(t, details, "1.3.6.1.4.1.11129.2.4.2")
+		require.Len(t, ext.SCTs, 1)
+		assert.Equal(t, "SHA256", ext.SCTs[0].HashAlgorithm)
+		assert.Equal(t, "ECDSA", ext.SCTs[0].SignatureAlgorithm)
+		assert.Equal(t, time.Date(2025, 11, 24, 8, 41, 5, 0, time.UTC), ext.SCTs[0].Timestamp)
+	})
+
+	t.Run("Unknown Extension", func(t *testing.T) {
+		ext := findExtension(t, details, "1.2.3.4.5")
+		assert.Equal(t, "1.2.3.4.5", ext.Name)
+		assert.Equal(t, "0500", ext.Raw)
+	})
+
+	t.Run("Stable JSON", func(t *testing.T) {
+		data, err := json.Marshal(details)
+		require.NoError(t, err)
+
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal(data, &decoded))
+		for _, key := range []string{"version", "serialNumber", "signatureAlgorithm", "issuer", "subject", "notBefore", "notAfter", "publicKey", "extensions", "signature", "sha256Fingerprint", "spkiSha256"} {
+			assert.Contains(t, decoded, key)
+		}
+	})
+
+	t.Run("Render Text", func(t *testing.T) {
+		text := details.RenderText()
+		assert.Contains(t, text, "Version: 3 (0x2)")
+		assert.Contains(t, text, "Not Before: Jan  1 00:00:00 2025 UTC")
+		assert.Contains(t, text, "X509v3 Basic Constraints: critical")
+		assert.Contains(t, text, "otherName:UPN:user@corp.example")
+		assert.Contains(t, text, "CPS: https://cps.example.com")
+		assert.Contains(t, text, "Signed Certificate Timestamp:")
+		assert.Contains(t, text, "SHA256 Fingerprint="+details.SHA256)
+	})
+}
+
+func TestCertificate_Inspect_RealCertificate(t *testing.T) {
+	cert, err := x509certs.New().Decode([]byte(testCertPEM))
+	require.NoError(t, err)
+
+	details := x509certs.New().Inspect(cert)
+	for _, ext := range details.Extensions {
+		assert.Empty(t, ext.Error, "extension %s should decode", ext.Name)
+		assert.NotEmpty(t, ext.Values, "extension %s should render", ext.Name)
+	}
+
+	sct := findExtension(t, details, "1.3.6.1.4.1.11129.2.4.2")
+	assert.Len(t, sct.SCTs, 2)
+}
+
+func TestParseSCTList_Malformed(t *testing.T) {
+	_, err := x509certs.ParseSCTList([]byte{0x04, 0x02, 0x00, 0x05})
+	assert.ErrorIs(t, err, x509certs.ErrInvalidSCTList)
+
+	_, err = x509certs.ParseSCTList([]byte{0x30, 0x00})
+	assert.ErrorIs(t, err, x509certs.ErrInvalidSCTList)
+}
+
+func TestEmbeddedSCTs(t *testing.T) {
+	cert, err := x509certs.New().Decode([]byte(testCertPEM))
+	require.NoError(t, err)
+
+	scts, err := x509certs.EmbeddedSCTs(cert)
+	require.NoError(t, err)
+	require.Len(t, scts, 2)
+	assert.Len(t, scts[0].LogID, 32)
+
+	// A certificate without the extension has no SCTs.
+	cert.Extensions = nil
+	scts, err = x509certs.EmbeddedSCTs(cert)
+	require.NoError(t, err)
+	assert.Nil(t, scts)
+}
+
+func TestParseSCT_Malformed(t *testing.T) {
+	_, err := x509certs.ParseSCT([]byte{0x00, 0x01, 0x02})
+	assert.ErrorIs(t, err, x509certs.ErrInvalidSCT)
+}
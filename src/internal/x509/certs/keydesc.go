@@ -0,0 +1,398 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/dsa" // Only used to recognize and describe deprecated DSA keys
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	// ErrInvalidSPKI indicates a malformed SubjectPublicKeyInfo structure.
+	ErrInvalidSPKI = errors.New("x509certs: invalid SubjectPublicKeyInfo")
+)
+
+// Key families reported in [KeyDescription.Family].
+const (
+	// KeyFamilyRSA is RSA (PKCS#1 or RSASSA-PSS).
+	KeyFamilyRSA = "RSA"
+	// KeyFamilyECDSA is ECDSA over a named curve.
+	KeyFamilyECDSA = "ECDSA"
+	// KeyFamilyEdDSA is Ed25519 or Ed448.
+	KeyFamilyEdDSA = "EdDSA"
+	// KeyFamilyECDH is an ECDH-only key such as X25519 or X448.
+	KeyFamilyECDH = "ECDH"
+	// KeyFamilyDSA is the deprecated FIPS 186-2 DSA.
+	KeyFamilyDSA = "DSA"
+	// KeyFamilyMLDSA is the FIPS 204 Module-Lattice-Based Digital Signature Algorithm.
+	KeyFamilyMLDSA = "ML-DSA"
+	// KeyFamilySLHDSA is the FIPS 205 Stateless Hash-Based Digital Signature Algorithm.
+	KeyFamilySLHDSA = "SLH-DSA"
+	// KeyFamilyComposite is a composite ML-DSA plus traditional hybrid key.
+	KeyFamilyComposite = "Composite"
+	// KeyFamilyUnknown is an algorithm this package does not recognize.
+	KeyFamilyUnknown = "Unknown"
+)
+
+// KeyDescription describes a subject public key, including algorithms that
+// crypto/x509 cannot parse such as Ed448 and the [post-quantum cryptography]
+// signature schemes.
+//
+// [post-quantum cryptography]: https://grokipedia.com/page/Post-quantum_cryptography
+type KeyDescription struct {
+	// Algorithm: Display name (e.g. "RSA", "ECDSA P-256", "Ed25519", "ML-DSA-65")
+	Algorithm string `json:"algorithm"`
+	// Family: Algorithm family, one of the KeyFamily constants
+	Family string `json:"family"`
+	// ParameterSet: Curve or parameter set (e.g. "P-256", "ML-DSA-65", "SLH-DSA-SHA2-128s")
+	ParameterSet string `json:"parameterSet,omitempty"`
+	// Bits: Key size in bits (modulus or curve size, or encoded public key length for PQC keys)
+	Bits int `json:"bits"`
+	// SecurityBits: Equivalent classical security strength per NIST SP 800-57, or 0 when unknown
+	SecurityBits int `json:"securityBits"`
+	// PostQuantum: Whether the key resists known quantum attacks
+	PostQuantum bool `json:"postQuantum,omitempty"`
+	// OID: Public key algorithm identifier in dotted notation, when known
+	OID string `json:"oid,omitempty"`
+	// Components: Component keys of a composite key
+	Components []KeyDescription `json:"components,omitempty"`
+}
+
+// String renders the description for tables and reports,
+// e.g. "2048-bit RSA (112-bit security)" or "ML-DSA-65 (192-bit security)".
+func (k KeyDescription) String() string {
+	s := k.Algorithm
+	if (k.Family == KeyFamilyRSA || k.Family == KeyFamilyDSA) && k.Bits > 0 {
+		s = fmt.Sprintf("%d-bit %s", k.Bits, k.Algorithm)
+	}
+	if k.SecurityBits > 0 {
+		s += fmt.Sprintf(" (%d-bit security)", k.SecurityBits)
+	}
+	return s
+}
+
+// Public key algorithm identifiers recognized in a SubjectPublicKeyInfo.
+var (
+	oidPublicKeyRSA     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidPublicKeyRSAPSS  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+	oidPublicKeyDSA     = asn1.ObjectIdentifier{1, 2, 840, 10040, 4, 1}
+	oidPublicKeyECDSA   = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidPublicKeyX25519  = asn1.ObjectIdentifier{1, 3, 101, 110}
+	oidPublicKeyX448    = asn1.ObjectIdentifier{1, 3, 101, 111}
+	oidPublicKeyEd25519 = asn1.ObjectIdentifier{1, 3, 101, 112}
+	oidPublicKeyEd448   = asn1.ObjectIdentifier{1, 3, 101, 113}
+
+	// oidNISTSigAlgs is the NIST sigAlgs arc holding the ML-DSA and SLH-DSA identifiers.
+	oidNISTSigAlgs = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3}
+	// oidCompositeSigs is the arc holding the composite ML-DSA identifiers.
+	oidCompositeSigs = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6}
+)
+
+// pqcParameterSet describes a FIPS 204 or FIPS 205 parameter set.
+type pqcParameterSet struct {
+	// family: KeyFamilyMLDSA or KeyFamilySLHDSA
+	family string
+	// name: Parameter set name
+	name string
+	// keyLen: Encoded public key length in bytes
+	keyLen int
+	// securityBits: Claimed security strength (NIST category 1, 3 or 5 maps to 128, 192 or 256;
+	// ML-DSA-44 is category 2, which is also 128)
+	securityBits int
+}
+
+// pqcParameterSets maps the last arc under oidNISTSigAlgs to its parameter set.
+var pqcParameterSets = map[int]pqcParameterSet{
+	17: {KeyFamilyMLDSA, "ML-DSA-44", 1312, 128},
+	18: {KeyFamilyMLDSA, "ML-DSA-65", 1952, 192},
+	19: {KeyFamilyMLDSA, "ML-DSA-87", 2592, 256},
+	20: {KeyFamilySLHDSA, "SLH-DSA-SHA2-128s", 32, 128},
+	21: {KeyFamilySLHDSA, "SLH-DSA-SHA2-128f", 32, 128},
+	22: {KeyFamilySLHDSA, "SLH-DSA-SHA2-192s", 48, 192},
+	23: {KeyFamilySLHDSA, "SLH-DSA-SHA2-192f", 48, 192},
+	24: {KeyFamilySLHDSA, "SLH-DSA-SHA2-256s", 64, 256},
+	25: {KeyFamilySLHDSA, "SLH-DSA-SHA2-256f", 64, 256},
+	26: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-128s", 32, 128},
+	27: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-128f", 32, 128},
+	28: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-192s", 48, 192},
+	29: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-192f", 48, 192},
+	30: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-256s", 64, 256},
+	31: {KeyFamilySLHDSA, "SLH-DSA-SHAKE-256f", 64, 256},
+}
+
+// compositeKey describes a composite ML-DSA key from the IETF LAMPS composite signatures draft.
+type compositeKey struct {
+	// name: Composite algorithm name without the "id-" prefix
+	name string
+	// mldsa: Last arc of the ML-DSA component under oidNISTSigAlgs
+	mldsa int
+	// traditional: Traditional component
+	traditional KeyDescription
+}
+
+// compositeKeys maps the last arc under oidCompositeSigs to its composite algorithm.
+var compositeKeys = map[int]compositeKey{
+	37: {"MLDSA44-RSA2048-PSS-SHA256", 17, rsaDescription(2048)},
+	38: {"MLDSA44-RSA2048-PKCS15-SHA256", 17, rsaDescription(2048)},
+	39: {"MLDSA44-Ed25519-SHA512", 17, ed25519Description()},
+	40: {"MLDSA44-ECDSA-P256-SHA256", 17, ecdsaDescription("P-256", 256)},
+	41: {"MLDSA65-RSA3072-PSS-SHA512", 18, rsaDescription(3072)},
+	42: {"MLDSA65-RSA3072-PKCS15-SHA512", 18, rsaDescription(3072)},
+	43: {"MLDSA65-RSA4096-PSS-SHA512", 18, rsaDescription(4096)},
+	44: {"MLDSA65-RSA4096-PKCS15-SHA512", 18, rsaDescription(4096)},
+	45: {"MLDSA65-ECDSA-P256-SHA512", 18, ecdsaDescription("P-256", 256)},
+	46: {"MLDSA65-ECDSA-P384-SHA512", 18, ecdsaDescription("P-384", 384)},
+	47: {"MLDSA65-ECDSA-brainpoolP256r1-SHA512", 18, ecdsaDescription("brainpoolP256r1", 256)},
+	48: {"MLDSA65-Ed25519-SHA512", 18, ed25519Description()},
+	49: {"MLDSA87-ECDSA-P384-SHA512", 19, ecdsaDescription("P-384", 384)},
+	50: {"MLDSA87-ECDSA-brainpoolP384r1-SHA512", 19, ecdsaDescription("brainpoolP384r1", 384)},
+	51: {"MLDSA87-Ed448-SHAKE256", 19, ed448Description()},
+	52: {"MLDSA87-RSA3072-PSS-SHA512", 19, rsaDescription(3072)},
+	53: {"MLDSA87-RSA4096-PSS-SHA512", 19, rsaDescription(4096)},
+	54: {"MLDSA87-ECDSA-P521-SHA512", 19, ecdsaDescription("P-521", 521)},
+}
+
+// DescribeKey describes a certificate's subject public key.
+//
+// The raw SubjectPublicKeyInfo is decoded first so that keys crypto/x509 leaves
+// unparsed (Ed448, X448, ML-DSA, SLH-DSA, composite) are still described. When
+// the raw structure is unavailable, the parsed public key is used instead.
+//
+// Parameters:
+//   - cert: Certificate whose public key is described
+//
+// Returns:
+//   - KeyDescription: Family, parameter set and security strength
+func DescribeKey(cert *x509.Certificate) KeyDescription {
+	return describeKey(cert.PublicKey, cert.RawSubjectPublicKeyInfo)
+}
+
+// describeKey describes a key from its raw SubjectPublicKeyInfo, falling back to the parsed key.
+func describeKey(pub any, rawSPKI []byte) KeyDescription {
+	if len(rawSPKI) > 0 {
+		if desc, err := DescribeSPKI(rawSPKI); err == nil {
+			return desc
+		}
+	}
+	return DescribePublicKey(pub)
+}
+
+// DescribePublicKey describes a public key parsed by the standard library.
+//
+// Parameters:
+//   - pub: Public key from a certificate, certificate request, or private key
+//
+// Returns:
+//   - KeyDescription: Family, parameter set and security strength, or KeyFamilyUnknown
+func DescribePublicKey(pub any) KeyDescription {
+	switch key := pub.(type) {
+	case *rsa.PublicKey:
+		return rsaDescription(key.N.BitLen())
+	case rsa.PublicKey:
+		return rsaDescription(key.N.BitLen())
+	case *ecdsa.PublicKey:
+		return ecdsaDescription(key.Curve.Params().Name, key.Curve.Params().BitSize)
+	case ecdsa.PublicKey:
+		return ecdsaDescription(key.Curve.Params().Name, key.Curve.Params().BitSize)
+	case ed25519.PublicKey:
+		return ed25519Description()
+	case *ecdh.PublicKey:
+		if key.Curve() == ecdh.X25519() {
+			return KeyDescription{Algorithm: "X25519", Family: KeyFamilyECDH, ParameterSet: "X25519", Bits: 256, SecurityBits: 128}
+		}
+		desc := ecdsaDescription(fmt.Sprint(key.Curve()), 0)
+		desc.Algorithm, desc.Family = "ECDH "+desc.ParameterSet, KeyFamilyECDH
+		return desc
+	case *dsa.PublicKey:
+		desc := rsaDescription(key.P.BitLen())
+		desc.Algorithm, desc.Family = "DSA", KeyFamilyDSA
+		return desc
+	default:
+		return KeyDescription{Algorithm: "Unknown", Family: KeyFamilyUnknown}
+	}
+}
+
+// DescribeSPKI describes a DER encoded SubjectPublicKeyInfo.
+//
+// Algorithms supported by crypto/x509 are parsed with the standard library;
+// Ed448, X448, ML-DSA, SLH-DSA and composite ML-DSA keys are recognized by
+// their algorithm identifier and validated against the expected key length.
+// Unrecognized algorithms are reported as KeyFamilyUnknown with their OID.
+//
+// Parameters:
+//   - spki: DER encoded SubjectPublicKeyInfo
+//
+// Returns:
+//   - KeyDescription: Family, parameter set and security strength
+//   - error: ErrInvalidSPKI if the structure or key length is malformed
+func DescribeSPKI(spki []byte) (KeyDescription, error) {
+	oid, key, err := parseSPKI(spki)
+	if err != nil {
+		return KeyDescription{}, err
+	}
+
+	var desc KeyDescription
+	switch {
+	case oid.Equal(oidPublicKeyRSA), oid.Equal(oidPublicKeyRSAPSS), oid.Equal(oidPublicKeyDSA),
+		oid.Equal(oidPublicKeyECDSA), oid.Equal(oidPublicKeyEd25519), oid.Equal(oidPublicKeyX25519):
+		pub, err := x509.ParsePKIXPublicKey(spki)
+		if err != nil {
+			// RSASSA-PSS keys are not supported by ParsePKIXPublicKey; the key itself is PKCS#1
+			rsaKey, rsaErr := x509.ParsePKCS1PublicKey(key)
+			if !oid.Equal(oidPublicKeyRSAPSS) || rsaErr != nil {
+				return KeyDescription{}, fmt.Errorf("%w: %v", ErrInvalidSPKI, err)
+			}
+			pub = rsaKey
+		}
+		desc = DescribePublicKey(pub)
+	case oid.Equal(oidPublicKeyEd448):
+		if len(key) != 57 {
+			return KeyDescription{}, fmt.Errorf("%w: Ed448 key is %d bytes", ErrInvalidSPKI, len(key))
+		}
+		desc = ed448Description()
+	case oid.Equal(oidPublicKeyX448):
+		desc = KeyDescription{Algorithm: "X448", Family: KeyFamilyECDH, ParameterSet: "X448", Bits: 448, SecurityBits: 224}
+	case len(oid) == len(oidNISTSigAlgs)+1 && oid[:len(oidNISTSigAlgs)].Equal(oidNISTSigAlgs):
+		params, ok := pqcParameterSets[oid[len(oid)-1]]
+		if !ok {
+			desc = KeyDescription{Algorithm: "Unknown", Family: KeyFamilyUnknown}
+			break
+		}
+		if len(key) != params.keyLen {
+			return KeyDescription{}, fmt.Errorf("%w: %s key is %d bytes, expected %d", ErrInvalidSPKI, params.name, len(key), params.keyLen)
+		}
+		desc = params.description()
+	case len(oid) == len(oidCompositeSigs)+1 && oid[:len(oidCompositeSigs)].Equal(oidCompositeSigs):
+		composite, ok := compositeKeys[oid[len(oid)-1]]
+		if !ok {
+			desc = KeyDescription{Algorithm: "Unknown", Family: KeyFamilyUnknown}
+			break
+		}
+		mldsa := pqcParameterSets[composite.mldsa]
+		// The composite public key is the ML-DSA key followed by the traditional key
+		if len(key) <= mldsa.keyLen {
+			return KeyDescription{}, fmt.Errorf("%w: %s key is %d bytes", ErrInvalidSPKI, composite.name, len(key))
+		}
+		desc = composite.description()
+	default:
+		desc = KeyDescription{Algorithm: "Unknown", Family: KeyFamilyUnknown}
+	}
+
+	desc.OID = oid.String()
+	return desc, nil
+}
+
+// parseSPKI splits a SubjectPublicKeyInfo into its algorithm identifier and key bytes.
+func parseSPKI(spki []byte) (asn1.ObjectIdentifier, []byte, error) {
+	var (
+		input     = cryptobyte.String(spki)
+		info      cryptobyte.String
+		algorithm cryptobyte.String
+		oid       asn1.ObjectIdentifier
+		key       asn1.BitString
+	)
+	if !input.ReadASN1(&info, cbasn1.SEQUENCE) || !input.Empty() ||
+		!info.ReadASN1(&algorithm, cbasn1.SEQUENCE) ||
+		!algorithm.ReadASN1ObjectIdentifier(&oid) ||
+		!info.ReadASN1BitString(&key) || !info.Empty() {
+		return nil, nil, ErrInvalidSPKI
+	}
+	if key.BitLength%8 != 0 {
+		return nil, nil, fmt.Errorf("%w: public key is not a whole number of bytes", ErrInvalidSPKI)
+	}
+	return oid, key.Bytes, nil
+}
+
+// description returns the key description of a PQC parameter set.
+func (p pqcParameterSet) description() KeyDescription {
+	return KeyDescription{
+		Algorithm:    p.name,
+		Family:       p.family,
+		ParameterSet: p.name,
+		Bits:         p.keyLen * 8,
+		SecurityBits: p.securityBits,
+		PostQuantum:  true,
+	}
+}
+
+// description returns the key description of a composite algorithm.
+//
+// A composite signature stays secure while either component is unbroken,
+// so its strength is that of the stronger component.
+func (c compositeKey) description() KeyDescription {
+	mldsa := pqcParameterSets[c.mldsa].description()
+	mldsa.OID = append(append(asn1.ObjectIdentifier{}, oidNISTSigAlgs...), c.mldsa).String()
+
+	return KeyDescription{
+		Algorithm:    c.name,
+		Family:       KeyFamilyComposite,
+		ParameterSet: c.name,
+		Bits:         mldsa.Bits + c.traditional.Bits,
+		SecurityBits: max(mldsa.SecurityBits, c.traditional.SecurityBits),
+		PostQuantum:  true,
+		Components:   []KeyDescription{mldsa, c.traditional},
+	}
+}
+
+// rsaDescription describes an RSA key with the given modulus size.
+func rsaDescription(bits int) KeyDescription {
+	return KeyDescription{Algorithm: "RSA", Family: KeyFamilyRSA, Bits: bits, SecurityBits: finiteFieldSecurityBits(bits)}
+}
+
+// ecdsaDescription describes an ECDSA key on the named curve.
+func ecdsaDescription(curve string, bits int) KeyDescription {
+	if bits == 0 {
+		bits = curveBits[curve]
+	}
+	return KeyDescription{
+		Algorithm:    "ECDSA " + curve,
+		Family:       KeyFamilyECDSA,
+		ParameterSet: curve,
+		Bits:         bits,
+		SecurityBits: min(bits/2, 256),
+	}
+}
+
+// curveBits holds the field sizes of curves only known by name.
+var curveBits = map[string]int{"P-224": 224, "P-256": 256, "P-384": 384, "P-521": 521}
+
+// ed25519Description describes an Ed25519 key.
+func ed25519Description() KeyDescription {
+	return KeyDescription{Algorithm: "Ed25519", Family: KeyFamilyEdDSA, ParameterSet: "Ed25519", Bits: 256, SecurityBits: 128}
+}
+
+// ed448Description describes an Ed448 key.
+func ed448Description() KeyDescription {
+	return KeyDescription{Algorithm: "Ed448", Family: KeyFamilyEdDSA, ParameterSet: "Ed448", Bits: 448, SecurityBits: 224}
+}
+
+// finiteFieldSecurityBits maps an RSA or DSA modulus size to its NIST SP 800-57 security strength.
+func finiteFieldSecurityBits(bits int) int {
+	switch {
+	case bits >= 15360:
+		return 256
+	case bits >= 7680:
+		return 192
+	case bits >= 3072:
+		return 128
+	case bits >= 2048:
+		return 112
+	case bits >= 1024:
+		return 80
+	default:
+		return 0
+	}
+}
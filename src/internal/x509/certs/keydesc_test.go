@@ -0,0 +1,242 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// buildSPKI encodes a SubjectPublicKeyInfo with an absent algorithm parameter.
+func buildSPKI(oid asn1.ObjectIdentifier, key []byte) []byte {
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+			b.AddASN1ObjectIdentifier(oid)
+		})
+		b.AddASN1BitString(key)
+	})
+	return b.BytesOrPanic()
+}
+
+// withSPKI re-encodes cert with its SubjectPublicKeyInfo replaced by spki.
+// The signature no longer verifies, which parsing does not check.
+func withSPKI(t *testing.T, cert *x509.Certificate, spki []byte) *x509.Certificate {
+	t.Helper()
+
+	var outer, tbs cryptobyte.String
+	input := cryptobyte.String(cert.Raw)
+	require.True(t, input.ReadASN1(&outer, cbasn1.SEQUENCE))
+	require.True(t, outer.ReadASN1(&tbs, cbasn1.SEQUENCE))
+	contents := bytes.Replace(tbs, cert.RawSubjectPublicKeyInfo, spki, 1)
+
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) { b.AddBytes(contents) })
+		b.AddBytes(outer) // signatureAlgorithm and signatureValue
+	})
+
+	parsed, err := x509.ParseCertificate(b.BytesOrPanic())
+	require.NoError(t, err)
+	return parsed
+}
+
+func TestDescribePublicKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		pub      any
+		want     x509certs.KeyDescription
+		wantText string
+	}{
+		{
+			name:     "RSA",
+			pub:      &rsaKey.PublicKey,
+			want:     x509certs.KeyDescription{Algorithm: "RSA", Family: x509certs.KeyFamilyRSA, Bits: 2048, SecurityBits: 112},
+			wantText: "2048-bit RSA (112-bit security)",
+		},
+		{
+			name:     "RSA Value",
+			pub:      rsaKey.PublicKey,
+			want:     x509certs.KeyDescription{Algorithm: "RSA", Family: x509certs.KeyFamilyRSA, Bits: 2048, SecurityBits: 112},
+			wantText: "2048-bit RSA (112-bit security)",
+		},
+		{
+			name:     "ECDSA",
+			pub:      &ecKey.PublicKey,
+			want:     x509certs.KeyDescription{Algorithm: "ECDSA P-384", Family: x509certs.KeyFamilyECDSA, ParameterSet: "P-384", Bits: 384, SecurityBits: 192},
+			wantText: "ECDSA P-384 (192-bit security)",
+		},
+		{
+			name:     "Ed25519",
+			pub:      edKey,
+			want:     x509certs.KeyDescription{Algorithm: "Ed25519", Family: x509certs.KeyFamilyEdDSA, ParameterSet: "Ed25519", Bits: 256, SecurityBits: 128},
+			wantText: "Ed25519 (128-bit security)",
+		},
+		{
+			name:     "Unknown",
+			pub:      "unsupported",
+			want:     x509certs.KeyDescription{Algorithm: "Unknown", Family: x509certs.KeyFamilyUnknown},
+			wantText: "Unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc := x509certs.DescribePublicKey(tt.pub)
+			assert.Equal(t, tt.want, desc)
+			assert.Equal(t, tt.wantText, desc.String())
+		})
+	}
+}
+
+func TestDescribeSPKI(t *testing.T) {
+	nist := func(arc int) asn1.ObjectIdentifier { return asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, arc} }
+	composite := func(arc int) asn1.ObjectIdentifier { return asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 6, arc} }
+
+	tests := []struct {
+		name            string
+		spki            []byte
+		wantAlgorithm   string
+		wantFamily      string
+		wantBits        int
+		wantSecurity    int
+		wantPostQuantum bool
+		wantOID         string
+		wantComponents  []string
+		wantErr         bool
+	}{
+		{
+			name:          "Ed448",
+			spki:          buildSPKI(asn1.ObjectIdentifier{1, 3, 101, 113}, make([]byte, 57)),
+			wantAlgorithm: "Ed448", wantFamily: x509certs.KeyFamilyEdDSA, wantBits: 448, wantSecurity: 224,
+			wantOID: "1.3.101.113",
+		},
+		{
+			name:          "ML-DSA-65",
+			spki:          buildSPKI(nist(18), make([]byte, 1952)),
+			wantAlgorithm: "ML-DSA-65", wantFamily: x509certs.KeyFamilyMLDSA, wantBits: 1952 * 8, wantSecurity: 192,
+			wantPostQuantum: true, wantOID: "2.16.840.1.101.3.4.3.18",
+		},
+		{
+			name:          "SLH-DSA-SHAKE-256f",
+			spki:          buildSPKI(nist(31), make([]byte, 64)),
+			wantAlgorithm: "SLH-DSA-SHAKE-256f", wantFamily: x509certs.KeyFamilySLHDSA, wantBits: 512, wantSecurity: 256,
+			wantPostQuantum: true, wantOID: "2.16.840.1.101.3.4.3.31",
+		},
+		{
+			name:          "Composite ML-DSA-65 With ECDSA P-256",
+			spki:          buildSPKI(composite(45), make([]byte, 1952+65)),
+			wantAlgorithm: "MLDSA65-ECDSA-P256-SHA512", wantFamily: x509certs.KeyFamilyComposite, wantBits: 1952*8 + 256, wantSecurity: 192,
+			wantPostQuantum: true, wantOID: "1.3.6.1.5.5.7.6.45", wantComponents: []string{"ML-DSA-65", "ECDSA P-256"},
+		},
+		{
+			name:          "Unknown Algorithm",
+			spki:          buildSPKI(asn1.ObjectIdentifier{1, 2, 3, 4}, []byte{1, 2, 3}),
+			wantAlgorithm: "Unknown", wantFamily: x509certs.KeyFamilyUnknown, wantOID: "1.2.3.4",
+		},
+		{name: "ML-DSA Wrong Length", spki: buildSPKI(nist(17), make([]byte, 100)), wantErr: true},
+		{name: "Composite Missing Traditional Key", spki: buildSPKI(composite(39), make([]byte, 1312)), wantErr: true},
+		{name: "Garbage", spki: []byte{0x30, 0x03, 0x01, 0x02}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desc, err := x509certs.DescribeSPKI(tt.spki)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, x509certs.ErrInvalidSPKI)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantAlgorithm, desc.Algorithm)
+			assert.Equal(t, tt.wantFamily, desc.Family)
+			assert.Equal(t, tt.wantBits, desc.Bits)
+			assert.Equal(t, tt.wantSecurity, desc.SecurityBits)
+			assert.Equal(t, tt.wantPostQuantum, desc.PostQuantum)
+			assert.Equal(t, tt.wantOID, desc.OID)
+
+			var components []string
+			for _, c := range desc.Components {
+				components = append(components, c.Algorithm)
+			}
+			assert.Equal(t, tt.wantComponents, components)
+		})
+	}
+
+	t.Run("Standard Library Key", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		spki, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+		require.NoError(t, err)
+
+		desc, err := x509certs.DescribeSPKI(spki)
+		require.NoError(t, err)
+		assert.Equal(t, "ECDSA P-256", desc.Algorithm)
+		assert.Equal(t, "1.2.840.10045.2.1", desc.OID)
+	})
+}
+
+func TestDescribeKey_PostQuantumCertificate(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "pq.example.com"},
+		DNSNames:     []string{"pq.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	ecCert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	cert := withSPKI(t, ecCert, buildSPKI(asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19}, make([]byte, 2592)))
+	// Go 1.25 leaves ML-DSA keys unparsed while newer toolchains parse them;
+	// the description comes from the raw SubjectPublicKeyInfo either way
+
+	desc := x509certs.DescribeKey(cert)
+	assert.Equal(t, "ML-DSA-87", desc.Algorithm)
+	assert.Equal(t, 256, desc.SecurityBits)
+	assert.True(t, desc.PostQuantum)
+
+	t.Run("Inspect", func(t *testing.T) {
+		details := x509certs.New().Inspect(cert)
+		assert.Equal(t, "ML-DSA-87", details.PublicKey.Algorithm)
+		assert.NotEmpty(t, details.PublicKey.Value, "raw key is shown")
+		assert.Contains(t, details.RenderText(), "Security Strength: 256 bit")
+	})
+
+	t.Run("Checks", func(t *testing.T) {
+		findings := x509certs.New().CheckCertificate(cert)
+		require.NotEmpty(t, findings)
+		assert.Equal(t, x509certs.CheckKeySize, findings[0].Check)
+		assert.Contains(t, findings[0].Message, "ML-DSA-87")
+	})
+}
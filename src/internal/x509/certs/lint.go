@@ -0,0 +1,167 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CertificateType classifies a certificate for [LintRule] applicability.
+type CertificateType string
+
+const (
+	// CertificateTypeSubscriber is an end-entity (leaf) certificate.
+	CertificateTypeSubscriber CertificateType = "subscriber"
+	// CertificateTypeIntermediate is a CA certificate issued by another CA.
+	CertificateTypeIntermediate CertificateType = "intermediate"
+	// CertificateTypeRoot is a self-signed CA certificate.
+	CertificateTypeRoot CertificateType = "root"
+)
+
+// LintRule is a single offline lint rule, in the spirit of [zlint].
+//
+// [zlint]: https://github.com/zmap/zlint
+type LintRule struct {
+	// ID: Stable rule identifier reported in [Finding.Check]
+	ID string `json:"id" yaml:"id"`
+	// Description: What the rule requires
+	Description string `json:"description" yaml:"description"`
+	// Citation: Requirement the rule enforces (e.g. "CABF BR §6.3.2")
+	Citation string `json:"citation" yaml:"citation"`
+	// Severity: Severity of every finding the rule produces
+	Severity Severity `json:"severity" yaml:"severity"`
+	// AppliesTo: Certificate types the rule is evaluated against
+	AppliesTo []CertificateType `json:"appliesTo" yaml:"appliesTo"`
+	// Check: Returns one message per violation, or none when the certificate passes
+	Check func(cert *x509.Certificate) []string `json:"-" yaml:"-"`
+}
+
+// LintReport is the result of linting a single certificate.
+type LintReport struct {
+	// Subject: Certificate subject distinguished name
+	Subject string `json:"subject" yaml:"subject"`
+	// SerialNumber: Certificate serial number in decimal
+	SerialNumber string `json:"serialNumber" yaml:"serialNumber"`
+	// Type: Certificate type used to select rules
+	Type CertificateType `json:"type" yaml:"type"`
+	// RulesEvaluated: Number of rules that applied to the certificate
+	RulesEvaluated int `json:"rulesEvaluated" yaml:"rulesEvaluated"`
+	// Findings: Rule violations with citations (empty when clean)
+	Findings []Finding `json:"findings" yaml:"findings"`
+	// Passed: True when there are no error-severity findings
+	Passed bool `json:"passed" yaml:"passed"`
+}
+
+// LintRules returns the built-in CA/Browser Forum Baseline Requirements rule set.
+//
+// The returned slice is a copy, so callers may filter or extend it and pass
+// the result to [Certificate.LintWithRules].
+//
+// Returns:
+//   - []LintRule: Built-in rules in evaluation order
+func LintRules() []LintRule {
+	return slices.Clone(baselineRules)
+}
+
+// ClassifyCertificate determines whether a certificate is a subscriber, intermediate, or root certificate.
+//
+// Parameters:
+//   - cert: Certificate to classify
+//
+// Returns:
+//   - CertificateType: Subscriber unless the certificate is a CA; root when the CA is self-signed
+func ClassifyCertificate(cert *x509.Certificate) CertificateType {
+	if !cert.BasicConstraintsValid || !cert.IsCA {
+		return CertificateTypeSubscriber
+	}
+	if bytes.Equal(cert.RawSubject, cert.RawIssuer) && cert.CheckSignatureFrom(cert) == nil {
+		return CertificateTypeRoot
+	}
+	return CertificateTypeIntermediate
+}
+
+// Lint checks a certificate against the built-in Baseline Requirements rules.
+//
+// Linting is deterministic and fully offline: it looks only at the certificate
+// itself, so it works without network access or an AI provider.
+//
+// Parameters:
+//   - cert: Certificate to lint
+//
+// Returns:
+//   - *LintReport: Findings with severities and citations
+func (c *Certificate) Lint(cert *x509.Certificate) *LintReport {
+	return c.LintWithRules(cert, baselineRules)
+}
+
+// LintWithRules checks a certificate against the given rules.
+//
+// Parameters:
+//   - cert: Certificate to lint
+//   - rules: Rules to evaluate, in order
+//
+// Returns:
+//   - *LintReport: Findings with severities and citations
+func (c *Certificate) LintWithRules(cert *x509.Certificate, rules []LintRule) *LintReport {
+	report := &LintReport{
+		Subject:      cert.Subject.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		Type:         ClassifyCertificate(cert),
+		Findings:     []Finding{},
+	}
+
+	for _, rule := range rules {
+		if !slices.Contains(rule.AppliesTo, report.Type) {
+			continue
+		}
+		report.RulesEvaluated++
+		for _, message := range rule.Check(cert) {
+			report.Findings = append(report.Findings, Finding{
+				Check:    rule.ID,
+				Severity: rule.Severity,
+				Message:  message,
+				Citation: rule.Citation,
+			})
+		}
+	}
+
+	report.Passed = !HasErrors(report.Findings)
+	return report
+}
+
+// RenderText renders the report as human-readable text, one finding per line
+// with its severity, rule identifier, and citation.
+//
+// Returns:
+//   - string: Multi-line text report
+func (r *LintReport) RenderText() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Subject:         %s\n", r.Subject)
+	fmt.Fprintf(&b, "Serial Number:   %s\n", r.SerialNumber)
+	fmt.Fprintf(&b, "Type:            %s\n", r.Type)
+	fmt.Fprintf(&b, "Rules Evaluated: %d\n", r.RulesEvaluated)
+
+	b.WriteString("\nFindings:\n")
+	if len(r.Findings) == 0 {
+		b.WriteString("  All rules passed.\n")
+	}
+	for _, f := range r.Findings {
+		fmt.Fprintf(&b, "  [%s] %s: %s (%s)\n", strings.ToUpper(string(f.Severity)), f.Check, f.Message, f.Citation)
+	}
+
+	result := "PASSED"
+	if !r.Passed {
+		result = "FAILED"
+	}
+	fmt.Fprintf(&b, "\nResult: %s\n", result)
+
+	return b.String()
+}
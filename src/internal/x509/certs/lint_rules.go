@@ -0,0 +1,559 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Lint rule identifiers reported in [Finding.Check].
+const (
+	// LintValidityPeriod limits the subscriber certificate validity period.
+	LintValidityPeriod = "br-validity-period"
+	// LintSANPresent requires a dNSName or iPAddress Subject Alternative Name.
+	LintSANPresent = "br-san-present"
+	// LintSANTypes forbids Subject Alternative Name types other than dNSName, iPAddress and otherName.
+	LintSANTypes = "br-san-types"
+	// LintSANSyntax validates dNSName syntax and wildcard placement.
+	LintSANSyntax = "br-san-syntax"
+	// LintInternalName forbids internal names and reserved top-level domains.
+	LintInternalName = "br-internal-name"
+	// LintReservedIP forbids private and reserved IP addresses.
+	LintReservedIP = "br-reserved-ip"
+	// LintCommonNameInSAN requires the common name to be one of the SAN values.
+	LintCommonNameInSAN = "br-cn-in-san"
+	// LintKeyAlgorithm restricts public keys to RSA and ECDSA.
+	LintKeyAlgorithm = "br-key-algorithm"
+	// LintKeySize enforces minimum RSA sizes and the permitted ECDSA curves.
+	LintKeySize = "br-key-size"
+	// LintRSAExponent enforces the RSA public exponent range.
+	LintRSAExponent = "br-rsa-exponent"
+	// LintSignatureAlgorithm forbids MD5, SHA-1 and DSA signatures.
+	LintSignatureAlgorithm = "br-signature-algorithm"
+	// LintSerialNumber requires a positive serial number of at most 20 octets.
+	LintSerialNumber = "br-serial-number"
+	// LintSerialEntropy flags serial numbers too short to hold 64 random bits.
+	LintSerialEntropy = "br-serial-entropy"
+	// LintSubscriberEKU requires serverAuth and forbids anyExtendedKeyUsage and unrelated purposes.
+	LintSubscriberEKU = "br-subscriber-eku"
+	// LintSubscriberKeyUsage forbids CA and algorithm-inappropriate key usages on subscriber certificates.
+	LintSubscriberKeyUsage = "br-subscriber-key-usage"
+	// LintCAKeyUsage requires a critical key usage with keyCertSign on CA certificates.
+	LintCAKeyUsage = "br-ca-key-usage"
+	// LintCABasicConstraints requires a critical basicConstraints with cA set on CA certificates.
+	LintCABasicConstraints = "br-ca-basic-constraints"
+	// LintAuthorityInfoAccess requires an AIA extension with a caIssuers URL.
+	LintAuthorityInfoAccess = "br-aia"
+	// LintRevocationInfo requires a CRL distribution point or OCSP URL.
+	LintRevocationInfo = "br-revocation-info"
+	// LintAuthorityKeyID requires the authority key identifier extension.
+	LintAuthorityKeyID = "br-authority-key-id"
+)
+
+var (
+	// subscriberOnly: Rules that apply to end-entity certificates
+	subscriberOnly = []CertificateType{CertificateTypeSubscriber}
+	// caOnly: Rules that apply to root and intermediate CA certificates
+	caOnly = []CertificateType{CertificateTypeIntermediate, CertificateTypeRoot}
+	// issuedOnly: Rules that apply to certificates issued by another CA
+	issuedOnly = []CertificateType{CertificateTypeSubscriber, CertificateTypeIntermediate}
+	// allTypes: Rules that apply to every certificate
+	allTypes = []CertificateType{CertificateTypeSubscriber, CertificateTypeIntermediate, CertificateTypeRoot}
+)
+
+// baselineRules is the built-in rule set, citing the CA/Browser Forum
+// [Baseline Requirements] for TLS server certificates (version 2).
+//
+// [Baseline Requirements]: https://cabforum.org/working-groups/server/baseline-requirements/
+var baselineRules = []LintRule{
+	{
+		ID:          LintValidityPeriod,
+		Description: "Subscriber certificates must not exceed the maximum validity period for their issuance date (398 days, reducing to 200, 100 and 47 days from 2026 to 2029)",
+		Citation:    "CABF BR §6.3.2",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintValidityPeriod,
+	},
+	{
+		ID:          LintSANPresent,
+		Description: "Subscriber certificates must contain at least one dNSName or iPAddress Subject Alternative Name",
+		Citation:    "CABF BR §7.1.2.7.12",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintSANPresent,
+	},
+	{
+		ID:          LintSANTypes,
+		Description: "Subscriber certificates must not contain email or URI Subject Alternative Names",
+		Citation:    "CABF BR §7.1.2.7.12",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintSANTypes,
+	},
+	{
+		ID:          LintSANSyntax,
+		Description: "dNSName entries must be valid hostnames with a wildcard only as the entire left-most label",
+		Citation:    "CABF BR §7.1.2.7.12",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintSANSyntax,
+	},
+	{
+		ID:          LintInternalName,
+		Description: "dNSName entries must not be internal names such as single labels or reserved top-level domains",
+		Citation:    "CABF BR §7.1.2.7.12",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintInternalName,
+	},
+	{
+		ID:          LintReservedIP,
+		Description: "iPAddress entries must not be private, loopback, link-local, or otherwise reserved addresses",
+		Citation:    "CABF BR §7.1.2.7.12",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintReservedIP,
+	},
+	{
+		ID:          LintCommonNameInSAN,
+		Description: "A subject common name, if present, must repeat one of the Subject Alternative Name values",
+		Citation:    "CABF BR §7.1.4.3",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintCommonNameInSAN,
+	},
+	{
+		ID:          LintKeyAlgorithm,
+		Description: "Public keys must be RSA or ECDSA",
+		Citation:    "CABF BR §7.1.3.1",
+		Severity:    SeverityError,
+		AppliesTo:   allTypes,
+		Check:       lintKeyAlgorithm,
+	},
+	{
+		ID:          LintKeySize,
+		Description: "RSA moduli must be at least 2048 bits and divisible by 8; ECDSA keys must use P-256, P-384 or P-521",
+		Citation:    "CABF BR §6.1.5",
+		Severity:    SeverityError,
+		AppliesTo:   allTypes,
+		Check:       lintKeySize,
+	},
+	{
+		ID:          LintRSAExponent,
+		Description: "RSA public exponents must be odd and between 2^16+1 and 2^256-1",
+		Citation:    "CABF BR §6.1.6",
+		Severity:    SeverityError,
+		AppliesTo:   allTypes,
+		Check:       lintRSAExponent,
+	},
+	{
+		ID:          LintSignatureAlgorithm,
+		Description: "Certificates must be signed with RSASSA-PKCS1-v1_5, RSASSA-PSS or ECDSA using SHA-256, SHA-384 or SHA-512",
+		Citation:    "CABF BR §7.1.3.2",
+		Severity:    SeverityError,
+		AppliesTo:   issuedOnly,
+		Check:       lintSignatureAlgorithm,
+	},
+	{
+		ID:          LintSerialNumber,
+		Description: "Serial numbers must be positive and less than 2^159",
+		Citation:    "CABF BR §7.1, RFC 5280 §4.1.2.2",
+		Severity:    SeverityError,
+		AppliesTo:   allTypes,
+		Check:       lintSerialNumber,
+	},
+	{
+		ID:          LintSerialEntropy,
+		Description: "Serial numbers should be at least 8 octets to hold 64 bits of CSPRNG output",
+		Citation:    "CABF BR §7.1",
+		Severity:    SeverityWarning,
+		AppliesTo:   issuedOnly,
+		Check:       lintSerialEntropy,
+	},
+	{
+		ID:          LintSubscriberEKU,
+		Description: "Subscriber certificates must include serverAuth and must not include anyExtendedKeyUsage, codeSigning, timeStamping or OCSPSigning",
+		Citation:    "CABF BR §7.1.2.7.10",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintSubscriberEKU,
+	},
+	{
+		ID:          LintSubscriberKeyUsage,
+		Description: "Subscriber certificates must not assert keyCertSign or cRLSign, and ECDSA keys must not assert keyEncipherment",
+		Citation:    "CABF BR §7.1.2.7.11",
+		Severity:    SeverityError,
+		AppliesTo:   subscriberOnly,
+		Check:       lintSubscriberKeyUsage,
+	},
+	{
+		ID:          LintCAKeyUsage,
+		Description: "CA certificates must have a critical key usage extension asserting keyCertSign",
+		Citation:    "CABF BR §7.1.2.10.7",
+		Severity:    SeverityError,
+		AppliesTo:   caOnly,
+		Check:       lintCAKeyUsage,
+	},
+	{
+		ID:          LintCABasicConstraints,
+		Description: "CA certificates must have a critical basicConstraints extension with cA set",
+		Citation:    "CABF BR §7.1.2.10.4",
+		Severity:    SeverityError,
+		AppliesTo:   caOnly,
+		Check:       lintCABasicConstraints,
+	},
+	{
+		ID:          LintAuthorityInfoAccess,
+		Description: "Issued certificates must contain an Authority Information Access extension with a caIssuers URL",
+		Citation:    "CABF BR §7.1.2.7.7, §7.1.2.10.3",
+		Severity:    SeverityError,
+		AppliesTo:   issuedOnly,
+		Check:       lintAuthorityInfoAccess,
+	},
+	{
+		ID:          LintRevocationInfo,
+		Description: "Issued certificates must provide a CRL distribution point or an OCSP responder URL",
+		Citation:    "CABF BR §7.1.2.11.2",
+		Severity:    SeverityError,
+		AppliesTo:   issuedOnly,
+		Check:       lintRevocationInfo,
+	},
+	{
+		ID:          LintAuthorityKeyID,
+		Description: "Issued certificates must contain an authority key identifier",
+		Citation:    "CABF BR §7.1.2.11.1",
+		Severity:    SeverityError,
+		AppliesTo:   issuedOnly,
+		Check:       lintAuthorityKeyID,
+	},
+}
+
+// validityLimit is a maximum subscriber validity period that applies from a given issuance date.
+type validityLimit struct {
+	// from: First notBefore date the limit applies to
+	from time.Time
+	// days: Maximum validity period in days
+	days int
+}
+
+// validityLimits lists the subscriber validity limits, newest first (CA/B Forum ballots SC22 and SC-081).
+var validityLimits = []validityLimit{
+	{from: time.Date(2029, time.March, 15, 0, 0, 0, 0, time.UTC), days: 47},
+	{from: time.Date(2027, time.March, 15, 0, 0, 0, 0, time.UTC), days: 100},
+	{from: time.Date(2026, time.March, 15, 0, 0, 0, 0, time.UTC), days: 200},
+	{from: time.Date(2020, time.September, 1, 0, 0, 0, 0, time.UTC), days: 398},
+	{from: time.Date(2018, time.March, 1, 0, 0, 0, 0, time.UTC), days: 825},
+}
+
+// reservedTLDs lists special-use and commonly used private top-level domains
+// (RFC 2606, RFC 6761, RFC 6762, RFC 8375 and the ICANN .internal reservation).
+// Without a public suffix list, these plus single-label names identify internal names.
+var reservedTLDs = []string{
+	"corp", "example", "home", "internal", "intranet", "invalid", "lan",
+	"local", "localdomain", "localhost", "private", "test",
+}
+
+// reservedNetworks lists IANA special-purpose ranges not covered by the net.IP predicates.
+var reservedNetworks = mustParseCIDRs(
+	"0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "192.0.2.0/24", "198.18.0.0/15",
+	"198.51.100.0/24", "203.0.113.0/24", "240.0.0.0/4", "2001:db8::/32", "64:ff9b:1::/48",
+)
+
+// oidExtensionKeyUsage and oidExtensionBasicConstraints are used to check criticality.
+var (
+	oidExtensionKeyUsage         = asn1.ObjectIdentifier{2, 5, 29, 15}
+	oidExtensionBasicConstraints = asn1.ObjectIdentifier{2, 5, 29, 19}
+)
+
+// lintValidityPeriod checks the validity period against the limit for the issuance date.
+func lintValidityPeriod(cert *x509.Certificate) []string {
+	for _, limit := range validityLimits {
+		if cert.NotBefore.Before(limit.from) {
+			continue
+		}
+		// RFC 5280 validity is inclusive of both notBefore and notAfter
+		validity := cert.NotAfter.Sub(cert.NotBefore) + time.Second
+		if validity > time.Duration(limit.days)*24*time.Hour {
+			return []string{fmt.Sprintf("validity period is %.1f days; certificates issued on or after %s may not exceed %d days",
+				validity.Hours()/24, limit.from.Format(time.DateOnly), limit.days)}
+		}
+		return nil
+	}
+	return nil
+}
+
+// lintSANPresent requires a dNSName or iPAddress SAN.
+func lintSANPresent(cert *x509.Certificate) []string {
+	if len(cert.DNSNames)+len(cert.IPAddresses) == 0 {
+		return []string{"no dNSName or iPAddress Subject Alternative Names"}
+	}
+	return nil
+}
+
+// lintSANTypes rejects email and URI SANs.
+func lintSANTypes(cert *x509.Certificate) []string {
+	var messages []string
+	for _, email := range cert.EmailAddresses {
+		messages = append(messages, fmt.Sprintf("rfc822Name %q is not permitted", email))
+	}
+	for _, uri := range cert.URIs {
+		messages = append(messages, fmt.Sprintf("uniformResourceIdentifier %q is not permitted", uri))
+	}
+	return messages
+}
+
+// lintSANSyntax validates each dNSName.
+func lintSANSyntax(cert *x509.Certificate) []string {
+	var messages []string
+	for _, name := range cert.DNSNames {
+		if err := validateDNSName(name); err != nil {
+			messages = append(messages, fmt.Sprintf("dNSName %q: %v", name, err))
+		}
+	}
+	return messages
+}
+
+// lintInternalName rejects single-label names and reserved top-level domains.
+func lintInternalName(cert *x509.Certificate) []string {
+	var messages []string
+	for _, name := range cert.DNSNames {
+		labels := strings.Split(strings.ToLower(strings.TrimSuffix(name, ".")), ".")
+		tld := labels[len(labels)-1]
+		switch {
+		case len(labels) == 1:
+			messages = append(messages, fmt.Sprintf("dNSName %q is a single-label internal name", name))
+		case slices.Contains(reservedTLDs, tld), strings.HasSuffix(name, ".home.arpa"):
+			messages = append(messages, fmt.Sprintf("dNSName %q uses the reserved top-level domain %q", name, tld))
+		}
+	}
+	return messages
+}
+
+// lintReservedIP rejects non-public IP addresses.
+func lintReservedIP(cert *x509.Certificate) []string {
+	var messages []string
+	for _, ip := range cert.IPAddresses {
+		if isReservedIP(ip) {
+			messages = append(messages, fmt.Sprintf("iPAddress %s is a reserved address", ip))
+		}
+	}
+	return messages
+}
+
+// lintCommonNameInSAN requires the common name to match a SAN value.
+func lintCommonNameInSAN(cert *x509.Certificate) []string {
+	cn := cert.Subject.CommonName
+	if cn == "" {
+		return nil
+	}
+	if slices.ContainsFunc(cert.DNSNames, func(n string) bool { return strings.EqualFold(n, cn) }) ||
+		slices.ContainsFunc(cert.IPAddresses, func(ip net.IP) bool { return ip.String() == cn }) {
+		return nil
+	}
+	return []string{fmt.Sprintf("common name %q is not one of the Subject Alternative Names", cn)}
+}
+
+// lintKeyAlgorithm restricts keys to RSA and ECDSA.
+func lintKeyAlgorithm(cert *x509.Certificate) []string {
+	key := DescribeKey(cert)
+	if key.Family == KeyFamilyRSA || key.Family == KeyFamilyECDSA {
+		return nil
+	}
+	return []string{fmt.Sprintf("%s keys are not permitted", key.Algorithm)}
+}
+
+// lintKeySize checks RSA moduli and ECDSA curves.
+func lintKeySize(cert *x509.Certificate) []string {
+	key := DescribeKey(cert)
+	switch key.Family {
+	case KeyFamilyRSA:
+		var messages []string
+		if key.Bits < minRSABits {
+			messages = append(messages, fmt.Sprintf("RSA modulus is %d bits; at least %d bits are required", key.Bits, minRSABits))
+		}
+		if key.Bits%8 != 0 {
+			messages = append(messages, fmt.Sprintf("RSA modulus size %d is not divisible by 8", key.Bits))
+		}
+		return messages
+	case KeyFamilyECDSA:
+		if !slices.Contains([]string{"P-256", "P-384", "P-521"}, key.ParameterSet) {
+			return []string{fmt.Sprintf("ECDSA curve %s is not permitted; use P-256, P-384 or P-521", key.ParameterSet)}
+		}
+	}
+	return nil
+}
+
+// lintRSAExponent checks the RSA public exponent range.
+func lintRSAExponent(cert *x509.Certificate) []string {
+	key, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return nil
+	}
+	if key.E%2 == 0 || key.E < 65537 {
+		return []string{fmt.Sprintf("RSA public exponent %d must be odd and at least 65537", key.E)}
+	}
+	return nil
+}
+
+// lintSignatureAlgorithm restricts the signature algorithm.
+func lintSignatureAlgorithm(cert *x509.Certificate) []string {
+	switch cert.SignatureAlgorithm {
+	case x509.SHA256WithRSA, x509.SHA384WithRSA, x509.SHA512WithRSA,
+		x509.SHA256WithRSAPSS, x509.SHA384WithRSAPSS, x509.SHA512WithRSAPSS,
+		x509.ECDSAWithSHA256, x509.ECDSAWithSHA384, x509.ECDSAWithSHA512:
+		return nil
+	}
+	return []string{fmt.Sprintf("signature algorithm %s is not permitted", cert.SignatureAlgorithm)}
+}
+
+// lintSerialNumber checks the serial number range.
+func lintSerialNumber(cert *x509.Certificate) []string {
+	serial := cert.SerialNumber
+	if serial == nil || serial.Sign() <= 0 {
+		return []string{"serial number must be greater than zero"}
+	}
+	if serial.BitLen() > 159 {
+		return []string{fmt.Sprintf("serial number is %d bits; it must be less than 2^159 (at most 20 octets)", serial.BitLen())}
+	}
+	return nil
+}
+
+// lintSerialEntropy flags serial numbers shorter than 8 octets.
+func lintSerialEntropy(cert *x509.Certificate) []string {
+	if cert.SerialNumber == nil || cert.SerialNumber.Sign() <= 0 {
+		return nil
+	}
+	if octets := len(cert.SerialNumber.Bytes()); octets < 8 {
+		return []string{fmt.Sprintf("serial number is %d octets; it cannot contain 64 bits of CSPRNG output", octets)}
+	}
+	return nil
+}
+
+// lintSubscriberEKU checks the extended key usages of a subscriber certificate.
+func lintSubscriberEKU(cert *x509.Certificate) []string {
+	if len(cert.ExtKeyUsage) == 0 && len(cert.UnknownExtKeyUsage) == 0 {
+		return []string{"extended key usage extension is missing"}
+	}
+
+	var messages []string
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageServerAuth) {
+		messages = append(messages, "extended key usage does not include serverAuth")
+	}
+	for _, forbidden := range []x509.ExtKeyUsage{x509.ExtKeyUsageAny, x509.ExtKeyUsageCodeSigning, x509.ExtKeyUsageTimeStamping, x509.ExtKeyUsageOCSPSigning} {
+		if slices.Contains(cert.ExtKeyUsage, forbidden) {
+			messages = append(messages, fmt.Sprintf("extended key usage %s is not permitted", extKeyUsageNames[forbidden]))
+		}
+	}
+	return messages
+}
+
+// lintSubscriberKeyUsage checks the key usages of a subscriber certificate.
+func lintSubscriberKeyUsage(cert *x509.Certificate) []string {
+	var messages []string
+	if cert.KeyUsage&(x509.KeyUsageCertSign|x509.KeyUsageCRLSign) != 0 {
+		messages = append(messages, "keyCertSign and cRLSign are reserved for CA certificates")
+	}
+	if DescribeKey(cert).Family == KeyFamilyECDSA && cert.KeyUsage&x509.KeyUsageKeyEncipherment != 0 {
+		messages = append(messages, "keyEncipherment is not permitted for ECDSA keys")
+	}
+	return messages
+}
+
+// lintCAKeyUsage checks the key usage extension of a CA certificate.
+func lintCAKeyUsage(cert *x509.Certificate) []string {
+	ext, ok := findExtension(cert, oidExtensionKeyUsage)
+	if !ok {
+		return []string{"key usage extension is missing"}
+	}
+
+	var messages []string
+	if !ext.Critical {
+		messages = append(messages, "key usage extension must be critical")
+	}
+	if cert.KeyUsage&x509.KeyUsageCertSign == 0 {
+		messages = append(messages, "key usage does not assert keyCertSign")
+	}
+	return messages
+}
+
+// lintCABasicConstraints checks the basic constraints extension of a CA certificate.
+func lintCABasicConstraints(cert *x509.Certificate) []string {
+	ext, ok := findExtension(cert, oidExtensionBasicConstraints)
+	if !ok {
+		return []string{"basic constraints extension is missing"}
+	}
+	if !ext.Critical {
+		return []string{"basic constraints extension must be critical"}
+	}
+	return nil
+}
+
+// lintAuthorityInfoAccess requires AIA with a caIssuers URL.
+func lintAuthorityInfoAccess(cert *x509.Certificate) []string {
+	if len(cert.OCSPServer) == 0 && len(cert.IssuingCertificateURL) == 0 {
+		return []string{"authority information access extension is missing"}
+	}
+	if len(cert.IssuingCertificateURL) == 0 {
+		return []string{"authority information access has no caIssuers URL"}
+	}
+	return nil
+}
+
+// lintRevocationInfo requires a CRL distribution point or OCSP URL.
+func lintRevocationInfo(cert *x509.Certificate) []string {
+	if len(cert.CRLDistributionPoints) == 0 && len(cert.OCSPServer) == 0 {
+		return []string{"neither a CRL distribution point nor an OCSP responder URL is present"}
+	}
+	return nil
+}
+
+// lintAuthorityKeyID requires the authority key identifier.
+func lintAuthorityKeyID(cert *x509.Certificate) []string {
+	if len(cert.AuthorityKeyId) == 0 {
+		return []string{"authority key identifier extension is missing"}
+	}
+	return nil
+}
+
+// findExtension returns the certificate extension with the given OID.
+func findExtension(cert *x509.Certificate, oid asn1.ObjectIdentifier) (ext pkix.Extension, ok bool) {
+	for _, e := range cert.Extensions {
+		if e.Id.Equal(oid) {
+			return e, true
+		}
+	}
+	return ext, false
+}
+
+// isReservedIP reports whether ip is not a publicly routable address.
+func isReservedIP(ip net.IP) bool {
+	if ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsMulticast() || ip.IsUnspecified() || ip.Equal(net.IPv4bcast) {
+		return true
+	}
+	return slices.ContainsFunc(reservedNetworks, func(n *net.IPNet) bool { return n.Contains(ip) })
+}
+
+// mustParseCIDRs parses CIDR literals, panicking on invalid input.
+func mustParseCIDRs(cidrs ...string) []*net.IPNet {
+	networks := make([]*net.IPNet, len(cidrs))
+	for i, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		networks[i] = network
+	}
+	return networks
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// issueLintLeaf issues a Baseline Requirements compliant subscriber certificate
+// from a throwaway CA after applying mutate to the template.
+func issueLintLeaf(t *testing.T, mutate func(tmpl *x509.Certificate)) *x509.Certificate {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Lint Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+	require.NoError(t, err)
+	notBefore := time.Now().Add(-time.Hour).Truncate(time.Second)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial.SetBit(serial, 127, 1),
+		Subject:               pkix.Name{CommonName: "www.example.com"},
+		DNSNames:              []string{"www.example.com", "example.com"},
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(45 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IssuingCertificateURL: []string{"http://ca.example.com/ca.crt"},
+		OCSPServer:            []string{"http://ocsp.example.com"},
+		CRLDistributionPoints: []string{"http://crl.example.com/ca.crl"},
+	}
+	if mutate != nil {
+		mutate(tmpl)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestCertificate_Lint(t *testing.T) {
+	date := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string
+		mutate     func(tmpl *x509.Certificate)
+		wantChecks []string
+		wantPassed bool
+	}{
+		{name: "Compliant", wantPassed: true},
+		{
+			name: "Validity Over 398 Days",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.NotBefore = date(2025, time.January, 1)
+				tmpl.NotAfter = tmpl.NotBefore.Add(400 * 24 * time.Hour)
+			},
+			wantChecks: []string{x509certs.LintValidityPeriod},
+		},
+		{
+			name: "Validity Over 200 Days After March 2026",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.NotBefore = date(2026, time.April, 1)
+				tmpl.NotAfter = tmpl.NotBefore.Add(201 * 24 * time.Hour)
+			},
+			wantChecks: []string{x509certs.LintValidityPeriod},
+		},
+		{
+			name: "Validity Exactly 200 Days",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.NotBefore = date(2026, time.April, 1)
+				tmpl.NotAfter = tmpl.NotBefore.Add(200*24*time.Hour - time.Second)
+			},
+			wantPassed: true,
+		},
+		{
+			name: "No SAN",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.Subject.CommonName = ""
+				tmpl.DNSNames = nil
+			},
+			wantChecks: []string{x509certs.LintSANPresent},
+		},
+		{
+			name: "Email And URI SANs",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.EmailAddresses = []string{"admin@example.com"}
+				tmpl.URIs = []*url.URL{{Scheme: "https", Host: "example.com"}}
+			},
+			wantChecks: []string{x509certs.LintSANTypes, x509certs.LintSANTypes},
+		},
+		{
+			name:       "Misplaced Wildcard",
+			mutate:     func(tmpl *x509.Certificate) { tmpl.DNSNames = append(tmpl.DNSNames, "www.*.example.com") },
+			wantChecks: []string{x509certs.LintSANSyntax},
+		},
+		{
+			name: "Internal Names",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.DNSNames = append(tmpl.DNSNames, "intranet", "printer.local", "nas.home.arpa")
+			},
+			wantChecks: []string{x509certs.LintInternalName, x509certs.LintInternalName, x509certs.LintInternalName},
+		},
+		{
+			name: "Reserved IP Addresses",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.IPAddresses = []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("100.64.1.1"), net.ParseIP("2001:db8::1"), net.ParseIP("8.8.8.8")}
+			},
+			wantChecks: []string{x509certs.LintReservedIP, x509certs.LintReservedIP, x509certs.LintReservedIP},
+		},
+		{
+			name:       "Common Name Not In SAN",
+			mutate:     func(tmpl *x509.Certificate) { tmpl.Subject.CommonName = "other.example.com" },
+			wantChecks: []string{x509certs.LintCommonNameInSAN},
+		},
+		{
+			name:       "Serial Number Too Large",
+			mutate:     func(tmpl *x509.Certificate) { tmpl.SerialNumber = new(big.Int).Lsh(big.NewInt(1), 159) },
+			wantChecks: []string{x509certs.LintSerialNumber},
+		},
+		{
+			name:       "Low Entropy Serial Number",
+			mutate:     func(tmpl *x509.Certificate) { tmpl.SerialNumber = big.NewInt(42) },
+			wantChecks: []string{x509certs.LintSerialEntropy},
+			wantPassed: true,
+		},
+		{
+			name:       "Missing EKU",
+			mutate:     func(tmpl *x509.Certificate) { tmpl.ExtKeyUsage = nil },
+			wantChecks: []string{x509certs.LintSubscriberEKU},
+		},
+		{
+			name: "Forbidden EKU",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageCodeSigning}
+			},
+			wantChecks: []string{x509certs.LintSubscriberEKU},
+		},
+		{
+			name: "CA Key Usage On Subscriber",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageKeyEncipherment
+			},
+			wantChecks: []string{x509certs.LintSubscriberKeyUsage, x509certs.LintSubscriberKeyUsage},
+		},
+		{
+			name: "No AIA Or CRL",
+			mutate: func(tmpl *x509.Certificate) {
+				tmpl.IssuingCertificateURL = nil
+				tmpl.OCSPServer = nil
+				tmpl.CRLDistributionPoints = nil
+			},
+			wantChecks: []string{x509certs.LintAuthorityInfoAccess, x509certs.LintRevocationInfo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := issueLintLeaf(t, tt.mutate)
+
+			report := x509certs.New().Lint(cert)
+			assert.Equal(t, x509certs.CertificateTypeSubscriber, report.Type)
+			assert.Equal(t, tt.wantPassed, report.Passed)
+			if tt.wantChecks == nil {
+				assert.Empty(t, report.Findings)
+			} else {
+				assert.Equal(t, tt.wantChecks, findingChecks(report.Findings))
+			}
+			for _, f := range report.Findings {
+				assert.NotEmpty(t, f.Citation, "every finding cites a requirement")
+			}
+		})
+	}
+}
+
+func TestCertificate_Lint_CertificateTypes(t *testing.T) {
+	root := newTestCA(t, pkix.Name{CommonName: "Lint Root"})
+	assert.Equal(t, x509certs.CertificateTypeRoot, x509certs.ClassifyCertificate(root))
+
+	report := x509certs.New().Lint(root)
+	assert.True(t, report.Passed)
+	assert.Empty(t, report.Findings)
+
+	t.Run("Subscriber Rules Skipped", func(t *testing.T) {
+		for _, rule := range x509certs.LintRules() {
+			if rule.ID == x509certs.LintSANPresent {
+				assert.NotContains(t, rule.AppliesTo, x509certs.CertificateTypeRoot)
+			}
+		}
+		assert.Less(t, report.RulesEvaluated, len(x509certs.LintRules()))
+	})
+}
+
+func TestCertificate_LintWithRules(t *testing.T) {
+	cert := issueLintLeaf(t, func(tmpl *x509.Certificate) { tmpl.Subject.CommonName = "other.example.com" })
+
+	rules := x509certs.LintRules()
+	custom := []x509certs.LintRule{{
+		ID:        "org-ou-required",
+		Citation:  "Org Policy §1",
+		Severity:  x509certs.SeverityWarning,
+		AppliesTo: []x509certs.CertificateType{x509certs.CertificateTypeSubscriber},
+		Check: func(cert *x509.Certificate) []string {
+			if len(cert.Subject.OrganizationalUnit) == 0 {
+				return []string{"organizational unit is missing"}
+			}
+			return nil
+		},
+	}}
+
+	report := x509certs.New().LintWithRules(cert, custom)
+	assert.Equal(t, 1, report.RulesEvaluated)
+	assert.Equal(t, []string{"org-ou-required"}, findingChecks(report.Findings))
+	assert.Equal(t, "Org Policy §1", report.Findings[0].Citation)
+	assert.True(t, report.Passed, "warnings do not fail the lint")
+
+	rules[0].ID = "modified"
+	assert.NotEqual(t, "modified", x509certs.LintRules()[0].ID, "LintRules returns a copy")
+}
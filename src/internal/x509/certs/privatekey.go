@@ -0,0 +1,337 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/des"
+	"crypto/pbkdf2"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+)
+
+var (
+	// ErrInvalidPrivateKey indicates that the data does not contain a supported private key.
+	ErrInvalidPrivateKey = errors.New("x509certs: invalid or unsupported private key")
+
+	// ErrPasswordRequired indicates that the private key is encrypted but no password was supplied.
+	ErrPasswordRequired = errors.New("x509certs: private key is encrypted, password required")
+
+	// ErrIncorrectPassword indicates that an encrypted private key could not be decrypted with the supplied password.
+	ErrIncorrectPassword = errors.New("x509certs: incorrect private key password")
+
+	// ErrUnsupportedKeyEncryption indicates an encrypted private key using an unsupported algorithm.
+	ErrUnsupportedKeyEncryption = errors.New("x509certs: unsupported private key encryption")
+
+	// ErrKeyMismatch indicates that a private key does not correspond to the certificate's public key.
+	ErrKeyMismatch = errors.New("x509certs: private key does not match certificate public key")
+)
+
+var (
+	// oidPBES2: Password-Based Encryption Scheme 2 (RFC 8018)
+	oidPBES2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	// oidPBKDF2: Password-Based Key Derivation Function 2 (RFC 8018)
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	// oidHMACWithSHA1: PBKDF2 PRF default when none is specified
+	oidHMACWithSHA1 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	// oidHMACWithSHA224: PBKDF2 PRF HMAC-SHA224
+	oidHMACWithSHA224 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 8}
+	// oidHMACWithSHA256: PBKDF2 PRF HMAC-SHA256 (OpenSSL 3 default)
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	// oidHMACWithSHA384: PBKDF2 PRF HMAC-SHA384
+	oidHMACWithSHA384 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 10}
+	// oidHMACWithSHA512: PBKDF2 PRF HMAC-SHA512
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	// oidAES128CBC: AES-128 in CBC mode
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	// oidAES192CBC: AES-192 in CBC mode
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	// oidAES256CBC: AES-256 in CBC mode
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	// oidDESEDE3CBC: Triple DES in CBC mode (legacy OpenSSL default)
+	oidDESEDE3CBC = asn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// encryptedPrivateKeyInfo mirrors the PKCS#8 EncryptedPrivateKeyInfo structure.
+type encryptedPrivateKeyInfo struct {
+	// Algorithm: Encryption algorithm identifier (PBES2 is supported)
+	Algorithm pkix.AlgorithmIdentifier
+	// EncryptedData: Encrypted PKCS#8 PrivateKeyInfo
+	EncryptedData []byte
+}
+
+// pbes2Params mirrors the PBES2-params structure from RFC 8018.
+type pbes2Params struct {
+	// KeyDerivationFunc: Key derivation function (PBKDF2 is supported)
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	// EncryptionScheme: Symmetric cipher and its IV
+	EncryptionScheme pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params mirrors the PBKDF2-params structure from RFC 8018.
+type pbkdf2Params struct {
+	// Salt: Key derivation salt (only the specified OCTET STRING form is supported)
+	Salt []byte
+	// IterationCount: Number of PBKDF2 iterations
+	IterationCount int
+	// KeyLength: Optional derived key length in bytes
+	KeyLength int `asn1:"optional"`
+	// PRF: Optional pseudo-random function (defaults to HMAC-SHA1)
+	PRF pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// DecodePrivateKey decodes a private key from PEM or DER data.
+//
+// Supported encodings:
+//  1. PKCS#1 RSA keys ("RSA PRIVATE KEY")
+//  2. PKCS#8 keys ("PRIVATE KEY") for RSA, ECDSA, Ed25519 and X25519
+//  3. SEC 1 EC keys ("EC PRIVATE KEY")
+//  4. Encrypted PKCS#8 keys ("ENCRYPTED PRIVATE KEY") using PBES2 with
+//     PBKDF2 and AES-CBC or 3DES-CBC, as produced by OpenSSL and keytool
+//
+// PEM input may contain other blocks (for example certificates or EC
+// parameters); the first private key block is used.
+//
+// Parameters:
+//   - data: Raw private key data (PEM or DER)
+//   - password: Password for encrypted PKCS#8 keys (ignored for unencrypted keys)
+//
+// Returns:
+//   - crypto.PrivateKey: Decoded private key
+//   - error: ErrInvalidPrivateKey, ErrPasswordRequired, ErrIncorrectPassword, or ErrUnsupportedKeyEncryption
+func (c *Certificate) DecodePrivateKey(data []byte, password []byte) (crypto.PrivateKey, error) {
+	if !c.IsPEM(data) {
+		if key, err := parsePrivateKeyDER(data); err == nil {
+			return key, nil
+		}
+		// Bare DER may also be an EncryptedPrivateKeyInfo
+		var info encryptedPrivateKeyInfo
+		if rest, err := asn1.Unmarshal(data, &info); err == nil && len(rest) == 0 && info.Algorithm.Algorithm.Equal(oidPBES2) {
+			return decryptPKCS8PrivateKey(info, password)
+		}
+		return nil, ErrInvalidPrivateKey
+	}
+
+	for len(data) > 0 {
+		block, rest := pem.Decode(data)
+		if block == nil {
+			break
+		}
+		data = rest
+
+		switch block.Type {
+		case "ENCRYPTED PRIVATE KEY":
+			var info encryptedPrivateKeyInfo
+			if _, err := asn1.Unmarshal(block.Bytes, &info); err != nil {
+				return nil, ErrInvalidPrivateKey
+			}
+			return decryptPKCS8PrivateKey(info, password)
+		case "RSA PRIVATE KEY", "EC PRIVATE KEY", "PRIVATE KEY":
+			if _, encrypted := block.Headers["DEK-Info"]; encrypted {
+				return nil, fmt.Errorf("%w: legacy PEM encryption (convert with 'openssl pkcs8 -topk8')", ErrUnsupportedKeyEncryption)
+			}
+			return parsePrivateKeyDER(block.Bytes)
+		}
+	}
+
+	return nil, ErrInvalidPrivateKey
+}
+
+// parsePrivateKeyDER parses an unencrypted DER private key in PKCS#8, PKCS#1 or SEC 1 form.
+func parsePrivateKeyDER(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, ErrInvalidPrivateKey
+}
+
+// decryptPKCS8PrivateKey decrypts a PBES2-protected PKCS#8 private key.
+//
+// Parameters:
+//   - info: Parsed EncryptedPrivateKeyInfo
+//   - password: Decryption password
+//
+// Returns:
+//   - crypto.PrivateKey: Decrypted private key
+//   - error: Error if the scheme is unsupported or the password is wrong
+func decryptPKCS8PrivateKey(info encryptedPrivateKeyInfo, password []byte) (crypto.PrivateKey, error) {
+	if len(password) == 0 {
+		return nil, ErrPasswordRequired
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyEncryption, info.Algorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("%w: key derivation %s", ErrUnsupportedKeyEncryption, params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdf pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf); err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	prf, err := pbkdf2PRF(kdf.PRF.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	newCipher, keyLen, err := pbes2Cipher(params.EncryptionScheme.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	if kdf.KeyLength != 0 && kdf.KeyLength != keyLen {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	key, err := pbkdf2.Key(prf, string(password), kdf.Salt, kdf.IterationCount, keyLen)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := newCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	if len(iv) != block.BlockSize() || len(info.EncryptedData) == 0 || len(info.EncryptedData)%block.BlockSize() != 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, info.EncryptedData)
+
+	plaintext, ok := unpadPKCS7(plaintext, block.BlockSize())
+	if !ok {
+		return nil, ErrIncorrectPassword
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(plaintext)
+	if err != nil {
+		return nil, ErrIncorrectPassword
+	}
+
+	return parsed, nil
+}
+
+// pbkdf2PRF maps a PBKDF2 PRF identifier to its hash constructor.
+func pbkdf2PRF(oid asn1.ObjectIdentifier) (func() hash.Hash, error) {
+	switch {
+	case len(oid) == 0, oid.Equal(oidHMACWithSHA1):
+		return sha1.New, nil
+	case oid.Equal(oidHMACWithSHA224):
+		return sha256.New224, nil
+	case oid.Equal(oidHMACWithSHA256):
+		return sha256.New, nil
+	case oid.Equal(oidHMACWithSHA384):
+		return sha512.New384, nil
+	case oid.Equal(oidHMACWithSHA512):
+		return sha512.New, nil
+	default:
+		return nil, fmt.Errorf("%w: PRF %s", ErrUnsupportedKeyEncryption, oid)
+	}
+}
+
+// pbes2Cipher maps a PBES2 encryption scheme identifier to its block cipher and key length.
+func pbes2Cipher(oid asn1.ObjectIdentifier) (func([]byte) (cipher.Block, error), int, error) {
+	switch {
+	case oid.Equal(oidAES128CBC):
+		return aes.NewCipher, 16, nil
+	case oid.Equal(oidAES192CBC):
+		return aes.NewCipher, 24, nil
+	case oid.Equal(oidAES256CBC):
+		return aes.NewCipher, 32, nil
+	case oid.Equal(oidDESEDE3CBC):
+		return des.NewTripleDESCipher, 24, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: cipher %s", ErrUnsupportedKeyEncryption, oid)
+	}
+}
+
+// unpadPKCS7 strips and validates PKCS#7 padding.
+func unpadPKCS7(data []byte, blockSize int) ([]byte, bool) {
+	if len(data) == 0 {
+		return nil, false
+	}
+	n := int(data[len(data)-1])
+	if n == 0 || n > blockSize || n > len(data) {
+		return nil, false
+	}
+	for _, b := range data[len(data)-n:] {
+		if int(b) != n {
+			return nil, false
+		}
+	}
+	return data[:len(data)-n], true
+}
+
+// EncodePrivateKeyPEM encodes a private key as an unencrypted PKCS#8 PEM block.
+//
+// Parameters:
+//   - key: Private key to encode
+//
+// Returns:
+//   - []byte: PEM encoded "PRIVATE KEY" block
+//   - error: Error if the key type cannot be marshaled
+func (c *Certificate) EncodePrivateKeyPEM(key crypto.PrivateKey) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
+
+// MatchPrivateKey verifies that a private key corresponds to the certificate's public key.
+//
+// Parameters:
+//   - cert: Certificate whose public key is checked (usually the leaf)
+//   - key: Private key to compare
+//
+// Returns:
+//   - error: ErrKeyMismatch if the keys differ, or ErrInvalidPrivateKey for unsupported key types
+func (c *Certificate) MatchPrivateKey(cert *x509.Certificate, key crypto.PrivateKey) error {
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return ErrInvalidPrivateKey
+	}
+
+	pub, ok := signer.Public().(interface{ Equal(crypto.PublicKey) bool })
+	if !ok {
+		return ErrInvalidPrivateKey
+	}
+
+	if !pub.Equal(cert.PublicKey) {
+		return ErrKeyMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,317 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cbasn1 "golang.org/x/crypto/cryptobyte/asn1"
+)
+
+var (
+	// ErrInvalidSCTList indicates a malformed embedded Signed Certificate Timestamp list.
+	ErrInvalidSCTList = errors.New("x509certs: invalid SCT list")
+	// ErrInvalidSCT indicates a malformed TLS-encoded Signed Certificate Timestamp.
+	ErrInvalidSCT = errors.New("x509certs: invalid SCT")
+	// ErrSCTSignature indicates an SCT whose signature does not verify against the log key.
+	ErrSCTSignature = errors.New("x509certs: SCT signature verification failed")
+	// ErrSCTIssuerRequired indicates an embedded SCT was verified without the issuing certificate.
+	ErrSCTIssuerRequired = errors.New("x509certs: issuer certificate required to verify embedded SCT")
+)
+
+// oidSCTListASN1 is the embedded SCT list extension OID, removed from the
+// TBSCertificate to reconstruct the precertificate that the log signed.
+var oidSCTListASN1 = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+
+// RFC 6962 Section 3.2 constants for the digitally-signed SCT input.
+const (
+	sctSignatureTypeCertificateTimestamp = 0
+	sctEntryTypeX509                     = 0
+	sctEntryTypePrecert                  = 1
+)
+
+// SignedCertificateTimestamp is a single SCT from an RFC 6962 SignedCertificateTimestampList,
+// as embedded by CAs for [Certificate Transparency].
+//
+// [Certificate Transparency]: https://grokipedia.com/page/Certificate_Transparency
+type SignedCertificateTimestamp struct {
+	// Version: SCT version (0 for v1)
+	Version uint8 `json:"version"`
+	// LogID: SHA-256 hash of the log's public key (base64 in JSON, as in CT log lists)
+	LogID []byte `json:"logId"`
+	// Timestamp: Time the log issued the SCT (millisecond precision)
+	Timestamp time.Time `json:"timestamp"`
+	// Extensions: Opaque CT extensions, usually empty
+	Extensions []byte `json:"extensions,omitempty"`
+	// HashAlgorithm: TLS HashAlgorithm name of the signature (e.g. "SHA256")
+	HashAlgorithm string `json:"hashAlgorithm"`
+	// SignatureAlgorithm: TLS SignatureAlgorithm name of the signature (e.g. "ECDSA")
+	SignatureAlgorithm string `json:"signatureAlgorithm"`
+	// Signature: Raw digitally-signed signature bytes
+	Signature []byte `json:"signature"`
+}
+
+// tlsHashAlgorithms maps TLS 1.2 HashAlgorithm codes (RFC 5246 Section 7.4.1.4.1) to names.
+var tlsHashAlgorithms = map[uint8]string{
+	0: "none", 1: "MD5", 2: "SHA1", 3: "SHA224", 4: "SHA256", 5: "SHA384", 6: "SHA512",
+}
+
+// tlsSignatureAlgorithms maps TLS 1.2 SignatureAlgorithm codes (RFC 5246 Section 7.4.1.4.1) to names.
+var tlsSignatureAlgorithms = map[uint8]string{
+	0: "anonymous", 1: "RSA", 2: "DSA", 3: "ECDSA",
+}
+
+// ParseSCTList parses the value of the embedded SCT list extension.
+//
+// The extension value is a DER OCTET STRING wrapping the TLS-encoded
+// SignedCertificateTimestampList defined in RFC 6962 Section 3.3.
+//
+// Parameters:
+//   - extValue: Raw extension value (the DER OCTET STRING)
+//
+// Returns:
+//   - []SignedCertificateTimestamp: SCTs in list order
+//   - error: ErrInvalidSCTList if the value is malformed
+func ParseSCTList(extValue []byte) ([]SignedCertificateTimestamp, error) {
+	var list cryptobyte.String
+	input := cryptobyte.String(extValue)
+	if !input.ReadASN1(&list, cbasn1.OCTET_STRING) || !input.Empty() {
+		return nil, fmt.Errorf("%w: extension is not an OCTET STRING", ErrInvalidSCTList)
+	}
+
+	var scts cryptobyte.String
+	if !list.ReadUint16LengthPrefixed(&scts) || !list.Empty() {
+		return nil, fmt.Errorf("%w: bad list length", ErrInvalidSCTList)
+	}
+
+	var result []SignedCertificateTimestamp
+	for !scts.Empty() {
+		var raw cryptobyte.String
+		if !scts.ReadUint16LengthPrefixed(&raw) {
+			return nil, fmt.Errorf("%w: bad SCT length", ErrInvalidSCTList)
+		}
+
+		sct, ok := parseSCT(raw)
+		if !ok {
+			return nil, fmt.Errorf("%w: truncated SCT %d", ErrInvalidSCTList, len(result))
+		}
+		result = append(result, sct)
+	}
+
+	return result, nil
+}
+
+// ParseSCT parses a single TLS-encoded SCT, as delivered by a server in the
+// signed_certificate_timestamp TLS extension or a stapled OCSP response.
+//
+// Each entry of [crypto/tls.ConnectionState.SignedCertificateTimestamps] is
+// in this form.
+//
+// Parameters:
+//   - data: Serialized SignedCertificateTimestamp (RFC 6962 Section 3.2)
+//
+// Returns:
+//   - SignedCertificateTimestamp: Parsed SCT
+//   - error: ErrInvalidSCT if the data is malformed
+func ParseSCT(data []byte) (SignedCertificateTimestamp, error) {
+	sct, ok := parseSCT(cryptobyte.String(data))
+	if !ok {
+		return SignedCertificateTimestamp{}, ErrInvalidSCT
+	}
+	return sct, nil
+}
+
+// EmbeddedSCTs returns the SCTs embedded in the certificate, or nil when the
+// certificate has no SCT list extension.
+//
+// Parameters:
+//   - cert: Certificate to read
+//
+// Returns:
+//   - []SignedCertificateTimestamp: Embedded SCTs in list order
+//   - error: ErrInvalidSCTList if the extension is malformed
+func EmbeddedSCTs(cert *x509.Certificate) ([]SignedCertificateTimestamp, error) {
+	ext, ok := findExtension(cert, oidSCTListASN1)
+	if !ok {
+		return nil, nil
+	}
+	return ParseSCTList(ext.Value)
+}
+
+// parseSCT reads one SCT body, which must span the whole input.
+func parseSCT(raw cryptobyte.String) (SignedCertificateTimestamp, bool) {
+	var (
+		sct             SignedCertificateTimestamp
+		timestamp       uint64
+		extensions      cryptobyte.String
+		hashAlg, sigAlg uint8
+		signature       cryptobyte.String
+	)
+	if !raw.ReadUint8(&sct.Version) ||
+		!raw.ReadBytes(&sct.LogID, 32) ||
+		!raw.ReadUint64(&timestamp) ||
+		!raw.ReadUint16LengthPrefixed(&extensions) ||
+		!raw.ReadUint8(&hashAlg) ||
+		!raw.ReadUint8(&sigAlg) ||
+		!raw.ReadUint16LengthPrefixed(&signature) ||
+		!raw.Empty() {
+		return sct, false
+	}
+
+	sct.Timestamp = time.UnixMilli(int64(timestamp)).UTC()
+	if len(extensions) > 0 {
+		sct.Extensions = []byte(extensions)
+	}
+	sct.HashAlgorithm = tlsAlgorithmName(tlsHashAlgorithms, hashAlg)
+	sct.SignatureAlgorithm = tlsAlgorithmName(tlsSignatureAlgorithms, sigAlg)
+	sct.Signature = []byte(signature)
+	return sct, true
+}
+
+// VerifySCT checks the signature of an SCT against the public key of the CT
+// log that issued it.
+//
+// Embedded SCTs are signed over the precertificate: the leaf TBSCertificate
+// without the SCT list extension, bound to the issuer's key hash. SCTs
+// delivered over TLS or OCSP are signed over the leaf certificate itself.
+// Precertificates issued by a dedicated precertificate signing CA are not
+// reconstructed and fail verification.
+//
+// Parameters:
+//   - sct: SCT to verify
+//   - logKey: Public key of the log (ECDSA P-256 or RSA)
+//   - leaf: Certificate the SCT was issued for
+//   - issuer: Issuer of leaf; required when embedded is true
+//   - embedded: Whether the SCT came from the leaf's SCT list extension
+//
+// Returns:
+//   - error: ErrSCTIssuerRequired, ErrSCTSignature, or an encoding error; nil if the signature is valid
+func VerifySCT(sct SignedCertificateTimestamp, logKey crypto.PublicKey, leaf, issuer *x509.Certificate, embedded bool) error {
+	if sct.HashAlgorithm != "SHA256" {
+		return fmt.Errorf("%w: unsupported hash algorithm %s", ErrSCTSignature, sct.HashAlgorithm)
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint8(sct.Version)
+	b.AddUint8(sctSignatureTypeCertificateTimestamp)
+	b.AddUint64(uint64(sct.Timestamp.UnixMilli()))
+	if embedded {
+		if issuer == nil {
+			return ErrSCTIssuerRequired
+		}
+		tbs, err := precertTBS(leaf)
+		if err != nil {
+			return err
+		}
+		issuerKeyHash := sha256.Sum256(issuer.RawSubjectPublicKeyInfo)
+		b.AddUint16(sctEntryTypePrecert)
+		b.AddBytes(issuerKeyHash[:])
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(tbs) })
+	} else {
+		b.AddUint16(sctEntryTypeX509)
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(leaf.Raw) })
+	}
+	b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(sct.Extensions) })
+
+	signed, err := b.Bytes()
+	if err != nil {
+		return fmt.Errorf("failed to encode SCT signature input: %w", err)
+	}
+	digest := sha256.Sum256(signed)
+
+	switch key := logKey.(type) {
+	case *ecdsa.PublicKey:
+		if sct.SignatureAlgorithm != "ECDSA" || !ecdsa.VerifyASN1(key, digest[:], sct.Signature) {
+			return ErrSCTSignature
+		}
+	case *rsa.PublicKey:
+		if sct.SignatureAlgorithm != "RSA" {
+			return ErrSCTSignature
+		}
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], sct.Signature); err != nil {
+			return fmt.Errorf("%w: %w", ErrSCTSignature, err)
+		}
+	default:
+		return fmt.Errorf("%w: unsupported log key type %T", ErrSCTSignature, logKey)
+	}
+	return nil
+}
+
+// precertTBS reconstructs the precertificate TBSCertificate a log signed for
+// an embedded SCT by removing the SCT list extension from the leaf's
+// TBSCertificate (RFC 6962 Section 3.2).
+func precertTBS(leaf *x509.Certificate) ([]byte, error) {
+	input := cryptobyte.String(leaf.RawTBSCertificate)
+	var tbs cryptobyte.String
+	if !input.ReadASN1(&tbs, cbasn1.SEQUENCE) {
+		return nil, fmt.Errorf("%w: malformed TBSCertificate", ErrSCTSignature)
+	}
+
+	extensionsTag := cbasn1.Tag(3).Constructed().ContextSpecific()
+	var b cryptobyte.Builder
+	b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		for !tbs.Empty() {
+			var (
+				element cryptobyte.String
+				tag     cbasn1.Tag
+			)
+			if !tbs.ReadAnyASN1Element(&element, &tag) {
+				b.SetError(fmt.Errorf("%w: malformed TBSCertificate", ErrSCTSignature))
+				return
+			}
+			if tag != extensionsTag {
+				b.AddBytes(element)
+				continue
+			}
+
+			var wrapper, extensions cryptobyte.String
+			if !element.ReadASN1(&wrapper, extensionsTag) || !wrapper.ReadASN1(&extensions, cbasn1.SEQUENCE) {
+				b.SetError(fmt.Errorf("%w: malformed extensions", ErrSCTSignature))
+				return
+			}
+			b.AddASN1(extensionsTag, func(b *cryptobyte.Builder) {
+				b.AddASN1(cbasn1.SEQUENCE, func(b *cryptobyte.Builder) {
+					for !extensions.Empty() {
+						var (
+							extension, element, body cryptobyte.String
+							id                       asn1.ObjectIdentifier
+						)
+						if !extensions.ReadASN1Element(&extension, cbasn1.SEQUENCE) {
+							b.SetError(fmt.Errorf("%w: malformed extension", ErrSCTSignature))
+							return
+						}
+						element = extension
+						if !element.ReadASN1(&body, cbasn1.SEQUENCE) || !body.ReadASN1ObjectIdentifier(&id) {
+							b.SetError(fmt.Errorf("%w: malformed extension", ErrSCTSignature))
+							return
+						}
+						if !id.Equal(oidSCTListASN1) {
+							b.AddBytes(extension)
+						}
+					}
+				})
+			})
+		}
+	})
+	return b.Bytes()
+}
+
+// tlsAlgorithmName returns the registered name for a TLS algorithm code.
+func tlsAlgorithmName(names map[uint8]string, code uint8) string {
+	if name, ok := names[code]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown(%d)", code)
+}
@@ -0,0 +1,393 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+var (
+	// ErrNoCertificates indicates that an empty certificate list was passed to a truststore encoder.
+	ErrNoCertificates = errors.New("x509certs: no certificates to encode")
+
+	// ErrInvalidJKS indicates that the data is not a well-formed Java KeyStore.
+	ErrInvalidJKS = errors.New("x509certs: invalid JKS data")
+
+	// ErrJKSIntegrity indicates that the JKS integrity digest does not match the supplied password.
+	ErrJKSIntegrity = errors.New("x509certs: JKS integrity check failed (wrong password or corrupted data)")
+
+	// ErrUnsupportedTrustStore indicates an unknown truststore format name.
+	ErrUnsupportedTrustStore = errors.New("x509certs: unsupported truststore format")
+)
+
+const (
+	// TrustStoreJKS is the format name for Java KeyStore (JKS) truststores.
+	TrustStoreJKS = "jks"
+	// TrustStorePKCS12 is the format name for PKCS#12 truststores.
+	TrustStorePKCS12 = "pkcs12"
+
+	// DefaultTrustStorePassword is the password used by the JDK for its bundled cacerts truststore.
+	DefaultTrustStorePassword = "changeit"
+)
+
+const (
+	// jksMagic: Magic number at the start of every JKS file
+	jksMagic uint32 = 0xFEEDFEED
+	// jksVersion: JKS file format version written by modern JDKs
+	jksVersion uint32 = 2
+	// jksTrustedCertTag: Entry tag for trusted certificate entries
+	jksTrustedCertTag uint32 = 2
+	// jksPrivateKeyTag: Entry tag for private key entries (rejected when decoding truststores)
+	jksPrivateKeyTag uint32 = 1
+	// jksCertType: Certificate type string stored with every certificate
+	jksCertType = "X.509"
+	// jksWhitener: Fixed salt mixed into the JKS integrity digest
+	jksWhitener = "Mighty Aphrodite"
+	// aliasFingerprintLen: Number of fingerprint bytes appended to aliases
+	aliasFingerprintLen = 8
+)
+
+// TrustStoreEntry represents a single trusted certificate within a truststore.
+type TrustStoreEntry struct {
+	// Alias: Entry alias (friendly name) as seen by keytool
+	Alias string
+	// Certificate: Trusted certificate stored under the alias
+	Certificate *x509.Certificate
+}
+
+// TrustStoreAlias derives a stable truststore alias for a certificate.
+//
+// The alias is built from the subject common name (falling back to the first
+// organization, then "cert") normalized to lowercase letters, digits and
+// hyphens, followed by the first 8 bytes of the SHA-256 fingerprint in hex.
+// Aliases are lowercase because keytool treats JKS aliases case-insensitively.
+//
+// Parameters:
+//   - cert: Certificate to derive the alias for
+//
+// Returns:
+//   - string: Alias such as "r11-4e5c5d2a8b7f9c01"
+func TrustStoreAlias(cert *x509.Certificate) string {
+	name := cert.Subject.CommonName
+	if name == "" && len(cert.Subject.Organization) > 0 {
+		name = cert.Subject.Organization[0]
+	}
+
+	var b strings.Builder
+	lastHyphen := true
+	for _, r := range strings.ToLower(name) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			lastHyphen = false
+			continue
+		}
+		if !lastHyphen {
+			b.WriteByte('-')
+			lastHyphen = true
+		}
+	}
+
+	base := strings.TrimSuffix(b.String(), "-")
+	if base == "" {
+		base = "cert"
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	return base + "-" + hex.EncodeToString(sum[:aliasFingerprintLen])
+}
+
+// TrustStoreEntries builds alias-tagged entries for certs, dropping exact duplicates.
+//
+// These are the entries [Certificate.EncodeTrustStore] stores, so callers can
+// report the aliases of a truststore before or after encoding it.
+//
+// Parameters:
+//   - certs: Certificates to convert, in output order
+//
+// Returns:
+//   - []TrustStoreEntry: Entries with stable aliases
+//   - error: ErrNoCertificates if certs is empty
+func TrustStoreEntries(certs []*x509.Certificate) ([]TrustStoreEntry, error) {
+	if len(certs) == 0 {
+		return nil, ErrNoCertificates
+	}
+
+	seen := make(map[string]struct{}, len(certs))
+	entries := make([]TrustStoreEntry, 0, len(certs))
+	for _, cert := range certs {
+		alias := TrustStoreAlias(cert)
+		if _, ok := seen[alias]; ok {
+			continue
+		}
+		seen[alias] = struct{}{}
+		entries = append(entries, TrustStoreEntry{Alias: alias, Certificate: cert})
+	}
+
+	return entries, nil
+}
+
+// EncodeTrustStore encodes certificates into the named truststore format.
+//
+// Parameters:
+//   - format: Truststore format, either TrustStoreJKS or TrustStorePKCS12
+//   - certs: Certificates to store as trusted entries
+//   - password: Store password protecting integrity (and encryption for PKCS#12)
+//
+// Returns:
+//   - []byte: Encoded truststore
+//   - error: ErrUnsupportedTrustStore for unknown formats, or an encoding error
+func (c *Certificate) EncodeTrustStore(format string, certs []*x509.Certificate, password string) ([]byte, error) {
+	switch strings.ToLower(format) {
+	case TrustStoreJKS:
+		return c.EncodeJKS(certs, password)
+	case TrustStorePKCS12, "p12", "pfx":
+		return c.EncodePKCS12TrustStore(certs, password)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedTrustStore, format)
+	}
+}
+
+// EncodeJKS encodes certificates into a [Java KeyStore] (JKS) truststore.
+//
+// Every certificate is written as a trusted certificate entry with the alias
+// from TrustStoreAlias. The entry creation date is the certificate's NotBefore,
+// which keeps the output byte-for-byte reproducible for the same input.
+//
+// Parameters:
+//   - certs: Certificates to store as trusted entries
+//   - password: Store password used for the integrity digest
+//
+// Returns:
+//   - []byte: JKS encoded truststore
+//   - error: ErrNoCertificates if certs is empty, or an encoding error
+//
+// [Java KeyStore]: https://grokipedia.com/page/Java_KeyStore
+func (c *Certificate) EncodeJKS(certs []*x509.Certificate, password string) ([]byte, error) {
+	entries, err := TrustStoreEntries(certs)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeUint32 := func(v uint32) { _ = binary.Write(&buf, binary.BigEndian, v) }
+
+	writeUint32(jksMagic)
+	writeUint32(jksVersion)
+	writeUint32(uint32(len(entries)))
+
+	for _, entry := range entries {
+		writeUint32(jksTrustedCertTag)
+		if err := writeJKSString(&buf, entry.Alias); err != nil {
+			return nil, err
+		}
+		_ = binary.Write(&buf, binary.BigEndian, entry.Certificate.NotBefore.UnixMilli())
+		if err := writeJKSString(&buf, jksCertType); err != nil {
+			return nil, err
+		}
+		writeUint32(uint32(len(entry.Certificate.Raw)))
+		buf.Write(entry.Certificate.Raw)
+	}
+
+	digest := jksDigest(password, buf.Bytes())
+	buf.Write(digest)
+
+	return buf.Bytes(), nil
+}
+
+// DecodeJKS decodes trusted certificate entries from a JKS truststore.
+//
+// The integrity digest is verified against the password before any entry is
+// returned. Private key entries are rejected since only truststores are supported.
+//
+// Parameters:
+//   - data: JKS encoded truststore
+//   - password: Store password used for the integrity digest
+//
+// Returns:
+//   - []TrustStoreEntry: Decoded entries in file order
+//   - error: ErrInvalidJKS, ErrJKSIntegrity, or a certificate parsing error
+func (c *Certificate) DecodeJKS(data []byte, password string) ([]TrustStoreEntry, error) {
+	if len(data) < 12+sha1.Size {
+		return nil, ErrInvalidJKS
+	}
+
+	body, sum := data[:len(data)-sha1.Size], data[len(data)-sha1.Size:]
+	if !bytes.Equal(jksDigest(password, body), sum) {
+		return nil, ErrJKSIntegrity
+	}
+
+	r := bytes.NewReader(body)
+	var magic, version, count uint32
+	for _, v := range []*uint32{&magic, &version, &count} {
+		if err := binary.Read(r, binary.BigEndian, v); err != nil {
+			return nil, ErrInvalidJKS
+		}
+	}
+	if magic != jksMagic || (version != 1 && version != jksVersion) {
+		return nil, ErrInvalidJKS
+	}
+
+	var entries []TrustStoreEntry
+	for range count {
+		var tag uint32
+		if err := binary.Read(r, binary.BigEndian, &tag); err != nil {
+			return nil, ErrInvalidJKS
+		}
+		if tag == jksPrivateKeyTag {
+			return nil, fmt.Errorf("%w: private key entries are not supported", ErrInvalidJKS)
+		}
+		if tag != jksTrustedCertTag {
+			return nil, ErrInvalidJKS
+		}
+
+		alias, err := readJKSString(r)
+		if err != nil {
+			return nil, err
+		}
+
+		var created int64
+		if err := binary.Read(r, binary.BigEndian, &created); err != nil {
+			return nil, ErrInvalidJKS
+		}
+
+		if version == jksVersion {
+			certType, err := readJKSString(r)
+			if err != nil {
+				return nil, err
+			}
+			if certType != jksCertType {
+				return nil, fmt.Errorf("%w: unsupported certificate type %q", ErrInvalidJKS, certType)
+			}
+		}
+
+		var certLen uint32
+		if err := binary.Read(r, binary.BigEndian, &certLen); err != nil || int64(certLen) > int64(r.Len()) {
+			return nil, ErrInvalidJKS
+		}
+		der := make([]byte, certLen)
+		if _, err := io.ReadFull(r, der); err != nil {
+			return nil, ErrInvalidJKS
+		}
+
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, ErrParseCertificate
+		}
+		entries = append(entries, TrustStoreEntry{Alias: alias, Certificate: cert})
+	}
+
+	if r.Len() != 0 {
+		return nil, ErrInvalidJKS
+	}
+
+	return entries, nil
+}
+
+// EncodePKCS12TrustStore encodes certificates into a [PKCS #12] truststore.
+//
+// Certificates carry the Java trusted-key-usage attribute so the file can be
+// used directly as a truststore by Java 8 and newer, with aliases from
+// TrustStoreAlias stored as friendly names. Modern (AES-256/PBKDF2) parameters
+// are used, matching the JDK default since Java 18.
+//
+// Parameters:
+//   - certs: Certificates to store as trusted entries
+//   - password: Store password protecting encryption and integrity
+//
+// Returns:
+//   - []byte: PKCS#12 encoded truststore
+//   - error: ErrNoCertificates if certs is empty, or an encoding error
+//
+// [PKCS #12]: https://grokipedia.com/page/PKCS_12
+func (c *Certificate) EncodePKCS12TrustStore(certs []*x509.Certificate, password string) ([]byte, error) {
+	entries, err := TrustStoreEntries(certs)
+	if err != nil {
+		return nil, err
+	}
+
+	p12Entries := make([]pkcs12.TrustStoreEntry, len(entries))
+	for i, entry := range entries {
+		p12Entries[i] = pkcs12.TrustStoreEntry{Cert: entry.Certificate, FriendlyName: entry.Alias}
+	}
+
+	data, err := pkcs12.Modern.EncodeTrustStoreEntries(p12Entries, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode PKCS#12 truststore: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodePKCS12TrustStore decodes trusted certificates from a PKCS#12 truststore.
+//
+// Parameters:
+//   - data: PKCS#12 encoded truststore
+//   - password: Store password
+//
+// Returns:
+//   - []*x509.Certificate: Decoded certificates in file order
+//   - error: Decoding error if the password is wrong or the data is malformed
+func (c *Certificate) DecodePKCS12TrustStore(data []byte, password string) ([]*x509.Certificate, error) {
+	certs, err := pkcs12.DecodeTrustStore(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode PKCS#12 truststore: %w", err)
+	}
+
+	return certs, nil
+}
+
+// jksDigest computes the JKS integrity digest over data.
+//
+// The digest is SHA-1(password as UTF-16BE || "Mighty Aphrodite" || data),
+// as implemented by sun.security.provider.JavaKeyStore.
+func jksDigest(password string, data []byte) []byte {
+	h := sha1.New()
+	for _, u := range utf16.Encode([]rune(password)) {
+		h.Write([]byte{byte(u >> 8), byte(u)})
+	}
+	h.Write([]byte(jksWhitener))
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// writeJKSString writes s using Java's DataOutput.writeUTF framing.
+//
+// Aliases produced by TrustStoreAlias are ASCII, for which modified UTF-8
+// and standard UTF-8 are identical.
+func writeJKSString(w *bytes.Buffer, s string) error {
+	if len(s) > 0xFFFF {
+		return fmt.Errorf("x509certs: JKS string too long (%d bytes)", len(s))
+	}
+	_ = binary.Write(w, binary.BigEndian, uint16(len(s)))
+	w.WriteString(s)
+	return nil
+}
+
+// readJKSString reads a string framed by Java's DataOutput.writeUTF.
+func readJKSString(r *bytes.Reader) (string, error) {
+	var n uint16
+	if err := binary.Read(r, binary.BigEndian, &n); err != nil {
+		return "", ErrInvalidJKS
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return "", ErrInvalidJKS
+	}
+	return string(b), nil
+}
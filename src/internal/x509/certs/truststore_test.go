@@ -0,0 +1,157 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509certs_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// newTestCA creates a self-signed CA certificate with the given subject.
+func newTestCA(t *testing.T, subject pkix.Name) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               subject,
+		NotBefore:             time.Now().Add(-time.Hour).Truncate(time.Second),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestTrustStoreAlias(t *testing.T) {
+	aliasPattern := regexp.MustCompile(`^[a-z0-9-]+-[0-9a-f]{16}$`)
+
+	tests := []struct {
+		name       string
+		subject    pkix.Name
+		wantPrefix string
+	}{
+		{name: "Common Name", subject: pkix.Name{CommonName: "Example Root CA"}, wantPrefix: "example-root-ca-"},
+		{name: "Punctuation Collapsed", subject: pkix.Name{CommonName: "  R11 (Let's Encrypt)  "}, wantPrefix: "r11-let-s-encrypt-"},
+		{name: "Organization Fallback", subject: pkix.Name{Organization: []string{"ACME Corp"}}, wantPrefix: "acme-corp-"},
+		{name: "Non-ASCII Only", subject: pkix.Name{CommonName: "証明書"}, wantPrefix: "cert-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cert := newTestCA(t, tt.subject)
+			alias := x509certs.TrustStoreAlias(cert)
+
+			assert.True(t, aliasPattern.MatchString(alias), "alias %q has unexpected shape", alias)
+			assert.True(t, strings.HasPrefix(alias, tt.wantPrefix), "alias %q should start with %q", alias, tt.wantPrefix)
+			assert.Equal(t, alias, x509certs.TrustStoreAlias(cert), "alias must be stable")
+		})
+	}
+
+	t.Run("Distinct Certificates Same Name", func(t *testing.T) {
+		a := newTestCA(t, pkix.Name{CommonName: "Duplicate"})
+		b := newTestCA(t, pkix.Name{CommonName: "Duplicate"})
+		assert.NotEqual(t, x509certs.TrustStoreAlias(a), x509certs.TrustStoreAlias(b))
+	})
+}
+
+func TestCertificate_TrustStoreRoundTrip(t *testing.T) {
+	leaf, err := x509certs.New().Decode([]byte(testCertPEM))
+	require.NoError(t, err)
+
+	root := newTestCA(t, pkix.Name{CommonName: "Test Root CA", Organization: []string{"Test"}})
+	certs := []*x509.Certificate{leaf, root, root} // duplicate must be dropped
+
+	c := x509certs.New()
+
+	t.Run("JKS", func(t *testing.T) {
+		data, err := c.EncodeTrustStore(x509certs.TrustStoreJKS, certs, x509certs.DefaultTrustStorePassword)
+		require.NoError(t, err)
+
+		entries, err := c.DecodeJKS(data, x509certs.DefaultTrustStorePassword)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		assert.True(t, leaf.Equal(entries[0].Certificate))
+		assert.Equal(t, x509certs.TrustStoreAlias(leaf), entries[0].Alias)
+		assert.True(t, root.Equal(entries[1].Certificate))
+		assert.Equal(t, x509certs.TrustStoreAlias(root), entries[1].Alias)
+	})
+
+	t.Run("PKCS12", func(t *testing.T) {
+		data, err := c.EncodeTrustStore(x509certs.TrustStorePKCS12, certs, x509certs.DefaultTrustStorePassword)
+		require.NoError(t, err)
+
+		decoded, err := c.DecodePKCS12TrustStore(data, x509certs.DefaultTrustStorePassword)
+		require.NoError(t, err)
+		require.Len(t, decoded, 2)
+
+		assert.True(t, leaf.Equal(decoded[0]))
+		assert.True(t, root.Equal(decoded[1]))
+
+		_, err = c.DecodePKCS12TrustStore(data, "wrong-password")
+		assert.Error(t, err, "decoding with the wrong password must fail")
+	})
+}
+
+func TestCertificate_EncodeJKS(t *testing.T) {
+	c := x509certs.New()
+	root := newTestCA(t, pkix.Name{CommonName: "Deterministic Root"})
+
+	t.Run("Deterministic Output", func(t *testing.T) {
+		first, err := c.EncodeJKS([]*x509.Certificate{root}, "secret")
+		require.NoError(t, err)
+		second, err := c.EncodeJKS([]*x509.Certificate{root}, "secret")
+		require.NoError(t, err)
+		assert.Equal(t, first, second)
+		assert.Equal(t, []byte{0xFE, 0xED, 0xFE, 0xED}, first[:4], "JKS magic")
+	})
+
+	t.Run("Empty Input", func(t *testing.T) {
+		_, err := c.EncodeJKS(nil, "secret")
+		assert.ErrorIs(t, err, x509certs.ErrNoCertificates)
+	})
+
+	t.Run("Wrong Password", func(t *testing.T) {
+		data, err := c.EncodeJKS([]*x509.Certificate{root}, "secret")
+		require.NoError(t, err)
+		_, err = c.DecodeJKS(data, "other")
+		assert.ErrorIs(t, err, x509certs.ErrJKSIntegrity)
+	})
+
+	t.Run("Truncated Data", func(t *testing.T) {
+		_, err := c.DecodeJKS([]byte{0xFE, 0xED}, "secret")
+		assert.ErrorIs(t, err, x509certs.ErrInvalidJKS)
+	})
+}
+
+func TestCertificate_EncodeTrustStore_Unsupported(t *testing.T) {
+	root := newTestCA(t, pkix.Name{CommonName: "Root"})
+	_, err := x509certs.New().EncodeTrustStore("bks", []*x509.Certificate{root}, "secret")
+	assert.ErrorIs(t, err, x509certs.ErrUnsupportedTrustStore)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"crypto"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Server bundle layouts supported by [Chain.ServerBundle].
+const (
+	// BundleNginx: fullchain.pem (leaf + intermediates) and privkey.pem
+	BundleNginx = "nginx"
+	// BundleApache: cert.pem, chain.pem (intermediates) and privkey.pem
+	BundleApache = "apache"
+	// BundleHAProxy: a single combined.pem holding leaf, intermediates and key
+	BundleHAProxy = "haproxy"
+)
+
+var (
+	// ErrUnsupportedBundleLayout indicates an unknown server bundle layout name.
+	ErrUnsupportedBundleLayout = errors.New("x509chain: unsupported bundle layout")
+
+	// ErrEmptyChain indicates that the chain has no certificates to bundle.
+	ErrEmptyChain = errors.New("x509chain: chain contains no certificates")
+
+	// ErrPrivateKeyRequired indicates that a server bundle was requested without a private key.
+	ErrPrivateKeyRequired = errors.New("x509chain: private key required for server bundle")
+)
+
+// BundleFile is a single file of a server bundle layout.
+type BundleFile struct {
+	// Name: Conventional file name for the layout (e.g. "fullchain.pem")
+	Name string
+	// Data: PEM encoded file contents
+	Data []byte
+	// Sensitive: True when the file contains private key material and must not be world-readable
+	Sensitive bool
+}
+
+// ServerBundle assembles ready-to-deploy files for a web server or load balancer.
+//
+// The private key is first verified against the leaf certificate's public key.
+// Certificates served to clients are the leaf followed by its intermediates;
+// a self-signed root at the end of the chain is omitted since clients must
+// already trust it and sending it only adds handshake overhead.
+//
+// Layouts:
+//   - nginx: fullchain.pem (ssl_certificate) and privkey.pem (ssl_certificate_key)
+//   - apache: cert.pem (SSLCertificateFile), chain.pem (SSLCertificateChainFile,
+//     omitted when there are no intermediates) and privkey.pem (SSLCertificateKeyFile)
+//   - haproxy: combined.pem with the certificate chain followed by the key (crt)
+//
+// Parameters:
+//   - layout: Bundle layout name (BundleNginx, BundleApache or BundleHAProxy)
+//   - key: Private key belonging to the leaf certificate
+//
+// Returns:
+//   - []BundleFile: Files making up the layout, in a stable order
+//   - error: Error if the layout is unknown, the key is missing or does not match the leaf
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) ServerBundle(layout string, key crypto.PrivateKey) ([]BundleFile, error) {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	if len(ch.Certs) == 0 {
+		return nil, ErrEmptyChain
+	}
+	if key == nil {
+		return nil, ErrPrivateKeyRequired
+	}
+
+	leaf := ch.Certs[0]
+	if err := ch.MatchPrivateKey(leaf, key); err != nil {
+		return nil, fmt.Errorf("leaf %q: %w", leaf.Subject.CommonName, err)
+	}
+
+	keyPEM, err := ch.EncodePrivateKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	served := ch.servedCertificates()
+	intermediates := served[1:]
+
+	switch strings.ToLower(layout) {
+	case BundleNginx:
+		return []BundleFile{
+			{Name: "fullchain.pem", Data: ch.EncodeMultiplePEM(served)},
+			{Name: "privkey.pem", Data: keyPEM, Sensitive: true},
+		}, nil
+	case BundleApache:
+		files := []BundleFile{{Name: "cert.pem", Data: ch.EncodePEM(leaf)}}
+		if len(intermediates) > 0 {
+			files = append(files, BundleFile{Name: "chain.pem", Data: ch.EncodeMultiplePEM(intermediates)})
+		}
+		return append(files, BundleFile{Name: "privkey.pem", Data: keyPEM, Sensitive: true}), nil
+	case BundleHAProxy:
+		combined := append(ch.EncodeMultiplePEM(served), keyPEM...)
+		return []BundleFile{{Name: "combined.pem", Data: combined, Sensitive: true}}, nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedBundleLayout, layout)
+	}
+}
+
+// servedCertificates returns the leaf and intermediates, dropping a trailing self-signed root.
+//
+// The caller must hold ch.mu.
+//
+// Returns:
+//   - []*x509.Certificate: Certificates a server should present, leaf first
+func (ch *Chain) servedCertificates() []*x509.Certificate {
+	certs := ch.Certs
+	if len(certs) > 1 && ch.IsRootNode(certs[len(certs)-1]) {
+		certs = certs[:len(certs)-1]
+	}
+	return certs
+}
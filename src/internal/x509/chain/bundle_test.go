@@ -0,0 +1,129 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestChainWithKey creates a leaf, intermediate and root chain and returns the leaf key.
+func createTestChainWithKey(t *testing.T) ([]*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	issue := func(serial int64, subject string, isCA bool, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(serial),
+			Subject:               pkix.Name{CommonName: subject},
+			NotBefore:             time.Now().Add(-time.Hour),
+			NotAfter:              time.Now().Add(24 * time.Hour),
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+		}
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+
+	root, rootKey := issue(1, "Bundle Root CA", true, nil, nil)
+	intermediate, intKey := issue(2, "Bundle Intermediate CA", true, root, rootKey)
+	leaf, leafKey := issue(3, "bundle.example.com", false, intermediate, intKey)
+
+	return []*x509.Certificate{leaf, intermediate, root}, leafKey
+}
+
+func TestChain_ServerBundle(t *testing.T) {
+	certs, leafKey := createTestChainWithKey(t)
+	leaf, intermediate := certs[0], certs[1]
+
+	chain := New(leaf, version)
+	chain.Certs = certs
+
+	keyPEM, err := chain.EncodePrivateKeyPEM(leafKey)
+	require.NoError(t, err)
+
+	served := chain.EncodeMultiplePEM([]*x509.Certificate{leaf, intermediate})
+
+	tests := []struct {
+		layout string
+		want   []BundleFile
+	}{
+		{
+			layout: BundleNginx,
+			want: []BundleFile{
+				{Name: "fullchain.pem", Data: served},
+				{Name: "privkey.pem", Data: keyPEM, Sensitive: true},
+			},
+		},
+		{
+			layout: BundleApache,
+			want: []BundleFile{
+				{Name: "cert.pem", Data: chain.EncodePEM(leaf)},
+				{Name: "chain.pem", Data: chain.EncodePEM(intermediate)},
+				{Name: "privkey.pem", Data: keyPEM, Sensitive: true},
+			},
+		},
+		{
+			layout: BundleHAProxy,
+			want: []BundleFile{
+				{Name: "combined.pem", Data: append(append([]byte{}, served...), keyPEM...), Sensitive: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.layout, func(t *testing.T) {
+			files, err := chain.ServerBundle(tt.layout, leafKey)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, files)
+		})
+	}
+}
+
+func TestChain_ServerBundle_Errors(t *testing.T) {
+	certs, leafKey := createTestChainWithKey(t)
+	_, otherKey := createTestChainWithKey(t)
+
+	chain := New(certs[0], version)
+	chain.Certs = certs
+
+	_, err := chain.ServerBundle(BundleNginx, otherKey)
+	assert.ErrorIs(t, err, x509certs.ErrKeyMismatch)
+
+	_, err = chain.ServerBundle(BundleNginx, nil)
+	assert.ErrorIs(t, err, ErrPrivateKeyRequired)
+
+	_, err = chain.ServerBundle("caddy", leafKey)
+	assert.ErrorIs(t, err, ErrUnsupportedBundleLayout)
+
+	t.Run("Apache Without Intermediates", func(t *testing.T) {
+		single := New(certs[0], version)
+		files, err := single.ServerBundle(BundleApache, leafKey)
+		require.NoError(t, err)
+		require.Len(t, files, 2)
+		assert.Equal(t, "cert.pem", files[0].Name)
+		assert.Equal(t, "privkey.pem", files[1].Name)
+	})
+}
@@ -7,8 +7,6 @@ package x509chain
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/rsa"
 	"crypto/x509"
 	"fmt"
 	"net/http"
@@ -32,6 +30,10 @@ type HTTPConfig struct {
 	mu sync.Mutex
 	// client: Reusable HTTP client instance
 	client *http.Client
+	// egressClient: Reusable HTTP client enforcing egressRules
+	egressClient *http.Client
+	// egressRules: Egress policy egressClient was built for
+	egressRules *egressRules
 }
 
 // NewHTTPConfig creates a new HTTP configuration with default values.
@@ -69,6 +71,8 @@ func (c *HTTPConfig) GetUserAgent() string {
 // Client returns an HTTP client configured with the current timeout.
 //
 // It creates or reuses an http.Client, ensuring it uses the configured timeout.
+// While an egress policy is installed with [SetEgressPolicy], the client dials
+// only addresses the policy allows and enforces its redirect limit.
 //
 // Returns:
 //   - *http.Client: Configured HTTP client
@@ -78,6 +82,16 @@ func (c *HTTPConfig) Client() *http.Client {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if rules := egressPolicy.Load(); rules != nil {
+		// Clients already handed out may be in use, so a changed policy or
+		// timeout gets a new client sharing the policy's transport
+		if c.egressClient == nil || c.egressRules != rules || c.egressClient.Timeout != c.Timeout {
+			c.egressClient = newEgressClient(rules, c.Timeout)
+			c.egressRules = rules
+		}
+		return c.egressClient
+	}
+
 	if c.client == nil {
 		c.client = &http.Client{Timeout: c.Timeout}
 		return c.client
@@ -109,6 +123,8 @@ type Chain struct {
 	Intermediates *x509.CertPool
 	// HTTPConfig: HTTP client configuration for certificate fetching
 	HTTPConfig *HTTPConfig
+	// SignedCertificateTimestamps: SCTs the server delivered in the TLS handshake, set by [FetchRemoteChain]
+	SignedCertificateTimestamps [][]byte
 }
 
 // New creates a new Chain.
@@ -154,6 +170,23 @@ func New(cert *x509.Certificate, version string) *Chain {
 //
 // [Rust]: https://www.rust-lang.org/
 func (ch *Chain) FetchCertificate(ctx context.Context) error {
+	if err := ch.fetchIssuers(ctx); err != nil {
+		return err
+	}
+	return ch.VerifyChain()
+}
+
+// fetchIssuers follows the AIA CA Issuers URL of the last certificate until a
+// root is reached or no issuer URL is left, without verifying the result.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - error: Error if a request, download, or decode fails
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) fetchIssuers(ctx context.Context) error {
 	for {
 		// Check context cancellation before each iteration
 		select {
@@ -171,6 +204,14 @@ func (ch *Chain) FetchCertificate(ctx context.Context) error {
 		parentURL := last.IssuingCertificateURL[0]
 		ch.mu.RUnlock()
 
+		if err := CheckEgressURL(parentURL); err != nil {
+			return fmt.Errorf("failed to fetch certificate from %s: %w", parentURL, err)
+		}
+		if err := waitForURL(ctx, parentURL); err != nil {
+			return fmt.Errorf("failed to fetch certificate from %s: %w", parentURL, err)
+		}
+		reportProgress(ctx, "fetching issuer of %s from %s", last.Subject.CommonName, parentURL)
+
 		req, err := http.NewRequestWithContext(ctx, http.MethodGet, parentURL, nil)
 		if err != nil {
 			return fmt.Errorf("failed to create HTTP request: %w", err)
@@ -219,7 +260,7 @@ func (ch *Chain) FetchCertificate(ctx context.Context) error {
 		}
 	}
 
-	return ch.VerifyChain()
+	return nil
 }
 
 // AddRootCA adds a root CA to the certificate chain if necessary.
@@ -339,6 +380,37 @@ func (ch *Chain) VerifyChain() error {
 	return nil
 }
 
+// VerifyAgainstRoots validates the leaf certificate against the configured
+// Roots and Intermediates for the given extended key usages.
+//
+// Unlike [Chain.VerifyChain], no certificate of the chain itself is trusted
+// as a root, so a presented chain only verifies if it leads to one of Roots.
+// On success, Certs is replaced by the verified chain (leaf first).
+//
+// Parameters:
+//   - usages: Extended key usages the leaf must permit; none defaults to server authentication
+//
+// Returns:
+//   - error: Error if verification fails (nil if chain is valid)
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) VerifyAgainstRoots(usages ...x509.ExtKeyUsage) error {
+	ch.mu.Lock()
+	defer ch.mu.Unlock()
+
+	chains, err := ch.Certs[0].Verify(x509.VerifyOptions{
+		Roots:         ch.Roots,
+		Intermediates: ch.Intermediates,
+		KeyUsages:     usages,
+	})
+	if err != nil {
+		return err
+	}
+
+	ch.Certs = chains[0]
+	return nil
+}
+
 // findIssuerForCertificate finds the certificate that issued the given cert in the chain.
 //
 // It iterates backwards through the chain to find a certificate that has signed
@@ -371,34 +443,22 @@ func (ch *Chain) findIssuerForCertificate(cert *x509.Certificate) *x509.Certific
 }
 
 // KeySize extracts the key size in bits from a certificate's public key.
-// It handles both RSA and ECDSA keys, returning the appropriate bit length.
+//
+// It is a shorthand for [x509certs.DescribeKey], which recognizes RSA, ECDSA,
+// EdDSA (Ed25519 and Ed448), and the [post-quantum cryptography] ML-DSA, SLH-DSA
+// and composite keys that crypto/x509 leaves unparsed.
 //
 // Parameters:
 //   - cert: X.509 certificate containing the public key to analyze
 //
 // Returns:
-//   - The key size in bits (e.g., 2048 for RSA, 256 for P-256 ECDSA)
+//   - The key size in bits (e.g., 2048 for RSA, 256 for P-256 ECDSA or Ed25519,
+//     the encoded public key length for ML-DSA and SLH-DSA)
 //   - 0 if the key type is unsupported or unrecognized
 //
-// The function supports both pointer and value types for RSA and ECDSA public keys
-// to handle different certificate parsing scenarios.
-//
-// TODO: future enhancement - add support for [post-quantum cryptography] keysize
-//
 // [post-quantum cryptography]: https://grokipedia.com/page/Post-quantum_cryptography
 func (ch *Chain) KeySize(cert *x509.Certificate) int {
-	switch pub := cert.PublicKey.(type) {
-	case *rsa.PublicKey:
-		return pub.Size() * 8 // Convert bytes to bits
-	case rsa.PublicKey:
-		return pub.Size() * 8 // Convert bytes to bits
-	case *ecdsa.PublicKey:
-		return pub.Curve.Params().BitSize
-	case ecdsa.PublicKey:
-		return pub.Curve.Params().BitSize
-	default:
-		return 0
-	}
+	return x509certs.DescribeKey(cert).Bits
 }
 
 // GetCertificateRole determines the role of a certificate in the chain.
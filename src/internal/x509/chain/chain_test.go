@@ -11,6 +11,7 @@ import (
 	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"math/big"
@@ -315,12 +316,30 @@ func TestChain_Visualization(t *testing.T) {
 	tableOutput := chain.RenderTable(t.Context())
 	assert.NotEmpty(t, tableOutput, "Expected non-empty table output")
 	assert.Contains(t, tableOutput, "test.example.com", "Expected table to contain leaf certificate")
+	assert.Contains(t, tableOutput, x509certs.SPKIPin(certs[0]), "Expected table to contain leaf SPKI pin")
+	leafFingerprints := x509certs.CertificateFingerprints(certs[0])
+	assert.Contains(t, tableOutput, leafFingerprints.SHA256, "Expected table to contain leaf SHA-256 fingerprint")
+	if leafFingerprints.SubjectKeyID != "" {
+		assert.Contains(t, tableOutput, leafFingerprints.SubjectKeyID, "Expected table to contain leaf SKI")
+	}
+	if leafFingerprints.AuthorityKeyID != "" {
+		assert.Contains(t, tableOutput, leafFingerprints.AuthorityKeyID, "Expected table to contain leaf AKI")
+	}
+	assert.Contains(t, tableOutput, "AKI", "Expected table to have an AKI column")
+	assert.Contains(t, tableOutput, x509certs.DescribeKey(certs[0]).String(), "Expected table to describe leaf key")
 
 	// Test JSON visualization
 	jsonData, err := chain.ToVisualizationJSON(t.Context())
 	require.NoError(t, err, "ToVisualizationJSON failed")
 	assert.NotEmpty(t, jsonData, "Expected non-empty JSON output")
 	assert.Contains(t, string(jsonData), "test.example.com", "Expected JSON to contain leaf certificate")
+
+	var viz struct {
+		Certificates []x509certs.Fingerprints `json:"certificates"`
+	}
+	require.NoError(t, json.Unmarshal(jsonData, &viz))
+	require.Len(t, viz.Certificates, len(certs))
+	assert.Equal(t, x509certs.CertificateFingerprints(certs[0]), viz.Certificates[0])
 }
 
 func TestChain_ContextCancellation(t *testing.T) {
@@ -542,6 +561,35 @@ func TestChain_VerifyChain_Error(t *testing.T) {
 	require.Error(t, err, "expected verification error for invalid chain")
 }
 
+func TestChain_VerifyAgainstRoots(t *testing.T) {
+	certs := createTestChain(t)
+	leaf, intermediate, root := certs[0], certs[1], certs[2]
+
+	t.Run("Trusted Root", func(t *testing.T) {
+		manager := New(leaf, version)
+		manager.Roots.AddCert(root)
+		manager.Intermediates.AddCert(intermediate)
+		require.NoError(t, manager.VerifyAgainstRoots(x509.ExtKeyUsageServerAuth))
+		assert.Equal(t, []*x509.Certificate{leaf, intermediate, root}, manager.Certs)
+	})
+
+	t.Run("Presented Root Not Trusted", func(t *testing.T) {
+		manager := New(leaf, version)
+		manager.Certs = []*x509.Certificate{leaf, intermediate, root}
+		manager.Intermediates.AddCert(intermediate)
+		manager.Intermediates.AddCert(root)
+		assert.Error(t, manager.VerifyAgainstRoots(x509.ExtKeyUsageServerAuth))
+		assert.Len(t, manager.Certs, 3)
+	})
+
+	t.Run("Key Usage", func(t *testing.T) {
+		manager := New(leaf, version)
+		manager.Roots.AddCert(root)
+		manager.Intermediates.AddCert(intermediate)
+		assert.Error(t, manager.VerifyAgainstRoots(x509.ExtKeyUsageClientAuth))
+	})
+}
+
 func TestRevocationStatus_Timeout(t *testing.T) {
 	if testing.Short() {
 		t.Skip("Skipping timeout test in short mode")
@@ -0,0 +1,679 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// anyPolicy is the special certificate policy OID that matches every policy (RFC 5280 §4.2.1.4).
+const anyPolicy = "2.5.29.32.0"
+
+// Name constraint check results reported in [NameConstraintCheck.Result].
+const (
+	// ConstraintPermitted means the name is inside a permitted subtree.
+	ConstraintPermitted = "permitted"
+	// ConstraintExcluded means the name is inside an excluded subtree.
+	ConstraintExcluded = "excluded"
+	// ConstraintOutside means permitted subtrees exist for the name type but none contains the name.
+	ConstraintOutside = "outside"
+	// ConstraintUnconstrained means the CA has no subtrees for the name type.
+	ConstraintUnconstrained = "unconstrained"
+)
+
+// NameConstraintsLevel lists the permitted and excluded subtrees of one CA certificate.
+type NameConstraintsLevel struct {
+	// Index: Position of the CA in the chain (leaf is 0)
+	Index int `json:"index"`
+	// Role: Role of the CA in the chain
+	Role string `json:"role"`
+	// Subject: Subject common name of the CA
+	Subject string `json:"subject"`
+	// Critical: Whether the name constraints extension is critical
+	Critical bool `json:"critical"`
+	// PermittedDNS: Permitted dNSName subtrees
+	PermittedDNS []string `json:"permittedDNS,omitempty"`
+	// ExcludedDNS: Excluded dNSName subtrees
+	ExcludedDNS []string `json:"excludedDNS,omitempty"`
+	// PermittedIP: Permitted iPAddress ranges in CIDR notation
+	PermittedIP []string `json:"permittedIP,omitempty"`
+	// ExcludedIP: Excluded iPAddress ranges in CIDR notation
+	ExcludedIP []string `json:"excludedIP,omitempty"`
+	// PermittedEmail: Permitted rfc822Name subtrees
+	PermittedEmail []string `json:"permittedEmail,omitempty"`
+	// ExcludedEmail: Excluded rfc822Name subtrees
+	ExcludedEmail []string `json:"excludedEmail,omitempty"`
+	// PermittedURI: Permitted uniformResourceIdentifier host subtrees
+	PermittedURI []string `json:"permittedURI,omitempty"`
+	// ExcludedURI: Excluded uniformResourceIdentifier host subtrees
+	ExcludedURI []string `json:"excludedURI,omitempty"`
+}
+
+// NameConstraintCheck is the result of checking one name against one CA's constraints.
+type NameConstraintCheck struct {
+	// Index: Position of the constraining CA in the chain
+	Index int `json:"index"`
+	// Subject: Subject common name of the constraining CA
+	Subject string `json:"subject"`
+	// Result: permitted, excluded, outside, or unconstrained
+	Result string `json:"result"`
+	// Subtree: Subtree that matched, when permitted or excluded
+	Subtree string `json:"subtree,omitempty"`
+}
+
+// SANConstraintResult is the evaluation of one leaf Subject Alternative Name.
+type SANConstraintResult struct {
+	// Type: Name type (DNS, IP, email, or URI)
+	Type string `json:"type"`
+	// Value: The name itself
+	Value string `json:"value"`
+	// Allowed: True when no CA excludes the name or omits it from its permitted subtrees
+	Allowed bool `json:"allowed"`
+	// Checks: Result for every CA that carries name constraints, leaf side first
+	Checks []NameConstraintCheck `json:"checks"`
+}
+
+// PolicyLevel describes certificate policy processing at one certificate of the path.
+type PolicyLevel struct {
+	// Index: Position of the certificate in the chain (leaf is 0)
+	Index int `json:"index"`
+	// Role: Role of the certificate in the chain
+	Role string `json:"role"`
+	// Subject: Subject common name of the certificate
+	Subject string `json:"subject"`
+	// Policies: Certificate policy OIDs asserted by the certificate
+	Policies []string `json:"policies,omitempty"`
+	// Mappings: Policy mappings as "issuerDomainPolicy -> subjectDomainPolicy"
+	Mappings []string `json:"mappings,omitempty"`
+	// RequireExplicitPolicy: requireExplicitPolicy skip count, when present
+	RequireExplicitPolicy *int `json:"requireExplicitPolicy,omitempty"`
+	// InhibitPolicyMapping: inhibitPolicyMapping skip count, when present
+	InhibitPolicyMapping *int `json:"inhibitPolicyMapping,omitempty"`
+	// InhibitAnyPolicy: inhibitAnyPolicy skip count, when present
+	InhibitAnyPolicy *int `json:"inhibitAnyPolicy,omitempty"`
+	// ValidPolicies: Policies that remain valid at this certificate after processing
+	ValidPolicies []string `json:"validPolicies"`
+}
+
+// EffectivePolicy is a policy that is valid for the whole path.
+type EffectivePolicy struct {
+	// Policy: Policy OID as asserted by the leaf
+	Policy string `json:"policy"`
+	// Path: Valid policy at each certificate from the top of the path down to the leaf,
+	// showing where policy mappings translated the OID
+	Path []string `json:"path"`
+}
+
+// PolicyEvaluation is the outcome of RFC 5280 §6.1 certificate policy processing.
+type PolicyEvaluation struct {
+	// Levels: Policy processing for every certificate of the path, leaf first
+	Levels []PolicyLevel `json:"levels"`
+	// EffectivePolicies: Policies valid for the whole path (empty when the policy tree is null)
+	EffectivePolicies []EffectivePolicy `json:"effectivePolicies"`
+	// ExplicitPolicyRequired: Whether a requireExplicitPolicy constraint took effect
+	ExplicitPolicyRequired bool `json:"explicitPolicyRequired"`
+	// Valid: False when an explicit policy is required but no policy is valid for the path
+	Valid bool `json:"valid"`
+	// Notes: Explanations of events that changed the outcome (e.g. a null policy tree)
+	Notes []string `json:"notes,omitempty"`
+}
+
+// ConstraintsReport explains the name constraints and certificate policy processing of a chain.
+type ConstraintsReport struct {
+	// NameConstraints: Subtrees of every CA that carries name constraints, leaf side first
+	NameConstraints []NameConstraintsLevel `json:"nameConstraints"`
+	// SANs: Evaluation of every leaf Subject Alternative Name against those subtrees
+	SANs []SANConstraintResult `json:"sans"`
+	// NameConstraintsSatisfied: True when every leaf SAN is allowed
+	NameConstraintsSatisfied bool `json:"nameConstraintsSatisfied"`
+	// Policy: Certificate policy processing result
+	Policy PolicyEvaluation `json:"policy"`
+}
+
+// EvaluateConstraints explains how the name constraints and certificate
+// policies of the chain apply to its leaf.
+//
+// [Chain.VerifyChain] only reports pass or fail; this report shows which
+// permitted and excluded subtrees apply at each CA, whether each leaf SAN is
+// inside them, and the effective certificate policy set after policy mappings,
+// requireExplicitPolicy, inhibitPolicyMapping, and inhibitAnyPolicy.
+//
+// Name constraints of every CA in the chain are applied, including the root,
+// as crypto/x509 does. Policy processing follows RFC 5280 §6.1 with an initial
+// policy set of anyPolicy, treating a self-signed last certificate as the
+// trust anchor.
+//
+// Returns:
+//   - *ConstraintsReport: Name constraints and policy evaluation
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) EvaluateConstraints() *ConstraintsReport {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	report := &ConstraintsReport{
+		NameConstraints:          []NameConstraintsLevel{},
+		SANs:                     []SANConstraintResult{},
+		NameConstraintsSatisfied: true,
+	}
+	if len(ch.Certs) == 0 {
+		report.Policy = PolicyEvaluation{Levels: []PolicyLevel{}, EffectivePolicies: []EffectivePolicy{}, Valid: true}
+		return report
+	}
+
+	for i, cert := range ch.Certs[1:] {
+		if hasNameConstraints(cert) {
+			report.NameConstraints = append(report.NameConstraints, nameConstraintsLevel(cert, i+1, ch.GetCertificateRole(i+1)))
+		}
+	}
+
+	for _, san := range leafSANs(ch.Certs[0]) {
+		san.Allowed = true
+		san.Checks = []NameConstraintCheck{}
+		for i, cert := range ch.Certs[1:] {
+			if !hasNameConstraints(cert) {
+				continue
+			}
+			check := checkNameConstraint(cert, san.Type, san.Value)
+			check.Index, check.Subject = i+1, cert.Subject.CommonName
+			san.Checks = append(san.Checks, check)
+			if check.Result == ConstraintExcluded || check.Result == ConstraintOutside {
+				san.Allowed = false
+			}
+		}
+		report.NameConstraintsSatisfied = report.NameConstraintsSatisfied && san.Allowed
+		report.SANs = append(report.SANs, san)
+	}
+
+	report.Policy = ch.evaluatePolicies()
+	return report
+}
+
+// hasNameConstraints reports whether the certificate carries any name constraint subtree.
+func hasNameConstraints(cert *x509.Certificate) bool {
+	return len(cert.PermittedDNSDomains)+len(cert.ExcludedDNSDomains)+
+		len(cert.PermittedIPRanges)+len(cert.ExcludedIPRanges)+
+		len(cert.PermittedEmailAddresses)+len(cert.ExcludedEmailAddresses)+
+		len(cert.PermittedURIDomains)+len(cert.ExcludedURIDomains) > 0
+}
+
+// nameConstraintsLevel lists the subtrees of a constrained CA.
+func nameConstraintsLevel(cert *x509.Certificate, index int, role string) NameConstraintsLevel {
+	ipStrings := func(ranges []*net.IPNet) []string {
+		var out []string
+		for _, r := range ranges {
+			out = append(out, r.String())
+		}
+		return out
+	}
+	return NameConstraintsLevel{
+		Index:          index,
+		Role:           role,
+		Subject:        cert.Subject.CommonName,
+		Critical:       cert.PermittedDNSDomainsCritical,
+		PermittedDNS:   cert.PermittedDNSDomains,
+		ExcludedDNS:    cert.ExcludedDNSDomains,
+		PermittedIP:    ipStrings(cert.PermittedIPRanges),
+		ExcludedIP:     ipStrings(cert.ExcludedIPRanges),
+		PermittedEmail: cert.PermittedEmailAddresses,
+		ExcludedEmail:  cert.ExcludedEmailAddresses,
+		PermittedURI:   cert.PermittedURIDomains,
+		ExcludedURI:    cert.ExcludedURIDomains,
+	}
+}
+
+// leafSANs lists the Subject Alternative Names subject to name constraints.
+func leafSANs(leaf *x509.Certificate) []SANConstraintResult {
+	var sans []SANConstraintResult
+	for _, name := range leaf.DNSNames {
+		sans = append(sans, SANConstraintResult{Type: "DNS", Value: name})
+	}
+	for _, ip := range leaf.IPAddresses {
+		sans = append(sans, SANConstraintResult{Type: "IP", Value: ip.String()})
+	}
+	for _, email := range leaf.EmailAddresses {
+		sans = append(sans, SANConstraintResult{Type: "email", Value: email})
+	}
+	for _, uri := range leaf.URIs {
+		sans = append(sans, SANConstraintResult{Type: "URI", Value: uri.String()})
+	}
+	return sans
+}
+
+// checkNameConstraint checks one name against a CA's subtrees of the same type.
+// Excluded subtrees take precedence over permitted ones.
+func checkNameConstraint(cert *x509.Certificate, nameType, value string) NameConstraintCheck {
+	var permitted, excluded []string
+	var match func(constraint string) bool
+
+	switch nameType {
+	case "DNS":
+		permitted, excluded = cert.PermittedDNSDomains, cert.ExcludedDNSDomains
+		match = func(constraint string) bool { return matchDomainConstraint(value, constraint, true) }
+	case "IP":
+		ip := net.ParseIP(value)
+		for _, r := range cert.PermittedIPRanges {
+			permitted = append(permitted, r.String())
+		}
+		for _, r := range cert.ExcludedIPRanges {
+			excluded = append(excluded, r.String())
+		}
+		match = func(constraint string) bool {
+			_, network, err := net.ParseCIDR(constraint)
+			return err == nil && network.Contains(ip)
+		}
+	case "email":
+		permitted, excluded = cert.PermittedEmailAddresses, cert.ExcludedEmailAddresses
+		match = func(constraint string) bool { return matchEmailConstraint(value, constraint) }
+	case "URI":
+		permitted, excluded = cert.PermittedURIDomains, cert.ExcludedURIDomains
+		host := ""
+		if u, err := url.Parse(value); err == nil {
+			host = u.Hostname()
+		}
+		match = func(constraint string) bool { return host != "" && matchDomainConstraint(host, constraint, false) }
+	}
+
+	if i := slices.IndexFunc(excluded, match); i >= 0 {
+		return NameConstraintCheck{Result: ConstraintExcluded, Subtree: excluded[i]}
+	}
+	if len(permitted) == 0 {
+		return NameConstraintCheck{Result: ConstraintUnconstrained}
+	}
+	if i := slices.IndexFunc(permitted, match); i >= 0 {
+		return NameConstraintCheck{Result: ConstraintPermitted, Subtree: permitted[i]}
+	}
+	return NameConstraintCheck{Result: ConstraintOutside}
+}
+
+// matchDomainConstraint reports whether a host is inside a domain subtree.
+// A leading "." matches subdomains only; otherwise the domain itself matches,
+// and so do its subdomains when includeSubdomains is set (dNSName semantics).
+func matchDomainConstraint(host, constraint string, includeSubdomains bool) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	constraint = strings.ToLower(constraint)
+	switch {
+	case constraint == "":
+		return true
+	case strings.HasPrefix(constraint, "."):
+		return strings.HasSuffix(host, constraint)
+	case host == constraint:
+		return true
+	default:
+		return includeSubdomains && strings.HasSuffix(host, "."+constraint)
+	}
+}
+
+// matchEmailConstraint reports whether a mailbox is inside an rfc822Name subtree:
+// a full mailbox matches exactly, a host matches mailboxes at that host, and a
+// leading "." matches mailboxes at any subdomain.
+func matchEmailConstraint(mailbox, constraint string) bool {
+	if strings.Contains(constraint, "@") {
+		return strings.EqualFold(mailbox, constraint)
+	}
+	_, host, ok := strings.Cut(mailbox, "@")
+	return ok && matchDomainConstraint(host, constraint, false)
+}
+
+// policyNode is a node of the RFC 5280 valid_policy_tree.
+type policyNode struct {
+	// validPolicy: Policy OID valid at the node's depth
+	validPolicy string
+	// expected: Policies expected in the next certificate
+	expected []string
+	// parent: Node at the previous depth (nil for the root)
+	parent *policyNode
+	// children: Number of live child nodes
+	children int
+}
+
+// policyTree holds the valid_policy_tree as one slice of nodes per depth.
+type policyTree [][]*policyNode
+
+// add creates a child node at depth.
+func (t policyTree) add(depth int, parent *policyNode, policy string, expected []string) {
+	t[depth] = append(t[depth], &policyNode{validPolicy: policy, expected: expected, parent: parent})
+	parent.children++
+}
+
+// remove deletes the nodes at depth for which drop returns true.
+func (t policyTree) remove(depth int, drop func(n *policyNode) bool) {
+	t[depth] = slices.DeleteFunc(t[depth], func(n *policyNode) bool {
+		if !drop(n) {
+			return false
+		}
+		if n.parent != nil {
+			n.parent.children--
+		}
+		return true
+	})
+}
+
+// prune deletes childless nodes above depth, walking towards the root.
+func (t policyTree) prune(depth int) {
+	for d := depth - 1; d >= 0; d-- {
+		t.remove(d, func(n *policyNode) bool { return n.children == 0 })
+	}
+}
+
+// policies returns the valid policies at depth.
+func (t policyTree) policies(depth int) []string {
+	out := []string{}
+	if depth < len(t) {
+		for _, n := range t[depth] {
+			if !slices.Contains(out, n.validPolicy) {
+				out = append(out, n.validPolicy)
+			}
+		}
+	}
+	return out
+}
+
+// policySkipCount returns the value of an optional policy constraint.
+func policySkipCount(value int, zero bool) (int, bool) {
+	return value, value > 0 || zero
+}
+
+// evaluatePolicies runs RFC 5280 §6.1 certificate policy processing with an
+// initial policy set of anyPolicy and no initial explicit policy or inhibit flags.
+func (ch *Chain) evaluatePolicies() PolicyEvaluation {
+	end := len(ch.Certs)
+	if last := ch.Certs[end-1]; end > 1 && bytes.Equal(last.RawSubject, last.RawIssuer) {
+		end-- // the self-signed root is the trust anchor, not part of the path
+	}
+	n := end
+
+	eval := PolicyEvaluation{EffectivePolicies: []EffectivePolicy{}}
+	tree := policyTree{{&policyNode{validPolicy: anyPolicy, expected: []string{anyPolicy}}}}
+	explicitPolicy, inhibitAny, policyMapping := n+1, n+1, n+1
+
+	levels := make([]PolicyLevel, n)
+	for i := 1; i <= n; i++ {
+		index := n - i
+		cert := ch.Certs[index]
+		selfIssued := bytes.Equal(cert.RawSubject, cert.RawIssuer)
+		level := PolicyLevel{Index: index, Role: ch.GetCertificateRole(index), Subject: cert.Subject.CommonName}
+		for _, oid := range cert.Policies {
+			level.Policies = append(level.Policies, oid.String())
+		}
+		for _, m := range cert.PolicyMappings {
+			level.Mappings = append(level.Mappings, fmt.Sprintf("%s -> %s", m.IssuerDomainPolicy, m.SubjectDomainPolicy))
+		}
+		if v, ok := policySkipCount(cert.RequireExplicitPolicy, cert.RequireExplicitPolicyZero); ok {
+			level.RequireExplicitPolicy = &v
+		}
+		if v, ok := policySkipCount(cert.InhibitPolicyMapping, cert.InhibitPolicyMappingZero); ok {
+			level.InhibitPolicyMapping = &v
+		}
+		if v, ok := policySkipCount(cert.InhibitAnyPolicy, cert.InhibitAnyPolicyZero); ok {
+			level.InhibitAnyPolicy = &v
+		}
+
+		// Process the certificate policies (RFC 5280 §6.1.3 (d) and (e))
+		if tree != nil && len(level.Policies) > 0 {
+			tree = append(tree, nil)
+			parents := tree[i-1]
+			for _, policy := range level.Policies {
+				if policy == anyPolicy {
+					continue
+				}
+				matched := false
+				for _, parent := range parents {
+					if slices.Contains(parent.expected, policy) {
+						tree.add(i, parent, policy, []string{policy})
+						matched = true
+					}
+				}
+				if !matched {
+					for _, parent := range parents {
+						if parent.validPolicy == anyPolicy {
+							tree.add(i, parent, policy, []string{policy})
+						}
+					}
+				}
+			}
+			if slices.Contains(level.Policies, anyPolicy) && (inhibitAny > 0 || (i < n && selfIssued)) {
+				for _, parent := range parents {
+					for _, expected := range parent.expected {
+						exists := slices.ContainsFunc(tree[i], func(c *policyNode) bool {
+							return c.parent == parent && c.validPolicy == expected
+						})
+						if !exists {
+							tree.add(i, parent, expected, []string{expected})
+						}
+					}
+				}
+			} else if slices.Contains(level.Policies, anyPolicy) {
+				eval.Notes = append(eval.Notes, fmt.Sprintf("anyPolicy in %q is ignored because of inhibitAnyPolicy", cert.Subject.CommonName))
+			}
+			tree.prune(i)
+			if len(tree[0]) == 0 {
+				tree = nil
+				eval.Notes = append(eval.Notes, fmt.Sprintf("no policy of %q chains to a policy valid above it; the policy tree is null", cert.Subject.CommonName))
+			}
+		} else if tree != nil {
+			tree = nil
+			eval.Notes = append(eval.Notes, fmt.Sprintf("%q asserts no certificate policies; the policy tree is null", cert.Subject.CommonName))
+		}
+
+		if i < n {
+			// Prepare for the next certificate (RFC 5280 §6.1.4 (a), (b), (h) to (j))
+			if tree != nil {
+				tree.applyMappings(i, cert, policyMapping > 0)
+				if len(tree[0]) == 0 {
+					tree = nil
+					eval.Notes = append(eval.Notes, fmt.Sprintf("inhibitPolicyMapping removed every policy mapped by %q; the policy tree is null", cert.Subject.CommonName))
+				}
+			}
+			if !selfIssued {
+				explicitPolicy = max(explicitPolicy-1, 0)
+				policyMapping = max(policyMapping-1, 0)
+				inhibitAny = max(inhibitAny-1, 0)
+			}
+			if level.RequireExplicitPolicy != nil {
+				explicitPolicy = min(explicitPolicy, *level.RequireExplicitPolicy)
+			}
+			if level.InhibitPolicyMapping != nil {
+				policyMapping = min(policyMapping, *level.InhibitPolicyMapping)
+			}
+			if level.InhibitAnyPolicy != nil {
+				inhibitAny = min(inhibitAny, *level.InhibitAnyPolicy)
+			}
+		} else {
+			// Wrap-up procedure (RFC 5280 §6.1.5 (a) and (b))
+			explicitPolicy = max(explicitPolicy-1, 0)
+			if level.RequireExplicitPolicy != nil && *level.RequireExplicitPolicy == 0 {
+				explicitPolicy = 0
+			}
+		}
+
+		if tree != nil {
+			level.ValidPolicies = tree.policies(i)
+		} else {
+			level.ValidPolicies = []string{}
+		}
+		levels[n-i] = level
+	}
+
+	eval.Levels = levels
+	eval.ExplicitPolicyRequired = explicitPolicy == 0
+	eval.Valid = explicitPolicy > 0 || tree != nil
+	if !eval.Valid {
+		eval.Notes = append(eval.Notes, "an explicit policy is required but no policy is valid for the path")
+	}
+
+	if tree != nil && n < len(tree) {
+		for _, node := range tree[n] {
+			path := make([]string, n)
+			for d, p := n-1, node; p != nil && d >= 0; d, p = d-1, p.parent {
+				path[d] = p.validPolicy
+			}
+			eval.EffectivePolicies = append(eval.EffectivePolicies, EffectivePolicy{Policy: node.validPolicy, Path: path})
+		}
+	}
+
+	return eval
+}
+
+// applyMappings applies the policy mappings of the certificate at depth
+// (RFC 5280 §6.1.4 (b)). When mapping is inhibited, mapped policies are deleted.
+func (t policyTree) applyMappings(depth int, cert *x509.Certificate, mappingAllowed bool) {
+	mapped := make(map[string][]string)
+	var issuerPolicies []string
+	for _, m := range cert.PolicyMappings {
+		issuer, subject := m.IssuerDomainPolicy.String(), m.SubjectDomainPolicy.String()
+		if issuer == anyPolicy || subject == anyPolicy {
+			continue // mappings to or from anyPolicy are not permitted
+		}
+		if _, ok := mapped[issuer]; !ok {
+			issuerPolicies = append(issuerPolicies, issuer)
+		}
+		mapped[issuer] = append(mapped[issuer], subject)
+	}
+
+	for _, issuer := range issuerPolicies {
+		if !mappingAllowed {
+			t.remove(depth, func(n *policyNode) bool { return n.validPolicy == issuer })
+			t.prune(depth)
+			continue
+		}
+
+		found := false
+		for _, node := range t[depth] {
+			if node.validPolicy == issuer {
+				node.expected = mapped[issuer]
+				found = true
+			}
+		}
+		if !found {
+			if i := slices.IndexFunc(t[depth], func(n *policyNode) bool { return n.validPolicy == anyPolicy }); i >= 0 {
+				t.add(depth, t[depth][i].parent, issuer, mapped[issuer])
+			}
+		}
+	}
+}
+
+// policyName returns a display name for a policy OID.
+func policyName(oid string) string {
+	if oid == anyPolicy {
+		return "anyPolicy"
+	}
+	if name := x509certs.PolicyName(oid); name != "" {
+		return oid + " (" + name + ")"
+	}
+	return oid
+}
+
+// RenderText renders the report as a human-readable section of a verbose validation report.
+//
+// Returns:
+//   - string: Multi-line text report
+func (r *ConstraintsReport) RenderText() string {
+	var b strings.Builder
+
+	b.WriteString("Name Constraints:\n")
+	if len(r.NameConstraints) == 0 {
+		b.WriteString("  No CA in the chain carries name constraints.\n")
+	}
+	for _, level := range r.NameConstraints {
+		critical := ""
+		if level.Critical {
+			critical = ", critical"
+		}
+		fmt.Fprintf(&b, "  [%d] %s (%s%s)\n", level.Index, level.Subject, level.Role, critical)
+		writeSubtrees := func(label string, values []string) {
+			if len(values) > 0 {
+				fmt.Fprintf(&b, "      %-16s %s\n", label+":", strings.Join(values, ", "))
+			}
+		}
+		writeSubtrees("Permitted DNS", level.PermittedDNS)
+		writeSubtrees("Excluded DNS", level.ExcludedDNS)
+		writeSubtrees("Permitted IP", level.PermittedIP)
+		writeSubtrees("Excluded IP", level.ExcludedIP)
+		writeSubtrees("Permitted email", level.PermittedEmail)
+		writeSubtrees("Excluded email", level.ExcludedEmail)
+		writeSubtrees("Permitted URI", level.PermittedURI)
+		writeSubtrees("Excluded URI", level.ExcludedURI)
+	}
+
+	if len(r.NameConstraints) > 0 {
+		b.WriteString("\nLeaf Subject Alternative Names:\n")
+		for _, san := range r.SANs {
+			status := "allowed"
+			if !san.Allowed {
+				status = "NOT ALLOWED"
+			}
+			fmt.Fprintf(&b, "  %s:%s — %s\n", san.Type, san.Value, status)
+			for _, check := range san.Checks {
+				subtree := ""
+				if check.Subtree != "" {
+					subtree = fmt.Sprintf(" by %q", check.Subtree)
+				}
+				fmt.Fprintf(&b, "      [%d] %s: %s%s\n", check.Index, check.Subject, check.Result, subtree)
+			}
+		}
+	}
+
+	b.WriteString("\nCertificate Policies:\n")
+	for _, level := range r.Policy.Levels {
+		fmt.Fprintf(&b, "  [%d] %s\n", level.Index, level.Subject)
+		policies := make([]string, len(level.Policies))
+		for i, p := range level.Policies {
+			policies[i] = policyName(p)
+		}
+		if len(policies) == 0 {
+			policies = []string{"(none)"}
+		}
+		fmt.Fprintf(&b, "      Policies:       %s\n", strings.Join(policies, ", "))
+		if len(level.Mappings) > 0 {
+			fmt.Fprintf(&b, "      Mappings:       %s\n", strings.Join(level.Mappings, ", "))
+		}
+		if level.RequireExplicitPolicy != nil {
+			fmt.Fprintf(&b, "      requireExplicitPolicy: %d\n", *level.RequireExplicitPolicy)
+		}
+		if level.InhibitPolicyMapping != nil {
+			fmt.Fprintf(&b, "      inhibitPolicyMapping:  %d\n", *level.InhibitPolicyMapping)
+		}
+		if level.InhibitAnyPolicy != nil {
+			fmt.Fprintf(&b, "      inhibitAnyPolicy:      %d\n", *level.InhibitAnyPolicy)
+		}
+	}
+
+	effective := make([]string, len(r.Policy.EffectivePolicies))
+	for i, p := range r.Policy.EffectivePolicies {
+		effective[i] = policyName(p.Policy)
+		if p.Path[0] != p.Policy && p.Path[0] != anyPolicy {
+			effective[i] += fmt.Sprintf(" (mapped from %s)", policyName(p.Path[0]))
+		}
+	}
+	if len(effective) == 0 {
+		effective = []string{"(none)"}
+	}
+	fmt.Fprintf(&b, "  Effective Policy Set:     %s\n", strings.Join(effective, ", "))
+	fmt.Fprintf(&b, "  Explicit Policy Required: %t\n", r.Policy.ExplicitPolicyRequired)
+	for _, note := range r.Policy.Notes {
+		fmt.Fprintf(&b, "  Note: %s\n", note)
+	}
+
+	nameResult, policyResult := "SATISFIED", "VALID"
+	if !r.NameConstraintsSatisfied {
+		nameResult = "VIOLATED"
+	}
+	if !r.Policy.Valid {
+		policyResult = "INVALID"
+	}
+	fmt.Fprintf(&b, "\nName Constraints: %s\nPolicy Processing: %s\n", nameResult, policyResult)
+
+	return b.String()
+}
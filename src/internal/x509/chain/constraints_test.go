@@ -0,0 +1,254 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// constrainedIssuer is a CA certificate with its key, used to issue test certificates.
+type constrainedIssuer struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issueConstrained creates a certificate from tmpl, signed by issuer or self-signed when issuer is nil.
+func issueConstrained(t *testing.T, tmpl *x509.Certificate, issuer *constrainedIssuer) *constrainedIssuer {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl.SerialNumber = big.NewInt(time.Now().UnixNano())
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(24 * time.Hour)
+	if tmpl.IsCA {
+		tmpl.BasicConstraintsValid = true
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	}
+
+	parent, parentKey := tmpl, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &constrainedIssuer{cert: cert, key: key}
+}
+
+// mustOIDs parses dotted policy OIDs.
+func mustOIDs(t *testing.T, oids ...string) []x509.OID {
+	t.Helper()
+	out := make([]x509.OID, len(oids))
+	for i, s := range oids {
+		oid, err := x509.ParseOID(s)
+		require.NoError(t, err)
+		out[i] = oid
+	}
+	return out
+}
+
+// policyExtensions encodes the extensions crypto/x509 parses but does not marshal:
+// a policy mapping, requireExplicitPolicy of zero, and inhibitAnyPolicy of zero.
+func policyExtensions(t *testing.T, mapping [2]string, requireExplicitZero, inhibitAnyZero bool) []pkix.Extension {
+	t.Helper()
+
+	var exts []pkix.Extension
+	if mapping != [2]string{} {
+		oids := mustOIDs(t, mapping[0], mapping[1])
+		issuer, _ := oids[0].MarshalBinary()
+		subject, _ := oids[1].MarshalBinary()
+		value, err := asn1.Marshal([]struct{ Issuer, Subject asn1.RawValue }{{
+			Issuer:  asn1.RawValue{Tag: asn1.TagOID, Bytes: issuer},
+			Subject: asn1.RawValue{Tag: asn1.TagOID, Bytes: subject},
+		}})
+		require.NoError(t, err)
+		exts = append(exts, pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 33}, Value: value})
+	}
+	if requireExplicitZero {
+		value, err := asn1.Marshal(struct{ Require asn1.RawValue }{asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, Bytes: []byte{0}}})
+		require.NoError(t, err)
+		exts = append(exts, pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 36}, Value: value})
+	}
+	if inhibitAnyZero {
+		value, err := asn1.Marshal(0)
+		require.NoError(t, err)
+		exts = append(exts, pkix.Extension{Id: asn1.ObjectIdentifier{2, 5, 29, 54}, Value: value})
+	}
+	return exts
+}
+
+// chainOf builds a Chain from certificates ordered leaf first.
+func chainOf(certs ...*constrainedIssuer) *Chain {
+	ch := New(certs[0].cert, "1.0.0")
+	ch.Certs = nil
+	for _, c := range certs {
+		ch.Certs = append(ch.Certs, c.cert)
+	}
+	return ch
+}
+
+func TestChain_EvaluateConstraints_NameConstraints(t *testing.T) {
+	_, private, err := net.ParseCIDR("10.0.0.0/8")
+	require.NoError(t, err)
+
+	root := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Private Root"}, IsCA: true}, nil)
+	intermediate := issueConstrained(t, &x509.Certificate{
+		Subject:                     pkix.Name{CommonName: "Constrained Intermediate"},
+		IsCA:                        true,
+		PermittedDNSDomainsCritical: true,
+		PermittedDNSDomains:         []string{"example.com"},
+		ExcludedDNSDomains:          []string{"secret.example.com"},
+		PermittedIPRanges:           []*net.IPNet{private},
+	}, root)
+	leaf := issueConstrained(t, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "www.example.com"},
+		DNSNames:       []string{"www.example.com", "api.secret.example.com", "www.example.org"},
+		IPAddresses:    []net.IP{net.ParseIP("10.1.2.3"), net.ParseIP("192.168.1.1")},
+		EmailAddresses: []string{"ops@example.net"},
+	}, intermediate)
+
+	report := chainOf(leaf, intermediate, root).EvaluateConstraints()
+
+	require.Len(t, report.NameConstraints, 1)
+	level := report.NameConstraints[0]
+	assert.Equal(t, 1, level.Index)
+	assert.Equal(t, "Constrained Intermediate", level.Subject)
+	assert.True(t, level.Critical)
+	assert.Equal(t, []string{"example.com"}, level.PermittedDNS)
+	assert.Equal(t, []string{"secret.example.com"}, level.ExcludedDNS)
+	assert.Equal(t, []string{"10.0.0.0/8"}, level.PermittedIP)
+
+	want := map[string]string{
+		"www.example.com":        ConstraintPermitted,
+		"api.secret.example.com": ConstraintExcluded,
+		"www.example.org":        ConstraintOutside,
+		"10.1.2.3":               ConstraintPermitted,
+		"192.168.1.1":            ConstraintOutside,
+		"ops@example.net":        ConstraintUnconstrained,
+	}
+	require.Len(t, report.SANs, len(want))
+	for _, san := range report.SANs {
+		require.Len(t, san.Checks, 1, san.Value)
+		assert.Equal(t, want[san.Value], san.Checks[0].Result, san.Value)
+		assert.Equal(t, san.Checks[0].Result == ConstraintPermitted || san.Checks[0].Result == ConstraintUnconstrained, san.Allowed, san.Value)
+	}
+	assert.False(t, report.NameConstraintsSatisfied)
+
+	text := report.RenderText()
+	assert.Contains(t, text, "Permitted DNS:   example.com")
+	assert.Contains(t, text, `DNS:api.secret.example.com — NOT ALLOWED`)
+	assert.Contains(t, text, `[1] Constrained Intermediate: excluded by "secret.example.com"`)
+	assert.Contains(t, text, "Name Constraints: VIOLATED")
+}
+
+func TestMatchConstraints(t *testing.T) {
+	tests := []struct {
+		name  string
+		match bool
+		got   bool
+	}{
+		{name: "DNS Exact", match: true, got: matchDomainConstraint("example.com", "example.com", true)},
+		{name: "DNS Subdomain", match: true, got: matchDomainConstraint("a.b.example.com", "example.com", true)},
+		{name: "DNS Label Boundary", match: false, got: matchDomainConstraint("badexample.com", "example.com", true)},
+		{name: "DNS Leading Dot Excludes Domain", match: false, got: matchDomainConstraint("example.com", ".example.com", true)},
+		{name: "URI Host Exact Only", match: false, got: matchDomainConstraint("www.example.com", "example.com", false)},
+		{name: "Email Mailbox", match: true, got: matchEmailConstraint("Ops@example.com", "ops@example.com")},
+		{name: "Email Host", match: true, got: matchEmailConstraint("ops@example.com", "example.com")},
+		{name: "Email Host Excludes Subdomain", match: false, got: matchEmailConstraint("ops@mail.example.com", "example.com")},
+		{name: "Email Subdomain", match: true, got: matchEmailConstraint("ops@mail.example.com", ".example.com")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.match, tt.got)
+		})
+	}
+}
+
+func TestChain_EvaluateConstraints_Policies(t *testing.T) {
+	const (
+		rootPolicy = "1.3.6.1.4.1.55555.1"
+		leafPolicy = "1.3.6.1.4.1.55555.2"
+	)
+	root := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Policy Root"}, IsCA: true}, nil)
+
+	t.Run("Policy Mapping", func(t *testing.T) {
+		intermediate := issueConstrained(t, &x509.Certificate{
+			Subject:         pkix.Name{CommonName: "Mapping Intermediate"},
+			IsCA:            true,
+			Policies:        mustOIDs(t, rootPolicy),
+			ExtraExtensions: policyExtensions(t, [2]string{rootPolicy, leafPolicy}, false, false),
+		}, root)
+		leaf := issueConstrained(t, &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "mapped.example.com"},
+			Policies: mustOIDs(t, leafPolicy),
+		}, intermediate)
+
+		policy := chainOf(leaf, intermediate, root).EvaluateConstraints().Policy
+		assert.True(t, policy.Valid)
+		assert.False(t, policy.ExplicitPolicyRequired)
+		require.Len(t, policy.Levels, 2, "the self-signed root is the trust anchor")
+		assert.Equal(t, 0, policy.Levels[0].Index)
+		assert.Equal(t, []string{rootPolicy + " -> " + leafPolicy}, policy.Levels[1].Mappings)
+		assert.Equal(t, []EffectivePolicy{{Policy: leafPolicy, Path: []string{rootPolicy, leafPolicy}}}, policy.EffectivePolicies)
+
+		text := chainOf(leaf, intermediate, root).EvaluateConstraints().RenderText()
+		assert.Contains(t, text, "Effective Policy Set:     "+leafPolicy+" (mapped from "+rootPolicy+")")
+		assert.Contains(t, text, "No CA in the chain carries name constraints.")
+	})
+
+	t.Run("Require Explicit Policy", func(t *testing.T) {
+		intermediate := issueConstrained(t, &x509.Certificate{
+			Subject:         pkix.Name{CommonName: "Explicit Intermediate"},
+			IsCA:            true,
+			Policies:        mustOIDs(t, rootPolicy),
+			ExtraExtensions: policyExtensions(t, [2]string{}, true, false),
+		}, root)
+		leaf := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "nopolicy.example.com"}}, intermediate)
+
+		policy := chainOf(leaf, intermediate, root).EvaluateConstraints().Policy
+		assert.False(t, policy.Valid)
+		assert.True(t, policy.ExplicitPolicyRequired)
+		assert.Empty(t, policy.EffectivePolicies)
+		require.NotNil(t, policy.Levels[1].RequireExplicitPolicy)
+		assert.Equal(t, 0, *policy.Levels[1].RequireExplicitPolicy)
+		assert.Contains(t, policy.Notes, `"nopolicy.example.com" asserts no certificate policies; the policy tree is null`)
+	})
+
+	t.Run("Inhibit Any Policy", func(t *testing.T) {
+		intermediate := issueConstrained(t, &x509.Certificate{
+			Subject:         pkix.Name{CommonName: "Inhibit Intermediate"},
+			IsCA:            true,
+			Policies:        mustOIDs(t, anyPolicy),
+			ExtraExtensions: policyExtensions(t, [2]string{}, false, true),
+		}, root)
+		leaf := issueConstrained(t, &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "any.example.com"},
+			Policies: mustOIDs(t, anyPolicy, leafPolicy),
+		}, intermediate)
+
+		policy := chainOf(leaf, intermediate, root).EvaluateConstraints().Policy
+		assert.True(t, policy.Valid, "no explicit policy is required")
+		assert.Equal(t, []string{anyPolicy}, policy.Levels[1].ValidPolicies)
+		assert.Equal(t, []string{leafPolicy}, policy.Levels[0].ValidPolicies, "the leaf's anyPolicy is ignored")
+		assert.Contains(t, policy.Notes, `anyPolicy in "any.example.com" is ignored because of inhibitAnyPolicy`)
+	})
+}
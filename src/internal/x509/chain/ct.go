@@ -0,0 +1,459 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// SCT sources reported in [SCTResult.Source].
+const (
+	// SCTSourceEmbedded marks an SCT from the leaf's SCT list extension.
+	SCTSourceEmbedded = "embedded"
+	// SCTSourceTLS marks an SCT delivered in the signed_certificate_timestamp TLS extension.
+	SCTSourceTLS = "tls"
+)
+
+// CT log states reported in [CTLog.State], in lifecycle order.
+const (
+	// CTLogPending marks a log that has applied for inclusion.
+	CTLogPending = "pending"
+	// CTLogQualified marks a log accepted into the list but not yet usable.
+	CTLogQualified = "qualified"
+	// CTLogUsable marks a log in normal operation.
+	CTLogUsable = "usable"
+	// CTLogReadOnly marks a log that no longer accepts new entries.
+	CTLogReadOnly = "readonly"
+	// CTLogRetired marks a log whose SCTs only count if issued before retirement.
+	CTLogRetired = "retired"
+	// CTLogRejected marks a log that was never accepted.
+	CTLogRejected = "rejected"
+)
+
+// CT policies reported in [CTPolicyResult.Policy].
+const (
+	// CTPolicyChrome is the [Chrome CT policy].
+	//
+	// [Chrome CT policy]: https://googlechrome.github.io/CertificateTransparency/ct_policy.html
+	CTPolicyChrome = "Chrome"
+	// CTPolicyApple is the [Apple CT policy].
+	//
+	// [Apple CT policy]: https://support.apple.com/en-us/103214
+	CTPolicyApple = "Apple"
+)
+
+var (
+	// ErrInvalidCTLogList indicates a CT log list that cannot be parsed.
+	ErrInvalidCTLogList = errors.New("x509chain: invalid CT log list")
+)
+
+// CTLog is a Certificate Transparency log from a log list.
+type CTLog struct {
+	// Description: Human-readable log name (e.g. "Google 'Argon2026h1' log")
+	Description string `json:"description"`
+	// Operator: Name of the organisation operating the log
+	Operator string `json:"operator"`
+	// LogID: SHA-256 hash of the log's public key (base64 in JSON)
+	LogID []byte `json:"logId"`
+	// Key: DER-encoded SubjectPublicKeyInfo of the log (base64 in JSON)
+	Key []byte `json:"key"`
+	// URL: Submission URL of the log
+	URL string `json:"url,omitempty"`
+	// State: Current log state (pending, qualified, usable, readonly, retired, or rejected)
+	State string `json:"state,omitempty"`
+	// StateTimestamp: Time the log entered its current state
+	StateTimestamp time.Time `json:"stateTimestamp,omitzero"`
+}
+
+// CTLogList is a set of known CT logs.
+type CTLogList struct {
+	// Version: Version string of the published list
+	Version string `json:"version,omitempty"`
+	// Timestamp: Time the list was published
+	Timestamp time.Time `json:"timestamp,omitzero"`
+	// Logs: Every log in the list
+	Logs []CTLog `json:"logs"`
+}
+
+// ctLogListSchema is the published log list format (v3), shared by the
+// Chrome and Apple lists.
+type ctLogListSchema struct {
+	Version   string    `json:"version"`
+	Timestamp time.Time `json:"log_list_timestamp"`
+	Operators []struct {
+		Name      string           `json:"name"`
+		Logs      []ctLogListEntry `json:"logs"`
+		TiledLogs []ctLogListEntry `json:"tiled_logs"`
+	} `json:"operators"`
+}
+
+// ctLogListEntry is a log entry of the published log list.
+type ctLogListEntry struct {
+	Description   string `json:"description"`
+	LogID         []byte `json:"log_id"`
+	Key           []byte `json:"key"`
+	URL           string `json:"url"`
+	SubmissionURL string `json:"submission_url"`
+	State         map[string]struct {
+		Timestamp time.Time `json:"timestamp"`
+	} `json:"state"`
+}
+
+// ParseCTLogList parses a log list in the published v3 JSON format, such as
+// https://www.gstatic.com/ct/log_list/v3/log_list.json.
+//
+// Both RFC 6962 logs and static CT API (tiled) logs are included. A log ID
+// that does not match the SHA-256 hash of the log key is rejected.
+//
+// Parameters:
+//   - data: JSON log list
+//
+// Returns:
+//   - *CTLogList: Parsed log list
+//   - error: ErrInvalidCTLogList if the JSON or a log entry is malformed
+func ParseCTLogList(data []byte) (*CTLogList, error) {
+	var schema ctLogListSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCTLogList, err)
+	}
+
+	list := &CTLogList{Version: schema.Version, Timestamp: schema.Timestamp, Logs: []CTLog{}}
+	for _, operator := range schema.Operators {
+		for _, entry := range slices.Concat(operator.Logs, operator.TiledLogs) {
+			keyHash := sha256.Sum256(entry.Key)
+			if len(entry.Key) == 0 || !bytes.Equal(keyHash[:], entry.LogID) {
+				return nil, fmt.Errorf("%w: log %q has a log ID that does not match its key", ErrInvalidCTLogList, entry.Description)
+			}
+
+			log := CTLog{
+				Description: entry.Description,
+				Operator:    operator.Name,
+				LogID:       entry.LogID,
+				Key:         entry.Key,
+				URL:         entry.URL,
+			}
+			if log.URL == "" {
+				log.URL = entry.SubmissionURL
+			}
+			for state, details := range entry.State {
+				log.State, log.StateTimestamp = state, details.Timestamp
+			}
+			list.Logs = append(list.Logs, log)
+		}
+	}
+	return list, nil
+}
+
+// LoadCTLogList reads and parses a log list file.
+//
+// Parameters:
+//   - path: Path to a JSON log list in the published v3 format
+//
+// Returns:
+//   - *CTLogList: Parsed log list
+//   - error: Read error or ErrInvalidCTLogList
+func LoadCTLogList(path string) (*CTLogList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CT log list: %w", err)
+	}
+	return ParseCTLogList(data)
+}
+
+// Lookup returns the log with the given log ID.
+//
+// Parameters:
+//   - logID: SHA-256 hash of the log's public key
+//
+// Returns:
+//   - *CTLog: Matching log, or nil if the log is not in the list
+func (l *CTLogList) Lookup(logID []byte) *CTLog {
+	for i := range l.Logs {
+		if bytes.Equal(l.Logs[i].LogID, logID) {
+			return &l.Logs[i]
+		}
+	}
+	return nil
+}
+
+// SCTResult is the verification result of one SCT.
+type SCTResult struct {
+	// Source: Where the SCT came from (embedded or tls)
+	Source string `json:"source"`
+	// LogID: Base64 log ID from the SCT
+	LogID string `json:"logId"`
+	// Log: Description of the log, empty when the log is unknown
+	Log string `json:"log,omitempty"`
+	// Operator: Operator of the log, empty when the log is unknown
+	Operator string `json:"operator,omitempty"`
+	// LogState: State of the log in the log list
+	LogState string `json:"logState,omitempty"`
+	// Timestamp: Time the log issued the SCT
+	Timestamp time.Time `json:"timestamp"`
+	// Verified: True when the signature verifies against the log key
+	Verified bool `json:"verified"`
+	// Error: Why the SCT could not be verified
+	Error string `json:"error,omitempty"`
+}
+
+// CTPolicyResult is the outcome of a browser CT policy.
+type CTPolicyResult struct {
+	// Policy: Browser policy name (Chrome or Apple)
+	Policy string `json:"policy"`
+	// Compliant: True when the certificate satisfies the policy
+	Compliant bool `json:"compliant"`
+	// Detail: How the policy was satisfied or what is missing
+	Detail string `json:"detail"`
+}
+
+// CTReport is the Certificate Transparency evaluation of a chain's leaf.
+type CTReport struct {
+	// Subject: Leaf certificate subject
+	Subject string `json:"subject"`
+	// SCTs: Every embedded and TLS-delivered SCT
+	SCTs []SCTResult `json:"scts"`
+	// Operators: Distinct operators of logs with a verified SCT
+	Operators []string `json:"operators"`
+	// Policies: Browser CT policy outcomes
+	Policies []CTPolicyResult `json:"policies"`
+	// Compliant: True when every browser policy is satisfied
+	Compliant bool `json:"compliant"`
+	// Notes: Problems reading SCTs
+	Notes []string `json:"notes,omitempty"`
+}
+
+// EvaluateCT extracts the leaf's embedded SCTs and any SCTs delivered during
+// the TLS handshake, verifies each against the log list, and checks the
+// result against the Chrome and Apple CT policies.
+//
+// Embedded SCTs are verified against the precertificate, which requires the
+// leaf's issuer to be in the chain. Only verified SCTs from logs that were
+// acceptable when the SCT was issued count towards a policy.
+//
+// Parameters:
+//   - logs: Known CT logs, e.g. from [LoadCTLogList]
+//
+// Returns:
+//   - *CTReport: Per-SCT results and policy outcomes
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) EvaluateCT(logs *CTLogList) *CTReport {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	report := &CTReport{SCTs: []SCTResult{}, Operators: []string{}, Policies: []CTPolicyResult{}}
+	if len(ch.Certs) == 0 {
+		return report
+	}
+
+	leaf := ch.Certs[0]
+	report.Subject = leaf.Subject.String()
+
+	var issuer *x509.Certificate
+	for _, candidate := range ch.Certs[1:] {
+		if leaf.CheckSignatureFrom(candidate) == nil {
+			issuer = candidate
+			break
+		}
+	}
+
+	embedded, err := x509certs.EmbeddedSCTs(leaf)
+	if err != nil {
+		report.Notes = append(report.Notes, err.Error())
+	}
+	for _, sct := range embedded {
+		report.SCTs = append(report.SCTs, verifySCT(sct, SCTSourceEmbedded, logs, leaf, issuer))
+	}
+	for i, raw := range ch.SignedCertificateTimestamps {
+		sct, err := x509certs.ParseSCT(raw)
+		if err != nil {
+			report.Notes = append(report.Notes, fmt.Sprintf("TLS SCT %d: %v", i, err))
+			continue
+		}
+		report.SCTs = append(report.SCTs, verifySCT(sct, SCTSourceTLS, logs, leaf, issuer))
+	}
+
+	for _, result := range report.SCTs {
+		if result.Verified && !slices.Contains(report.Operators, result.Operator) {
+			report.Operators = append(report.Operators, result.Operator)
+		}
+	}
+
+	now := time.Now()
+	report.Compliant = true
+	for _, policy := range []string{CTPolicyChrome, CTPolicyApple} {
+		result := evaluateCTPolicy(policy, leaf, report.SCTs, logs, now)
+		report.Compliant = report.Compliant && result.Compliant
+		report.Policies = append(report.Policies, result)
+	}
+	return report
+}
+
+// verifySCT looks up the log of an SCT and verifies its signature.
+func verifySCT(sct x509certs.SignedCertificateTimestamp, source string, logs *CTLogList, leaf, issuer *x509.Certificate) SCTResult {
+	result := SCTResult{
+		Source:    source,
+		LogID:     base64.StdEncoding.EncodeToString(sct.LogID),
+		Timestamp: sct.Timestamp,
+	}
+
+	log := logs.Lookup(sct.LogID)
+	if log == nil {
+		result.Error = "unknown log"
+		return result
+	}
+	result.Log, result.Operator, result.LogState = log.Description, log.Operator, log.State
+
+	key, err := x509.ParsePKIXPublicKey(log.Key)
+	if err != nil {
+		result.Error = fmt.Sprintf("invalid log key: %v", err)
+		return result
+	}
+	if err := x509certs.VerifySCT(sct, key, leaf, issuer, source == SCTSourceEmbedded); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+
+	result.Verified = true
+	return result
+}
+
+// evaluateCTPolicy checks the verified SCTs against a browser CT policy.
+//
+// Embedded SCTs must come from 2 distinct logs for leaves valid up to 180
+// days and from 3 otherwise; SCTs delivered over TLS need 2 distinct logs.
+// In both cases the logs must belong to at least 2 operators. Logs count when
+// qualified, usable, or read-only, or when retired after the SCT was issued;
+// Chrome additionally requires one embedded SCT from a log that has not retired.
+func evaluateCTPolicy(policy string, leaf *x509.Certificate, scts []SCTResult, logs *CTLogList, now time.Time) CTPolicyResult {
+	lifetimeDays := leaf.NotAfter.Sub(leaf.NotBefore).Hours() / 24
+	required := 3
+	// Chrome and Apple share the 180-day boundary, inclusive
+	if lifetimeDays <= 180 {
+		required = 2
+	}
+
+	embeddedLogs, embeddedOperators, embeddedCurrent := qualifyingSCTs(scts, SCTSourceEmbedded, logs)
+	tlsLogs, tlsOperators, _ := qualifyingSCTs(scts, SCTSourceTLS, logs)
+
+	embeddedOK := embeddedLogs >= required && embeddedOperators >= 2
+	if policy == CTPolicyChrome {
+		embeddedOK = embeddedOK && embeddedCurrent
+	}
+	tlsOK := tlsLogs >= 2 && tlsOperators >= 2
+
+	result := CTPolicyResult{Policy: policy, Compliant: embeddedOK || tlsOK}
+	switch {
+	case embeddedOK:
+		result.Detail = fmt.Sprintf("embedded SCTs from %d logs and %d operators (%d logs required)", embeddedLogs, embeddedOperators, required)
+	case tlsOK:
+		result.Detail = fmt.Sprintf("TLS SCTs from %d logs and %d operators (2 logs required)", tlsLogs, tlsOperators)
+	default:
+		result.Detail = fmt.Sprintf("embedded SCTs from %d logs and %d operators (%d logs from 2 operators required); TLS SCTs from %d logs and %d operators (2 logs from 2 operators required)",
+			embeddedLogs, embeddedOperators, required, tlsLogs, tlsOperators)
+	}
+	return result
+}
+
+// qualifyingSCTs counts the distinct logs and operators of verified SCTs from
+// source whose logs were acceptable when the SCT was issued, and reports
+// whether at least one of those logs is still current.
+func qualifyingSCTs(scts []SCTResult, source string, logs *CTLogList) (logCount, operatorCount int, current bool) {
+	var seenLogs, seenOperators []string
+	for _, sct := range scts {
+		if sct.Source != source || !sct.Verified {
+			continue
+		}
+		logID, _ := base64.StdEncoding.DecodeString(sct.LogID)
+		log := logs.Lookup(logID)
+		if log == nil {
+			continue
+		}
+
+		switch log.State {
+		case CTLogQualified, CTLogUsable, CTLogReadOnly:
+			current = true
+		case CTLogRetired:
+			if !sct.Timestamp.Before(log.StateTimestamp) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		if !slices.Contains(seenLogs, sct.LogID) {
+			seenLogs = append(seenLogs, sct.LogID)
+		}
+		if !slices.Contains(seenOperators, log.Operator) {
+			seenOperators = append(seenOperators, log.Operator)
+		}
+	}
+	return len(seenLogs), len(seenOperators), current
+}
+
+// RenderText renders the report in a human-readable form.
+//
+// Returns:
+//   - string: Multi-line text report
+func (r *CTReport) RenderText() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Subject: %s\n\n", r.Subject)
+
+	b.WriteString("Signed Certificate Timestamps:\n")
+	if len(r.SCTs) == 0 {
+		b.WriteString("  None\n")
+	}
+	for _, sct := range r.SCTs {
+		log := sct.LogID
+		if sct.Log != "" {
+			log = fmt.Sprintf("%s (%s, %s)", sct.Log, sct.Operator, sct.LogState)
+		}
+		status := "verified"
+		if !sct.Verified {
+			status = "NOT VERIFIED: " + sct.Error
+		}
+		fmt.Fprintf(&b, "  [%s] %s\n", sct.Source, log)
+		fmt.Fprintf(&b, "      %s — %s\n", sct.Timestamp.Format("2006-01-02 15:04:05 MST"), status)
+	}
+	for _, note := range r.Notes {
+		fmt.Fprintf(&b, "  Note: %s\n", note)
+	}
+
+	operators := "None"
+	if len(r.Operators) > 0 {
+		operators = strings.Join(r.Operators, ", ")
+	}
+	fmt.Fprintf(&b, "\nLog Operators: %s\n\n", operators)
+
+	b.WriteString("CT Policies:\n")
+	for _, p := range r.Policies {
+		status := "PASS"
+		if !p.Compliant {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "  [%s] %s: %s\n", status, p.Policy, p.Detail)
+	}
+
+	if r.Compliant {
+		b.WriteString("\nResult: COMPLIANT\n")
+	} else {
+		b.WriteString("\nResult: NOT COMPLIANT\n")
+	}
+	return b.String()
+}
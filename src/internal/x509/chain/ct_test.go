@@ -0,0 +1,354 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// testCTLog is a locally generated CT log.
+type testCTLog struct {
+	description string
+	operator    string
+	state       string
+	stateTime   time.Time
+	key         *ecdsa.PrivateKey
+}
+
+// newTestCTLog generates a log key for a log in the given state.
+func newTestCTLog(t *testing.T, description, operator, state string, stateTime time.Time) *testCTLog {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &testCTLog{description: description, operator: operator, state: state, stateTime: stateTime, key: key}
+}
+
+// spki returns the DER SubjectPublicKeyInfo of the log key.
+func (l *testCTLog) spki(t *testing.T) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&l.key.PublicKey)
+	require.NoError(t, err)
+	return der
+}
+
+// sign issues a TLS-encoded SCT at timestamp over the given log entry.
+func (l *testCTLog) sign(t *testing.T, timestamp time.Time, entry func(b *cryptobyte.Builder)) []byte {
+	t.Helper()
+
+	var input cryptobyte.Builder
+	input.AddUint8(0) // v1
+	input.AddUint8(0) // certificate_timestamp
+	input.AddUint64(uint64(timestamp.UnixMilli()))
+	entry(&input)
+	input.AddUint16(0) // no extensions
+	digest := sha256.Sum256(input.BytesOrPanic())
+	signature, err := ecdsa.SignASN1(rand.Reader, l.key, digest[:])
+	require.NoError(t, err)
+
+	logID := sha256.Sum256(l.spki(t))
+	var sct cryptobyte.Builder
+	sct.AddUint8(0)
+	sct.AddBytes(logID[:])
+	sct.AddUint64(uint64(timestamp.UnixMilli()))
+	sct.AddUint16(0)
+	sct.AddUint8(4) // SHA256
+	sct.AddUint8(3) // ECDSA
+	sct.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(signature) })
+	return sct.BytesOrPanic()
+}
+
+// testCTLogList builds a log list from test logs through the published JSON format.
+func testCTLogList(t *testing.T, logs ...*testCTLog) *CTLogList {
+	t.Helper()
+
+	operators := map[string][]map[string]any{}
+	var order []string
+	for _, l := range logs {
+		logID := sha256.Sum256(l.spki(t))
+		if _, ok := operators[l.operator]; !ok {
+			order = append(order, l.operator)
+		}
+		operators[l.operator] = append(operators[l.operator], map[string]any{
+			"description": l.description,
+			"log_id":      logID[:],
+			"key":         l.spki(t),
+			"url":         "https://ct.example/" + l.description + "/",
+			"state":       map[string]any{l.state: map[string]any{"timestamp": l.stateTime}},
+		})
+	}
+	var schema []map[string]any
+	for _, name := range order {
+		schema = append(schema, map[string]any{"name": name, "logs": operators[name]})
+	}
+
+	data, err := json.Marshal(map[string]any{"version": "test", "operators": schema})
+	require.NoError(t, err)
+	list, err := ParseCTLogList(data)
+	require.NoError(t, err)
+	return list
+}
+
+// ctTestPKI is a CA and a leaf template used to issue certificates with SCTs.
+type ctTestPKI struct {
+	ca      *x509.Certificate
+	caKey   *ecdsa.PrivateKey
+	leafKey *ecdsa.PrivateKey
+	tmpl    *x509.Certificate
+}
+
+// newCTTestPKI creates a CA and a leaf template valid for the given number of days.
+func newCTTestPKI(t *testing.T, validityDays int) *ctTestPKI {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "CT Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	notBefore := time.Now().Add(-time.Hour).Truncate(time.Second)
+	return &ctTestPKI{
+		ca:      ca,
+		caKey:   caKey,
+		leafKey: leafKey,
+		tmpl: &x509.Certificate{
+			SerialNumber: big.NewInt(2),
+			Subject:      pkix.Name{CommonName: "ct.example.com"},
+			DNSNames:     []string{"ct.example.com"},
+			NotBefore:    notBefore,
+			NotAfter:     notBefore.Add(time.Duration(validityDays) * 24 * time.Hour),
+		},
+	}
+}
+
+// issue signs the leaf template with the given extra extensions.
+func (p *ctTestPKI) issue(t *testing.T, extensions ...pkix.Extension) *x509.Certificate {
+	t.Helper()
+	p.tmpl.ExtraExtensions = extensions
+	der, err := x509.CreateCertificate(rand.Reader, p.tmpl, p.ca, &p.leafKey.PublicKey, p.caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// issueWithEmbeddedSCTs issues a leaf whose SCT list holds one precertificate SCT per log.
+func (p *ctTestPKI) issueWithEmbeddedSCTs(t *testing.T, logs ...*testCTLog) *x509.Certificate {
+	t.Helper()
+
+	tbs := p.issue(t).RawTBSCertificate
+	issuerKeyHash := sha256.Sum256(p.ca.RawSubjectPublicKeyInfo)
+
+	var list cryptobyte.Builder
+	list.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, l := range logs {
+			sct := l.sign(t, time.Now().Add(-time.Minute), func(b *cryptobyte.Builder) {
+				b.AddUint16(1) // precert_entry
+				b.AddBytes(issuerKeyHash[:])
+				b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(tbs) })
+			})
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(sct) })
+		}
+	})
+	value, err := asn1.Marshal(list.BytesOrPanic())
+	require.NoError(t, err)
+
+	return p.issue(t, pkix.Extension{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}, Value: value})
+}
+
+// tlsSCT issues an SCT over the final leaf, as delivered in the TLS extension.
+func tlsSCT(t *testing.T, l *testCTLog, leaf *x509.Certificate) []byte {
+	t.Helper()
+	return l.sign(t, time.Now().Add(-time.Minute), func(b *cryptobyte.Builder) {
+		b.AddUint16(0) // x509_entry
+		b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) { b.AddBytes(leaf.Raw) })
+	})
+}
+
+// ctChain builds a chain of leaf and its issuer.
+func ctChain(leaf, issuer *x509.Certificate) *Chain {
+	ch := New(leaf, "1.0.0")
+	if issuer != nil {
+		ch.Certs = append(ch.Certs, issuer)
+	}
+	return ch
+}
+
+func TestChain_EvaluateCT(t *testing.T) {
+	since := time.Now().Add(-365 * 24 * time.Hour)
+	argon := newTestCTLog(t, "Argon", "Google", CTLogUsable, since)
+	xenon := newTestCTLog(t, "Xenon", "Google", CTLogUsable, since)
+	nimbus := newTestCTLog(t, "Nimbus", "Cloudflare", CTLogUsable, since)
+	sabre := newTestCTLog(t, "Sabre", "Sectigo", CTLogUsable, since)
+	oldLog := newTestCTLog(t, "Retired", "Sectigo", CTLogRetired, time.Now().Add(-24*time.Hour))
+	logs := testCTLogList(t, argon, xenon, nimbus, sabre, oldLog)
+
+	t.Run("Embedded SCTs From Two Operators", func(t *testing.T) {
+		pki := newCTTestPKI(t, 90)
+		report := ctChain(pki.issueWithEmbeddedSCTs(t, argon, nimbus), pki.ca).EvaluateCT(logs)
+
+		require.Len(t, report.SCTs, 2)
+		for _, sct := range report.SCTs {
+			assert.True(t, sct.Verified, sct.Error)
+			assert.Equal(t, SCTSourceEmbedded, sct.Source)
+		}
+		assert.Equal(t, "Argon", report.SCTs[0].Log)
+		assert.Equal(t, []string{"Google", "Cloudflare"}, report.Operators)
+		assert.True(t, report.Compliant)
+		require.Len(t, report.Policies, 2)
+		assert.Equal(t, CTPolicyChrome, report.Policies[0].Policy)
+		assert.Equal(t, CTPolicyApple, report.Policies[1].Policy)
+
+		text := report.RenderText()
+		assert.Contains(t, text, "[embedded] Argon (Google, usable)")
+		assert.Contains(t, text, "[PASS] Chrome: embedded SCTs from 2 logs and 2 operators (2 logs required)")
+		assert.Contains(t, text, "Result: COMPLIANT")
+	})
+
+	t.Run("Single Operator", func(t *testing.T) {
+		pki := newCTTestPKI(t, 90)
+		report := ctChain(pki.issueWithEmbeddedSCTs(t, argon, xenon), pki.ca).EvaluateCT(logs)
+		assert.Equal(t, []string{"Google"}, report.Operators)
+		assert.False(t, report.Compliant)
+	})
+
+	t.Run("Long-Lived Leaf Needs Three Logs", func(t *testing.T) {
+		pki := newCTTestPKI(t, 365)
+		report := ctChain(pki.issueWithEmbeddedSCTs(t, argon, nimbus), pki.ca).EvaluateCT(logs)
+		assert.False(t, report.Compliant)
+		assert.Contains(t, report.Policies[0].Detail, "3 logs from 2 operators required")
+
+		report = ctChain(pki.issueWithEmbeddedSCTs(t, argon, nimbus, sabre), pki.ca).EvaluateCT(logs)
+		assert.True(t, report.Compliant)
+	})
+
+	t.Run("180-Day Leaf Needs Two Logs", func(t *testing.T) {
+		// The boundary is inclusive for both policies
+		for _, days := range []int{180, 181} {
+			pki := newCTTestPKI(t, days)
+			report := ctChain(pki.issueWithEmbeddedSCTs(t, argon, nimbus), pki.ca).EvaluateCT(logs)
+			require.Len(t, report.Policies, 2)
+			for _, policy := range report.Policies {
+				assert.Equal(t, days == 180, policy.Compliant, "%s, %d days", policy.Policy, days)
+			}
+		}
+	})
+
+	t.Run("Retired Log", func(t *testing.T) {
+		// The SCTs are issued after the log retired, so they do not count.
+		pki := newCTTestPKI(t, 90)
+		report := ctChain(pki.issueWithEmbeddedSCTs(t, argon, oldLog), pki.ca).EvaluateCT(logs)
+		assert.True(t, report.SCTs[1].Verified)
+		assert.False(t, report.Compliant)
+	})
+
+	t.Run("TLS SCTs", func(t *testing.T) {
+		pki := newCTTestPKI(t, 90)
+		leaf := pki.issue(t)
+		ch := ctChain(leaf, nil)
+		ch.SignedCertificateTimestamps = [][]byte{tlsSCT(t, argon, leaf), tlsSCT(t, sabre, leaf)}
+
+		report := ch.EvaluateCT(logs)
+		require.Len(t, report.SCTs, 2)
+		for _, sct := range report.SCTs {
+			assert.True(t, sct.Verified, sct.Error)
+			assert.Equal(t, SCTSourceTLS, sct.Source)
+		}
+		assert.True(t, report.Compliant)
+		assert.Contains(t, report.Policies[0].Detail, "TLS SCTs from 2 logs and 2 operators")
+	})
+
+	t.Run("Unknown Log And Bad Signature", func(t *testing.T) {
+		pki := newCTTestPKI(t, 90)
+		leaf := pki.issue(t)
+		other := pki.issue(t)
+		ch := ctChain(leaf, nil)
+		unknown := newTestCTLog(t, "Unknown", "Nobody", CTLogUsable, since)
+		ch.SignedCertificateTimestamps = [][]byte{
+			tlsSCT(t, unknown, leaf),
+			tlsSCT(t, argon, other), // signed over a different certificate
+			{0x00, 0x01},
+		}
+
+		report := ch.EvaluateCT(logs)
+		require.Len(t, report.SCTs, 2)
+		assert.Equal(t, "unknown log", report.SCTs[0].Error)
+		assert.False(t, report.SCTs[1].Verified)
+		assert.Contains(t, report.SCTs[1].Error, x509certs.ErrSCTSignature.Error())
+		require.Len(t, report.Notes, 1)
+		assert.Contains(t, report.Notes[0], x509certs.ErrInvalidSCT.Error())
+		assert.Empty(t, report.Operators)
+		assert.Contains(t, report.RenderText(), "Result: NOT COMPLIANT")
+	})
+
+	t.Run("Embedded SCTs Without Issuer", func(t *testing.T) {
+		pki := newCTTestPKI(t, 90)
+		report := ctChain(pki.issueWithEmbeddedSCTs(t, argon), nil).EvaluateCT(logs)
+		require.Len(t, report.SCTs, 1)
+		assert.Equal(t, x509certs.ErrSCTIssuerRequired.Error(), report.SCTs[0].Error)
+	})
+}
+
+func TestParseCTLogList(t *testing.T) {
+	argon := newTestCTLog(t, "Argon", "Google", CTLogUsable, time.Now())
+
+	t.Run("Lookup", func(t *testing.T) {
+		logs := testCTLogList(t, argon)
+		logID := sha256.Sum256(argon.spki(t))
+		log := logs.Lookup(logID[:])
+		require.NotNil(t, log)
+		assert.Equal(t, "Google", log.Operator)
+		assert.Equal(t, CTLogUsable, log.State)
+		assert.Equal(t, "https://ct.example/Argon/", log.URL)
+		assert.Nil(t, logs.Lookup(make([]byte, 32)))
+	})
+
+	t.Run("Mismatched Log ID", func(t *testing.T) {
+		_, err := ParseCTLogList([]byte(`{"operators": [{"name": "Google", "logs": [{"description": "Bad", "log_id": "AAAA", "key": "AAAA"}]}]}`))
+		assert.ErrorIs(t, err, ErrInvalidCTLogList)
+	})
+
+	t.Run("Load From File", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "log_list.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version": "1.2", "tiled_logs": [], "operators": []}`), 0644))
+		logs, err := LoadCTLogList(path)
+		require.NoError(t, err)
+		assert.Equal(t, "1.2", logs.Version)
+
+		_, err = LoadCTLogList(filepath.Join(t.TempDir(), "missing.json"))
+		assert.ErrorContains(t, err, "failed to read CT log list")
+	})
+
+}
@@ -0,0 +1,315 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"slices"
+	"strings"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+)
+
+// Certificate diff statuses reported in [CertificateDiff.Status].
+const (
+	// DiffUnchanged marks a certificate present in both chains without differences.
+	DiffUnchanged = "unchanged"
+	// DiffChanged marks aligned certificates with at least one differing field.
+	DiffChanged = "changed"
+	// DiffAdded marks a certificate only present in the new chain.
+	DiffAdded = "added"
+	// DiffRemoved marks a certificate only present in the old chain.
+	DiffRemoved = "removed"
+)
+
+// Extension OIDs compared as sets or summarised rather than as rendered text.
+const (
+	oidSubjectAltName = "2.5.29.17"
+	oidEmbeddedSCTs   = "1.3.6.1.4.1.11129.2.4.2"
+)
+
+// sanField is the field name of Subject Alternative Name changes.
+const sanField = "Subject Alternative Name"
+
+// FieldChange is a single difference between two aligned certificates.
+//
+// Old is empty for an added value and New is empty for a removed one.
+// Subject Alternative Names are reported one name per change.
+type FieldChange struct {
+	// Field: Field or extension name (e.g. "Issuer", "X509v3 Key Usage")
+	Field string `json:"field"`
+	// Old: Value in the old certificate
+	Old string `json:"old,omitempty"`
+	// New: Value in the new certificate
+	New string `json:"new,omitempty"`
+}
+
+// CertificateDiff compares one position of two chains.
+type CertificateDiff struct {
+	// Status: unchanged, changed, added, or removed
+	Status string `json:"status"`
+	// Role: Role of the certificate in its chain (leaf, intermediate, or root)
+	Role string `json:"role"`
+	// OldIndex: Position in the old chain, or -1 when added
+	OldIndex int `json:"oldIndex"`
+	// NewIndex: Position in the new chain, or -1 when removed
+	NewIndex int `json:"newIndex"`
+	// OldSubject: Subject in the old chain
+	OldSubject string `json:"oldSubject,omitempty"`
+	// NewSubject: Subject in the new chain
+	NewSubject string `json:"newSubject,omitempty"`
+	// Changes: Field differences of aligned certificates
+	Changes []FieldChange `json:"changes"`
+}
+
+// ChainDiff is the comparison of two resolved chains.
+type ChainDiff struct {
+	// OldLength: Number of certificates in the old chain
+	OldLength int `json:"oldLength"`
+	// NewLength: Number of certificates in the new chain
+	NewLength int `json:"newLength"`
+	// Certificates: Aligned comparisons, in old chain order followed by added certificates
+	Certificates []CertificateDiff `json:"certificates"`
+	// Identical: True when every certificate is unchanged
+	Identical bool `json:"identical"`
+}
+
+// DiffChains aligns two chains and reports what changed between them, such as
+// a new issuer, a different intermediate, added or removed SANs, a key
+// algorithm change, or a new validity period.
+//
+// The leaves are always aligned with each other. Other certificates are
+// aligned by identical encoding, then by subject, then by position; the rest
+// are reported as added or removed.
+//
+// Parameters:
+//   - oldChain: Chain before the change (e.g. the current deployment)
+//   - newChain: Chain after the change (e.g. the renewed certificate)
+//
+// Returns:
+//   - *ChainDiff: Per-certificate, per-field differences
+//
+// Thread Safety: Safe for concurrent use.
+func DiffChains(oldChain, newChain *Chain) *ChainDiff {
+	oldChain.mu.RLock()
+	oldCerts := slices.Clone(oldChain.Certs)
+	oldChain.mu.RUnlock()
+	newChain.mu.RLock()
+	newCerts := slices.Clone(newChain.Certs)
+	newChain.mu.RUnlock()
+
+	diff := &ChainDiff{
+		OldLength:    len(oldCerts),
+		NewLength:    len(newCerts),
+		Certificates: []CertificateDiff{},
+		Identical:    true,
+	}
+
+	certManager := x509certs.New()
+	pairs := alignCertificates(oldCerts, newCerts)
+	for _, pair := range pairs {
+		d := CertificateDiff{OldIndex: pair[0], NewIndex: pair[1], Changes: []FieldChange{}}
+		var oldCert, newCert *x509.Certificate
+		if pair[0] >= 0 {
+			oldCert = oldCerts[pair[0]]
+			d.OldSubject = oldCert.Subject.String()
+			d.Role = oldChain.GetCertificateRole(pair[0])
+		}
+		if pair[1] >= 0 {
+			newCert = newCerts[pair[1]]
+			d.NewSubject = newCert.Subject.String()
+			d.Role = newChain.GetCertificateRole(pair[1])
+		}
+
+		switch {
+		case oldCert == nil:
+			d.Status = DiffAdded
+		case newCert == nil:
+			d.Status = DiffRemoved
+		default:
+			d.Changes = diffCertificates(certManager, oldCert, newCert)
+			d.Status = DiffUnchanged
+			if len(d.Changes) > 0 {
+				d.Status = DiffChanged
+			}
+		}
+		diff.Identical = diff.Identical && d.Status == DiffUnchanged
+		diff.Certificates = append(diff.Certificates, d)
+	}
+	return diff
+}
+
+// alignCertificates pairs old and new certificate indexes, using -1 for a
+// certificate without a counterpart.
+func alignCertificates(oldCerts, newCerts []*x509.Certificate) [][2]int {
+	match := make([]int, len(oldCerts))
+	for i := range match {
+		match[i] = -1
+	}
+	used := make([]bool, len(newCerts))
+	if len(oldCerts) > 0 && len(newCerts) > 0 {
+		match[0], used[0] = 0, true
+	}
+
+	passes := []func(o, n int) bool{
+		func(o, n int) bool { return bytes.Equal(oldCerts[o].Raw, newCerts[n].Raw) },
+		func(o, n int) bool { return bytes.Equal(oldCerts[o].RawSubject, newCerts[n].RawSubject) },
+		func(o, n int) bool { return o == n },
+	}
+	for _, same := range passes {
+		for o := 1; o < len(oldCerts); o++ {
+			if match[o] >= 0 {
+				continue
+			}
+			for n := 1; n < len(newCerts); n++ {
+				if !used[n] && same(o, n) {
+					match[o], used[n] = n, true
+					break
+				}
+			}
+		}
+	}
+
+	pairs := make([][2]int, 0, len(oldCerts)+len(newCerts))
+	for o, n := range match {
+		pairs = append(pairs, [2]int{o, n})
+	}
+	for n, ok := range used {
+		if !ok {
+			pairs = append(pairs, [2]int{-1, n})
+		}
+	}
+	return pairs
+}
+
+// certificateField is a named, rendered certificate field.
+type certificateField struct {
+	name  string
+	value string
+}
+
+// diffCertificates compares the fields, extensions, and SANs of two certificates.
+func diffCertificates(certManager *x509certs.Certificate, oldCert, newCert *x509.Certificate) []FieldChange {
+	oldDetails, newDetails := certManager.Inspect(oldCert), certManager.Inspect(newCert)
+	oldFields, newFields := certificateFields(oldDetails), certificateFields(newDetails)
+
+	var changes []FieldChange
+	for _, field := range oldFields {
+		i := slices.IndexFunc(newFields, func(f certificateField) bool { return f.name == field.name })
+		switch {
+		case i < 0:
+			changes = append(changes, FieldChange{Field: field.name, Old: field.value})
+		case newFields[i].value != field.value:
+			changes = append(changes, FieldChange{Field: field.name, Old: field.value, New: newFields[i].value})
+		}
+	}
+	for _, field := range newFields {
+		if !slices.ContainsFunc(oldFields, func(f certificateField) bool { return f.name == field.name }) {
+			changes = append(changes, FieldChange{Field: field.name, New: field.value})
+		}
+	}
+
+	oldSANs, newSANs := subjectAltNames(oldDetails), subjectAltNames(newDetails)
+	for _, name := range oldSANs {
+		if !slices.Contains(newSANs, name) {
+			changes = append(changes, FieldChange{Field: sanField, Old: name})
+		}
+	}
+	for _, name := range newSANs {
+		if !slices.Contains(oldSANs, name) {
+			changes = append(changes, FieldChange{Field: sanField, New: name})
+		}
+	}
+	return changes
+}
+
+// certificateFields renders the compared fields of a decoded certificate, in display order.
+func certificateFields(d *x509certs.CertificateDetails) []certificateField {
+	const timeFormat = "2006-01-02 15:04:05 MST"
+	fields := []certificateField{
+		{"Subject", d.Subject},
+		{"Issuer", d.Issuer},
+		{"Serial Number", d.SerialNumber},
+		{"Signature Algorithm", d.SignatureAlgorithm},
+		{"Not Before", d.NotBefore.Format(timeFormat)},
+		{"Not After", d.NotAfter.Format(timeFormat)},
+		{"Validity Period", fmt.Sprintf("%d days", int(d.NotAfter.Sub(d.NotBefore).Hours()/24))},
+		{"Public Key", d.PublicKey.KeyDescription.String()},
+		{"SPKI SHA-256", d.SPKISHA256},
+		{"SHA-256 Fingerprint", d.SHA256},
+	}
+	for _, ext := range d.Extensions {
+		switch ext.OID {
+		case oidSubjectAltName:
+			continue
+		case oidEmbeddedSCTs:
+			fields = append(fields, certificateField{ext.Name, fmt.Sprintf("%d SCTs", len(ext.SCTs))})
+		default:
+			fields = append(fields, certificateField{ext.Name, strings.Join(ext.Values, "; ")})
+		}
+	}
+	return fields
+}
+
+// subjectAltNames returns every Subject Alternative Name as "Type:Value".
+func subjectAltNames(d *x509certs.CertificateDetails) []string {
+	var names []string
+	for _, ext := range d.Extensions {
+		if ext.OID != oidSubjectAltName {
+			continue
+		}
+		for _, name := range ext.GeneralNames {
+			names = append(names, name.String())
+		}
+	}
+	return names
+}
+
+// RenderText renders the diff in a human-readable form.
+//
+// Changed values are prefixed with "~", added values with "+", and removed
+// values with "-".
+//
+// Returns:
+//   - string: Multi-line text report
+func (d *ChainDiff) RenderText() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Old chain: %d certificates\n", d.OldLength)
+	fmt.Fprintf(&b, "New chain: %d certificates\n", d.NewLength)
+
+	differ := 0
+	for _, c := range d.Certificates {
+		subject := c.NewSubject
+		if subject == "" {
+			subject = c.OldSubject
+		}
+		fmt.Fprintf(&b, "\n[%s] %s: %s\n", c.Status, c.Role, subject)
+		if c.Status != DiffUnchanged {
+			differ++
+		}
+
+		for _, change := range c.Changes {
+			switch {
+			case change.Old == "":
+				fmt.Fprintf(&b, "  + %s: %s\n", change.Field, change.New)
+			case change.New == "":
+				fmt.Fprintf(&b, "  - %s: %s\n", change.Field, change.Old)
+			default:
+				fmt.Fprintf(&b, "  ~ %s: %s → %s\n", change.Field, change.Old, change.New)
+			}
+		}
+	}
+
+	if d.Identical {
+		b.WriteString("\nResult: IDENTICAL\n")
+	} else {
+		fmt.Fprintf(&b, "\nResult: %d of %d certificates differ\n", differ, len(d.Certificates))
+	}
+	return b.String()
+}
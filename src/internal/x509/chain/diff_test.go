@@ -0,0 +1,128 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffChains(t *testing.T) {
+	root := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Diff Root"}, IsCA: true}, nil)
+	inter := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Diff Intermediate R1"}, IsCA: true}, root)
+	leaf := issueConstrained(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "www.example.com"},
+		DNSNames: []string{"www.example.com", "old.example.com"},
+	}, inter)
+
+	t.Run("Identical", func(t *testing.T) {
+		diff := DiffChains(chainOf(leaf, inter, root), chainOf(leaf, inter, root))
+		assert.True(t, diff.Identical)
+		require.Len(t, diff.Certificates, 3)
+		for _, c := range diff.Certificates {
+			assert.Equal(t, DiffUnchanged, c.Status)
+			assert.Empty(t, c.Changes)
+		}
+		assert.Contains(t, diff.RenderText(), "Result: IDENTICAL")
+	})
+
+	t.Run("Renewal With New Intermediate", func(t *testing.T) {
+		newInter := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Diff Intermediate R2"}, IsCA: true}, root)
+		renewed := issueConstrained(t, &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "www.example.com"},
+			DNSNames: []string{"www.example.com", "new.example.com"},
+		}, newInter)
+
+		diff := DiffChains(chainOf(leaf, inter, root), chainOf(renewed, newInter, root))
+		assert.False(t, diff.Identical)
+		require.Len(t, diff.Certificates, 3)
+
+		leafDiff := diff.Certificates[0]
+		assert.Equal(t, DiffChanged, leafDiff.Status)
+		assert.Contains(t, leafDiff.Changes, FieldChange{Field: "Issuer", Old: "CN=Diff Intermediate R1", New: "CN=Diff Intermediate R2"})
+		assert.Contains(t, leafDiff.Changes, FieldChange{Field: "Subject Alternative Name", Old: "DNS:old.example.com"})
+		assert.Contains(t, leafDiff.Changes, FieldChange{Field: "Subject Alternative Name", New: "DNS:new.example.com"})
+		for _, change := range leafDiff.Changes {
+			assert.NotEqual(t, "Subject", change.Field)
+		}
+
+		assert.Equal(t, DiffChanged, diff.Certificates[1].Status)
+		assert.Equal(t, "CN=Diff Intermediate R1", diff.Certificates[1].OldSubject)
+		assert.Equal(t, "CN=Diff Intermediate R2", diff.Certificates[1].NewSubject)
+		assert.Equal(t, DiffUnchanged, diff.Certificates[2].Status)
+
+		text := diff.RenderText()
+		assert.Contains(t, text, "[changed]")
+		assert.Contains(t, text, "~ Issuer: CN=Diff Intermediate R1 → CN=Diff Intermediate R2")
+		assert.Contains(t, text, "- Subject Alternative Name: DNS:old.example.com")
+		assert.Contains(t, text, "+ Subject Alternative Name: DNS:new.example.com")
+		assert.Contains(t, text, "Result: 2 of 3 certificates differ")
+	})
+
+	t.Run("Removed Intermediate", func(t *testing.T) {
+		direct := issueConstrained(t, &x509.Certificate{
+			Subject:  pkix.Name{CommonName: "www.example.com"},
+			DNSNames: []string{"www.example.com", "old.example.com"},
+		}, root)
+
+		diff := DiffChains(chainOf(leaf, inter, root), chainOf(direct, root))
+		require.Len(t, diff.Certificates, 3)
+		assert.Equal(t, DiffRemoved, diff.Certificates[1].Status)
+		assert.Equal(t, -1, diff.Certificates[1].NewIndex)
+		assert.Equal(t, DiffUnchanged, diff.Certificates[2].Status)
+		assert.Equal(t, 2, diff.Certificates[2].OldIndex)
+		assert.Equal(t, 1, diff.Certificates[2].NewIndex)
+
+		reversed := DiffChains(chainOf(direct, root), chainOf(leaf, inter, root))
+		require.Len(t, reversed.Certificates, 3)
+		assert.Equal(t, DiffAdded, reversed.Certificates[2].Status)
+		assert.Equal(t, 1, reversed.Certificates[2].NewIndex)
+		assert.Contains(t, reversed.RenderText(), "[added]")
+	})
+}
+
+func TestResolveInput(t *testing.T) {
+	root := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "Input Root"}, IsCA: true}, nil)
+	leaf := issueConstrained(t, &x509.Certificate{Subject: pkix.Name{CommonName: "input.example.com"}}, root)
+
+	var bundle []byte
+	for _, c := range []*constrainedIssuer{leaf, root} {
+		bundle = append(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	path := filepath.Join(t.TempDir(), "bundle.pem")
+	require.NoError(t, os.WriteFile(path, bundle, 0644))
+
+	t.Run("File Bundle", func(t *testing.T) {
+		ch, err := ResolveInput(context.Background(), path, time.Second, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, ch.Certs, 2)
+		assert.Equal(t, leaf.cert.Raw, ch.Certs[0].Raw)
+	})
+
+	t.Run("Base64 DER", func(t *testing.T) {
+		ch, err := ResolveInput(context.Background(), base64.StdEncoding.EncodeToString(leaf.cert.Raw), time.Second, "1.0.0")
+		require.NoError(t, err)
+		require.Len(t, ch.Certs, 1)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := ResolveInput(context.Background(), "not a certificate!", time.Second, "1.0.0")
+		assert.ErrorIs(t, err, ErrInvalidInput)
+
+		_, err = ResolveInput(context.Background(), base64.StdEncoding.EncodeToString([]byte("garbage")), time.Second, "1.0.0")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,448 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultEgressMaxRedirects is the redirect limit of a policy that does not set one.
+const DefaultEgressMaxRedirects = 5
+
+var (
+	// ErrEgressDenied indicates a connection or request refused by the egress policy.
+	ErrEgressDenied = errors.New("x509chain: egress denied by policy")
+
+	// defaultEgressSchemes are the URL schemes a policy allows when it lists
+	// none: every scheme the AIA, OCSP, and CRL fetchers implement.
+	defaultEgressSchemes = []string{"http", "https"}
+
+	// sharedAddressSpace is the RFC 6598 carrier-grade NAT range, which
+	// [netip.Addr.IsPrivate] does not cover.
+	sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+	// thisNetwork is the RFC 1122 "this network" range, reachable as loopback on most systems.
+	thisNetwork = netip.MustParsePrefix("0.0.0.0/8")
+
+	// reservedRanges are further IPv4 ranges that never name a public host:
+	// RFC 2544 benchmarking and the RFC 1112 reserved block including broadcast.
+	reservedRanges = []netip.Prefix{
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+	}
+
+	// nat64Prefix is the RFC 6052 well-known NAT64 prefix, which embeds an
+	// IPv4 address in its last 32 bits.
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+
+	// nat64LocalPrefix is the RFC 8215 local-use NAT64 prefix.
+	nat64LocalPrefix = netip.MustParsePrefix("64:ff9b:1::/48")
+
+	// sixToFourPrefix is the RFC 3056 6to4 prefix, which embeds an IPv4
+	// address in bits 16 to 47.
+	sixToFourPrefix = netip.MustParsePrefix("2002::/16")
+)
+
+// EgressPolicy restricts the outbound connections made for remote chain
+// fetching and for AIA, OCSP, and CRL downloads.
+//
+// Certificates name their own AIA, OCSP, and CRL URLs, so without a policy a
+// crafted certificate or hostname can steer the resolver to internal services
+// such as cloud metadata endpoints. Every resolved address is checked before
+// it is dialed, which also defeats DNS rebinding.
+//
+// Rules are evaluated in this order:
+//  1. DenyHosts and DenyCIDRs reject matching hostnames and addresses
+//  2. AllowHosts, when set, admits only matching hostnames; IP literals must then match AllowCIDRs
+//  3. AllowCIDRs, when set, admits only matching addresses
+//  4. BlockPrivate rejects loopback, private, link-local, shared, reserved, and
+//     multicast addresses, including NAT64 and 6to4 addresses embedding them,
+//     unless AllowCIDRs explicitly admits them
+type EgressPolicy struct {
+	// BlockPrivate: Reject non-public addresses; nil lets the caller choose a mode-specific default
+	BlockPrivate *bool `json:"blockPrivate,omitempty" yaml:"blockPrivate,omitempty"`
+	// AllowCIDRs: Address ranges that may be dialed; empty allows any address not otherwise blocked
+	AllowCIDRs []string `json:"allowCIDRs,omitempty" yaml:"allowCIDRs,omitempty"`
+	// DenyCIDRs: Address ranges that are never dialed
+	DenyCIDRs []string `json:"denyCIDRs,omitempty" yaml:"denyCIDRs,omitempty"`
+	// AllowHosts: Hostnames that may be contacted ("example.com" or "*.example.com"); empty allows any
+	AllowHosts []string `json:"allowHosts,omitempty" yaml:"allowHosts,omitempty"`
+	// DenyHosts: Hostnames that are never contacted, in the same pattern syntax
+	DenyHosts []string `json:"denyHosts,omitempty" yaml:"denyHosts,omitempty"`
+	// AllowedSchemes: URL schemes allowed for AIA, OCSP, and CRL downloads, among http and https (default both)
+	AllowedSchemes []string `json:"allowedSchemes,omitempty" yaml:"allowedSchemes,omitempty"`
+	// MaxRedirects: HTTP redirects followed per request; 0 uses DefaultEgressMaxRedirects, negative follows none
+	MaxRedirects int `json:"maxRedirects,omitempty" yaml:"maxRedirects,omitempty"`
+}
+
+// EgressError describes a connection or request refused by the egress policy.
+type EgressError struct {
+	// Target: Hostname, address, or URL that was refused
+	Target string
+	// Reason: Rule that refused it
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *EgressError) Error() string {
+	return fmt.Sprintf("x509chain: egress policy denied %s: %s", e.Target, e.Reason)
+}
+
+// Unwrap returns [ErrEgressDenied] so callers can match with [errors.Is].
+func (e *EgressError) Unwrap() error { return ErrEgressDenied }
+
+// egressRules is the parsed form of an [EgressPolicy].
+type egressRules struct {
+	// policy: Copy of the policy the rules were parsed from
+	policy EgressPolicy
+	// allow, deny: Parsed AllowCIDRs and DenyCIDRs
+	allow, deny []netip.Prefix
+	// allowHosts, denyHosts: Lower-cased host patterns
+	allowHosts, denyHosts []string
+	// blockPrivate: Resolved BlockPrivate
+	blockPrivate bool
+	// schemes: Allowed URL schemes
+	schemes []string
+	// maxRedirects: Resolved redirect limit
+	maxRedirects int
+
+	// transportOnce: Guards the construction of transport
+	transportOnce sync.Once
+	// transport: Connection pool shared by every client enforcing these rules
+	transport *http.Transport
+}
+
+// egressPolicy holds the active rules; nil means unrestricted.
+var egressPolicy atomic.Pointer[egressRules]
+
+// SetEgressPolicy installs the egress policy used by [FetchRemoteChain] and
+// every [HTTPConfig.Client].
+//
+// Parameters:
+//   - policy: Policy to enforce; nil removes any policy and allows all egress
+//
+// Returns:
+//   - error: Invalid CIDR, host pattern, or scheme; the previous policy stays active
+//
+// Thread Safety: Safe for concurrent use.
+func SetEgressPolicy(policy *EgressPolicy) error {
+	if policy == nil {
+		egressPolicy.Store(nil)
+		return nil
+	}
+	rules, err := parseEgressPolicy(*policy)
+	if err != nil {
+		return err
+	}
+	egressPolicy.Store(rules)
+	return nil
+}
+
+// GetEgressPolicy returns a copy of the active egress policy.
+//
+// Returns:
+//   - *EgressPolicy: Active policy, or nil when egress is unrestricted
+//
+// Thread Safety: Safe for concurrent use.
+func GetEgressPolicy() *EgressPolicy {
+	rules := egressPolicy.Load()
+	if rules == nil {
+		return nil
+	}
+	policy := rules.policy
+	return &policy
+}
+
+// parseEgressPolicy validates a policy and converts it into rules.
+func parseEgressPolicy(policy EgressPolicy) (*egressRules, error) {
+	rules := &egressRules{
+		policy:       policy,
+		blockPrivate: policy.BlockPrivate != nil && *policy.BlockPrivate,
+		schemes:      defaultEgressSchemes,
+		maxRedirects: policy.MaxRedirects,
+	}
+	if policy.BlockPrivate != nil {
+		blockPrivate := *policy.BlockPrivate
+		rules.policy.BlockPrivate = &blockPrivate
+	}
+	if rules.maxRedirects == 0 {
+		rules.maxRedirects = DefaultEgressMaxRedirects
+	}
+
+	for _, list := range []struct {
+		name  string
+		cidrs []string
+		dst   *[]netip.Prefix
+	}{{"allowCIDRs", policy.AllowCIDRs, &rules.allow}, {"denyCIDRs", policy.DenyCIDRs, &rules.deny}} {
+		for _, cidr := range list.cidrs {
+			prefix, err := netip.ParsePrefix(cidr)
+			if err != nil {
+				// A bare address is a single-host range
+				addr, addrErr := netip.ParseAddr(cidr)
+				if addrErr != nil {
+					return nil, fmt.Errorf("x509chain: invalid %s entry %q: %w", list.name, cidr, err)
+				}
+				prefix = netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen())
+			}
+			*list.dst = append(*list.dst, prefix.Masked())
+		}
+	}
+
+	for _, list := range []struct {
+		name     string
+		patterns []string
+		dst      *[]string
+	}{{"allowHosts", policy.AllowHosts, &rules.allowHosts}, {"denyHosts", policy.DenyHosts, &rules.denyHosts}} {
+		for _, pattern := range list.patterns {
+			pattern = normalizeHost(pattern)
+			if name, _ := strings.CutPrefix(pattern, "*."); name == "" || strings.Contains(name, "*") {
+				return nil, fmt.Errorf("x509chain: invalid %s entry %q: use a hostname or *.domain", list.name, pattern)
+			}
+			*list.dst = append(*list.dst, pattern)
+		}
+	}
+
+	if len(policy.AllowedSchemes) > 0 {
+		rules.schemes = nil
+		for _, scheme := range policy.AllowedSchemes {
+			scheme = strings.ToLower(strings.TrimSuffix(scheme, "://"))
+			if scheme == "" || strings.ContainsAny(scheme, ":/") {
+				return nil, fmt.Errorf("x509chain: invalid allowedSchemes entry %q", scheme)
+			}
+			// Allowing a scheme no fetcher implements would only defer the failure
+			if !slices.Contains(defaultEgressSchemes, scheme) {
+				return nil, fmt.Errorf("x509chain: unsupported allowedSchemes entry %q: only %s can be fetched", scheme, strings.Join(defaultEgressSchemes, " and "))
+			}
+			rules.schemes = append(rules.schemes, scheme)
+		}
+	}
+	return rules, nil
+}
+
+// normalizeHost lower-cases a hostname and strips a trailing root dot.
+func normalizeHost(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
+
+// matchHost reports whether host matches any pattern.
+func matchHost(host string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+			if strings.HasSuffix(host, suffix) {
+				return true
+			}
+		} else if host == pattern {
+			return true
+		}
+	}
+	return false
+}
+
+// isNonPublic reports whether addr is loopback, private, link-local,
+// shared, reserved, unspecified, or multicast.
+//
+// NAT64 and 6to4 addresses are judged by the IPv4 address they embed, since
+// a translator or relay forwards them to that address.
+func isNonPublic(addr netip.Addr) bool {
+	if embedded, ok := embeddedIPv4(addr); ok {
+		return isNonPublic(embedded)
+	}
+	return addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() || addr.IsInterfaceLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || sharedAddressSpace.Contains(addr) || thisNetwork.Contains(addr) ||
+		containsAddr(reservedRanges, addr) || nat64LocalPrefix.Contains(addr)
+}
+
+// embeddedIPv4 returns the IPv4 address embedded in a well-known NAT64 or
+// 6to4 address.
+func embeddedIPv4(addr netip.Addr) (netip.Addr, bool) {
+	b := addr.As16()
+	switch {
+	case nat64Prefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[12:16])), true
+	case sixToFourPrefix.Contains(addr):
+		return netip.AddrFrom4([4]byte(b[2:6])), true
+	}
+	return netip.Addr{}, false
+}
+
+// containsAddr reports whether any prefix contains addr.
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	return slices.ContainsFunc(prefixes, func(p netip.Prefix) bool { return p.Contains(addr) })
+}
+
+// checkHost applies the hostname rules. IP literals are left to checkAddr
+// unless AllowHosts is set, in which case they must be admitted by AllowCIDRs.
+func (r *egressRules) checkHost(host string) error {
+	host = normalizeHost(host)
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if len(r.allowHosts) > 0 && !containsAddr(r.allow, addr.Unmap()) {
+			return &EgressError{Target: host, Reason: "IP literals are not in allowHosts and not admitted by allowCIDRs"}
+		}
+		return nil
+	}
+	if matchHost(host, r.denyHosts) {
+		return &EgressError{Target: host, Reason: "hostname is in denyHosts"}
+	}
+	if len(r.allowHosts) > 0 && !matchHost(host, r.allowHosts) {
+		return &EgressError{Target: host, Reason: "hostname is not in allowHosts"}
+	}
+	return nil
+}
+
+// checkAddr applies the address rules to a resolved address of host.
+func (r *egressRules) checkAddr(host string, addr netip.Addr) error {
+	addr = addr.Unmap()
+	target := addr.String()
+	if host != target {
+		target = fmt.Sprintf("%s (%s)", host, addr)
+	}
+	if containsAddr(r.deny, addr) {
+		return &EgressError{Target: target, Reason: "address is in denyCIDRs"}
+	}
+	allowed := containsAddr(r.allow, addr)
+	if len(r.allow) > 0 && !allowed {
+		return &EgressError{Target: target, Reason: "address is not in allowCIDRs"}
+	}
+	if r.blockPrivate && !allowed && isNonPublic(addr) {
+		return &EgressError{Target: target, Reason: "private, loopback, and link-local addresses are blocked"}
+	}
+	return nil
+}
+
+// checkURL applies the scheme and hostname rules to a request URL.
+func (r *egressRules) checkURL(u *url.URL) error {
+	if !slices.Contains(r.schemes, strings.ToLower(u.Scheme)) {
+		return &EgressError{Target: u.Redacted(), Reason: fmt.Sprintf("scheme %q is not allowed (allowed: %s)", u.Scheme, strings.Join(r.schemes, ", "))}
+	}
+	return r.checkHost(u.Hostname())
+}
+
+// dial resolves address, drops every resolved address the policy refuses,
+// and dials the remaining ones in order. The checked address is dialed
+// directly so a second DNS answer cannot bypass the policy.
+func (r *egressRules) dial(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.checkHost(host); err != nil {
+		return nil, err
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		ipNetwork := "ip"
+		switch network {
+		case "tcp4":
+			ipNetwork = "ip4"
+		case "tcp6":
+			ipNetwork = "ip6"
+		}
+		if addrs, err = net.DefaultResolver.LookupNetIP(ctx, ipNetwork, host); err != nil {
+			return nil, err
+		}
+	}
+
+	var deniedErr, dialErr error
+	for _, addr := range addrs {
+		if err := r.checkAddr(host, addr); err != nil {
+			deniedErr = cmp.Or(deniedErr, err)
+			continue
+		}
+		conn, err := dialer.DialContext(ctx, network, net.JoinHostPort(addr.Unmap().String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		dialErr = err
+	}
+	if dialErr != nil {
+		return nil, dialErr
+	}
+	if deniedErr == nil {
+		deniedErr = &EgressError{Target: host, Reason: "no addresses resolved"}
+	}
+	return nil, deniedErr
+}
+
+// checkRedirect enforces the redirect limit and the URL rules on redirect targets.
+func (r *egressRules) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > max(r.maxRedirects, 0) {
+		return &EgressError{Target: req.URL.Redacted(), Reason: fmt.Sprintf("more than %d redirects", max(r.maxRedirects, 0))}
+	}
+	return r.checkURL(req.URL)
+}
+
+// dialContext dials address under the active egress policy, or directly
+// when no policy is installed.
+func dialContext(ctx context.Context, dialer *net.Dialer, network, address string) (net.Conn, error) {
+	if rules := egressPolicy.Load(); rules != nil {
+		return rules.dial(ctx, dialer, network, address)
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// CheckEgressURL reports whether the active egress policy allows requests to rawURL.
+//
+// The resolver calls it before every AIA, OCSP, and CRL request so that
+// refused URLs fail with a clear error instead of a transport error.
+//
+// Parameters:
+//   - rawURL: URL taken from a certificate or supplied by a caller
+//
+// Returns:
+//   - error: *EgressError for a refused scheme or hostname, a parse error, or nil
+//
+// Thread Safety: Safe for concurrent use.
+func CheckEgressURL(rawURL string) error {
+	rules := egressPolicy.Load()
+	if rules == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("x509chain: invalid URL %q: %w", rawURL, err)
+	}
+	return rules.checkURL(u)
+}
+
+// httpTransport returns the transport that dials only addresses the rules
+// allow, creating it on first use so that every client enforcing the rules
+// shares one connection pool.
+//
+// Proxies are not used, because a proxy would resolve and dial the
+// destination on the resolver's behalf, outside the policy.
+func (r *egressRules) httpTransport() *http.Transport {
+	r.transportOnce.Do(func() {
+		dialer := &net.Dialer{}
+		r.transport = http.DefaultTransport.(*http.Transport).Clone()
+		r.transport.Proxy = nil
+		r.transport.DialContext = func(ctx context.Context, network, address string) (net.Conn, error) {
+			return r.dial(ctx, dialer, network, address)
+		}
+	})
+	return r.transport
+}
+
+// newEgressClient returns an HTTP client that enforces the egress policy
+// on every connection and redirect.
+func newEgressClient(rules *egressRules, timeout time.Duration) *http.Client {
+	return &http.Client{Transport: rules.httpTransport(), CheckRedirect: rules.checkRedirect, Timeout: timeout}
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setEgressPolicy installs policy for the duration of the test.
+func setEgressPolicy(t *testing.T, policy EgressPolicy) {
+	t.Helper()
+	require.NoError(t, SetEgressPolicy(&policy))
+	t.Cleanup(func() { SetEgressPolicy(nil) })
+}
+
+// serverHostPort splits the address of an httptest server.
+func serverHostPort(t *testing.T, srv *httptest.Server) (string, int) {
+	t.Helper()
+	host, portStr, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func TestSetEgressPolicy(t *testing.T) {
+	t.Cleanup(func() { SetEgressPolicy(nil) })
+
+	tests := []struct {
+		name    string
+		policy  EgressPolicy
+		wantErr string
+	}{
+		{"Valid", EgressPolicy{AllowCIDRs: []string{"10.0.0.0/8", "192.0.2.1"}, AllowHosts: []string{"*.example.com"}, AllowedSchemes: []string{"http", "HTTPS://"}}, ""},
+		{"Invalid CIDR", EgressPolicy{DenyCIDRs: []string{"10.0.0.0/33"}}, "invalid denyCIDRs entry"},
+		{"Invalid Host Pattern", EgressPolicy{AllowHosts: []string{"foo.*.com"}}, "invalid allowHosts entry"},
+		{"Bare Wildcard", EgressPolicy{DenyHosts: []string{"*"}}, "invalid denyHosts entry"},
+		{"Invalid Scheme", EgressPolicy{AllowedSchemes: []string{"ht/tp"}}, "invalid allowedSchemes entry"},
+		{"Unsupported Scheme", EgressPolicy{AllowedSchemes: []string{"http", "ldap"}}, `unsupported allowedSchemes entry "ldap": only http and https can be fetched`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := SetEgressPolicy(&tt.policy)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	// A rejected policy leaves the previous one active
+	active := GetEgressPolicy()
+	require.NotNil(t, active)
+	assert.Equal(t, []string{"*.example.com"}, active.AllowHosts)
+
+	require.NoError(t, SetEgressPolicy(nil))
+	assert.Nil(t, GetEgressPolicy())
+}
+
+func TestEgressRules(t *testing.T) {
+	blockPrivate := true
+	rules, err := parseEgressPolicy(EgressPolicy{
+		BlockPrivate: &blockPrivate,
+		DenyCIDRs:    []string{"203.0.113.0/24"},
+		DenyHosts:    []string{"*.internal.example", "metadata.google.internal"},
+	})
+	require.NoError(t, err)
+
+	hosts := []struct {
+		host    string
+		allowed bool
+	}{
+		{"pki.example.com", true},
+		{"METADATA.google.internal.", false},
+		{"ocsp.internal.example", false},
+		{"internal.example", true},
+		{"192.0.2.1", true},
+	}
+	for _, tt := range hosts {
+		err := rules.checkHost(tt.host)
+		if tt.allowed {
+			assert.NoError(t, err, tt.host)
+		} else {
+			assert.ErrorIs(t, err, ErrEgressDenied, tt.host)
+		}
+	}
+
+	addrs := []struct {
+		addr    string
+		allowed bool
+	}{
+		{"8.8.8.8", true},
+		{"2001:4860:4860::8888", true},
+		{"203.0.113.7", false},
+		{"127.0.0.1", false},
+		{"0.0.0.0", false},
+		{"169.254.169.254", false},
+		{"100.64.1.1", false},
+		{"::ffff:192.168.1.1", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"198.18.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"64:ff9b::a00:1", false},
+		{"64:ff9b::808:808", true},
+		{"64:ff9b:1::808:808", false},
+		{"2002:c0a8:101::1", false},
+		{"2002:808:808::1", true},
+	}
+	for _, tt := range addrs {
+		err := rules.checkAddr("host", netip.MustParseAddr(tt.addr))
+		if tt.allowed {
+			assert.NoError(t, err, tt.addr)
+		} else {
+			assert.ErrorIs(t, err, ErrEgressDenied, tt.addr)
+		}
+	}
+
+	// AllowCIDRs restricts dialing to its ranges and admits private ones explicitly
+	rules, err = parseEgressPolicy(EgressPolicy{BlockPrivate: &blockPrivate, AllowCIDRs: []string{"10.1.0.0/16", "8.8.8.8"}})
+	require.NoError(t, err)
+	assert.NoError(t, rules.checkAddr("host", netip.MustParseAddr("10.1.2.3")))
+	assert.NoError(t, rules.checkAddr("host", netip.MustParseAddr("8.8.8.8")))
+	assert.ErrorIs(t, rules.checkAddr("host", netip.MustParseAddr("10.2.0.1")), ErrEgressDenied)
+	assert.ErrorIs(t, rules.checkAddr("host", netip.MustParseAddr("1.1.1.1")), ErrEgressDenied)
+}
+
+func TestEgressRules_AllowHosts(t *testing.T) {
+	rules, err := parseEgressPolicy(EgressPolicy{
+		AllowHosts: []string{"pki.example.com", "*.ca.example"},
+		AllowCIDRs: []string{"192.0.2.0/24"},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, rules.checkHost("pki.example.com"))
+	assert.NoError(t, rules.checkHost("crl.ca.example"))
+	assert.ErrorIs(t, rules.checkHost("ca.example"), ErrEgressDenied)
+	assert.ErrorIs(t, rules.checkHost("evil.example.com"), ErrEgressDenied)
+	// IP literals bypass hostnames, so they must match AllowCIDRs
+	assert.NoError(t, rules.checkHost("192.0.2.10"))
+	assert.ErrorIs(t, rules.checkHost("198.51.100.1"), ErrEgressDenied)
+}
+
+func TestCheckEgressURL(t *testing.T) {
+	// Without a policy every URL is allowed
+	require.NoError(t, SetEgressPolicy(nil))
+	assert.NoError(t, CheckEgressURL("file:///etc/passwd"))
+
+	setEgressPolicy(t, EgressPolicy{})
+	assert.NoError(t, CheckEgressURL("http://crl.example.com/ca.crl"))
+	assert.NoError(t, CheckEgressURL("HTTPS://ocsp.example.com"))
+
+	err := CheckEgressURL("file:///etc/passwd")
+	assert.ErrorIs(t, err, ErrEgressDenied)
+	assert.ErrorContains(t, err, `scheme "file" is not allowed`)
+	assert.ErrorIs(t, CheckEgressURL("ldap://ldap.example.com/cn=CA"), ErrEgressDenied)
+
+	// Schemes can be narrowed
+	setEgressPolicy(t, EgressPolicy{AllowedSchemes: []string{"http"}})
+	assert.NoError(t, CheckEgressURL("http://crl.example.com/ca.crl"))
+	assert.ErrorIs(t, CheckEgressURL("https://ocsp.example.com"), ErrEgressDenied)
+}
+
+func TestFetchRemoteChain_Egress(t *testing.T) {
+	srv := httptest.NewTLSServer(http.NotFoundHandler())
+	defer srv.Close()
+	host, port := serverHostPort(t, srv)
+
+	blockPrivate := true
+	setEgressPolicy(t, EgressPolicy{BlockPrivate: &blockPrivate})
+	_, _, err := FetchRemoteChain(context.Background(), host, port, 5*time.Second, "1.0.0")
+	assert.ErrorIs(t, err, ErrEgressDenied)
+	assert.ErrorContains(t, err, "private, loopback, and link-local addresses are blocked")
+
+	setEgressPolicy(t, EgressPolicy{DenyHosts: []string{"localhost"}})
+	_, _, err = FetchRemoteChain(context.Background(), "localhost", port, 5*time.Second, "1.0.0")
+	assert.ErrorIs(t, err, ErrEgressDenied)
+
+	// An explicit allow range overrides BlockPrivate
+	setEgressPolicy(t, EgressPolicy{BlockPrivate: &blockPrivate, AllowCIDRs: []string{"127.0.0.0/8"}})
+	_, certs, err := FetchRemoteChain(context.Background(), host, port, 5*time.Second, "1.0.0")
+	require.NoError(t, err)
+	assert.NotEmpty(t, certs)
+}
+
+func TestHTTPConfig_Client_Egress(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) })
+	mux.HandleFunc("/hop1", func(w http.ResponseWriter, r *http.Request) { http.Redirect(w, r, "/ok", http.StatusFound) })
+	mux.HandleFunc("/hop2", func(w http.ResponseWriter, r *http.Request) { http.Redirect(w, r, "/hop1", http.StatusFound) })
+	mux.HandleFunc("/file", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "file:///etc/passwd", http.StatusFound)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	cfg := &HTTPConfig{Timeout: 5 * time.Second}
+	plain := cfg.Client()
+
+	blockPrivate := true
+	setEgressPolicy(t, EgressPolicy{BlockPrivate: &blockPrivate})
+	client := cfg.Client()
+	assert.NotSame(t, plain, client)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+	_, err := client.Get(srv.URL + "/ok")
+	assert.ErrorIs(t, err, ErrEgressDenied)
+
+	// A new policy rebuilds the client
+	setEgressPolicy(t, EgressPolicy{AllowCIDRs: []string{"127.0.0.1/32"}, MaxRedirects: 1})
+	client = cfg.Client()
+	assert.Same(t, client, cfg.Client())
+
+	// A new timeout gets a new client sharing the policy's transport
+	cfg.Timeout = 3 * time.Second
+	retimed := cfg.Client()
+	assert.NotSame(t, client, retimed)
+	assert.Equal(t, 5*time.Second, client.Timeout)
+	assert.Equal(t, 3*time.Second, retimed.Timeout)
+	assert.Same(t, client.Transport, retimed.Transport)
+
+	resp, err := client.Get(srv.URL + "/hop1")
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = client.Get(srv.URL + "/hop2")
+	assert.ErrorIs(t, err, ErrEgressDenied)
+	assert.ErrorContains(t, err, "more than 1 redirects")
+
+	_, err = client.Get(srv.URL + "/file")
+	var urlErr *url.Error
+	require.ErrorAs(t, err, &urlErr)
+	assert.ErrorIs(t, err, ErrEgressDenied)
+
+	// Removing the policy restores the unrestricted client
+	require.NoError(t, SetEgressPolicy(nil))
+	assert.Same(t, plain, cfg.Client())
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"net/url"
+	"sync/atomic"
+)
+
+// HostLimiter throttles outbound requests per destination host.
+//
+// It is consulted before every remote TLS handshake made by [FetchRemoteChain]
+// and before every AIA, OCSP, and CRL download that is not served from cache.
+type HostLimiter interface {
+	// Wait blocks until host may be contacted or returns an error when the
+	// request must not proceed, such as ctx ending first.
+	Wait(ctx context.Context, host string) error
+}
+
+// hostLimiterHolder wraps a HostLimiter so it can be stored atomically.
+type hostLimiterHolder struct {
+	limiter HostLimiter
+}
+
+// hostLimiter holds the active limiter; nil means unthrottled.
+var hostLimiter atomic.Pointer[hostLimiterHolder]
+
+// SetHostLimiter installs the limiter applied to outbound requests.
+//
+// Parameters:
+//   - limiter: Limiter to consult; nil removes any limiter
+//
+// Thread Safety: Safe for concurrent use.
+func SetHostLimiter(limiter HostLimiter) {
+	if limiter == nil {
+		hostLimiter.Store(nil)
+		return
+	}
+	hostLimiter.Store(&hostLimiterHolder{limiter: limiter})
+}
+
+// waitForHost waits for the active limiter, if any, to admit a request to host.
+func waitForHost(ctx context.Context, host string) error {
+	holder := hostLimiter.Load()
+	if holder == nil {
+		return nil
+	}
+	return holder.limiter.Wait(ctx, normalizeHost(host))
+}
+
+// waitForURL waits for the active limiter, if any, to admit a request to rawURL.
+func waitForURL(ctx context.Context, rawURL string) error {
+	if hostLimiter.Load() == nil {
+		return nil
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		// The request itself reports the malformed URL
+		return nil
+	}
+	return waitForHost(ctx, u.Hostname())
+}
@@ -0,0 +1,51 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingLimiter records the hosts it is asked about and refuses them with err.
+type recordingLimiter struct {
+	mu    sync.Mutex
+	hosts []string
+	err   error
+}
+
+func (l *recordingLimiter) Wait(ctx context.Context, host string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.hosts = append(l.hosts, host)
+	return l.err
+}
+
+func TestSetHostLimiter(t *testing.T) {
+	t.Cleanup(func() { SetHostLimiter(nil) })
+
+	errLimited := errors.New("limited")
+	limiter := &recordingLimiter{err: errLimited}
+	SetHostLimiter(limiter)
+
+	_, _, err := FetchRemoteChain(context.Background(), "Example.COM.", 443, time.Second, "1.0.0")
+	assert.ErrorIs(t, err, errLimited)
+	assert.ErrorContains(t, err, "failed to connect to Example.COM.:443")
+
+	assert.ErrorIs(t, waitForURL(context.Background(), "http://CRL.example.com:8080/ca.crl"), errLimited)
+	// Malformed URLs are left for the request to report
+	assert.NoError(t, waitForURL(context.Background(), "http://%zz"))
+	assert.Equal(t, []string{"example.com", "crl.example.com"}, limiter.hosts)
+
+	// Without a limiter requests are not throttled
+	SetHostLimiter(nil)
+	assert.NoError(t, waitForHost(context.Background(), "example.com"))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var (
+	// ErrInvalidInput indicates an input that is not a certificate file, base64 data, or host:port address.
+	ErrInvalidInput = errors.New("x509chain: input is not a file path, base64-encoded certificate data, or host:port address")
+)
+
+// ResolveInput builds a chain from a certificate file, base64-encoded
+// certificate data, or a host:port address, and completes it through AIA.
+//
+// Files and base64 data may hold a single certificate or a bundle, in PEM or
+// DER; the certificates of a bundle start the chain in the order given. A
+// host:port address is contacted with [FetchRemoteChain] and starts from the
+// chain the server presents. Unlike [Chain.FetchCertificate], the result is
+// not verified, so expired or untrusted chains can still be examined.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - input: File path, base64 data, or host:port address
+//   - timeout: Timeout for the TLS handshake and each AIA download
+//   - version: Application version for HTTP User-Agent headers
+//
+// Returns:
+//   - *Chain: Resolved chain, leaf first
+//   - error: ErrInvalidInput, or a decoding, connection, or download error
+func ResolveInput(ctx context.Context, input string, timeout time.Duration, version string) (*Chain, error) {
+	var (
+		ch  *Chain
+		err error
+	)
+	if data, readErr := os.ReadFile(input); readErr == nil {
+		ch, err = chainFromData(data, version)
+	} else if host, port, ok := splitHostPort(input); ok {
+		ch, _, err = FetchRemoteChain(ctx, host, port, timeout, version)
+	} else if decoded, decodeErr := base64.StdEncoding.DecodeString(input); decodeErr == nil {
+		ch, err = chainFromData(decoded, version)
+	} else {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidInput, input)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := ch.complete(ctx, timeout); err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// ResolveCertificates builds a chain from already decoded certificates and
+// completes it through AIA.
+//
+// The certificates start the chain in the order given, leaf first. Like
+// [ResolveInput], the result is not verified. On a download error the chain
+// built so far is returned together with the error.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - certs: Leaf followed by any certificates supplied with it
+//   - timeout: Timeout for each AIA download
+//   - version: Application version for HTTP User-Agent headers
+//
+// Returns:
+//   - *Chain: Resolved chain, leaf first
+//   - error: Download error
+func ResolveCertificates(ctx context.Context, certs []*x509.Certificate, timeout time.Duration, version string) (*Chain, error) {
+	ch := New(certs[0], version)
+	ch.Certs = slices.Clone(certs)
+	return ch, ch.complete(ctx, timeout)
+}
+
+// complete applies timeout to AIA downloads and fetches the missing issuers.
+func (ch *Chain) complete(ctx context.Context, timeout time.Duration) error {
+	ch.HTTPConfig.Timeout = timeout
+	if err := ch.fetchIssuers(ctx); err != nil {
+		return fmt.Errorf("failed to resolve certificate chain: %w", err)
+	}
+	return nil
+}
+
+// chainFromData decodes a certificate or bundle into a chain.
+func chainFromData(data []byte, version string) (*Chain, error) {
+	ch := New(nil, version)
+	certs, err := ch.Certificate.DecodeMultiple(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("failed to decode certificate: no certificates found")
+	}
+	ch.Certs = certs
+	return ch, nil
+}
+
+// splitHostPort reports whether input is a host:port address with a numeric port.
+func splitHostPort(input string) (host string, port int, ok bool) {
+	host, portStr, err := net.SplitHostPort(input)
+	if err != nil || host == "" {
+		return "", 0, false
+	}
+	port, err = strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, false
+	}
+	return host, port, true
+}
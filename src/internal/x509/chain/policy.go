@@ -0,0 +1,328 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy rule identifiers reported in [PolicyResult.Rule].
+const (
+	// PolicyAllowedIssuers restricts which CAs may issue the leaf certificate.
+	PolicyAllowedIssuers = "allowed-issuers"
+	// PolicyMinRSABits sets the minimum RSA modulus size for every certificate in the chain.
+	PolicyMinRSABits = "min-rsa-bits"
+	// PolicyMinECDSABits sets the minimum ECDSA curve size for every certificate in the chain.
+	PolicyMinECDSABits = "min-ecdsa-bits"
+	// PolicyForbiddenSignatureAlgorithms forbids signature algorithms anywhere in the chain.
+	PolicyForbiddenSignatureAlgorithms = "forbidden-signature-algorithms"
+	// PolicyMaxLeafValidity limits the validity period of the leaf certificate.
+	PolicyMaxLeafValidity = "max-leaf-validity"
+	// PolicyMustStaple requires OCSP Must-Staple on leaves covering the listed domains.
+	PolicyMustStaple = "must-staple"
+)
+
+var (
+	// ErrEmptyPolicy indicates that a policy defines no rules, which would pass every chain.
+	ErrEmptyPolicy = errors.New("x509chain: policy defines no rules")
+)
+
+// Policy is an organisational policy evaluated against a resolved chain.
+//
+// Every rule is optional; zero values disable the rule, and only configured
+// rules appear in the [PolicyReport]. Policies are loaded from JSON or YAML
+// with [LoadPolicy], the same way the MCP server loads its configuration.
+type Policy struct {
+	// Name: Human-readable policy name shown in reports
+	Name string `json:"name,omitempty" yaml:"name,omitempty"`
+	// AllowedIssuers: Issuing CAs permitted for the leaf, matched by common name,
+	// full subject DN, or base64 SPKI SHA-256 pin
+	AllowedIssuers []string `json:"allowedIssuers,omitempty" yaml:"allowedIssuers,omitempty"`
+	// MinRSABits: Minimum RSA modulus size in bits for every certificate in the chain
+	MinRSABits int `json:"minRSABits,omitempty" yaml:"minRSABits,omitempty"`
+	// MinECDSABits: Minimum ECDSA curve size in bits for every certificate in the chain
+	MinECDSABits int `json:"minECDSABits,omitempty" yaml:"minECDSABits,omitempty"`
+	// ForbiddenSignatureAlgorithms: Case-insensitive fragments (e.g. "SHA1", "MD5")
+	// that must not appear in the signature algorithm of any certificate in the chain
+	ForbiddenSignatureAlgorithms []string `json:"forbiddenSignatureAlgorithms,omitempty" yaml:"forbiddenSignatureAlgorithms,omitempty"`
+	// MaxLeafValidityDays: Maximum validity period of the leaf certificate in days
+	MaxLeafValidityDays int `json:"maxLeafValidityDays,omitempty" yaml:"maxLeafValidityDays,omitempty"`
+	// MustStapleDomains: Domains whose leaf certificates must carry OCSP Must-Staple;
+	// "*.example.com" matches every subdomain of example.com
+	MustStapleDomains []string `json:"mustStapleDomains,omitempty" yaml:"mustStapleDomains,omitempty"`
+}
+
+// PolicyResult is the outcome of a single policy rule.
+type PolicyResult struct {
+	// Rule: Policy rule identifier
+	Rule string `json:"rule" yaml:"rule"`
+	// Passed: True when the chain satisfies the rule
+	Passed bool `json:"passed" yaml:"passed"`
+	// Violations: One message per violation (empty when passed)
+	Violations []string `json:"violations,omitempty" yaml:"violations,omitempty"`
+}
+
+// PolicyReport is the result of evaluating a [Policy] against a chain.
+type PolicyReport struct {
+	// Policy: Name of the evaluated policy
+	Policy string `json:"policy" yaml:"policy"`
+	// Subject: Subject distinguished name of the leaf certificate
+	Subject string `json:"subject" yaml:"subject"`
+	// Results: Outcome of every configured rule, in a fixed order
+	Results []PolicyResult `json:"results" yaml:"results"`
+	// Passed: True when every configured rule passed
+	Passed bool `json:"passed" yaml:"passed"`
+}
+
+// LoadPolicy reads a policy from a JSON or YAML file.
+//
+// The format is detected from the file extension (.yaml and .yml are YAML,
+// anything else is JSON). Unknown fields are rejected so that a misspelled
+// rule cannot silently weaken the policy.
+//
+// Parameters:
+//   - path: Path to the policy file
+//
+// Returns:
+//   - *Policy: Parsed policy
+//   - error: Reading or parsing error, or [ErrEmptyPolicy]
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+
+	policy := &Policy{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(policy); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML policy file: %w", err)
+		}
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(policy); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON policy file: %w", err)
+		}
+	}
+
+	if policy.Name == "" {
+		policy.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	if len(policy.rules()) == 0 {
+		return nil, ErrEmptyPolicy
+	}
+	return policy, nil
+}
+
+// policyRule pairs a rule identifier with its evaluation function.
+type policyRule struct {
+	// id: Policy rule identifier
+	id string
+	// check: Returns one message per violation
+	check func(ch *Chain) []string
+}
+
+// rules returns the configured rules of the policy in a fixed order.
+func (p *Policy) rules() []policyRule {
+	var rules []policyRule
+	if len(p.AllowedIssuers) > 0 {
+		rules = append(rules, policyRule{PolicyAllowedIssuers, p.checkAllowedIssuers})
+	}
+	if p.MinRSABits > 0 {
+		rules = append(rules, policyRule{PolicyMinRSABits, func(ch *Chain) []string {
+			return checkMinKeyBits(ch, x509certs.KeyFamilyRSA, p.MinRSABits)
+		}})
+	}
+	if p.MinECDSABits > 0 {
+		rules = append(rules, policyRule{PolicyMinECDSABits, func(ch *Chain) []string {
+			return checkMinKeyBits(ch, x509certs.KeyFamilyECDSA, p.MinECDSABits)
+		}})
+	}
+	if len(p.ForbiddenSignatureAlgorithms) > 0 {
+		rules = append(rules, policyRule{PolicyForbiddenSignatureAlgorithms, p.checkSignatureAlgorithms})
+	}
+	if p.MaxLeafValidityDays > 0 {
+		rules = append(rules, policyRule{PolicyMaxLeafValidity, p.checkLeafValidity})
+	}
+	if len(p.MustStapleDomains) > 0 {
+		rules = append(rules, policyRule{PolicyMustStaple, p.checkMustStaple})
+	}
+	return rules
+}
+
+// EvaluatePolicy evaluates an organisational policy against the chain.
+//
+// The chain should already be resolved with [Chain.FetchCertificate] (and
+// optionally [Chain.AddRootCA]) so that issuer and chain-wide rules see every
+// certificate. Evaluation is offline and deterministic.
+//
+// Parameters:
+//   - policy: Policy to evaluate
+//
+// Returns:
+//   - *PolicyReport: Pass or fail for every configured rule
+//
+// Thread Safety: Safe for concurrent use (no state modification).
+func (ch *Chain) EvaluatePolicy(policy *Policy) *PolicyReport {
+	report := &PolicyReport{
+		Policy:  policy.Name,
+		Results: []PolicyResult{},
+		Passed:  true,
+	}
+	if len(ch.Certs) > 0 {
+		report.Subject = ch.Certs[0].Subject.String()
+	}
+
+	for _, rule := range policy.rules() {
+		violations := rule.check(ch)
+		report.Results = append(report.Results, PolicyResult{
+			Rule:       rule.id,
+			Passed:     len(violations) == 0,
+			Violations: violations,
+		})
+		report.Passed = report.Passed && len(violations) == 0
+	}
+
+	return report
+}
+
+// RenderText renders the report as human-readable text, one line per rule.
+//
+// Returns:
+//   - string: Multi-line text report
+func (r *PolicyReport) RenderText() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "Policy:  %s\n", r.Policy)
+	fmt.Fprintf(&b, "Subject: %s\n\n", r.Subject)
+	for _, result := range r.Results {
+		status := "PASS"
+		if !result.Passed {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "  [%s] %s\n", status, result.Rule)
+		for _, v := range result.Violations {
+			fmt.Fprintf(&b, "         %s\n", v)
+		}
+	}
+
+	result := "PASSED"
+	if !r.Passed {
+		result = "FAILED"
+	}
+	fmt.Fprintf(&b, "\nResult: %s\n", result)
+
+	return b.String()
+}
+
+// checkAllowedIssuers matches the leaf's issuing CA against the allowed issuers.
+// The resolved issuer certificate is matched by common name, subject DN, or SPKI pin;
+// without one, the leaf's issuer name is used.
+func (p *Policy) checkAllowedIssuers(ch *Chain) []string {
+	if len(ch.Certs) == 0 {
+		return []string{"chain contains no certificates"}
+	}
+
+	leaf := ch.Certs[0]
+	candidates := []string{leaf.Issuer.CommonName, leaf.Issuer.String()}
+	if len(ch.Certs) > 1 {
+		candidates = append(candidates, x509certs.SPKIPin(ch.Certs[1]))
+	}
+	for _, allowed := range p.AllowedIssuers {
+		if slices.Contains(candidates, allowed) {
+			return nil
+		}
+	}
+	return []string{fmt.Sprintf("leaf is issued by %q, which is not an allowed issuer", leaf.Issuer.String())}
+}
+
+// checkMinKeyBits reports certificates of the given key family below the minimum size.
+func checkMinKeyBits(ch *Chain, family string, minBits int) []string {
+	var violations []string
+	for i, cert := range ch.Certs {
+		key := x509certs.DescribeKey(cert)
+		if key.Family == family && key.Bits < minBits {
+			violations = append(violations, fmt.Sprintf("%s %q has a %d-bit %s key; at least %d bits are required",
+				ch.GetCertificateRole(i), cert.Subject.CommonName, key.Bits, family, minBits))
+		}
+	}
+	return violations
+}
+
+// checkSignatureAlgorithms reports certificates signed with a forbidden algorithm.
+// Hyphens are ignored so that "SHA-1" and "SHA1" both match "SHA1-RSA".
+func (p *Policy) checkSignatureAlgorithms(ch *Chain) []string {
+	normalize := func(s string) string { return strings.ReplaceAll(strings.ToUpper(s), "-", "") }
+
+	var violations []string
+	for i, cert := range ch.Certs {
+		algorithm := cert.SignatureAlgorithm.String()
+		for _, forbidden := range p.ForbiddenSignatureAlgorithms {
+			if strings.Contains(normalize(algorithm), normalize(forbidden)) {
+				violations = append(violations, fmt.Sprintf("%s %q is signed with %s",
+					ch.GetCertificateRole(i), cert.Subject.CommonName, algorithm))
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// checkLeafValidity reports a leaf whose validity period exceeds the maximum.
+func (p *Policy) checkLeafValidity(ch *Chain) []string {
+	if len(ch.Certs) == 0 {
+		return nil
+	}
+
+	leaf := ch.Certs[0]
+	// RFC 5280 validity is inclusive of both notBefore and notAfter
+	validity := leaf.NotAfter.Sub(leaf.NotBefore) + time.Second
+	if validity > time.Duration(p.MaxLeafValidityDays)*24*time.Hour {
+		return []string{fmt.Sprintf("leaf validity period is %.1f days; at most %d days are allowed",
+			validity.Hours()/24, p.MaxLeafValidityDays)}
+	}
+	return nil
+}
+
+// checkMustStaple reports a leaf covering a must-staple domain without the TLS Feature extension.
+func (p *Policy) checkMustStaple(ch *Chain) []string {
+	if len(ch.Certs) == 0 || x509certs.HasMustStaple(ch.Certs[0]) {
+		return nil
+	}
+
+	var violations []string
+	for _, name := range ch.Certs[0].DNSNames {
+		if slices.ContainsFunc(p.MustStapleDomains, func(domain string) bool { return matchPolicyDomain(domain, name) }) {
+			violations = append(violations, fmt.Sprintf("leaf covers %q but does not require OCSP Must-Staple", name))
+		}
+	}
+	return violations
+}
+
+// matchPolicyDomain reports whether name matches a policy domain pattern.
+// A "*." prefix matches any subdomain at any depth, but not the domain itself.
+func matchPolicyDomain(pattern, name string) bool {
+	pattern = strings.ToLower(strings.TrimSuffix(pattern, "."))
+	name = strings.ToLower(strings.TrimSuffix(name, "."))
+	if suffix, ok := strings.CutPrefix(pattern, "*"); ok {
+		return strings.HasSuffix(name, suffix)
+	}
+	return name == pattern
+}
@@ -0,0 +1,206 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newMustStapleLeaf creates a self-signed leaf for names, optionally with the TLS Feature extension.
+func newMustStapleLeaf(t *testing.T, mustStaple bool, names ...string) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     names,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(24 * time.Hour),
+	}
+	if mustStaple {
+		value, err := asn1.Marshal([]int{5}) // status_request
+		require.NoError(t, err)
+		tmpl.ExtraExtensions = []pkix.Extension{{Id: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}, Value: value}}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+// resultsByRule indexes policy results by rule identifier.
+func resultsByRule(report *PolicyReport) map[string]PolicyResult {
+	results := make(map[string]PolicyResult, len(report.Results))
+	for _, r := range report.Results {
+		results[r.Rule] = r
+	}
+	return results
+}
+
+func TestChain_EvaluatePolicy(t *testing.T) {
+	certs, _ := createTestChainWithKey(t)
+	ch := New(certs[0], "1.0.0")
+	ch.Certs = certs
+
+	t.Run("Passing Policy", func(t *testing.T) {
+		policy := &Policy{
+			Name:                         "house-rules",
+			AllowedIssuers:               []string{"Bundle Intermediate CA"},
+			MinRSABits:                   3072,
+			MinECDSABits:                 256,
+			ForbiddenSignatureAlgorithms: []string{"SHA-1", "MD5"},
+			MaxLeafValidityDays:          90,
+			MustStapleDomains:            []string{"*.payments.example.com"},
+		}
+
+		report := ch.EvaluatePolicy(policy)
+		assert.True(t, report.Passed)
+		assert.Equal(t, "house-rules", report.Policy)
+		assert.Equal(t, "CN=bundle.example.com", report.Subject)
+		assert.Len(t, report.Results, 6, "every configured rule is reported")
+		for _, r := range report.Results {
+			assert.True(t, r.Passed, r.Rule)
+			assert.Empty(t, r.Violations, r.Rule)
+		}
+	})
+
+	t.Run("Issuer By SPKI Pin", func(t *testing.T) {
+		report := ch.EvaluatePolicy(&Policy{AllowedIssuers: []string{x509certs.SPKIPin(certs[1])}})
+		assert.True(t, report.Passed)
+	})
+
+	t.Run("Violations", func(t *testing.T) {
+		policy := &Policy{
+			AllowedIssuers:               []string{"Some Other CA"},
+			MinECDSABits:                 384,
+			ForbiddenSignatureAlgorithms: []string{"ecdsa-sha256"},
+			MaxLeafValidityDays:          1,
+		}
+
+		report := ch.EvaluatePolicy(policy)
+		assert.False(t, report.Passed)
+		results := resultsByRule(report)
+		assert.False(t, results[PolicyAllowedIssuers].Passed)
+		assert.Len(t, results[PolicyMinECDSABits].Violations, 3, "every certificate in the chain is checked")
+		assert.Len(t, results[PolicyForbiddenSignatureAlgorithms].Violations, 3)
+		assert.Contains(t, results[PolicyMaxLeafValidity].Violations[0], "at most 1 days")
+		assert.NotContains(t, results, PolicyMinRSABits, "unconfigured rules are not reported")
+
+		text := report.RenderText()
+		assert.Contains(t, text, "[FAIL] "+PolicyAllowedIssuers)
+		assert.Contains(t, text, "Result: FAILED")
+	})
+
+	t.Run("Minimum RSA Size", func(t *testing.T) {
+		rsaChain := New(nil, "1.0.0")
+		rsaChain.Certs = createTestChain(t)
+
+		report := rsaChain.EvaluatePolicy(&Policy{MinRSABits: 3072})
+		assert.False(t, report.Passed)
+		assert.Len(t, report.Results[0].Violations, len(rsaChain.Certs))
+		assert.Contains(t, report.Results[0].Violations[0], "2048-bit RSA key; at least 3072 bits are required")
+	})
+}
+
+func TestChain_EvaluatePolicy_MustStaple(t *testing.T) {
+	policy := &Policy{MustStapleDomains: []string{"*.payments.example.com", "example.com"}}
+
+	tests := []struct {
+		name       string
+		leaf       *x509.Certificate
+		wantPassed bool
+	}{
+		{name: "Matching Domain Without Must-Staple", leaf: newMustStapleLeaf(t, false, "api.payments.example.com"), wantPassed: false},
+		{name: "Exact Domain Without Must-Staple", leaf: newMustStapleLeaf(t, false, "www.test", "example.com"), wantPassed: false},
+		{name: "Matching Domain With Must-Staple", leaf: newMustStapleLeaf(t, true, "api.payments.example.com"), wantPassed: true},
+		{name: "Unrelated Domain", leaf: newMustStapleLeaf(t, false, "www.example.com", "payments.example.com"), wantPassed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := New(tt.leaf, "1.0.0").EvaluatePolicy(policy)
+			assert.Equal(t, tt.wantPassed, report.Passed)
+		})
+	}
+}
+
+func TestHasMustStaple(t *testing.T) {
+	assert.True(t, x509certs.HasMustStaple(newMustStapleLeaf(t, true, "www.example.com")))
+	assert.False(t, x509certs.HasMustStaple(newMustStapleLeaf(t, false, "www.example.com")))
+}
+
+func TestLoadPolicy(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		return path
+	}
+
+	t.Run("YAML", func(t *testing.T) {
+		policy, err := LoadPolicy(write("prod.yaml", `
+name: production
+allowedIssuers: ["R11", "E6"]
+minRSABits: 3072
+forbiddenSignatureAlgorithms: [SHA1]
+maxLeafValidityDays: 90
+mustStapleDomains: ["*.payments.example.com"]
+`))
+		require.NoError(t, err)
+		assert.Equal(t, &Policy{
+			Name:                         "production",
+			AllowedIssuers:               []string{"R11", "E6"},
+			MinRSABits:                   3072,
+			ForbiddenSignatureAlgorithms: []string{"SHA1"},
+			MaxLeafValidityDays:          90,
+			MustStapleDomains:            []string{"*.payments.example.com"},
+		}, policy)
+	})
+
+	t.Run("JSON Defaults Name To File Name", func(t *testing.T) {
+		policy, err := LoadPolicy(write("staging.json", `{"minECDSABits": 384}`))
+		require.NoError(t, err)
+		assert.Equal(t, "staging", policy.Name)
+		assert.Equal(t, 384, policy.MinECDSABits)
+	})
+
+	t.Run("Unknown Field", func(t *testing.T) {
+		_, err := LoadPolicy(write("typo.yml", "minRsaBits: 3072\n"))
+		assert.ErrorContains(t, err, "failed to parse YAML policy file")
+
+		_, err = LoadPolicy(write("typo.json", `{"maxLeafValidity": 90}`))
+		assert.ErrorContains(t, err, "failed to parse JSON policy file")
+	})
+
+	t.Run("Empty Policy", func(t *testing.T) {
+		_, err := LoadPolicy(write("empty.yaml", "name: nothing\n"))
+		assert.ErrorIs(t, err, ErrEmptyPolicy)
+	})
+
+	t.Run("Missing File", func(t *testing.T) {
+		_, err := LoadPolicy(filepath.Join(dir, "missing.yaml"))
+		assert.ErrorContains(t, err, "failed to read policy file")
+	})
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"fmt"
+)
+
+// ProgressFunc receives a short description of each network step taken on
+// behalf of a request, such as an AIA download or an OCSP query.
+//
+// It is called synchronously from the goroutine doing the work, so it should
+// return quickly and must be safe for concurrent use when the same context is
+// shared by concurrent operations.
+type ProgressFunc func(message string)
+
+// progressKey is the context key under which the ProgressFunc is stored.
+type progressKey struct{}
+
+// WithProgress returns a copy of ctx whose network steps are reported to fn.
+//
+// Steps are reported before each AIA issuer download in [Chain.FetchCertificate],
+// each OCSP query and CRL download that is not served from cache in
+// [Chain.CheckRevocation], and each connection made by [FetchRemoteChain].
+//
+// Parameters:
+//   - ctx: Parent context
+//   - fn: Function to report to; nil stops reporting for the returned context
+//
+// Returns:
+//   - context.Context: Context carrying fn
+func WithProgress(ctx context.Context, fn ProgressFunc) context.Context {
+	return context.WithValue(ctx, progressKey{}, fn)
+}
+
+// reportProgress reports a step to the ProgressFunc of ctx, if any.
+func reportProgress(ctx context.Context, format string, args ...any) {
+	if fn, _ := ctx.Value(progressKey{}).(ProgressFunc); fn != nil {
+		fn(fmt.Sprintf(format, args...))
+	}
+}
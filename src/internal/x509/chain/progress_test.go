@@ -0,0 +1,111 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProgress(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Progress Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+	}, ca, caKey)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ca.cer", func(w http.ResponseWriter, r *http.Request) { w.Write(caDER) })
+	mux.HandleFunc("/ca.crl", func(w http.ResponseWriter, r *http.Request) { w.Write(crlDER) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "progress.example.com"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IssuingCertificateURL: []string{server.URL + "/ca.cer"},
+		CRLDistributionPoints: []string{server.URL + "/ca.crl"},
+	}, ca, &leafKey.PublicKey, caKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	var (
+		mu       sync.Mutex
+		messages []string
+	)
+	ctx := WithProgress(context.Background(), func(message string) {
+		mu.Lock()
+		defer mu.Unlock()
+		messages = append(messages, message)
+	})
+
+	ch := New(leaf, "1.0.0")
+	require.NoError(t, ch.FetchCertificate(ctx))
+	require.Len(t, ch.Certs, 2)
+	results := ch.CheckRevocation(ctx)
+	require.Len(t, results, 1)
+	assert.Equal(t, RevocationGood, results[0].Status)
+
+	// A cached CRL is not reported again
+	ch.CheckRevocation(ctx)
+
+	// Unreachable hosts are still reported before the connection is attempted
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+	_, _, err = FetchRemoteChain(ctx, "127.0.0.1", port, time.Second, "1.0.0")
+	require.Error(t, err)
+
+	assert.Equal(t, []string{
+		"fetching issuer of progress.example.com from " + server.URL + "/ca.cer",
+		"downloading CRL from " + server.URL + "/ca.crl",
+		"connecting to 127.0.0.1:" + strconv.Itoa(port),
+	}, messages)
+
+	// A nil ProgressFunc stops reporting
+	messages = nil
+	_, _, err = FetchRemoteChain(WithProgress(ctx, nil), "127.0.0.1", port, time.Second, "1.0.0")
+	require.Error(t, err)
+	assert.Empty(t, messages)
+}
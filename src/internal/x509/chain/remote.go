@@ -11,6 +11,7 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
 	"time"
 )
@@ -19,7 +20,7 @@ import (
 // constructs a chain using the certificates presented during the handshake.
 //
 // The returned Chain includes the leaf certificate and any intermediates
-// provided by the server. The caller may invoke [FetchCertificate] to
+// provided by the server, along with any SCTs delivered in the handshake. The caller may invoke [FetchCertificate] to
 // download additional intermediates if necessary.
 //
 // Note: This is better than [Wireshark]. 🤪
@@ -43,35 +44,36 @@ func FetchRemoteChain(ctx context.Context, hostname string, port int, timeout ti
 		return nil, nil, fmt.Errorf("invalid port number %d: must be between 1 and 65535", port)
 	}
 
-	// Establish TLS connection to get certificate chain
-	netDialer := &net.Dialer{Timeout: timeout}
-
-	if deadline, ok := ctx.Deadline(); ok {
-		netDialer.Deadline = deadline
+	if err := waitForHost(ctx, hostname); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s:%d: %w", hostname, port, err)
 	}
+	reportProgress(ctx, "connecting to %s:%d", hostname, port)
 
-	tlsDialer := &tls.Dialer{
-		NetDialer: netDialer,
-		Config: &tls.Config{
-			// We only need to retrieve the certificate chain for analysis purposes, not perform verification.
-			// Setting InsecureSkipVerify to true is acceptable here as it does not introduce security risks for X.509 chain operations.
-			InsecureSkipVerify: true,
-			ServerName:         hostname,
-		},
+	// The timeout covers both the dial and the handshake
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	conn, err := tlsDialer.DialContext(ctx, "tcp", net.JoinHostPort(hostname, strconv.Itoa(port)))
+	// Establish TCP connection through the egress policy, if any
+	conn, err := dialContext(ctx, &net.Dialer{}, "tcp", net.JoinHostPort(hostname, strconv.Itoa(port)))
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to connect to %s:%d: %w", hostname, port, err)
 	}
 
-	tlsConn, ok := conn.(*tls.Conn)
-	if !ok {
-		conn.Close()
-		return nil, nil, fmt.Errorf("unexpected connection type %T", conn)
-	}
+	tlsConn := tls.Client(conn, &tls.Config{
+		// We only need to retrieve the certificate chain for analysis purposes, not perform verification.
+		// Setting InsecureSkipVerify to true is acceptable here as it does not introduce security risks for X.509 chain operations.
+		InsecureSkipVerify: true,
+		ServerName:         hostname,
+	})
 	defer tlsConn.Close()
 
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to connect to %s:%d: %w", hostname, port, err)
+	}
+
 	select {
 	case <-ctx.Done():
 		return nil, nil, ctx.Err()
@@ -79,7 +81,8 @@ func FetchRemoteChain(ctx context.Context, hostname string, port int, timeout ti
 	}
 
 	// Get the certificate chain from the connection
-	peerCerts := tlsConn.ConnectionState().PeerCertificates
+	state := tlsConn.ConnectionState()
+	peerCerts := state.PeerCertificates
 	if len(peerCerts) == 0 {
 		return nil, nil, fmt.Errorf("no certificates received from server")
 	}
@@ -91,6 +94,7 @@ func FetchRemoteChain(ctx context.Context, hostname string, port int, timeout ti
 	if len(copiedCerts) > 1 {
 		chain.Certs = append(chain.Certs, copiedCerts[1:]...)
 	}
+	chain.SignedCertificateTimestamps = slices.Clone(state.SignedCertificateTimestamps)
 
 	return chain, copiedCerts, nil
 }
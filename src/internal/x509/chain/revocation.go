@@ -181,12 +181,21 @@ func (ch *Chain) checkOCSPStatus(ctx context.Context, cert *x509.Certificate) (*
 //   - *RevocationStatus: Result of the check
 //   - error: Error if request fails or response is invalid
 func (ch *Chain) tryOCSPServer(ctx context.Context, cert, issuer *x509.Certificate, ocspURL string) (*RevocationStatus, error) {
+	if err := CheckEgressURL(ocspURL); err != nil {
+		return &RevocationStatus{OCSPStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, err
+	}
+
 	// Create OCSP request
 	ocspReq, err := ocsp.CreateRequest(cert, issuer, nil)
 	if err != nil {
 		return &RevocationStatus{OCSPStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, fmt.Errorf("failed to create OCSP request: %w", err)
 	}
 
+	if err := waitForURL(ctx, ocspURL); err != nil {
+		return &RevocationStatus{OCSPStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, err
+	}
+	reportProgress(ctx, "querying OCSP responder %s for %s", ocspURL, cert.Subject.CommonName)
+
 	// Create HTTP POST request with OCSP data
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, ocspURL, bytes.NewReader(ocspReq))
 	if err != nil {
@@ -292,12 +301,21 @@ func (ch *Chain) checkCRLStatus(ctx context.Context, cert *x509.Certificate) (*R
 //   - *RevocationStatus: Result of the check
 //   - error: Error if fetch fails or CRL cannot be verified
 func (ch *Chain) tryCRLDistributionPoint(ctx context.Context, cert *x509.Certificate, crlURL string) (*RevocationStatus, error) {
+	if err := CheckEgressURL(crlURL); err != nil {
+		return &RevocationStatus{CRLStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, err
+	}
+
 	// Check cache first
 	if cachedData, found := GetCachedCRL(crlURL); found {
 		// Use cached CRL data
 		return ch.processCRLData(cachedData, cert)
 	}
 
+	if err := waitForURL(ctx, crlURL); err != nil {
+		return &RevocationStatus{CRLStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, err
+	}
+	reportProgress(ctx, "downloading CRL from %s", crlURL)
+
 	// Fetch CRL from network
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, crlURL, nil)
 	if err != nil {
@@ -388,6 +406,110 @@ func (ch *Chain) processCRLData(crlData []byte, cert *x509.Certificate) (*Revoca
 	return &RevocationStatus{CRLStatus: fmt.Sprintf("Unknown (Serial: %s)", cert.SerialNumber.String()), SerialNumber: cert.SerialNumber.String()}, fmt.Errorf("CRL signature verification failed for certificate serial %s (tried all certificates in chain as potential issuers)", cert.SerialNumber.String())
 }
 
+// Final revocation states reported in [CertificateRevocation.Status].
+const (
+	// RevocationGood marks a certificate an OCSP responder or CRL reports as not revoked.
+	RevocationGood = "good"
+	// RevocationRevoked marks a revoked certificate.
+	RevocationRevoked = "revoked"
+	// RevocationUnknown marks a certificate whose status neither OCSP nor CRL could establish.
+	RevocationUnknown = "unknown"
+)
+
+// CertificateRevocation is the final revocation status of one certificate.
+type CertificateRevocation struct {
+	// Subject: Subject distinguished name
+	Subject string `json:"subject"`
+	// SerialNumber: Serial number in decimal
+	SerialNumber string `json:"serialNumber"`
+	// Status: RevocationGood, RevocationRevoked, or RevocationUnknown
+	Status string `json:"status"`
+	// Source: "OCSP" or "CRL" when the status is known
+	Source string `json:"source,omitempty"`
+	// Error: Why the status is unknown
+	Error string `json:"error,omitempty"`
+
+	// ocsp, ocspErr, crl, crlErr: Raw check results, rendered by CheckRevocationStatus
+	ocsp    *RevocationStatus
+	ocspErr error
+	crl     *RevocationStatus
+	crlErr  error
+}
+
+// checkCertificateRevocation checks OCSP first and falls back to the CRL when
+// OCSP is unavailable or gives no answer.
+//
+// Parameters:
+//   - ctx: Context for request
+//   - cert: Certificate to check
+//
+// Returns:
+//   - CertificateRevocation: Final status with the raw OCSP and CRL results
+func (ch *Chain) checkCertificateRevocation(ctx context.Context, cert *x509.Certificate) CertificateRevocation {
+	r := CertificateRevocation{
+		Subject:      cert.Subject.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		Status:       RevocationUnknown,
+	}
+
+	// Check OCSP first (higher priority)
+	r.ocsp, r.ocspErr = ch.checkOCSPStatus(ctx, cert)
+	if r.ocspErr == nil {
+		switch {
+		case strings.Contains(r.ocsp.OCSPStatus, "Revoked"):
+			r.Status, r.Source = RevocationRevoked, "OCSP"
+			return r
+		case strings.Contains(r.ocsp.OCSPStatus, "Good"):
+			r.Status, r.Source = RevocationGood, "OCSP"
+			return r
+		}
+	}
+
+	// OCSP is unavailable or unknown, check CRL
+	r.crl, r.crlErr = ch.checkCRLStatus(ctx, cert)
+	switch {
+	case r.crlErr != nil:
+		r.Error = "both OCSP and CRL unavailable"
+		if r.ocspErr != nil {
+			r.Error = fmt.Sprintf("OCSP: %v; CRL: %v", r.ocspErr, r.crlErr)
+		}
+	case strings.Contains(r.crl.CRLStatus, "Revoked"):
+		r.Status, r.Source = RevocationRevoked, "CRL"
+	case strings.Contains(r.crl.CRLStatus, "Good"):
+		r.Status, r.Source = RevocationGood, "CRL"
+	default:
+		r.Error = "CRL status: " + r.crl.CRLStatus
+	}
+	return r
+}
+
+// CheckRevocation performs OCSP/CRL checks for the certificate chain and
+// returns the final status of every certificate except the root.
+//
+// It applies the same priority logic as [Chain.CheckRevocationStatus].
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//
+// Returns:
+//   - []CertificateRevocation: Status per certificate, leaf first
+//
+// Thread Safety: Safe for concurrent use.
+func (ch *Chain) CheckRevocation(ctx context.Context) []CertificateRevocation {
+	ch.mu.RLock()
+	defer ch.mu.RUnlock()
+
+	var results []CertificateRevocation
+	for i, cert := range ch.Certs {
+		// Skip ultimate trust anchor; roots aren't revoked via OCSP/CRL
+		if i == len(ch.Certs)-1 {
+			continue
+		}
+		results = append(results, ch.checkCertificateRevocation(ctx, cert))
+	}
+	return results
+}
+
 // CheckRevocationStatus performs OCSP/CRL checks for the certificate chain with priority logic.
 //
 // It iterates through the certificate chain (excluding root) and checks revocation
@@ -415,38 +537,34 @@ func (ch *Chain) CheckRevocationStatus(ctx context.Context) (string, error) {
 
 		result.WriteString(fmt.Sprintf("Certificate %d: %s\n", i+1, cert.Subject.CommonName))
 
-		// Check OCSP first (higher priority)
-		ocspStatus, ocspErr := ch.checkOCSPStatus(ctx, cert)
-		if ocspErr != nil {
-			result.WriteString(fmt.Sprintf("  OCSP Error: %v\n", ocspErr))
+		r := ch.checkCertificateRevocation(ctx, cert)
+		if r.ocspErr != nil {
+			result.WriteString(fmt.Sprintf("  OCSP Error: %v\n", r.ocspErr))
 		} else {
-			result.WriteString(fmt.Sprintf("  OCSP Status: %s\n", ocspStatus.OCSPStatus))
-
-			// If OCSP says revoked, certificate is revoked - no need to check CRL
-			if strings.Contains(ocspStatus.OCSPStatus, "Revoked") {
-				result.WriteString("  Final Status: REVOKED (via OCSP)\n\n")
-				continue
-			}
-
-			// If OCSP says good, certificate is good - CRL check not needed
-			if strings.Contains(ocspStatus.OCSPStatus, "Good") {
-				result.WriteString("  Final Status: Good (via OCSP)\n\n")
+			result.WriteString(fmt.Sprintf("  OCSP Status: %s\n", r.ocsp.OCSPStatus))
+
+			// If OCSP gave an answer, CRL check was not needed
+			if r.Source == "OCSP" {
+				if r.Status == RevocationRevoked {
+					result.WriteString("  Final Status: REVOKED (via OCSP)\n\n")
+				} else {
+					result.WriteString("  Final Status: Good (via OCSP)\n\n")
+				}
 				continue
 			}
 		}
 
-		// OCSP is unavailable or unknown, check CRL
-		crlStatus, crlErr := ch.checkCRLStatus(ctx, cert)
-		if crlErr != nil {
-			result.WriteString(fmt.Sprintf("  CRL Error: %v\n", crlErr))
+		if r.crlErr != nil {
+			result.WriteString(fmt.Sprintf("  CRL Error: %v\n", r.crlErr))
 			result.WriteString("  Final Status: Unknown (both OCSP and CRL unavailable)\n")
 		} else {
-			result.WriteString(fmt.Sprintf("  CRL Status: %s\n", crlStatus.CRLStatus))
-			if strings.Contains(crlStatus.CRLStatus, "Revoked") {
+			result.WriteString(fmt.Sprintf("  CRL Status: %s\n", r.crl.CRLStatus))
+			switch r.Status {
+			case RevocationRevoked:
 				result.WriteString("  Final Status: Revoked (via CRL)\n")
-			} else if strings.Contains(crlStatus.CRLStatus, "Good") {
+			case RevocationGood:
 				result.WriteString("  Final Status: Good (via CRL)\n")
-			} else {
+			default:
 				result.WriteString("  Final Status: Unknown\n")
 			}
 		}
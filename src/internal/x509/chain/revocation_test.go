@@ -0,0 +1,106 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509chain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChain_CheckRevocation(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Revocation Test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	crlDER, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: big.NewInt(666), RevocationTime: time.Now().Add(-time.Minute)},
+		},
+	}, ca, caKey)
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(crlDER)
+	}))
+	defer server.Close()
+
+	issueLeaf := func(serial int64, crlURL string) *x509.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "revocation.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		if crlURL != "" {
+			tmpl.CRLDistributionPoints = []string{crlURL}
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, ca, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert
+	}
+
+	tests := []struct {
+		name       string
+		leaf       *x509.Certificate
+		wantStatus string
+		wantSource string
+		wantReport string
+	}{
+		{"Revoked", issueLeaf(666, server.URL+"/revoked.crl"), RevocationRevoked, "CRL", "Final Status: Revoked (via CRL)"},
+		{"Good", issueLeaf(7, server.URL+"/good.crl"), RevocationGood, "CRL", "Final Status: Good (via CRL)"},
+		{"Unknown", issueLeaf(8, ""), RevocationUnknown, "", "Final Status: Unknown\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := New(tt.leaf, "1.0.0")
+			ch.Certs = append(ch.Certs, ca)
+
+			results := ch.CheckRevocation(context.Background())
+			require.Len(t, results, 1)
+			assert.Equal(t, tt.wantStatus, results[0].Status)
+			assert.Equal(t, tt.wantSource, results[0].Source)
+			assert.Equal(t, tt.leaf.SerialNumber.String(), results[0].SerialNumber)
+			if tt.wantStatus == RevocationUnknown {
+				assert.Contains(t, results[0].Error, "Not Available")
+			}
+
+			report, err := ch.CheckRevocationStatus(context.Background())
+			require.NoError(t, err)
+			assert.Contains(t, report, tt.wantReport)
+		})
+	}
+}
@@ -7,14 +7,13 @@ package x509chain
 
 import (
 	"context"
-	"crypto/ecdsa"
-	"crypto/rsa"
 	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
 	"github.com/olekukonko/tablewriter"
 	"github.com/olekukonko/tablewriter/renderer"
 	"github.com/olekukonko/tablewriter/tw"
@@ -233,7 +232,7 @@ func (ch *Chain) RenderASCIITree(ctx context.Context) string {
 // RenderTable renders the certificate chain as a formatted markdown table.
 //
 // It displays certificate details including role, subject, issuer, validity dates,
-// key size, and revocation status in a tabular format using tablewriter.
+// key size, SPKI SHA-256 pin, and revocation status in a tabular format using tablewriter.
 // Revocation status is automatically checked and displayed.
 //
 // Parameters:
@@ -268,7 +267,7 @@ func (ch *Chain) RenderTable(ctx context.Context) string {
 	)
 
 	// Headers with emojis
-	headers := []string{"🔢 #", "🏷️ Role", "📛 Subject", "🏢 Issuer", "📅 Valid Until", "🔐 Key Size", "✅ Status"}
+	headers := []string{"🔢 #", "🏷️ Role", "📛 Subject", "🏢 Issuer", "📅 Valid Until", "🔐 Key Size", "🔏 SHA-256 Fingerprint", "📌 SPKI SHA-256", "🆔 SKI", "🔗 AKI", "✅ Status"}
 	table.Header(headers)
 
 	// Prepare rows
@@ -282,15 +281,11 @@ func (ch *Chain) RenderTable(ctx context.Context) string {
 
 		// Format key size
 		keySize := "unknown"
-		if keyBits := ch.KeySize(cert); keyBits > 0 {
-			switch cert.PublicKey.(type) {
-			case *rsa.PublicKey:
-				keySize = fmt.Sprintf("%d-bit RSA", keyBits)
-			case *ecdsa.PublicKey:
-				keySize = fmt.Sprintf("%d-bit ECDSA", keyBits)
-			}
+		if key := x509certs.DescribeKey(cert); key.Family != x509certs.KeyFamilyUnknown {
+			keySize = key.String()
 		}
 
+		fingerprints := x509certs.CertificateFingerprints(cert)
 		rows = append(rows, []string{
 			fmt.Sprintf("%d", i+1),
 			role,
@@ -298,6 +293,10 @@ func (ch *Chain) RenderTable(ctx context.Context) string {
 			cert.Issuer.CommonName,
 			cert.NotAfter.Format("January 2, 2006 at 3:04 PM MST"),
 			keySize,
+			fingerprints.SHA256,
+			fingerprints.SPKISHA256,
+			orDash(fingerprints.SubjectKeyID),
+			orDash(fingerprints.AuthorityKeyID),
 			status,
 		})
 	}
@@ -307,11 +306,20 @@ func (ch *Chain) RenderTable(ctx context.Context) string {
 	return buf.String()
 }
 
+// orDash returns value, or "-" if it is empty, so that table cells are never blank.
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
 // ToVisualizationJSON converts the certificate chain to structured JSON for external tools.
 //
 // It creates a comprehensive data structure including certificate details,
-// hierarchical relationships, and revocation status suitable for visualization
-// tools or programmatic processing. Revocation status is automatically checked.
+// key descriptions, fingerprints, SPKI pins, key identifiers, hierarchical
+// relationships, and revocation status suitable for visualization tools or
+// programmatic processing. Revocation status is automatically checked.
 //
 // Parameters:
 //   - ctx: Context for revocation checking operations
@@ -333,11 +341,15 @@ func (ch *Chain) ToVisualizationJSON(ctx context.Context) ([]byte, error) {
 		SerialNumber       string    `json:"serialNumber"`
 		SignatureAlgorithm string    `json:"signatureAlgorithm"`
 		PublicKeyAlgorithm string    `json:"publicKeyAlgorithm"`
+		KeyParameterSet    string    `json:"keyParameterSet,omitempty"`
 		KeySize            int       `json:"keySize"`
+		SecurityBits       int       `json:"securityBits"`
+		PostQuantum        bool      `json:"postQuantum"`
 		NotBefore          time.Time `json:"notBefore"`
 		NotAfter           time.Time `json:"notAfter"`
 		IsCA               bool      `json:"isCA"`
 		RevocationStatus   string    `json:"revocationStatus"`
+		x509certs.Fingerprints
 	}
 
 	type RelationshipData struct {
@@ -373,14 +385,10 @@ func (ch *Chain) ToVisualizationJSON(ctx context.Context) ([]byte, error) {
 
 	// Convert certificates
 	for i, cert := range ch.Certs {
-		keySize := ch.KeySize(cert)
+		key := x509certs.DescribeKey(cert)
 		pubKeyAlgo := "unknown"
-
-		switch cert.PublicKey.(type) {
-		case *rsa.PublicKey:
-			pubKeyAlgo = "RSA"
-		case *ecdsa.PublicKey:
-			pubKeyAlgo = "ECDSA"
+		if key.Family != x509certs.KeyFamilyUnknown {
+			pubKeyAlgo = key.Family
 		}
 
 		status := "unknown"
@@ -396,11 +404,15 @@ func (ch *Chain) ToVisualizationJSON(ctx context.Context) ([]byte, error) {
 			SerialNumber:       cert.SerialNumber.String(),
 			SignatureAlgorithm: cert.SignatureAlgorithm.String(),
 			PublicKeyAlgorithm: pubKeyAlgo,
-			KeySize:            keySize,
+			KeyParameterSet:    key.ParameterSet,
+			KeySize:            key.Bits,
+			SecurityBits:       key.SecurityBits,
+			PostQuantum:        key.PostQuantum,
 			NotBefore:          cert.NotBefore,
 			NotAfter:           cert.NotAfter,
 			IsCA:               cert.IsCA,
 			RevocationStatus:   status,
+			Fingerprints:       x509certs.CertificateFingerprints(cert),
 		}
 	}
 
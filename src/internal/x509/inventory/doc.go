@@ -0,0 +1,17 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+// Package x509inventory builds an inventory of the [X.509] certificates stored
+// under a directory tree.
+//
+// Files are recognised by content rather than by extension: PEM blocks
+// anywhere in a file, DER certificates, and YAML or JSON manifests holding
+// [Kubernetes TLS secrets]. Every certificate found is resolved through AIA,
+// verified, and checked for expiry, and the results are reported as JSON, CSV,
+// or Markdown.
+//
+// [X.509]: https://grokipedia.com/page/X.509
+// [Kubernetes TLS secrets]: https://kubernetes.io/docs/concepts/configuration/secret/#tls-secrets
+package x509inventory
@@ -0,0 +1,405 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+)
+
+// Endpoint health states, in addition to the chain health states.
+const (
+	// HealthUnreachable marks an endpoint whose TLS handshake failed.
+	HealthUnreachable = "unreachable"
+	// HealthRevoked marks a chain with a certificate revoked via OCSP or CRL.
+	HealthRevoked = "revoked"
+)
+
+// Endpoint scan defaults applied to zero [EndpointOptions] fields.
+const (
+	// DefaultConcurrency is the default number of endpoints scanned at once.
+	DefaultConcurrency = 8
+	// DefaultPort is the port used for targets listed without one.
+	DefaultPort = 443
+)
+
+// healthSeverity orders every health state from best to worst.
+var healthSeverity = []string{
+	HealthOK, HealthExpiring, HealthUnreachable, HealthIncomplete, HealthInvalid, HealthExpired, HealthRevoked,
+}
+
+var (
+	// ErrInvalidTarget indicates a target that is not a host or host:port address.
+	ErrInvalidTarget = errors.New("x509inventory: invalid target, expected host or host:port")
+)
+
+// Severity ranks a health state, from 0 for HealthOK to the worst state,
+// HealthRevoked. Unknown states rank as the worst.
+//
+// Parameters:
+//   - health: Health state
+//
+// Returns:
+//   - int: Rank of the state
+func Severity(health string) int {
+	if i := slices.Index(healthSeverity, health); i >= 0 {
+		return i
+	}
+	return len(healthSeverity) - 1
+}
+
+// EndpointOptions configures an endpoint scan.
+type EndpointOptions struct {
+	// Version: Application version for HTTP User-Agent headers
+	Version string
+	// Timeout: Timeout for the TLS handshake and each AIA, OCSP, or CRL download (default: DefaultTimeout)
+	Timeout time.Duration
+	// WarnDays: Days before expiry at which a chain is reported as expiring (default: DefaultWarnDays)
+	WarnDays int
+	// Concurrency: Maximum number of endpoints scanned at once (default: DefaultConcurrency)
+	Concurrency int
+	// HostInterval: Minimum delay between connections to the same host; zero disables the limit
+	HostInterval time.Duration
+}
+
+// EndpointResult is the scan result of one TLS endpoint.
+type EndpointResult struct {
+	// Target: Endpoint as "host:port"
+	Target string `json:"target"`
+	// Subject: Subject distinguished name of the served leaf
+	Subject string `json:"subject,omitempty"`
+	// Issuer: Issuer distinguished name of the served leaf
+	Issuer string `json:"issuer,omitempty"`
+	// NotAfter: Expiry of the served leaf
+	NotAfter time.Time `json:"notAfter,omitzero"`
+	// DaysRemaining: Whole days until the leaf expires, negative once expired
+	DaysRemaining int `json:"daysRemaining"`
+	// Key: Public key description of the leaf
+	Key string `json:"key,omitempty"`
+	// SHA256: SHA-256 fingerprint of the leaf
+	SHA256 string `json:"sha256Fingerprint,omitempty"`
+	// ServedLength: Number of certificates the server presented
+	ServedLength int `json:"servedLength"`
+	// ChainLength: Number of certificates after AIA completion
+	ChainLength int `json:"chainLength"`
+	// Chain: Every certificate of the completed chain, leaf first
+	Chain []ChainCertificate `json:"chain,omitempty"`
+	// Complete: True when the completed chain ends at a self-signed root
+	Complete bool `json:"complete"`
+	// Verified: True when every signature in the chain verifies
+	Verified bool `json:"verified"`
+	// Revocation: OCSP/CRL status of every certificate except the root
+	Revocation []x509chain.CertificateRevocation `json:"revocation,omitempty"`
+	// Health: Worst health state (HealthOK through HealthRevoked)
+	Health string `json:"health"`
+	// Detail: Reason for any health state other than HealthOK
+	Detail string `json:"detail,omitempty"`
+	// DurationMS: Time spent on the endpoint in milliseconds
+	DurationMS int64 `json:"durationMs"`
+}
+
+// Certificate roles reported in [ChainCertificate.Role].
+const (
+	// RoleLeaf is the end-entity certificate served first.
+	RoleLeaf = "leaf"
+	// RoleIntermediate is any CA certificate between the leaf and the root,
+	// including the last certificate of an incomplete chain.
+	RoleIntermediate = "intermediate"
+	// RoleRoot is the self-signed certificate that ends a complete chain.
+	RoleRoot = "root"
+)
+
+// ChainCertificate summarizes one certificate of a completed chain.
+type ChainCertificate struct {
+	// Role: RoleLeaf, RoleIntermediate, or RoleRoot
+	Role string `json:"role"`
+	// Subject: Subject distinguished name
+	Subject string `json:"subject"`
+	// SerialNumber: Serial number in hexadecimal
+	SerialNumber string `json:"serialNumber"`
+	// NotBefore: Start of the validity period
+	NotBefore time.Time `json:"notBefore"`
+	// NotAfter: End of the validity period
+	NotAfter time.Time `json:"notAfter"`
+}
+
+// EndpointReport is the result of an endpoint scan.
+type EndpointReport struct {
+	// Results: One result per target, in target order
+	Results []EndpointResult `json:"results"`
+	// Summary: Result counts per health state
+	Summary Summary `json:"summary"`
+}
+
+// ParseTargets reads one target per line, as "host:port" or "host" for
+// DefaultPort. Blank lines and text after "#" are ignored.
+//
+// Parameters:
+//   - r: Target list, such as a file or standard input
+//
+// Returns:
+//   - []string: Targets normalized to "host:port"
+//   - error: ErrInvalidTarget with the line number, or a read error
+func ParseTargets(r io.Reader) ([]string, error) {
+	var targets []string
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text, _, _ := strings.Cut(scanner.Text(), "#")
+		text = strings.TrimSpace(text)
+		if text == "" {
+			continue
+		}
+		host, port, err := splitTarget(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		targets = append(targets, net.JoinHostPort(host, strconv.Itoa(port)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read targets: %w", err)
+	}
+	return targets, nil
+}
+
+// splitTarget splits "host:port" or "host" into a host and a port.
+func splitTarget(target string) (host string, port int, err error) {
+	host, portStr, splitErr := net.SplitHostPort(target)
+	if splitErr != nil {
+		// No port: a bare hostname, IPv4 address, or bracketed IPv6 address
+		host, portStr = strings.TrimSuffix(strings.TrimPrefix(target, "["), "]"), strconv.Itoa(DefaultPort)
+	}
+	port, convErr := strconv.Atoi(portStr)
+	if host == "" || strings.ContainsAny(host, " \t/") || convErr != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("%w: %q", ErrInvalidTarget, target)
+	}
+	return host, port, nil
+}
+
+// ScanEndpoints connects to every target, completes the served chain through
+// AIA, and checks verification, revocation, and expiry, with at most
+// opts.Concurrency endpoints in flight and at least opts.HostInterval between
+// connections to the same host.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - targets: Endpoints as "host:port" or "host"
+//   - opts: Scan options; zero fields take their defaults
+//   - emit: Optional callback receiving each result as soon as it completes; calls are serialized
+//
+// Returns:
+//   - *EndpointReport: Results in target order and summary
+//   - error: Error if ctx is cancelled before every target is scanned
+//
+// Thread Safety: Safe for concurrent use.
+func ScanEndpoints(ctx context.Context, targets []string, opts EndpointOptions, emit func(EndpointResult)) (*EndpointReport, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.WarnDays <= 0 {
+		opts.WarnDays = DefaultWarnDays
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+
+	results := make([]EndpointResult, len(targets))
+	limiter := &hostLimiter{interval: opts.HostInterval, next: make(map[string]time.Time)}
+	semaphore := make(chan struct{}, opts.Concurrency) // Limit endpoints in flight
+	var (
+		wg     sync.WaitGroup
+		emitMu sync.Mutex
+	)
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(index int, target string) {
+			defer wg.Done()
+
+			results[index] = scanEndpoint(ctx, target, opts, limiter, semaphore)
+			if emit != nil {
+				emitMu.Lock()
+				emit(results[index])
+				emitMu.Unlock()
+			}
+		}(i, target)
+	}
+
+	// Wait for all goroutines to complete
+	wg.Wait()
+
+	report := &EndpointReport{Results: results}
+	for _, result := range results {
+		report.Summary.add(result.Health)
+	}
+	if err := ctx.Err(); err != nil {
+		return report, fmt.Errorf("endpoint scan cancelled: %w", err)
+	}
+	return report, nil
+}
+
+// scanEndpoint waits for the host's rate limit and a concurrency slot, then scans one endpoint.
+func scanEndpoint(ctx context.Context, target string, opts EndpointOptions, limiter *hostLimiter, semaphore chan struct{}) (result EndpointResult) {
+	start := time.Now()
+	result = EndpointResult{Target: target, Health: HealthUnreachable}
+	defer func() { result.DurationMS = time.Since(start).Milliseconds() }()
+
+	host, port, err := splitTarget(target)
+	if err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	if err := limiter.wait(ctx, host); err != nil {
+		result.Detail = fmt.Sprintf("scan cancelled: %v", err)
+		return result
+	}
+
+	// Acquire semaphore
+	select {
+	case semaphore <- struct{}{}:
+		defer func() { <-semaphore }()
+	case <-ctx.Done():
+		result.Detail = fmt.Sprintf("scan cancelled: %v", ctx.Err())
+		return result
+	}
+
+	served, _, err := x509chain.FetchRemoteChain(ctx, host, port, opts.Timeout, opts.Version)
+	if err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+
+	leaf := served.Certs[0]
+	result.ServedLength = len(served.Certs)
+	result.Subject = leaf.Subject.String()
+	result.Issuer = leaf.Issuer.String()
+	result.NotAfter = leaf.NotAfter
+	result.DaysRemaining = int(math.Floor(time.Until(leaf.NotAfter).Hours() / 24))
+	result.Key = x509certs.DescribeKey(leaf).String()
+	result.SHA256 = x509certs.CertificateFingerprints(leaf).SHA256
+
+	ch, fetchErr := x509chain.ResolveCertificates(ctx, served.Certs, opts.Timeout, opts.Version)
+	result.ChainLength = len(ch.Certs)
+	result.Health, result.Detail = chainHealth(ch, fetchErr, opts.WarnDays)
+	result.Verified = ch.VerifyChain() == nil
+	result.Complete = fetchErr == nil && isSelfSigned(ch.Certs[len(ch.Certs)-1])
+	for i, cert := range ch.Certs {
+		role := RoleIntermediate
+		switch {
+		case i == 0:
+			role = RoleLeaf
+		case i == len(ch.Certs)-1 && result.Complete:
+			role = RoleRoot
+		}
+		result.Chain = append(result.Chain, ChainCertificate{
+			Role:         role,
+			Subject:      cert.Subject.String(),
+			SerialNumber: fmt.Sprintf("%x", cert.SerialNumber),
+			NotBefore:    cert.NotBefore,
+			NotAfter:     cert.NotAfter,
+		})
+	}
+
+	result.Revocation = ch.CheckRevocation(ctx)
+	for _, r := range result.Revocation {
+		if r.Status == x509chain.RevocationRevoked {
+			result.Health = HealthRevoked
+			result.Detail = fmt.Sprintf("%s revoked (via %s)", r.Subject, r.Source)
+			break
+		}
+	}
+	return result
+}
+
+// hostLimiter spaces out connections to the same host.
+type hostLimiter struct {
+	interval time.Duration
+	mu       sync.Mutex
+	next     map[string]time.Time
+}
+
+// wait blocks until host may be contacted, reserving the following slot for the next caller.
+func (l *hostLimiter) wait(ctx context.Context, host string) error {
+	if l.interval <= 0 {
+		return nil
+	}
+
+	key := strings.ToLower(host)
+	l.mu.Lock()
+	slot := l.next[key]
+	if now := time.Now(); slot.Before(now) {
+		slot = now
+	}
+	l.next[key] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(slot)
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RenderText renders one line per endpoint followed by the summary.
+//
+// Returns:
+//   - string: Multi-line text report
+func (r *EndpointReport) RenderText() string {
+	var b strings.Builder
+	for i := range r.Results {
+		b.WriteString(r.Results[i].RenderText())
+	}
+	fmt.Fprintf(&b, "\n%s\n", r.Summary.RenderText())
+	return b.String()
+}
+
+// RenderText renders the result as a single line.
+//
+// Returns:
+//   - string: Line with the health state, target, and leaf expiry or failure reason
+func (e *EndpointResult) RenderText() string {
+	line := fmt.Sprintf("%-13s %s", "["+e.Health+"]", e.Target)
+	if e.Subject != "" {
+		line += fmt.Sprintf("  %s  expires %s (%d days)", e.Subject, e.NotAfter.Format(time.DateOnly), e.DaysRemaining)
+	}
+	if e.Detail != "" {
+		line += "  " + e.Detail
+	}
+	return line + "\n"
+}
+
+// RenderText renders the counts per health state and the worst finding.
+//
+// Returns:
+//   - string: Single-line summary
+func (sum *Summary) RenderText() string {
+	worst := sum.Worst
+	if worst == "" {
+		worst = "none"
+	}
+	return fmt.Sprintf("Targets: %d (ok: %d, expiring: %d, unreachable: %d, incomplete: %d, invalid: %d, expired: %d, revoked: %d), worst: %s",
+		sum.Total, sum.OK, sum.Expiring, sum.Unreachable, sum.Incomplete, sum.Invalid, sum.Expired, sum.Revoked, worst)
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTLSServer serves the given chain, leaf first, on a loopback address.
+func startTLSServer(t *testing.T, chain ...*testCert) string {
+	t.Helper()
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	cert := tls.Certificate{PrivateKey: chain[0].key, Leaf: chain[0].cert}
+	for _, c := range chain {
+		cert.Certificate = append(cert.Certificate, c.cert.Raw)
+	}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{cert}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String()
+}
+
+func TestParseTargets(t *testing.T) {
+	input := `# production endpoints
+example.com
+example.org:8443   # admin
+127.0.0.1
+
+[::1]:10443
+[::1]
+`
+	targets, err := ParseTargets(strings.NewReader(input))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"example.com:443",
+		"example.org:8443",
+		"127.0.0.1:443",
+		"[::1]:10443",
+		"[::1]:443",
+	}, targets)
+
+	for _, invalid := range []string{"example.com:0", "example.com:70000", "example.com:https", "http://example.com"} {
+		_, err := ParseTargets(strings.NewReader("ok.example.com\n" + invalid + "\n"))
+		assert.ErrorIs(t, err, ErrInvalidTarget, invalid)
+		assert.ErrorContains(t, err, "line 2", invalid)
+	}
+}
+
+func TestScanEndpoints(t *testing.T) {
+	now := time.Now()
+	root := issue(t, "Endpoint Root CA", true, now.Add(-time.Hour), now.AddDate(2, 0, 0), nil)
+	leaf := issue(t, "ok.example.com", false, now.Add(-time.Hour), now.AddDate(1, 0, 0), root)
+	expiring := issue(t, "expiring.example.com", false, now.Add(-time.Hour), now.AddDate(0, 0, 5), root)
+	expired := issue(t, "expired.example.com", false, now.AddDate(-1, 0, 0), now.AddDate(0, 0, -1), root)
+
+	// Reserve a port, then close it so connections are refused
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	targets := []string{
+		startTLSServer(t, leaf, root),
+		startTLSServer(t, expiring, root),
+		startTLSServer(t, expired, root),
+		closed,
+	}
+
+	var (
+		mu      sync.Mutex
+		emitted []string
+	)
+	report, err := ScanEndpoints(context.Background(), targets, EndpointOptions{
+		Version:     "1.0.0",
+		Timeout:     5 * time.Second,
+		Concurrency: 2,
+	}, func(result EndpointResult) {
+		mu.Lock()
+		emitted = append(emitted, result.Target)
+		mu.Unlock()
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Results, len(targets))
+	assert.ElementsMatch(t, targets, emitted)
+
+	ok := report.Results[0]
+	assert.Equal(t, targets[0], ok.Target)
+	assert.Equal(t, HealthOK, ok.Health, ok.Detail)
+	assert.Equal(t, "CN=ok.example.com", ok.Subject)
+	assert.Equal(t, 2, ok.ServedLength)
+	assert.Equal(t, 2, ok.ChainLength)
+	assert.Len(t, ok.Revocation, 1)
+	assert.True(t, ok.Complete)
+	assert.True(t, ok.Verified)
+	require.Len(t, ok.Chain, 2)
+	assert.Equal(t, RoleLeaf, ok.Chain[0].Role)
+	assert.Equal(t, RoleRoot, ok.Chain[1].Role)
+	assert.Equal(t, "CN=Endpoint Root CA", ok.Chain[1].Subject)
+	assert.NotEmpty(t, ok.SHA256)
+
+	assert.Equal(t, HealthExpiring, report.Results[1].Health)
+	assert.Equal(t, HealthExpired, report.Results[2].Health)
+	assert.Equal(t, HealthUnreachable, report.Results[3].Health)
+	assert.Contains(t, report.Results[3].Detail, "failed to connect")
+
+	assert.Equal(t, Summary{Total: 4, OK: 1, Expiring: 1, Expired: 1, Unreachable: 1, Worst: HealthExpired}, report.Summary)
+
+	text := report.RenderText()
+	assert.Contains(t, text, "[ok]")
+	assert.Contains(t, text, "[unreachable]")
+	assert.Contains(t, text, "Targets: 4 (ok: 1, expiring: 1, unreachable: 1, incomplete: 0, invalid: 0, expired: 1, revoked: 0), worst: expired")
+}
+
+func TestScanEndpoints_HostInterval(t *testing.T) {
+	// Connections to an unused loopback port fail fast, so the elapsed time is
+	// dominated by the per-host interval.
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closed := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	interval := 100 * time.Millisecond
+	start := time.Now()
+	report, err := ScanEndpoints(context.Background(), []string{closed, closed, closed}, EndpointOptions{
+		Timeout:      time.Second,
+		Concurrency:  3,
+		HostInterval: interval,
+	}, nil)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 2*interval)
+	assert.Equal(t, 3, report.Summary.Unreachable)
+}
+
+func TestScanEndpoints_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report, err := ScanEndpoints(ctx, []string{"127.0.0.1:1", "127.0.0.1:1"}, EndpointOptions{HostInterval: time.Hour}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+	require.Len(t, report.Results, 2)
+	for _, result := range report.Results {
+		assert.Equal(t, HealthUnreachable, result.Health)
+	}
+}
+
+func TestSeverity(t *testing.T) {
+	assert.Less(t, Severity(HealthOK), Severity(HealthExpiring))
+	assert.Less(t, Severity(HealthExpiring), Severity(HealthUnreachable))
+	assert.Less(t, Severity(HealthExpired), Severity(HealthRevoked))
+	assert.Equal(t, Severity(HealthRevoked), Severity("bogus"))
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"gopkg.in/yaml.v3"
+)
+
+// kubernetesTLSType is the type of Kubernetes secrets holding a TLS certificate and key.
+const kubernetesTLSType = "kubernetes.io/tls"
+
+// kubernetesTLSCertKey is the secret key holding the PEM certificate chain.
+const kubernetesTLSCertKey = "tls.crt"
+
+// secretMetadata is the part of Kubernetes object metadata used to name a secret.
+type secretMetadata struct {
+	Name      string `yaml:"name"`
+	Namespace string `yaml:"namespace"`
+}
+
+// secretManifest is a Kubernetes object that may be a Secret or a List of objects.
+type secretManifest struct {
+	Kind       string            `yaml:"kind"`
+	Type       string            `yaml:"type"`
+	Metadata   secretMetadata    `yaml:"metadata"`
+	Data       map[string]string `yaml:"data"`
+	StringData map[string]string `yaml:"stringData"`
+	Items      []secretManifest  `yaml:"items"`
+}
+
+// tlsSecret is the certificate data of a Kubernetes TLS secret.
+type tlsSecret struct {
+	// name: Secret as "namespace/name"
+	name string
+	// certData: PEM data of tls.crt
+	certData []byte
+}
+
+// parseTLSSecrets extracts the TLS secrets from a YAML or JSON manifest,
+// which may hold several documents and List objects.
+//
+// Parameters:
+//   - data: Manifest content
+//
+// Returns:
+//   - []tlsSecret: TLS secrets in document order
+//   - error: YAML decoding error, or invalid base64 in a secret's data
+func parseTLSSecrets(data []byte) ([]tlsSecret, error) {
+	var secrets []tlsSecret
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var manifest secretManifest
+		if err := decoder.Decode(&manifest); err != nil {
+			if errors.Is(err, io.EOF) {
+				return secrets, nil
+			}
+			return nil, fmt.Errorf("failed to decode manifest: %w", err)
+		}
+
+		found, err := manifest.tlsSecrets()
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, found...)
+	}
+}
+
+// tlsSecrets returns the TLS secrets of the object and of any List items.
+func (m *secretManifest) tlsSecrets() ([]tlsSecret, error) {
+	var secrets []tlsSecret
+	for i := range m.Items {
+		found, err := m.Items[i].tlsSecrets()
+		if err != nil {
+			return nil, err
+		}
+		secrets = append(secrets, found...)
+	}
+	if m.Kind != "Secret" || m.Type != kubernetesTLSType {
+		return secrets, nil
+	}
+
+	namespace := m.Metadata.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	name := namespace + "/" + m.Metadata.Name
+
+	// stringData takes precedence over data, as it does when applied to a cluster
+	if certData, ok := m.StringData[kubernetesTLSCertKey]; ok {
+		return append(secrets, tlsSecret{name: name, certData: []byte(certData)}), nil
+	}
+	if encoded, ok := m.Data[kubernetesTLSCertKey]; ok {
+		certData, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("secret %s: invalid base64 in %s: %w", name, kubernetesTLSCertKey, err)
+		}
+		return append(secrets, tlsSecret{name: name, certData: certData}), nil
+	}
+	return secrets, nil
+}
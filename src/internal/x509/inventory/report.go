@@ -0,0 +1,110 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/olekukonko/tablewriter/renderer"
+	"github.com/olekukonko/tablewriter/tw"
+)
+
+// reportColumns are the CSV and Markdown columns, in order.
+var reportColumns = []string{
+	"Path", "Source", "Secret", "Subject", "Issuer", "Serial Number", "Not After",
+	"Days Remaining", "Key", "SHA-256 Fingerprint", "Chain Length", "Health", "Detail",
+}
+
+// row returns the entry's values in reportColumns order.
+func (e *Entry) row() []string {
+	return []string{
+		e.Path,
+		e.Source,
+		e.Secret,
+		e.Subject,
+		e.Issuer,
+		e.SerialNumber,
+		e.NotAfter.UTC().Format(time.RFC3339),
+		strconv.Itoa(e.DaysRemaining),
+		e.Key,
+		e.SHA256,
+		strconv.Itoa(e.ChainLength),
+		e.Health,
+		e.Detail,
+	}
+}
+
+// Unhealthy reports whether any entry is expired or fails verification.
+//
+// Returns:
+//   - bool: true if the report holds an HealthExpired or HealthInvalid entry
+func (r *Report) Unhealthy() bool {
+	return r.Summary.Expired > 0 || r.Summary.Invalid > 0
+}
+
+// RenderCSV renders the entries as CSV with a header row, one row per entry.
+//
+// Returns:
+//   - string: CSV document
+//   - error: Error if writing the CSV fails
+func (r *Report) RenderCSV() (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	if err := w.Write(reportColumns); err != nil {
+		return "", err
+	}
+	for i := range r.Entries {
+		if err := w.Write(r.Entries[i].row()); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return "", fmt.Errorf("failed to write CSV: %w", err)
+	}
+	return b.String(), nil
+}
+
+// RenderMarkdown renders the summary, a Markdown table of the entries, and
+// any file errors.
+//
+// Returns:
+//   - string: Markdown document
+func (r *Report) RenderMarkdown() string {
+	var b strings.Builder
+
+	b.WriteString("# Certificate Inventory\n\n")
+	fmt.Fprintf(&b, "Files scanned: %d (skipped: %d)\n\n", r.FilesScanned, r.FilesSkipped)
+	fmt.Fprintf(&b, "Certificates: %d (ok: %d, expiring: %d, incomplete: %d, invalid: %d, expired: %d)\n\n",
+		r.Summary.Total, r.Summary.OK, r.Summary.Expiring, r.Summary.Incomplete, r.Summary.Invalid, r.Summary.Expired)
+
+	if len(r.Entries) > 0 {
+		table := tablewriter.NewTable(&b,
+			tablewriter.WithRenderer(renderer.NewMarkdown(tw.Rendition{Streaming: true})),
+			tablewriter.WithHeaderAutoFormat(tw.Off),
+		)
+		table.Header(reportColumns)
+		rows := make([][]string, len(r.Entries))
+		for i := range r.Entries {
+			rows[i] = r.Entries[i].row()
+		}
+		table.Bulk(rows)
+		table.Render()
+	}
+
+	if len(r.Errors) > 0 {
+		b.WriteString("\n## Errors\n\n")
+		for _, fileErr := range r.Errors {
+			fmt.Fprintf(&b, "- %s: %s\n", fileErr.Path, fileErr.Error)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,460 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+)
+
+// Certificate sources reported in [Entry.Source].
+const (
+	// SourceFile marks certificates read from a PEM or DER file.
+	SourceFile = "file"
+	// SourceKubernetesSecret marks certificates read from the tls.crt key of a Kubernetes TLS secret manifest.
+	SourceKubernetesSecret = "kubernetes-secret"
+)
+
+// Chain health states reported in [Entry.Health], from best to worst.
+const (
+	// HealthOK marks a complete, verified chain that is not close to expiry.
+	HealthOK = "ok"
+	// HealthExpiring marks a chain with a certificate expiring within the warning window.
+	HealthExpiring = "expiring"
+	// HealthIncomplete marks a chain that could not be completed up to a self-signed root.
+	HealthIncomplete = "incomplete"
+	// HealthInvalid marks a chain that fails verification.
+	HealthInvalid = "invalid"
+	// HealthExpired marks a chain with an expired or not yet valid certificate.
+	HealthExpired = "expired"
+)
+
+// Scan defaults applied to zero [Options] fields.
+const (
+	// DefaultTimeout is the default timeout for each AIA download.
+	DefaultTimeout = 10 * time.Second
+	// DefaultWarnDays is the default expiry warning window in days.
+	DefaultWarnDays = 30
+	// DefaultMaxFileSize is the default size limit of scanned files; larger files are skipped.
+	DefaultMaxFileSize = 1 << 20
+)
+
+// pemCertificateHeader marks files holding PEM certificates.
+var pemCertificateHeader = []byte("-----BEGIN CERTIFICATE-----")
+
+// skippedDirs are version control directories never descended into.
+var skippedDirs = map[string]bool{".git": true, ".hg": true, ".svn": true}
+
+// Options configures a scan.
+type Options struct {
+	// Version: Application version for HTTP User-Agent headers
+	Version string
+	// Timeout: Timeout for each AIA download (default: DefaultTimeout)
+	Timeout time.Duration
+	// WarnDays: Days before expiry at which a chain is reported as expiring (default: DefaultWarnDays)
+	WarnDays int
+	// MaxFileSize: Size limit in bytes of scanned files (default: DefaultMaxFileSize)
+	MaxFileSize int64
+}
+
+// Entry is the inventory record of one certificate and its resolved chain.
+type Entry struct {
+	// Path: File the certificate was found in
+	Path string `json:"path"`
+	// Source: SourceFile or SourceKubernetesSecret
+	Source string `json:"source"`
+	// Secret: Kubernetes secret as "namespace/name", for SourceKubernetesSecret
+	Secret string `json:"secret,omitempty"`
+	// Subject: Subject distinguished name
+	Subject string `json:"subject"`
+	// Issuer: Issuer distinguished name
+	Issuer string `json:"issuer"`
+	// SerialNumber: Serial number in hexadecimal
+	SerialNumber string `json:"serialNumber"`
+	// NotAfter: Expiry of the certificate
+	NotAfter time.Time `json:"notAfter"`
+	// DaysRemaining: Whole days until expiry, negative once expired
+	DaysRemaining int `json:"daysRemaining"`
+	// Key: Public key description (e.g. "ECDSA P-256 (128-bit security)")
+	Key string `json:"key"`
+	// SHA256: SHA-256 fingerprint of the certificate
+	SHA256 string `json:"sha256Fingerprint"`
+	// ChainLength: Number of certificates in the resolved chain, including the certificate itself
+	ChainLength int `json:"chainLength"`
+	// Health: Worst chain health state (HealthOK through HealthExpired)
+	Health string `json:"health"`
+	// Detail: Reason for any health state other than HealthOK
+	Detail string `json:"detail,omitempty"`
+}
+
+// FileError is a file that looked like certificate data but could not be read or decoded.
+type FileError struct {
+	// Path: File path
+	Path string `json:"path"`
+	// Error: Reading or decoding error
+	Error string `json:"error"`
+}
+
+// Summary counts the inventory entries per health state.
+type Summary struct {
+	// Total: Number of entries
+	Total int `json:"total"`
+	// OK: Entries with HealthOK
+	OK int `json:"ok"`
+	// Expiring: Entries with HealthExpiring
+	Expiring int `json:"expiring"`
+	// Incomplete: Entries with HealthIncomplete
+	Incomplete int `json:"incomplete"`
+	// Invalid: Entries with HealthInvalid
+	Invalid int `json:"invalid"`
+	// Expired: Entries with HealthExpired
+	Expired int `json:"expired"`
+	// Unreachable: Endpoints with HealthUnreachable
+	Unreachable int `json:"unreachable,omitempty"`
+	// Revoked: Endpoints with HealthRevoked
+	Revoked int `json:"revoked,omitempty"`
+	// Worst: Worst health state counted, empty when nothing was counted
+	Worst string `json:"worst,omitempty"`
+}
+
+// Report is the result of a scan.
+type Report struct {
+	// FilesScanned: Number of files read
+	FilesScanned int `json:"filesScanned"`
+	// FilesSkipped: Number of files over the size limit
+	FilesSkipped int `json:"filesSkipped"`
+	// Entries: One entry per certificate found, in path order
+	Entries []Entry `json:"entries"`
+	// Errors: Files that could not be read or decoded
+	Errors []FileError `json:"errors"`
+	// Summary: Entry counts per health state
+	Summary Summary `json:"summary"`
+}
+
+// certificateGroup is a leaf with the certificates that follow it in a file.
+type certificateGroup struct {
+	path   string
+	source string
+	secret string
+	certs  []*x509.Certificate
+}
+
+// scanner holds the state of a single scan.
+type scanner struct {
+	ctx      context.Context
+	opts     Options
+	report   *Report
+	groups   []certificateGroup
+	resolved map[string]Entry
+}
+
+// Scan walks the given files and directories, finds every certificate by
+// content sniffing, and resolves, verifies, and checks the expiry of each chain.
+//
+// A file holding several certificates is split into chains: a certificate
+// issued by the one before it continues that chain, so a fullchain.pem
+// yields one entry while a CA bundle yields one entry per root. Symbolic
+// links, version control directories, and files over the size limit are
+// skipped. Identical chains found in several places are resolved once.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeouts
+//   - paths: Files and directories to scan
+//   - opts: Scan options; zero fields take their defaults
+//
+// Returns:
+//   - *Report: Inventory entries, file errors, and summary
+//   - error: Error if a scanned path does not exist or ctx is cancelled
+func Scan(ctx context.Context, paths []string, opts Options) (*Report, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.WarnDays <= 0 {
+		opts.WarnDays = DefaultWarnDays
+	}
+	if opts.MaxFileSize <= 0 {
+		opts.MaxFileSize = DefaultMaxFileSize
+	}
+
+	s := &scanner{
+		ctx:      ctx,
+		opts:     opts,
+		report:   &Report{Entries: []Entry{}, Errors: []FileError{}},
+		resolved: make(map[string]Entry),
+	}
+	for _, root := range paths {
+		if err := filepath.WalkDir(root, s.visit); err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, fmt.Errorf("inventory scan cancelled: %w", ctxErr)
+			}
+			return nil, err
+		}
+	}
+
+	for _, group := range s.groups {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("inventory scan cancelled: %w", err)
+		}
+		entry := s.resolve(ctx, group.certs)
+		entry.Path, entry.Source, entry.Secret = group.path, group.source, group.secret
+		s.report.Entries = append(s.report.Entries, entry)
+		s.report.Summary.add(entry.Health)
+	}
+	return s.report, nil
+}
+
+// visit is the [fs.WalkDirFunc] reading every regular file under a scanned path.
+//
+// A scanned path that does not exist stops the walk, as does cancellation of
+// the scan's context. Entries below it that cannot be read are recorded as
+// file errors and skipped, so one unreadable directory does not discard the
+// rest of the scan.
+func (s *scanner) visit(path string, d fs.DirEntry, err error) error {
+	if ctxErr := s.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	if err != nil {
+		// Only a scanned path itself is visited without an entry
+		if d == nil {
+			return err
+		}
+		s.fileError(path, err)
+		if d.IsDir() {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if d.IsDir() {
+		if skippedDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+		return nil
+	}
+	if !d.Type().IsRegular() {
+		return nil
+	}
+
+	info, err := d.Info()
+	if err != nil {
+		s.fileError(path, err)
+		return nil
+	}
+	if info.Size() > s.opts.MaxFileSize {
+		s.report.FilesSkipped++
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		s.fileError(path, err)
+		return nil
+	}
+	s.report.FilesScanned++
+	s.sniff(path, data)
+	return nil
+}
+
+// sniff records the certificate groups found in a file's content.
+func (s *scanner) sniff(path string, data []byte) {
+	if bytes.Contains(data, []byte(kubernetesTLSType)) {
+		secrets, err := parseTLSSecrets(data)
+		if err != nil {
+			s.fileError(path, err)
+			return
+		}
+		for _, secret := range secrets {
+			certs, err := decodeCertificates(secret.certData)
+			if err != nil {
+				s.fileError(path, fmt.Errorf("secret %s: %w", secret.name, err))
+				continue
+			}
+			s.addGroups(path, SourceKubernetesSecret, secret.name, certs)
+		}
+		if len(secrets) > 0 {
+			return
+		}
+	}
+
+	certs, err := decodeCertificates(data)
+	if err != nil {
+		s.fileError(path, err)
+		return
+	}
+	s.addGroups(path, SourceFile, "", certs)
+}
+
+// addGroups splits certificates into chains and queues them for resolution.
+func (s *scanner) addGroups(path, source, secret string, certs []*x509.Certificate) {
+	for _, group := range groupChains(certs) {
+		s.groups = append(s.groups, certificateGroup{path: path, source: source, secret: secret, certs: group})
+	}
+}
+
+// fileError records a file that could not be read or decoded.
+func (s *scanner) fileError(path string, err error) {
+	s.report.Errors = append(s.report.Errors, FileError{Path: path, Error: err.Error()})
+}
+
+// decodeCertificates returns the PEM certificates in data, skipping other PEM
+// blocks such as private keys, or the DER certificates data consists of.
+//
+// Returns:
+//   - []*x509.Certificate: Certificates in data order; nil when data holds none
+//   - error: Error if a PEM certificate block cannot be parsed
+func decodeCertificates(data []byte) ([]*x509.Certificate, error) {
+	if bytes.Contains(data, pemCertificateHeader) {
+		var certs []*x509.Certificate
+		for {
+			block, rest := pem.Decode(data)
+			if block == nil {
+				return certs, nil
+			}
+			data = rest
+			if block.Type != "CERTIFICATE" {
+				continue
+			}
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse PEM certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+
+	// A DER certificate starts with an ASN.1 SEQUENCE; anything else is not certificate data
+	if len(data) == 0 || data[0] != 0x30 {
+		return nil, nil
+	}
+	certs, err := x509.ParseCertificates(data)
+	if err != nil {
+		return nil, nil
+	}
+	return certs, nil
+}
+
+// groupChains splits certificates into chains, continuing a chain while each
+// certificate is the issuer of the previous one.
+func groupChains(certs []*x509.Certificate) [][]*x509.Certificate {
+	var groups [][]*x509.Certificate
+	for _, cert := range certs {
+		if n := len(groups); n > 0 {
+			last := groups[n-1][len(groups[n-1])-1]
+			if !isSelfSigned(last) && bytes.Equal(last.RawIssuer, cert.RawSubject) {
+				groups[n-1] = append(groups[n-1], cert)
+				continue
+			}
+		}
+		groups = append(groups, []*x509.Certificate{cert})
+	}
+	return groups
+}
+
+// isSelfSigned reports whether cert is issued and signed by itself.
+//
+// Unlike [x509chain.Chain.IsSelfSigned], the certificate does not have to be a
+// CA, so self-signed server certificates count as complete chains.
+func isSelfSigned(cert *x509.Certificate) bool {
+	return bytes.Equal(cert.RawIssuer, cert.RawSubject) &&
+		cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature) == nil
+}
+
+// resolve completes, verifies, and checks the expiry of a chain, reusing the
+// result of an identical chain found earlier in the scan.
+func (s *scanner) resolve(ctx context.Context, certs []*x509.Certificate) Entry {
+	var key strings.Builder
+	for _, cert := range certs {
+		key.WriteString(x509certs.CertificateFingerprints(cert).SHA256)
+	}
+	if entry, ok := s.resolved[key.String()]; ok {
+		return entry
+	}
+
+	leaf := certs[0]
+	entry := Entry{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		SerialNumber:  fmt.Sprintf("%x", leaf.SerialNumber),
+		NotAfter:      leaf.NotAfter,
+		DaysRemaining: int(math.Floor(time.Until(leaf.NotAfter).Hours() / 24)),
+		Key:           x509certs.DescribeKey(leaf).String(),
+		SHA256:        x509certs.CertificateFingerprints(leaf).SHA256,
+	}
+
+	ch, fetchErr := x509chain.ResolveCertificates(ctx, certs, s.opts.Timeout, s.opts.Version)
+	entry.ChainLength = len(ch.Certs)
+	entry.Health, entry.Detail = chainHealth(ch, fetchErr, s.opts.WarnDays)
+
+	s.resolved[key.String()] = entry
+	return entry
+}
+
+// chainHealth returns the worst health state of a resolved chain and its reason.
+func chainHealth(ch *x509chain.Chain, fetchErr error, warnDays int) (health, detail string) {
+	now := time.Now()
+	for _, cert := range ch.Certs {
+		if now.After(cert.NotAfter) {
+			return HealthExpired, fmt.Sprintf("%s expired on %s", cert.Subject, cert.NotAfter.Format(time.DateOnly))
+		}
+		if now.Before(cert.NotBefore) {
+			return HealthExpired, fmt.Sprintf("%s is not valid before %s", cert.Subject, cert.NotBefore.Format(time.DateOnly))
+		}
+	}
+
+	if err := ch.VerifyChain(); err != nil {
+		return HealthInvalid, err.Error()
+	}
+
+	if fetchErr != nil {
+		return HealthIncomplete, fetchErr.Error()
+	}
+	if last := ch.Certs[len(ch.Certs)-1]; !isSelfSigned(last) {
+		return HealthIncomplete, fmt.Sprintf("chain ends at %s, which is not a self-signed root", last.Subject)
+	}
+
+	warnBefore := now.AddDate(0, 0, warnDays)
+	for _, cert := range ch.Certs {
+		if cert.NotAfter.Before(warnBefore) {
+			return HealthExpiring, fmt.Sprintf("%s expires on %s", cert.Subject, cert.NotAfter.Format(time.DateOnly))
+		}
+	}
+	return HealthOK, ""
+}
+
+// add counts an entry with the given health state.
+func (sum *Summary) add(health string) {
+	sum.Total++
+	switch health {
+	case HealthOK:
+		sum.OK++
+	case HealthExpiring:
+		sum.Expiring++
+	case HealthIncomplete:
+		sum.Incomplete++
+	case HealthInvalid:
+		sum.Invalid++
+	case HealthExpired:
+		sum.Expired++
+	case HealthUnreachable:
+		sum.Unreachable++
+	case HealthRevoked:
+		sum.Revoked++
+	}
+	if sum.Worst == "" || Severity(health) > Severity(sum.Worst) {
+		sum.Worst = health
+	}
+}
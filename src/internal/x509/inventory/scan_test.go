@@ -0,0 +1,285 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509inventory
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testCert is a generated certificate with its key.
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+// issue creates a certificate valid from notBefore to notAfter, signed by
+// issuer or self-signed when issuer is nil.
+func issue(t *testing.T, cn string, isCA bool, notBefore, notAfter time.Time, issuer *testCert) *testCert {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		IsCA:                  isCA,
+		BasicConstraintsValid: isCA,
+	}
+	if isCA {
+		tmpl.KeyUsage = x509.KeyUsageCertSign
+	} else {
+		tmpl.DNSNames = []string{cn}
+	}
+
+	parent, parentKey := tmpl, key
+	if issuer != nil {
+		parent, parentKey = issuer.cert, issuer.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCert{cert: cert, key: key}
+}
+
+// pemBundle encodes certificates as concatenated PEM blocks.
+func pemBundle(certs ...*testCert) []byte {
+	var b []byte
+	for _, c := range certs {
+		b = append(b, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw})...)
+	}
+	return b
+}
+
+// writeFile writes data to dir/name, creating parent directories.
+func writeFile(t *testing.T, dir, name string, data []byte) {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	require.NoError(t, os.WriteFile(path, data, 0644))
+}
+
+// entriesAt returns the entries found in the file at dir/name.
+func entriesAt(report *Report, dir, name string) []Entry {
+	var entries []Entry
+	for _, e := range report.Entries {
+		if e.Path == filepath.Join(dir, name) {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
+
+func TestScan(t *testing.T) {
+	now := time.Now()
+	root := issue(t, "Inventory Root", true, now.Add(-time.Hour), now.AddDate(5, 0, 0), nil)
+	inter := issue(t, "Inventory Intermediate", true, now.Add(-time.Hour), now.AddDate(2, 0, 0), root)
+	leaf := issue(t, "www.example.com", false, now.Add(-time.Hour), now.AddDate(0, 3, 0), inter)
+	expiring := issue(t, "soon.example.com", false, now.Add(-time.Hour), now.AddDate(0, 0, 10), root)
+	expired := issue(t, "old.example.com", false, now.AddDate(-1, 0, 0), now.AddDate(0, 0, -1), nil)
+	orphanCA := issue(t, "Missing Intermediate", true, now.Add(-time.Hour), now.AddDate(1, 0, 0), root)
+	orphan := issue(t, "orphan.example.com", false, now.Add(-time.Hour), now.AddDate(0, 3, 0), orphanCA)
+	otherRoot := issue(t, "Other Root", true, now.Add(-time.Hour), now.AddDate(5, 0, 0), nil)
+
+	key, err := x509.MarshalECPrivateKey(leaf.key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key})
+
+	dir := t.TempDir()
+	writeFile(t, dir, "nginx/fullchain.pem", pemBundle(leaf, inter, root))
+	writeFile(t, dir, "nginx/combined.pem", append(keyPEM, pemBundle(leaf, inter, root)...))
+	writeFile(t, dir, "nginx/server.key", keyPEM)
+	writeFile(t, dir, "soon.pem", pemBundle(expiring, root))
+	writeFile(t, dir, "legacy/old.der", expired.cert.Raw)
+	writeFile(t, dir, "orphan.crt", pemBundle(orphan))
+	writeFile(t, dir, "ca-bundle.pem", pemBundle(root, otherRoot))
+	writeFile(t, dir, "broken.pem", []byte("-----BEGIN CERTIFICATE-----\nAAAA\n-----END CERTIFICATE-----\n"))
+	writeFile(t, dir, "README.md", []byte("# not a certificate\n"))
+	writeFile(t, dir, ".git/objects/cert.pem", pemBundle(leaf))
+	writeFile(t, dir, "huge.pem", append(pemBundle(leaf), bytes.Repeat([]byte("#"), 4096)...))
+
+	manifests := `apiVersion: v1
+kind: Secret
+metadata:
+  name: web-tls
+  namespace: prod
+type: kubernetes.io/tls
+data:
+  tls.crt: ` + base64.StdEncoding.EncodeToString(pemBundle(leaf, inter, root)) + `
+  tls.key: ` + base64.StdEncoding.EncodeToString(keyPEM) + `
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: opaque
+type: Opaque
+data:
+  password: c2VjcmV0
+---
+apiVersion: v1
+kind: List
+items:
+  - apiVersion: v1
+    kind: Secret
+    metadata:
+      name: legacy-tls
+    type: kubernetes.io/tls
+    stringData:
+      tls.crt: |
+` + indent(string(pemBundle(expired)), "        ")
+	writeFile(t, dir, "k8s/secrets.yaml", []byte(manifests))
+	writeFile(t, dir, "k8s/bad.yaml", []byte("kind: Secret\ntype: kubernetes.io/tls\ndata:\n  tls.crt: \"!!!\"\n"))
+
+	report, err := Scan(context.Background(), []string{dir}, Options{Version: "1.0.0", MaxFileSize: 4096})
+	require.NoError(t, err)
+
+	t.Run("Bundles", func(t *testing.T) {
+		for _, name := range []string{"nginx/fullchain.pem", "nginx/combined.pem"} {
+			entries := entriesAt(report, dir, name)
+			require.Len(t, entries, 1, name)
+			assert.Equal(t, SourceFile, entries[0].Source)
+			assert.Equal(t, "CN=www.example.com", entries[0].Subject)
+			assert.Equal(t, "CN=Inventory Intermediate", entries[0].Issuer)
+			assert.Equal(t, 3, entries[0].ChainLength)
+			assert.Equal(t, HealthOK, entries[0].Health, entries[0].Detail)
+			assert.Contains(t, entries[0].Key, "ECDSA")
+			assert.NotEmpty(t, entries[0].SHA256)
+		}
+		assert.Empty(t, entriesAt(report, dir, "nginx/server.key"))
+
+		bundle := entriesAt(report, dir, "ca-bundle.pem")
+		require.Len(t, bundle, 2)
+		assert.Equal(t, "CN=Inventory Root", bundle[0].Subject)
+		assert.Equal(t, "CN=Other Root", bundle[1].Subject)
+	})
+
+	t.Run("Health", func(t *testing.T) {
+		soon := entriesAt(report, dir, "soon.pem")
+		require.Len(t, soon, 1)
+		assert.Equal(t, HealthExpiring, soon[0].Health)
+		assert.Contains(t, soon[0].Detail, "CN=soon.example.com expires on")
+		assert.InDelta(t, 9, soon[0].DaysRemaining, 1)
+
+		old := entriesAt(report, dir, "legacy/old.der")
+		require.Len(t, old, 1)
+		assert.Equal(t, HealthExpired, old[0].Health)
+		assert.Negative(t, old[0].DaysRemaining)
+
+		orphanEntry := entriesAt(report, dir, "orphan.crt")
+		require.Len(t, orphanEntry, 1)
+		assert.Equal(t, HealthIncomplete, orphanEntry[0].Health)
+		assert.Contains(t, orphanEntry[0].Detail, "not a self-signed root")
+	})
+
+	t.Run("Kubernetes Secrets", func(t *testing.T) {
+		secrets := entriesAt(report, dir, "k8s/secrets.yaml")
+		require.Len(t, secrets, 2)
+		assert.Equal(t, SourceKubernetesSecret, secrets[0].Source)
+		assert.Equal(t, "prod/web-tls", secrets[0].Secret)
+		assert.Equal(t, HealthOK, secrets[0].Health)
+		assert.Equal(t, "default/legacy-tls", secrets[1].Secret)
+		assert.Equal(t, HealthExpired, secrets[1].Health)
+	})
+
+	t.Run("Skipped And Errors", func(t *testing.T) {
+		for _, e := range report.Entries {
+			assert.NotContains(t, e.Path, ".git")
+		}
+		assert.Equal(t, 1, report.FilesSkipped)
+
+		var errPaths []string
+		for _, fileErr := range report.Errors {
+			errPaths = append(errPaths, fileErr.Path)
+		}
+		assert.ElementsMatch(t, []string{filepath.Join(dir, "broken.pem"), filepath.Join(dir, "k8s/bad.yaml")}, errPaths)
+	})
+
+	t.Run("Summary", func(t *testing.T) {
+		assert.Equal(t, Summary{Total: 9, OK: 5, Expiring: 1, Incomplete: 1, Expired: 2, Worst: HealthExpired}, report.Summary)
+		assert.True(t, report.Unhealthy())
+	})
+
+	t.Run("CSV", func(t *testing.T) {
+		out, err := report.RenderCSV()
+		require.NoError(t, err)
+		records, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 10)
+		assert.Equal(t, reportColumns, records[0])
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		out := report.RenderMarkdown()
+		assert.Contains(t, out, "Certificates: 9 (ok: 5, expiring: 1, incomplete: 1, invalid: 0, expired: 2)")
+		assert.Contains(t, out, "prod/web-tls")
+		assert.Contains(t, out, "## Errors")
+		assert.Contains(t, out, "broken.pem")
+	})
+}
+
+func TestScan_Errors(t *testing.T) {
+	_, err := Scan(context.Background(), []string{filepath.Join(t.TempDir(), "missing")}, Options{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	dir := t.TempDir()
+	now := time.Now()
+	writeFile(t, dir, "cert.pem", pemBundle(issue(t, "a.example.com", false, now.Add(-time.Hour), now.AddDate(1, 0, 0), nil)))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Scan(ctx, []string{dir}, Options{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestScan_UnreadableDirectory(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+
+	dir := t.TempDir()
+	now := time.Now()
+	writeFile(t, dir, "cert.pem", pemBundle(issue(t, "a.example.com", false, now.Add(-time.Hour), now.AddDate(1, 0, 0), nil)))
+	locked := filepath.Join(dir, "locked")
+	require.NoError(t, os.Mkdir(locked, 0o755))
+	writeFile(t, locked, "hidden.pem", pemBundle(issue(t, "b.example.com", false, now.Add(-time.Hour), now.AddDate(1, 0, 0), nil)))
+	require.NoError(t, os.Chmod(locked, 0))
+	t.Cleanup(func() { os.Chmod(locked, 0o755) })
+
+	report, err := Scan(context.Background(), []string{dir}, Options{})
+	require.NoError(t, err)
+	require.Len(t, report.Entries, 1)
+	assert.Equal(t, filepath.Join(dir, "cert.pem"), report.Entries[0].Path)
+	require.Len(t, report.Errors, 1)
+	assert.Equal(t, locked, report.Errors[0].Path)
+}
+
+// indent prefixes every line of s with prefix.
+func indent(s, prefix string) string {
+	lines := strings.SplitAfter(strings.TrimSuffix(s, "\n"), "\n")
+	return prefix + strings.Join(lines, prefix) + "\n"
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509watch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sink types accepted in [SinkConfig.Type].
+const (
+	// SinkWebhook POSTs each event as JSON to a URL.
+	SinkWebhook = "webhook"
+	// SinkCommand runs a command with the event as JSON on standard input.
+	SinkCommand = "command"
+	// SinkFile appends each event as a JSON line to a file.
+	SinkFile = "file"
+	// SinkSyslog writes each event to the local syslog daemon.
+	SinkSyslog = "syslog"
+)
+
+// Configuration defaults applied to empty [Config] fields.
+const (
+	// DefaultInterval is the default time between checks.
+	DefaultInterval = time.Hour
+	// DefaultTimeout is the default timeout for each handshake, download, and notification.
+	DefaultTimeout = 10 * time.Second
+)
+
+// DefaultThresholds are the default alert tiers in days before expiry.
+var DefaultThresholds = []int{30, 14, 7, 1}
+
+var (
+	// ErrNoTargets indicates a configuration without files or endpoints to watch.
+	ErrNoTargets = errors.New("x509watch: configuration defines no files or endpoints")
+	// ErrNoSinks indicates a configuration without notification sinks.
+	ErrNoSinks = errors.New("x509watch: configuration defines no sinks")
+)
+
+// Config describes what to watch, how often, and where to send notifications.
+type Config struct {
+	// Interval: Time between checks as a Go duration such as "1h" (default: DefaultInterval)
+	Interval string `json:"interval,omitempty" yaml:"interval,omitempty"`
+	// Timeout: Timeout for each handshake, download, and notification as a Go duration (default: DefaultTimeout)
+	Timeout string `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Thresholds: Alert tiers in days before expiry (default: DefaultThresholds)
+	Thresholds []int `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	// StateFile: Path of the JSON file that keeps alert state between runs; state is kept in memory only when empty
+	StateFile string `json:"stateFile,omitempty" yaml:"stateFile,omitempty"`
+	// Files: Certificate files and directories, scanned like the inventory command
+	Files []string `json:"files,omitempty" yaml:"files,omitempty"`
+	// Endpoints: TLS endpoints as "host:port" or "host"
+	Endpoints []string `json:"endpoints,omitempty" yaml:"endpoints,omitempty"`
+	// Sinks: Notification destinations
+	Sinks []SinkConfig `json:"sinks" yaml:"sinks"`
+
+	// interval: Parsed Interval
+	interval time.Duration
+	// timeout: Parsed Timeout
+	timeout time.Duration
+}
+
+// SinkConfig configures one notification sink.
+type SinkConfig struct {
+	// Type: SinkWebhook, SinkCommand, SinkFile, or SinkSyslog
+	Type string `json:"type" yaml:"type"`
+	// URL: Webhook URL (webhook)
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// Headers: Extra HTTP headers such as Authorization (webhook)
+	Headers map[string]string `json:"headers,omitempty" yaml:"headers,omitempty"`
+	// Command: Program and arguments, run without a shell (command)
+	Command []string `json:"command,omitempty" yaml:"command,omitempty"`
+	// Path: File that receives one JSON line per event (file)
+	Path string `json:"path,omitempty" yaml:"path,omitempty"`
+	// Tag: Syslog tag (syslog, default: the executable name)
+	Tag string `json:"tag,omitempty" yaml:"tag,omitempty"`
+}
+
+// LoadConfig reads a watch configuration from a JSON or YAML file.
+//
+// The format is detected from the file extension (.yaml and .yml are YAML,
+// anything else is JSON). Unknown fields are rejected so that a misspelled
+// option cannot silently disable an alert.
+//
+// Parameters:
+//   - path: Path to the configuration file
+//
+// Returns:
+//   - *Config: Parsed and validated configuration
+//   - error: Reading, parsing, or validation error
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read watch config file: %w", err)
+	}
+
+	cfg := &Config{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse YAML watch config file: %w", err)
+		}
+	default:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(cfg); err != nil {
+			return nil, fmt.Errorf("failed to parse JSON watch config file: %w", err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks the configuration and applies defaults to empty fields.
+// Thresholds are sorted from the furthest to the nearest tier.
+//
+// Returns:
+//   - error: [ErrNoTargets], [ErrNoSinks], or a description of the invalid field
+func (c *Config) Validate() error {
+	var err error
+	if c.interval, err = parseDuration("interval", c.Interval, DefaultInterval); err != nil {
+		return err
+	}
+	if c.timeout, err = parseDuration("timeout", c.Timeout, DefaultTimeout); err != nil {
+		return err
+	}
+
+	if len(c.Thresholds) == 0 {
+		c.Thresholds = slices.Clone(DefaultThresholds)
+	}
+	for _, days := range c.Thresholds {
+		if days < 1 {
+			return fmt.Errorf("x509watch: invalid threshold %d, must be at least 1 day", days)
+		}
+	}
+	slices.Sort(c.Thresholds)
+	c.Thresholds = slices.Compact(c.Thresholds)
+	slices.Reverse(c.Thresholds)
+
+	if len(c.Files) == 0 && len(c.Endpoints) == 0 {
+		return ErrNoTargets
+	}
+	if len(c.Sinks) == 0 {
+		return ErrNoSinks
+	}
+	for i, sink := range c.Sinks {
+		if err := sink.validate(); err != nil {
+			return fmt.Errorf("x509watch: sink %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// validate checks that the fields required by the sink type are set.
+func (s *SinkConfig) validate() error {
+	switch s.Type {
+	case SinkWebhook:
+		if !strings.HasPrefix(s.URL, "http://") && !strings.HasPrefix(s.URL, "https://") {
+			return errors.New("webhook url must be an http or https URL")
+		}
+	case SinkCommand:
+		if len(s.Command) == 0 || s.Command[0] == "" {
+			return errors.New("command sink requires a command")
+		}
+	case SinkFile:
+		if s.Path == "" {
+			return errors.New("file sink requires a path")
+		}
+	case SinkSyslog:
+	default:
+		return fmt.Errorf("unsupported sink type %q, supported types: webhook, command, file, syslog", s.Type)
+	}
+	return nil
+}
+
+// parseDuration parses a positive Go duration, returning def for an empty value.
+func parseDuration(field, value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("x509watch: invalid %s %q, expected a positive duration such as \"1h\"", field, value)
+	}
+	return d, nil
+}
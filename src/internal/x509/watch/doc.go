@@ -0,0 +1,17 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+// Package x509watch continuously monitors the expiry of [X.509] certificates
+// stored in files and served by TLS endpoints.
+//
+// A [Watcher] periodically re-checks its targets with the inventory scanner,
+// remembers the last alert tier of every certificate in a state file, and
+// sends an [Event] to every configured [Sink] when a certificate crosses a
+// new expiry threshold (for example 30, 14, 7, and 1 days), expires, or is
+// renewed after an alert. Built-in sinks POST JSON to a webhook, run a
+// command, append JSON lines to a file, or write to the local syslog.
+//
+// [X.509]: https://grokipedia.com/page/X.509
+package x509watch
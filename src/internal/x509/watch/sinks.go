@@ -0,0 +1,220 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509watch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+)
+
+// Sink delivers watch events to a notification destination.
+type Sink interface {
+	// Notify delivers one event.
+	Notify(ctx context.Context, event Event) error
+}
+
+// NewSink creates the sink described by cfg.
+//
+// Parameters:
+//   - cfg: Sink configuration
+//   - version: Application version for the webhook User-Agent header
+//   - timeout: Timeout for webhook requests and commands
+//
+// Returns:
+//   - Sink: Configured sink
+//   - error: Error if the configuration is invalid or the sink is unsupported on this platform
+func NewSink(cfg SinkConfig, version string, timeout time.Duration) (Sink, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("x509watch: %w", err)
+	}
+
+	switch cfg.Type {
+	case SinkWebhook:
+		httpConfig := x509chain.NewHTTPConfig(version)
+		httpConfig.Timeout = timeout
+		return &WebhookSink{
+			URL:       cfg.URL,
+			Headers:   cfg.Headers,
+			UserAgent: httpConfig.GetUserAgent(),
+			Client:    httpConfig.Client(),
+		}, nil
+	case SinkCommand:
+		return &CommandSink{Command: cfg.Command, Timeout: timeout}, nil
+	case SinkFile:
+		return &FileSink{Path: cfg.Path}, nil
+	default:
+		return newSyslogSink(cfg.Tag)
+	}
+}
+
+// WebhookSink POSTs each event as a JSON object.
+type WebhookSink struct {
+	// URL: Webhook endpoint
+	URL string
+	// Headers: Extra HTTP headers, such as Authorization
+	Headers map[string]string
+	// UserAgent: User-Agent header value
+	UserAgent string
+	// Client: HTTP client used for requests
+	Client *http.Client
+}
+
+// Notify POSTs event to the webhook and expects a 2xx response.
+//
+// Errors name the webhook by scheme and host only, as chat webhooks and
+// generic tokens carry their secret in the path or query.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - event: Event to deliver
+//
+// Returns:
+//   - error: Encoding, request, or non-2xx status error
+func (s *WebhookSink) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		// The parse error quotes the URL
+		return errors.New("failed to create webhook request: invalid URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.UserAgent != "" {
+		req.Header.Set("User-Agent", s.UserAgent)
+	}
+	for name, value := range s.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		// The client error quotes the URL; keep only its cause
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("webhook request to %s failed: %w", redactURL(req.URL), err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook %s returned status %s", redactURL(req.URL), resp.Status)
+	}
+	return nil
+}
+
+// redactURL returns the scheme and host of a webhook URL, leaving out the
+// user info, path, and query that may carry its secret.
+func redactURL(u *url.URL) string {
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host}).String()
+}
+
+// CommandSink runs a command for each event.
+//
+// The event is written as JSON to the command's standard input, and its main
+// fields are exported as CERT_WATCH_* environment variables so that simple
+// shell scripts do not need a JSON parser.
+type CommandSink struct {
+	// Command: Program and arguments, run without a shell
+	Command []string
+	// Timeout: Maximum run time of each invocation; zero means no limit
+	Timeout time.Duration
+}
+
+// Notify runs the command and expects it to exit successfully.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//   - event: Event to deliver
+//
+// Returns:
+//   - error: Encoding error, or the command's exit error with its trimmed output
+func (s *CommandSink) Notify(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode command input: %w", err)
+	}
+
+	if s.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, s.Command[0], s.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Env = append(os.Environ(),
+		"CERT_WATCH_KIND="+event.Kind,
+		"CERT_WATCH_SOURCE="+event.Source,
+		"CERT_WATCH_TARGET="+event.Target,
+		"CERT_WATCH_SUBJECT="+event.Subject,
+		"CERT_WATCH_NOT_AFTER="+event.NotAfter.UTC().Format(time.RFC3339),
+		"CERT_WATCH_DAYS_REMAINING="+strconv.Itoa(event.DaysRemaining),
+		"CERT_WATCH_THRESHOLD="+strconv.Itoa(event.Threshold),
+		"CERT_WATCH_MESSAGE="+event.Message,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("command %s failed: %w: %s", s.Command[0], err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// FileSink appends each event to a file as one JSON line.
+type FileSink struct {
+	// Path: File to append to, created with mode 0644 if missing
+	Path string
+
+	// mu: Serializes appends from concurrent watchers sharing the sink
+	mu sync.Mutex
+}
+
+// Notify appends event to the file.
+//
+// Parameters:
+//   - ctx: Unused; appends are not cancellable
+//   - event: Event to deliver
+//
+// Returns:
+//   - error: Encoding, open, or write error
+//
+// Thread Safety: Safe for concurrent use.
+func (s *FileSink) Notify(ctx context.Context, event Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write event file: %w", err)
+	}
+	return f.Close()
+}
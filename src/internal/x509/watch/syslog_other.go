@@ -0,0 +1,15 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+//go:build windows || plan9
+
+package x509watch
+
+import "errors"
+
+// newSyslogSink reports that syslog is unavailable on this platform.
+func newSyslogSink(tag string) (Sink, error) {
+	return nil, errors.New("x509watch: syslog sink is not supported on this platform, use a file sink instead")
+}
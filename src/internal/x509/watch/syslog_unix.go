@@ -0,0 +1,55 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+//go:build !windows && !plan9
+
+package x509watch
+
+import (
+	"context"
+	"fmt"
+	"log/syslog"
+	"sync"
+)
+
+// syslogSink writes each event message to the local syslog daemon.
+type syslogSink struct {
+	// tag: Syslog tag; empty uses the executable name
+	tag string
+
+	// mu: Guards writer
+	mu sync.Mutex
+	// writer: Connection opened on first use, so a missing daemon only fails notifications
+	writer *syslog.Writer
+}
+
+// newSyslogSink creates a syslog sink with the given tag.
+func newSyslogSink(tag string) (Sink, error) {
+	return &syslogSink{tag: tag}, nil
+}
+
+// Notify logs expired certificates at LOG_CRIT, threshold crossings at
+// LOG_WARNING, and renewals at LOG_NOTICE, using the daemon facility.
+func (s *syslogSink) Notify(ctx context.Context, event Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.writer == nil {
+		writer, err := syslog.New(syslog.LOG_WARNING|syslog.LOG_DAEMON, s.tag)
+		if err != nil {
+			return fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		s.writer = writer
+	}
+
+	switch event.Kind {
+	case EventExpired:
+		return s.writer.Crit(event.Message)
+	case EventRenewed:
+		return s.writer.Notice(event.Message)
+	default:
+		return s.writer.Warning(event.Message)
+	}
+}
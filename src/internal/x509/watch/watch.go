@@ -0,0 +1,424 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509watch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+)
+
+// Event kinds reported in [Event.Kind].
+const (
+	// EventThreshold reports a certificate that entered a nearer expiry tier.
+	EventThreshold = "threshold"
+	// EventExpired reports a certificate that has expired.
+	EventExpired = "expired"
+	// EventRenewed reports that a previously alerted certificate is no longer within any tier.
+	EventRenewed = "renewed"
+)
+
+// SourceEndpoint marks certificates served by a TLS endpoint, alongside the
+// inventory sources x509inventory.SourceFile and x509inventory.SourceKubernetesSecret.
+const SourceEndpoint = "endpoint"
+
+// Event is a notification sent to every [Sink].
+type Event struct {
+	// Kind: EventThreshold, EventExpired, or EventRenewed
+	Kind string `json:"kind"`
+	// Source: SourceEndpoint or an inventory source
+	Source string `json:"source"`
+	// Target: File path or endpoint "host:port"
+	Target string `json:"target"`
+	// Secret: Kubernetes secret as "namespace/name", for secrets only
+	Secret string `json:"secret,omitempty"`
+	// Subject: Subject distinguished name of the leaf certificate
+	Subject string `json:"subject"`
+	// Issuer: Issuer distinguished name of the leaf certificate
+	Issuer string `json:"issuer"`
+	// SHA256: SHA-256 fingerprint of the leaf certificate
+	SHA256 string `json:"sha256Fingerprint"`
+	// NotAfter: Expiry of the leaf certificate
+	NotAfter time.Time `json:"notAfter"`
+	// DaysRemaining: Whole days until expiry, negative once expired
+	DaysRemaining int `json:"daysRemaining"`
+	// Threshold: Tier in days that was crossed, for EventThreshold only
+	Threshold int `json:"threshold,omitempty"`
+	// Health: Chain health state from the inventory scanner
+	Health string `json:"health"`
+	// Detail: Reason for any health state other than ok
+	Detail string `json:"detail,omitempty"`
+	// Message: Human-readable summary of the event
+	Message string `json:"message"`
+	// Time: When the event was raised
+	Time time.Time `json:"time"`
+}
+
+// itemState is the alert state of one watched certificate, persisted between runs.
+type itemState struct {
+	// SHA256: Fingerprint of the certificate the state refers to; a new fingerprint resets the state
+	SHA256 string `json:"sha256Fingerprint"`
+	// NotAfter: Expiry of the certificate
+	NotAfter time.Time `json:"notAfter"`
+	// Threshold: Nearest tier in days already alerted, 0 when none
+	Threshold int `json:"threshold,omitempty"`
+	// Expired: True once the expiry has been alerted
+	Expired bool `json:"expired,omitempty"`
+	// LastSeen: Time of the last check that found the certificate
+	LastSeen time.Time `json:"lastSeen"`
+}
+
+// alerted reports whether any alert was sent for the certificate.
+func (s itemState) alerted() bool { return s.Threshold > 0 || s.Expired }
+
+// stateFile is the on-disk layout of [Config.StateFile].
+type stateFile struct {
+	// Items: Alert state keyed by certificate location
+	Items map[string]itemState `json:"items"`
+}
+
+// observation is one certificate found during a check.
+type observation struct {
+	// key: Stable location of the certificate, used as the state key
+	key string
+	// source: SourceEndpoint or an inventory source
+	source string
+	// target: File path or endpoint "host:port"
+	target string
+	// secret: Kubernetes secret as "namespace/name", for secrets only
+	secret string
+	// entry: Leaf details and chain health
+	entry x509inventory.EndpointResult
+}
+
+// Watcher periodically checks certificates and notifies sinks of expiry
+// threshold crossings.
+type Watcher struct {
+	// config: Validated configuration
+	config *Config
+	// sinks: Notification destinations, in configuration order
+	sinks []Sink
+	// version: Application version for User-Agent headers
+	version string
+	// log: Destination for progress and delivery errors
+	log logger.Logger
+
+	// mu: Serializes checks and guards state
+	mu sync.Mutex
+	// state: Alert state keyed by certificate location
+	state map[string]itemState
+}
+
+// New creates a watcher for cfg, building its sinks and loading any
+// previous state from cfg.StateFile.
+//
+// Parameters:
+//   - cfg: Watch configuration, validated by New
+//   - version: Application version for User-Agent headers
+//   - log: Logger for progress and delivery errors
+//
+// Returns:
+//   - *Watcher: Configured watcher
+//   - error: Configuration, sink, or state file error
+func New(cfg *Config, version string, log logger.Logger) (*Watcher, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{config: cfg, version: version, log: log, state: make(map[string]itemState)}
+	for _, sinkConfig := range cfg.Sinks {
+		sink, err := NewSink(sinkConfig, version, cfg.timeout)
+		if err != nil {
+			return nil, err
+		}
+		w.sinks = append(w.sinks, sink)
+	}
+
+	if cfg.StateFile != "" {
+		data, err := os.ReadFile(cfg.StateFile)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+		case err != nil:
+			return nil, fmt.Errorf("failed to read watch state file: %w", err)
+		default:
+			var saved stateFile
+			if err := json.Unmarshal(data, &saved); err != nil {
+				return nil, fmt.Errorf("failed to parse watch state file: %w", err)
+			}
+			if saved.Items != nil {
+				w.state = saved.Items
+			}
+		}
+	}
+	return w, nil
+}
+
+// Run checks immediately and then once per configured interval until ctx is
+// cancelled. Check errors are logged and do not stop the watcher.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the watcher
+//
+// Returns:
+//   - error: nil once ctx is cancelled
+func (w *Watcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(w.config.interval)
+	defer ticker.Stop()
+
+	for {
+		events, err := w.Check(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			w.log.Printf("Watch check failed: %v", err)
+		}
+		w.log.Printf("Watch check complete: %d event(s), next check in %s", len(events), w.config.interval)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check scans every configured file and endpoint once, sends an event to
+// every sink for each tier crossing, expiry, or renewal, and saves the state.
+//
+// State advances even when a sink fails, so a broken sink cannot cause the
+// same alert to be repeated through the working sinks on every check.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - []Event: Events raised by this check
+//   - error: Scan, delivery, or state file errors, joined
+//
+// Thread Safety: Safe for concurrent use; checks are serialized.
+func (w *Watcher) Check(ctx context.Context) ([]Event, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	observations, keep, scanErr := w.collect(ctx)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	now := time.Now()
+	var events []Event
+	for _, obs := range observations {
+		keep[obs.key] = true
+		next, event, ok := w.evaluate(w.state[obs.key], obs, now)
+		w.state[obs.key] = next
+		if ok {
+			events = append(events, event)
+		}
+	}
+	// Forget certificates that were removed from disk or the configuration,
+	// unless a failed scan may have missed them
+	if scanErr == nil {
+		for key := range w.state {
+			if !keep[key] {
+				delete(w.state, key)
+			}
+		}
+	}
+
+	errs := []error{scanErr}
+	for _, event := range events {
+		for _, sink := range w.sinks {
+			if err := sink.Notify(ctx, event); err != nil {
+				w.log.Printf("Failed to deliver %s event for %s: %v", event.Kind, event.Target, err)
+				errs = append(errs, err)
+			}
+		}
+	}
+	errs = append(errs, w.saveState())
+	return events, errors.Join(errs...)
+}
+
+// collect scans the configured files and endpoints. Unreachable endpoints are
+// returned in keep so that their state survives a transient outage.
+func (w *Watcher) collect(ctx context.Context) (observations []observation, keep map[string]bool, err error) {
+	keep = make(map[string]bool)
+	var errs []error
+
+	if len(w.config.Files) > 0 {
+		report, scanErr := x509inventory.Scan(ctx, w.config.Files, x509inventory.Options{
+			Version:  w.version,
+			Timeout:  w.config.timeout,
+			WarnDays: w.config.Thresholds[0],
+		})
+		if scanErr != nil {
+			errs = append(errs, fmt.Errorf("error scanning files: %w", scanErr))
+		} else {
+			for _, fileErr := range report.Errors {
+				w.log.Printf("Skipping %s: %s", fileErr.Path, fileErr.Error)
+			}
+			for _, entry := range report.Entries {
+				observations = append(observations, observation{
+					key:    entry.Source + ":" + entry.Path + "#" + entry.Secret + "#" + entry.Subject,
+					source: entry.Source,
+					target: entry.Path,
+					secret: entry.Secret,
+					entry: x509inventory.EndpointResult{
+						Subject:       entry.Subject,
+						Issuer:        entry.Issuer,
+						NotAfter:      entry.NotAfter,
+						DaysRemaining: entry.DaysRemaining,
+						SHA256:        entry.SHA256,
+						Health:        entry.Health,
+						Detail:        entry.Detail,
+					},
+				})
+			}
+		}
+	}
+
+	if len(w.config.Endpoints) > 0 {
+		report, scanErr := x509inventory.ScanEndpoints(ctx, w.config.Endpoints, x509inventory.EndpointOptions{
+			Version:  w.version,
+			Timeout:  w.config.timeout,
+			WarnDays: w.config.Thresholds[0],
+		}, nil)
+		if scanErr != nil {
+			errs = append(errs, fmt.Errorf("error scanning endpoints: %w", scanErr))
+		}
+		if report != nil {
+			for _, result := range report.Results {
+				key := SourceEndpoint + ":" + result.Target
+				if result.Health == x509inventory.HealthUnreachable {
+					w.log.Printf("Endpoint %s unreachable: %s", result.Target, result.Detail)
+					keep[key] = true
+					continue
+				}
+				observations = append(observations, observation{
+					key:    key,
+					source: SourceEndpoint,
+					target: result.Target,
+					entry:  result,
+				})
+			}
+		}
+	}
+	return observations, keep, errors.Join(errs...)
+}
+
+// evaluate compares a certificate with its previous state and returns the
+// next state and the event to raise, if any.
+func (w *Watcher) evaluate(prev itemState, obs observation, now time.Time) (next itemState, event Event, ok bool) {
+	days := obs.entry.DaysRemaining
+	next = prev
+	if prev.SHA256 != obs.entry.SHA256 {
+		// A different certificate at the same location starts with fresh tiers
+		next = itemState{}
+	}
+	next.SHA256 = obs.entry.SHA256
+	next.NotAfter = obs.entry.NotAfter
+	next.LastSeen = now
+
+	event = Event{
+		Source:        obs.source,
+		Target:        obs.target,
+		Secret:        obs.secret,
+		Subject:       obs.entry.Subject,
+		Issuer:        obs.entry.Issuer,
+		SHA256:        obs.entry.SHA256,
+		NotAfter:      obs.entry.NotAfter,
+		DaysRemaining: days,
+		Health:        obs.entry.Health,
+		Detail:        obs.entry.Detail,
+		Time:          now,
+	}
+	location := obs.source + " " + obs.target
+	if obs.secret != "" {
+		location += " (" + obs.secret + ")"
+	}
+	expiry := obs.entry.NotAfter.UTC().Format(time.DateOnly)
+
+	tier := w.tier(days)
+	switch {
+	case obs.entry.NotAfter.Before(now):
+		if next.Expired {
+			return next, Event{}, false
+		}
+		next.Expired = true
+		event.Kind = EventExpired
+		event.Message = fmt.Sprintf("Certificate %s at %s expired on %s", event.Subject, location, expiry)
+	case tier > 0:
+		if next.Threshold > 0 && next.Threshold <= tier {
+			return next, Event{}, false
+		}
+		next.Threshold = tier
+		event.Kind = EventThreshold
+		event.Threshold = tier
+		event.Message = fmt.Sprintf("Certificate %s at %s expires in %d days on %s, within the %d-day threshold",
+			event.Subject, location, days, expiry, tier)
+	default:
+		if !prev.alerted() {
+			return next, Event{}, false
+		}
+		next = itemState{SHA256: next.SHA256, NotAfter: next.NotAfter, LastSeen: now}
+		event.Kind = EventRenewed
+		event.Message = fmt.Sprintf("Certificate %s at %s was renewed and now expires in %d days on %s",
+			event.Subject, location, days, expiry)
+	}
+	return next, event, true
+}
+
+// tier returns the nearest threshold that days falls within, or 0 when days
+// is beyond every threshold.
+func (w *Watcher) tier(days int) int {
+	tier := 0
+	for _, threshold := range w.config.Thresholds {
+		if days <= threshold {
+			tier = threshold
+		}
+	}
+	return tier
+}
+
+// saveState atomically writes the state to the configured state file.
+func (w *Watcher) saveState() error {
+	if w.config.StateFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(stateFile{Items: w.state}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode watch state: %w", err)
+	}
+
+	// Write to a temporary file in the same directory, then rename over the
+	// state file so that a crash never leaves a truncated file behind
+	tmp, err := os.CreateTemp(filepath.Dir(w.config.StateFile), ".watch-state-*")
+	if err != nil {
+		return fmt.Errorf("failed to write watch state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write watch state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write watch state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), w.config.StateFile); err != nil {
+		return fmt.Errorf("failed to write watch state file: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package x509watch
+
+import (
+	"bufio"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes a self-signed CA certificate expiring after validFor to dir/name.
+func writeCert(t *testing.T, dir, name, cn string, validFor time.Duration) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		NotBefore:             time.Now().Add(-48 * time.Hour),
+		NotAfter:              time.Now().Add(validFor),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+}
+
+// webhookReceiver records the events POSTed to an in-process HTTP server.
+type webhookReceiver struct {
+	mu      sync.Mutex
+	events  []Event
+	headers []http.Header
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var event Event
+	if err := json.NewDecoder(req.Body).Decode(&event); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	r.mu.Lock()
+	r.events = append(r.events, event)
+	r.headers = append(r.headers, req.Header.Clone())
+	r.mu.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// take returns and clears the received events, sorted by subject.
+func (r *webhookReceiver) take() []Event {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	events := r.events
+	r.events = nil
+	sort.Slice(events, func(i, j int) bool { return events[i].Subject < events[j].Subject })
+	return events
+}
+
+// kinds returns "subject=kind" for every event.
+func kinds(events []Event) []string {
+	var out []string
+	for _, event := range events {
+		out = append(out, event.Subject+"="+event.Kind)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestWatcher_Check(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+	receiver := &webhookReceiver{}
+	server := httptest.NewServer(receiver)
+	defer server.Close()
+
+	certDir := t.TempDir()
+	stateDir := t.TempDir()
+	day := 24 * time.Hour
+	writeCert(t, certDir, "far.pem", "far.example.com", 200*day)
+	writeCert(t, certDir, "soon.pem", "soon.example.com", 20*day+time.Hour)
+	writeCert(t, certDir, "urgent.pem", "urgent.example.com", 5*day+time.Hour)
+	writeCert(t, certDir, "expired.pem", "expired.example.com", -day)
+
+	eventLog := filepath.Join(stateDir, "events.jsonl")
+	cfg := &Config{
+		StateFile: filepath.Join(stateDir, "state.json"),
+		Files:     []string{certDir},
+		Sinks: []SinkConfig{
+			{Type: SinkWebhook, URL: server.URL + "/hook", Headers: map[string]string{"Authorization": "Bearer secret"}},
+			{Type: SinkFile, Path: eventLog},
+		},
+	}
+	w, err := New(cfg, "1.0.0", log)
+	require.NoError(t, err)
+	assert.Equal(t, []int{30, 14, 7, 1}, cfg.Thresholds)
+
+	t.Run("First Check", func(t *testing.T) {
+		events, err := w.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"CN=expired.example.com=expired",
+			"CN=soon.example.com=threshold",
+			"CN=urgent.example.com=threshold",
+		}, kinds(events))
+
+		received := receiver.take()
+		require.Len(t, received, 3)
+		assert.Equal(t, 30, received[1].Threshold)
+		assert.Equal(t, 20, received[1].DaysRemaining)
+		assert.Equal(t, 7, received[2].Threshold)
+		assert.Contains(t, received[2].Message, "within the 7-day threshold")
+		assert.Equal(t, "Bearer secret", receiver.headers[0].Get("Authorization"))
+		assert.Equal(t, "application/json", receiver.headers[0].Get("Content-Type"))
+		assert.Contains(t, receiver.headers[0].Get("User-Agent"), "1.0.0")
+
+		f, err := os.Open(eventLog)
+		require.NoError(t, err)
+		defer f.Close()
+		lines := 0
+		for scanner := bufio.NewScanner(f); scanner.Scan(); lines++ {
+			var event Event
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		}
+		assert.Equal(t, 3, lines)
+	})
+
+	t.Run("No Repeat", func(t *testing.T) {
+		events, err := w.Check(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, events)
+		assert.Empty(t, receiver.take())
+	})
+
+	t.Run("State Survives Restart", func(t *testing.T) {
+		restarted, err := New(cfg, "1.0.0", log)
+		require.NoError(t, err)
+		events, err := restarted.Check(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("Nearer Tier And Renewal", func(t *testing.T) {
+		writeCert(t, certDir, "soon.pem", "soon.example.com", 90*day)
+		writeCert(t, certDir, "urgent.pem", "urgent.example.com", 12*time.Hour)
+
+		events, err := w.Check(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{
+			"CN=soon.example.com=renewed",
+			"CN=urgent.example.com=threshold",
+		}, kinds(events))
+		for _, event := range events {
+			if event.Kind == EventThreshold {
+				assert.Equal(t, 1, event.Threshold)
+			}
+		}
+	})
+
+	t.Run("Removed Certificates Are Forgotten", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(certDir, "expired.pem")))
+		_, err := w.Check(context.Background())
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(cfg.StateFile)
+		require.NoError(t, err)
+		var saved stateFile
+		require.NoError(t, json.Unmarshal(data, &saved))
+		assert.Len(t, saved.Items, 3)
+	})
+}
+
+func TestWatcher_SinkFailure(t *testing.T) {
+	log := logger.NewMCPLogger(io.Discard, true)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	certDir := t.TempDir()
+	writeCert(t, certDir, "soon.pem", "soon.example.com", 3*24*time.Hour)
+
+	eventLog := filepath.Join(t.TempDir(), "events.jsonl")
+	w, err := New(&Config{
+		Files: []string{certDir},
+		Sinks: []SinkConfig{
+			{Type: SinkWebhook, URL: server.URL + "/services/T000/B000/secret-token?token=query-secret"},
+			{Type: SinkFile, Path: eventLog},
+		},
+	}, "1.0.0", log)
+	require.NoError(t, err)
+
+	events, err := w.Check(context.Background())
+	assert.ErrorContains(t, err, "503")
+	// The webhook is named without the secrets in its path and query
+	assert.ErrorContains(t, err, "webhook "+server.URL+" returned")
+	assert.NotContains(t, err.Error(), "secret")
+	assert.Len(t, events, 1)
+	assert.FileExists(t, eventLog, "working sinks still receive the event")
+
+	// State advanced despite the failure, so the alert is not repeated
+	events, err = w.Check(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
+
+func TestWebhookSink_RedactsURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	hookURL := server.URL + "/hooks/secret-token?key=query-secret"
+	// Requests to a closed server fail before any response
+	server.Close()
+
+	sink, err := NewSink(SinkConfig{Type: SinkWebhook, URL: hookURL}, "1.0.0", time.Second)
+	require.NoError(t, err)
+	err = sink.Notify(context.Background(), Event{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "webhook request to "+server.URL+" failed")
+	assert.NotContains(t, err.Error(), "secret")
+}
+
+func TestCommandSink(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a POSIX shell")
+	}
+
+	out := filepath.Join(t.TempDir(), "out.txt")
+	sink, err := NewSink(SinkConfig{
+		Type:    SinkCommand,
+		Command: []string{"sh", "-c", `printf '%s|%s|' "$CERT_WATCH_KIND" "$CERT_WATCH_THRESHOLD" > "$0"; cat >> "$0"`, out},
+	}, "1.0.0", 5*time.Second)
+	require.NoError(t, err)
+
+	event := Event{Kind: EventThreshold, Target: "/etc/ssl/site.pem", Subject: "CN=site", Threshold: 14}
+	require.NoError(t, sink.Notify(context.Background(), event))
+
+	data, err := os.ReadFile(out)
+	require.NoError(t, err)
+	parts := strings.SplitN(string(data), "|", 3)
+	require.Len(t, parts, 3)
+	assert.Equal(t, []string{"threshold", "14"}, parts[:2])
+	var got Event
+	require.NoError(t, json.Unmarshal([]byte(parts[2]), &got))
+	assert.Equal(t, event.Subject, got.Subject)
+
+	failing, err := NewSink(SinkConfig{Type: SinkCommand, Command: []string{"sh", "-c", "echo broken >&2; exit 3"}}, "1.0.0", time.Second)
+	require.NoError(t, err)
+	assert.ErrorContains(t, failing.Notify(context.Background(), event), "broken")
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("YAML", func(t *testing.T) {
+		path := filepath.Join(dir, "watch.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(`interval: 15m
+thresholds: [7, 30, 7]
+files: [/etc/ssl/private]
+endpoints: [example.com]
+sinks:
+  - type: webhook
+    url: https://hooks.example.com/tls
+  - type: syslog
+`), 0644))
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, cfg.interval)
+		assert.Equal(t, DefaultTimeout, cfg.timeout)
+		assert.Equal(t, []int{30, 7}, cfg.Thresholds)
+	})
+
+	t.Run("JSON Unknown Field", func(t *testing.T) {
+		path := filepath.Join(dir, "watch.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"files":["/tmp"],"sinks":[{"type":"file","path":"/tmp/x"}],"treshold":[1]}`), 0644))
+		_, err := LoadConfig(path)
+		assert.ErrorContains(t, err, "unknown field")
+	})
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{"No Targets", Config{Sinks: []SinkConfig{{Type: SinkSyslog}}}, ErrNoTargets.Error()},
+		{"No Sinks", Config{Files: []string{"/tmp"}}, ErrNoSinks.Error()},
+		{"Bad Interval", Config{Interval: "soon", Files: []string{"/tmp"}, Sinks: []SinkConfig{{Type: SinkSyslog}}}, "invalid interval"},
+		{"Bad Threshold", Config{Thresholds: []int{0}, Files: []string{"/tmp"}, Sinks: []SinkConfig{{Type: SinkSyslog}}}, "invalid threshold"},
+		{"Bad Sink", Config{Files: []string{"/tmp"}, Sinks: []SinkConfig{{Type: "pager"}}}, "unsupported sink type"},
+		{"Webhook Without URL", Config{Files: []string{"/tmp"}, Sinks: []SinkConfig{{Type: SinkWebhook}}}, "sink 1: webhook url"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.cfg.Validate(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,609 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// Authentication method names reported in [Identity.Method].
+const (
+	// AuthMethodToken identifies clients by a static bearer token
+	AuthMethodToken = "token"
+	// AuthMethodClientCert identifies clients by a TLS client certificate
+	AuthMethodClientCert = "mtls"
+	// AuthMethodIntrospection identifies clients by an OAuth token validated through RFC 7662 introspection
+	AuthMethodIntrospection = "introspection"
+)
+
+// RoleAll grants an identity every tool role.
+const RoleAll = "*"
+
+// legacyTokenIdentity is the identity name of the --auth-token bearer token,
+// which keeps full access unless the configuration assigns it roles.
+const legacyTokenIdentity = "auth-token"
+
+// authMethods lists the authentication methods an identity key may name.
+var authMethods = []string{AuthMethodToken, AuthMethodClientCert, AuthMethodIntrospection}
+
+// roleKey returns the key of an identity in [AuthConfig.Identities].
+//
+// Keys qualify the identity name with its authentication method, so that a
+// certificate common name or introspection subject matching a token identity
+// does not receive that identity's roles.
+func roleKey(method, name string) string {
+	return method + ":" + name
+}
+
+var (
+	// ErrNoCredentials is returned by an [Authenticator] when the request
+	// carries none of the credentials it understands, so that the next
+	// authenticator in the chain is tried.
+	ErrNoCredentials = errors.New("no credentials")
+
+	// ErrUnauthenticated is returned when credentials are missing or invalid.
+	ErrUnauthenticated = errors.New("unauthenticated")
+
+	// ErrToolForbidden is returned when an identity calls a tool outside its allowlist.
+	ErrToolForbidden = errors.New("tool not permitted for this identity")
+)
+
+// AuthConfig configures authentication and per-identity authorization for the
+// network transports.
+//
+// Each authenticator resolves a request to an identity name; Identities then
+// lists the tool roles (the Role field of [ToolDefinition] and
+// [ToolDefinitionWithConfig]) that identity may list and call, keyed by
+// "<method>:<name>" with method token, mtls, or introspection (for example
+// "token:ci" or "mtls:client.example.com"). Authenticated identities without
+// an entry may not use any tool.
+type AuthConfig struct {
+	// Tokens: Static bearer tokens and the identity each one authenticates
+	Tokens []TokenCredential `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+	// ClientCA: PEM file of CAs that issue client certificates; enables mTLS authentication
+	ClientCA string `json:"clientCA,omitempty" yaml:"clientCA,omitempty"`
+	// CheckClientRevocation: Reject client certificates reported revoked by OCSP or CRL
+	CheckClientRevocation bool `json:"checkClientRevocation,omitempty" yaml:"checkClientRevocation,omitempty"`
+	// Introspection: OAuth 2.0 token introspection endpoint for bearer tokens
+	Introspection IntrospectionConfig `json:"introspection" yaml:"introspection"`
+	// Identities: Tool roles allowed per "<method>:<name>" identity key; "*" allows every tool
+	Identities map[string][]string `json:"identities,omitempty" yaml:"identities,omitempty"`
+}
+
+// TokenCredential maps a static bearer token to an identity.
+type TokenCredential struct {
+	// Identity: Identity name the token authenticates
+	Identity string `json:"identity" yaml:"identity"`
+	// Token: Bearer token value
+	Token string `json:"token,omitempty" yaml:"token,omitempty"`
+	// TokenEnv: Environment variable holding the token, used when Token is empty
+	TokenEnv string `json:"tokenEnv,omitempty" yaml:"tokenEnv,omitempty"`
+}
+
+// IntrospectionConfig configures [RFC 7662] token introspection.
+//
+// [RFC 7662]: https://www.rfc-editor.org/rfc/rfc7662
+type IntrospectionConfig struct {
+	// URL: Introspection endpoint; empty disables introspection
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+	// ClientID: Client ID for HTTP basic authentication at the endpoint
+	ClientID string `json:"clientId,omitempty" yaml:"clientId,omitempty"`
+	// ClientSecret: Client secret for HTTP basic authentication at the endpoint
+	ClientSecret string `json:"clientSecret,omitempty" yaml:"clientSecret,omitempty"`
+}
+
+// Identity is an authenticated MCP client.
+type Identity struct {
+	// Name: Identity name from the token mapping, certificate common name, or introspection subject
+	Name string
+	// Method: Authentication method that produced the identity
+	Method string
+	// Roles: Tool roles the identity may use
+	Roles []string
+}
+
+// Allows reports whether the identity may use a tool with the given role.
+//
+// Parameters:
+//   - role: Tool role from the tool definition
+//
+// Returns:
+//   - bool: True when the role, or RoleAll, is in the allowlist
+func (id *Identity) Allows(role string) bool {
+	return slices.Contains(id.Roles, RoleAll) || (role != "" && slices.Contains(id.Roles, role))
+}
+
+// identityKey is the context key for the authenticated [Identity].
+type identityKey struct{}
+
+// withIdentity returns a copy of ctx carrying id.
+func withIdentity(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// IdentityFromContext returns the authenticated identity of the request
+// being handled, or nil for unauthenticated transports such as stdio.
+//
+// Parameters:
+//   - ctx: Request context passed to tool, resource, or prompt handlers
+//
+// Returns:
+//   - *Identity: Authenticated identity, or nil
+func IdentityFromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
+
+// Authenticator resolves an HTTP request to an identity name.
+//
+// Implementations return [ErrNoCredentials] when the request does not carry
+// their kind of credential, and any other error when the credential is
+// present but invalid.
+type Authenticator interface {
+	// Authenticate returns the identity name and method for the request.
+	Authenticate(r *http.Request) (name, method string, err error)
+}
+
+// StaticTokenAuthenticator authenticates bearer tokens against a fixed set.
+//
+// Tokens are compared by SHA-256 digest in constant time, so neither the
+// token nor its length leaks through response timing.
+type StaticTokenAuthenticator struct {
+	// tokens: Token digests mapped to identity names
+	tokens map[[sha256.Size]byte]string
+}
+
+// NewStaticTokenAuthenticator creates an authenticator for the given credentials.
+//
+// Parameters:
+//   - creds: Token to identity mappings; TokenEnv is resolved when Token is empty
+//
+// Returns:
+//   - *StaticTokenAuthenticator: Authenticator for the tokens
+//   - error: Credential without an identity or token
+func NewStaticTokenAuthenticator(creds []TokenCredential) (*StaticTokenAuthenticator, error) {
+	a := &StaticTokenAuthenticator{tokens: make(map[[sha256.Size]byte]string, len(creds))}
+	for i, cred := range creds {
+		token := cred.Token
+		if token == "" && cred.TokenEnv != "" {
+			token = os.Getenv(cred.TokenEnv)
+		}
+		if cred.Identity == "" || token == "" {
+			return nil, fmt.Errorf("auth token %d: identity and token (or a set tokenEnv) are required", i+1)
+		}
+		a.tokens[sha256.Sum256([]byte(token))] = cred.Identity
+	}
+	return a, nil
+}
+
+// Authenticate implements [Authenticator].
+func (a *StaticTokenAuthenticator) Authenticate(r *http.Request) (string, string, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return "", "", ErrNoCredentials
+	}
+	digest := sha256.Sum256([]byte(token))
+	var name string
+	for known, identity := range a.tokens {
+		if subtle.ConstantTimeCompare(digest[:], known[:]) == 1 {
+			name = identity
+		}
+	}
+	if name == "" {
+		// Leave the token to a later authenticator such as introspection
+		return "", "", ErrNoCredentials
+	}
+	return name, AuthMethodToken, nil
+}
+
+// ClientCertAuthenticator authenticates TLS client certificates issued by a
+// configured set of client CAs. The identity name is the certificate's
+// subject common name.
+type ClientCertAuthenticator struct {
+	// roots: Client CAs trusted as chain anchors
+	roots *x509.CertPool
+	// checkRevocation: Whether to consult OCSP and CRLs through the chain package
+	checkRevocation bool
+	// version: Application version for OCSP and CRL request User-Agent headers
+	version string
+	// timeout: Bound on revocation checks
+	timeout time.Duration
+}
+
+// NewClientCertAuthenticator loads the client CA file.
+//
+// Parameters:
+//   - caFile: PEM file containing one or more client CA certificates
+//   - checkRevocation: Reject certificates reported revoked by OCSP or CRL
+//   - version: Application version for HTTP User-Agent headers
+//   - timeout: Bound on revocation checks
+//
+// Returns:
+//   - *ClientCertAuthenticator: Authenticator trusting the CAs in caFile
+//   - error: Unreadable file or no certificates in it
+func NewClientCertAuthenticator(caFile string, checkRevocation bool, version string, timeout time.Duration) (*ClientCertAuthenticator, error) {
+	data, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client CA file: %w", err)
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", caFile)
+	}
+	return &ClientCertAuthenticator{roots: roots, checkRevocation: checkRevocation, version: version, timeout: timeout}, nil
+}
+
+// Authenticate implements [Authenticator].
+//
+// The presented chain is verified for client authentication against the
+// configured CAs with [x509chain.Chain.VerifyAgainstRoots] and, when enabled,
+// every non-root certificate of the verified chain is checked for revocation
+// with [x509chain.Chain.CheckRevocation].
+func (a *ClientCertAuthenticator) Authenticate(r *http.Request) (string, string, error) {
+	if r.TLS == nil || len(r.TLS.PeerCertificates) == 0 {
+		return "", "", ErrNoCredentials
+	}
+	peer := r.TLS.PeerCertificates
+
+	ch := x509chain.New(peer[0], a.version)
+	ch.Roots = a.roots
+	for _, cert := range peer[1:] {
+		ch.Intermediates.AddCert(cert)
+	}
+	if err := ch.VerifyAgainstRoots(x509.ExtKeyUsageClientAuth); err != nil {
+		return "", "", fmt.Errorf("client certificate rejected: %w", err)
+	}
+
+	if a.checkRevocation {
+		ctx, cancel := context.WithTimeout(r.Context(), a.timeout)
+		defer cancel()
+		for _, status := range ch.CheckRevocation(ctx) {
+			if status.Status == x509chain.RevocationRevoked {
+				return "", "", fmt.Errorf("client certificate %s is revoked (%s)", status.Subject, status.Source)
+			}
+		}
+	}
+
+	name := peer[0].Subject.CommonName
+	if name == "" {
+		return "", "", errors.New("client certificate has no subject common name")
+	}
+	return name, AuthMethodClientCert, nil
+}
+
+const (
+	// introspectionCacheTTL bounds how long an active token is trusted without asking the endpoint again.
+	introspectionCacheTTL = time.Minute
+	// maxIntrospectionCacheEntries bounds the tokens remembered by an [IntrospectionAuthenticator].
+	maxIntrospectionCacheEntries = 1024
+)
+
+// IntrospectionAuthenticator validates opaque OAuth bearer tokens with an
+// [RFC 7662] introspection endpoint. The identity name is the "sub" claim,
+// falling back to "username" and "client_id".
+//
+// Active tokens are remembered by their SHA-256 hash until they expire, but
+// for at most introspectionCacheTTL, so that each request does not wait for
+// the endpoint; a revoked token is refused once its entry lapses.
+//
+// Thread Safety: Safe for concurrent use.
+//
+// [RFC 7662]: https://www.rfc-editor.org/rfc/rfc7662
+type IntrospectionAuthenticator struct {
+	// config: Endpoint and client credentials
+	config IntrospectionConfig
+	// client: HTTP client used for introspection requests
+	client *http.Client
+	// mu: Protects cache
+	mu sync.Mutex
+	// cache: Identities of recently introspected active tokens, by token hash
+	cache map[[sha256.Size]byte]introspectedToken
+}
+
+// introspectedToken is an active token remembered by an [IntrospectionAuthenticator].
+type introspectedToken struct {
+	// name: Identity name of the token
+	name string
+	// expires: Time until which the token is trusted without introspection
+	expires time.Time
+}
+
+// introspectionResponse holds the introspection claims used for identification.
+type introspectionResponse struct {
+	Active   bool   `json:"active"`
+	Subject  string `json:"sub"`
+	Username string `json:"username"`
+	ClientID string `json:"client_id"`
+	Expiry   int64  `json:"exp"`
+}
+
+// NewIntrospectionAuthenticator creates an authenticator for the endpoint.
+//
+// Parameters:
+//   - config: Endpoint URL and optional client credentials
+//   - timeout: Bound on each introspection request
+//
+// Returns:
+//   - *IntrospectionAuthenticator: Authenticator using the endpoint
+//   - error: Invalid endpoint URL
+func NewIntrospectionAuthenticator(config IntrospectionConfig, timeout time.Duration) (*IntrospectionAuthenticator, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid introspection URL %q", config.URL)
+	}
+	return &IntrospectionAuthenticator{
+		config: config,
+		client: &http.Client{Timeout: timeout},
+		cache:  make(map[[sha256.Size]byte]introspectedToken),
+	}, nil
+}
+
+// Authenticate implements [Authenticator].
+func (a *IntrospectionAuthenticator) Authenticate(r *http.Request) (string, string, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return "", "", ErrNoCredentials
+	}
+
+	key := sha256.Sum256([]byte(token))
+	now := time.Now()
+	a.mu.Lock()
+	cached, ok := a.cache[key]
+	a.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.name, AuthMethodIntrospection, nil
+	}
+
+	name, expiry, err := a.introspect(r.Context(), token)
+	if err != nil {
+		return "", "", err
+	}
+	expires := now.Add(introspectionCacheTTL)
+	if !expiry.IsZero() && expiry.Before(expires) {
+		expires = expiry
+	}
+	a.remember(key, introspectedToken{name: name, expires: expires}, now)
+	return name, AuthMethodIntrospection, nil
+}
+
+// remember caches an active token, dropping expired entries, and then the
+// one expiring soonest, once maxIntrospectionCacheEntries are held.
+func (a *IntrospectionAuthenticator) remember(key [sha256.Size]byte, token introspectedToken, now time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.cache[key]; !ok && len(a.cache) >= maxIntrospectionCacheEntries {
+		maps.DeleteFunc(a.cache, func(_ [sha256.Size]byte, t introspectedToken) bool { return !now.Before(t.expires) })
+		if len(a.cache) >= maxIntrospectionCacheEntries {
+			var soonest [sha256.Size]byte
+			first := true
+			for k, t := range a.cache {
+				if first || t.expires.Before(a.cache[soonest].expires) {
+					soonest, first = k, false
+				}
+			}
+			delete(a.cache, soonest)
+		}
+	}
+	a.cache[key] = token
+}
+
+// introspect asks the endpoint about token.
+//
+// Parameters:
+//   - ctx: Context of the authenticated request
+//   - token: Bearer token to introspect
+//
+// Returns:
+//   - string: Identity name of the active token
+//   - time.Time: Expiry of the token, or the zero time if the endpoint reports none
+//   - error: Request error, or error if the token is not active or identifies no subject
+func (a *IntrospectionAuthenticator) introspect(ctx context.Context, token string) (string, time.Time, error) {
+	form := url.Values{"token": {token}, "token_type_hint": {"access_token"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.config.URL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if a.config.ClientID != "" {
+		req.SetBasicAuth(url.QueryEscape(a.config.ClientID), url.QueryEscape(a.config.ClientSecret))
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("token introspection failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", time.Time{}, fmt.Errorf("token introspection failed: %s", resp.Status)
+	}
+
+	var claims introspectionResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&claims); err != nil {
+		return "", time.Time{}, fmt.Errorf("invalid introspection response: %w", err)
+	}
+	if !claims.Active || (claims.Expiry != 0 && time.Now().Unix() >= claims.Expiry) {
+		return "", time.Time{}, errors.New("token is not active")
+	}
+	var expiry time.Time
+	if claims.Expiry != 0 {
+		expiry = time.Unix(claims.Expiry, 0)
+	}
+	for _, name := range []string{claims.Subject, claims.Username, claims.ClientID} {
+		if name != "" {
+			return name, expiry, nil
+		}
+	}
+	return "", time.Time{}, errors.New("introspection response identifies no subject")
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// authenticatorChain tries each authenticator in order.
+type authenticatorChain []Authenticator
+
+// Authenticate implements [Authenticator], returning the first identity found.
+func (c authenticatorChain) Authenticate(r *http.Request) (string, string, error) {
+	for _, a := range c {
+		name, method, err := a.Authenticate(r)
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return name, method, err
+	}
+	return "", "", ErrNoCredentials
+}
+
+// newAuthenticator builds the authenticator chain from the configuration and
+// the --auth-token option.
+//
+// The --auth-token token authenticates the "auth-token" identity, which is
+// granted every tool unless the configuration lists it.
+//
+// Parameters:
+//   - cfg: Authentication configuration
+//   - authToken: Token from --auth-token or MCP_X509_AUTH_TOKEN; may be empty
+//   - version: Application version for HTTP User-Agent headers
+//   - timeout: Bound on revocation checks and introspection requests
+//
+// Returns:
+//   - Authenticator: Chain of configured authenticators, or nil when none are configured
+//   - map[string][]string: Tool roles per identity key (see [roleKey])
+//   - error: Invalid identity key or authenticator configuration
+func newAuthenticator(cfg AuthConfig, authToken, version string, timeout time.Duration) (Authenticator, map[string][]string, error) {
+	identities := make(map[string][]string, len(cfg.Identities)+1)
+	for key, roles := range cfg.Identities {
+		method, name, ok := strings.Cut(key, ":")
+		if !ok || name == "" || !slices.Contains(authMethods, method) {
+			return nil, nil, fmt.Errorf("invalid identity %q: expected <method>:<name> with method %s", key, strings.Join(authMethods, ", "))
+		}
+		identities[key] = roles
+	}
+
+	tokens := cfg.Tokens
+	if authToken != "" {
+		tokens = append(slices.Clone(tokens), TokenCredential{Identity: legacyTokenIdentity, Token: authToken})
+		key := roleKey(AuthMethodToken, legacyTokenIdentity)
+		if _, ok := identities[key]; !ok {
+			identities[key] = []string{RoleAll}
+		}
+	}
+
+	var chain authenticatorChain
+	if cfg.ClientCA != "" {
+		a, err := NewClientCertAuthenticator(cfg.ClientCA, cfg.CheckClientRevocation, version, timeout)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain = append(chain, a)
+	}
+	if len(tokens) > 0 {
+		a, err := NewStaticTokenAuthenticator(tokens)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain = append(chain, a)
+	}
+	if cfg.Introspection.URL != "" {
+		a, err := NewIntrospectionAuthenticator(cfg.Introspection, timeout)
+		if err != nil {
+			return nil, nil, err
+		}
+		chain = append(chain, a)
+	}
+	if len(chain) == 0 {
+		return nil, nil, nil
+	}
+	return chain, identities, nil
+}
+
+// requireAuthentication rejects requests that no authenticator accepts and
+// attaches the resulting [Identity] to the request context of the rest.
+//
+// Parameters:
+//   - auth: Authenticator resolving requests to identity names
+//   - identities: Tool roles per identity key (see [roleKey])
+//   - next: Handler serving authenticated requests
+//
+// Returns:
+//   - http.Handler: Handler enforcing authentication
+func requireAuthentication(auth Authenticator, identities map[string][]string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, method, err := auth.Authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="x509-cert-chain-resolver"`)
+			http.Error(w, ErrUnauthenticated.Error(), http.StatusUnauthorized)
+			return
+		}
+		id := &Identity{Name: name, Method: method, Roles: identities[roleKey(method, name)]}
+		next.ServeHTTP(w, r.WithContext(withIdentity(r.Context(), id)))
+	})
+}
+
+// toolRoleIndex maps tool names to their roles.
+func toolRoleIndex(tools []ToolDefinition, toolsWithConfig []ToolDefinitionWithConfig) map[string]string {
+	roles := make(map[string]string, len(tools)+len(toolsWithConfig))
+	for _, tool := range tools {
+		roles[tool.Tool.Name] = tool.Role
+	}
+	for _, tool := range toolsWithConfig {
+		roles[tool.Tool.Name] = tool.Role
+	}
+	return roles
+}
+
+// authorizedToolFilter hides tools outside the caller's allowlist from
+// tools/list. Requests without an identity, such as stdio, see every tool.
+func authorizedToolFilter(roles map[string]string) server.ToolFilterFunc {
+	return func(ctx context.Context, tools []mcp.Tool) []mcp.Tool {
+		id := IdentityFromContext(ctx)
+		if id == nil {
+			return tools
+		}
+		return slices.DeleteFunc(slices.Clone(tools), func(tool mcp.Tool) bool {
+			return !id.Allows(roles[tool.Name])
+		})
+	}
+}
+
+// authorizeToolCall rejects tools/call requests outside the caller's
+// allowlist before the tool handler runs.
+func authorizeToolCall(roles map[string]string) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if id := IdentityFromContext(ctx); id != nil && !id.Allows(roles[request.Params.Name]) {
+				return nil, fmt.Errorf("%w: %s may not call %s", ErrToolForbidden, id.Name, request.Params.Name)
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issueClientCert creates a CA and a client certificate with the given common name.
+func issueClientCert(t *testing.T, cn string) (ca *x509.Certificate, leaf tls.Certificate) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Client CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	ca, err = x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return ca, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: cert}
+}
+
+// writeCAFile writes ca as PEM to dir/ca.pem.
+func writeCAFile(t *testing.T, dir string, ca *x509.Certificate) string {
+	t.Helper()
+	path := filepath.Join(dir, "ca.pem")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0644))
+	return path
+}
+
+func TestStaticTokenAuthenticator(t *testing.T) {
+	t.Setenv("TEST_MCP_TOKEN", "from-env")
+	a, err := NewStaticTokenAuthenticator([]TokenCredential{
+		{Identity: "ci", Token: "ci-token"},
+		{Identity: "ops", TokenEnv: "TEST_MCP_TOKEN"},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		header   string
+		identity string
+		wantErr  error
+	}{
+		{"Inline Token", "Bearer ci-token", "ci", nil},
+		{"Environment Token", "bearer from-env", "ops", nil},
+		{"Unknown Token", "Bearer other", "", ErrNoCredentials},
+		{"Basic Auth", "Basic Y2k6Y2k=", "", ErrNoCredentials},
+		{"Missing Header", "", "", ErrNoCredentials},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			name, method, err := a.Authenticate(r)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.identity, name)
+			assert.Equal(t, AuthMethodToken, method)
+		})
+	}
+
+	_, err = NewStaticTokenAuthenticator([]TokenCredential{{Identity: "ci", TokenEnv: "TEST_MCP_TOKEN_UNSET"}})
+	assert.ErrorContains(t, err, "auth token 1")
+}
+
+func TestClientCertAuthenticator(t *testing.T) {
+	ca, leaf := issueClientCert(t, "alice")
+	_, foreign := issueClientCert(t, "mallory")
+
+	a, err := NewClientCertAuthenticator(writeCAFile(t, t.TempDir(), ca), true, "1.0.0", 5*time.Second)
+	require.NoError(t, err)
+
+	request := func(certs ...*x509.Certificate) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: certs}
+		return r
+	}
+
+	t.Run("Trusted Client", func(t *testing.T) {
+		// The certificate has no OCSP or CRL endpoints, so revocation is unknown and accepted
+		name, method, err := a.Authenticate(request(leaf.Leaf))
+		require.NoError(t, err)
+		assert.Equal(t, "alice", name)
+		assert.Equal(t, AuthMethodClientCert, method)
+	})
+
+	t.Run("Untrusted Issuer", func(t *testing.T) {
+		_, _, err := a.Authenticate(request(foreign.Leaf))
+		assert.ErrorContains(t, err, "client certificate rejected")
+	})
+
+	t.Run("No Certificate", func(t *testing.T) {
+		_, _, err := a.Authenticate(request())
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+
+	_, err = NewClientCertAuthenticator(filepath.Join(t.TempDir(), "missing.pem"), false, "1.0.0", time.Second)
+	assert.ErrorContains(t, err, "failed to read client CA file")
+}
+
+func TestIntrospectionAuthenticator(t *testing.T) {
+	// Local stand-in for an OAuth authorization server
+	var introspections atomic.Int32
+	idp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		introspections.Add(1)
+		if id, secret, ok := r.BasicAuth(); !ok || id != "x509-mcp" || secret != "shh" {
+			http.Error(w, "bad client", http.StatusUnauthorized)
+			return
+		}
+		require.NoError(t, r.ParseForm())
+		var claims map[string]any
+		switch r.PostForm.Get("token") {
+		case "live":
+			claims = map[string]any{"active": true, "sub": "bob", "exp": time.Now().Add(time.Hour).Unix()}
+		case "client":
+			claims = map[string]any{"active": true, "client_id": "ci-bot"}
+		case "expired":
+			claims = map[string]any{"active": true, "sub": "bob", "exp": time.Now().Add(-time.Minute).Unix()}
+		default:
+			claims = map[string]any{"active": false}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(claims)
+	}))
+	defer idp.Close()
+
+	a, err := NewIntrospectionAuthenticator(IntrospectionConfig{URL: idp.URL, ClientID: "x509-mcp", ClientSecret: "shh"}, 5*time.Second)
+	require.NoError(t, err)
+
+	tests := []struct {
+		token    string
+		identity string
+		wantErr  string
+	}{
+		{"live", "bob", ""},
+		{"client", "ci-bot", ""},
+		{"expired", "", "not active"},
+		{"revoked", "", "not active"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			r.Header.Set("Authorization", "Bearer "+tt.token)
+			name, method, err := a.Authenticate(r)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.identity, name)
+			assert.Equal(t, AuthMethodIntrospection, method)
+		})
+	}
+
+	t.Run("cached", func(t *testing.T) {
+		cached, err := NewIntrospectionAuthenticator(IntrospectionConfig{URL: idp.URL, ClientID: "x509-mcp", ClientSecret: "shh"}, 5*time.Second)
+		require.NoError(t, err)
+		authenticate := func(token string) error {
+			r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+			r.Header.Set("Authorization", "Bearer "+token)
+			_, _, err := cached.Authenticate(r)
+			return err
+		}
+
+		// Active tokens are introspected once
+		start := introspections.Load()
+		require.NoError(t, authenticate("live"))
+		require.NoError(t, authenticate("live"))
+		assert.Equal(t, start+1, introspections.Load())
+
+		// Inactive tokens are asked about every time, so activation is seen at once
+		start = introspections.Load()
+		require.Error(t, authenticate("revoked"))
+		require.Error(t, authenticate("revoked"))
+		assert.Equal(t, start+2, introspections.Load())
+	})
+
+	t.Run("cache bounded", func(t *testing.T) {
+		bounded, err := NewIntrospectionAuthenticator(IntrospectionConfig{URL: idp.URL}, time.Second)
+		require.NoError(t, err)
+		now := time.Now()
+		for i := range maxIntrospectionCacheEntries + 10 {
+			key := sha256.Sum256(fmt.Appendf(nil, "token-%d", i))
+			bounded.remember(key, introspectedToken{name: "bob", expires: now.Add(time.Duration(i+1) * time.Second)}, now)
+		}
+		assert.Len(t, bounded.cache, maxIntrospectionCacheEntries)
+		// The entries expiring soonest make room
+		assert.NotContains(t, bounded.cache, sha256.Sum256([]byte("token-0")))
+		assert.Contains(t, bounded.cache, sha256.Sum256(fmt.Appendf(nil, "token-%d", maxIntrospectionCacheEntries+9)))
+	})
+
+	_, err = NewIntrospectionAuthenticator(IntrospectionConfig{URL: "file:///etc/passwd"}, time.Second)
+	assert.ErrorContains(t, err, "invalid introspection URL")
+}
+
+func TestIdentityNamespaces(t *testing.T) {
+	ca, ciCert := issueClientCert(t, "ci")
+	authenticator, identities, err := newAuthenticator(AuthConfig{
+		Tokens:     []TokenCredential{{Identity: "ci", Token: "ci-token"}},
+		ClientCA:   writeCAFile(t, t.TempDir(), ca),
+		Identities: map[string][]string{"token:ci": {RoleAll}},
+	}, "legacy-token", "1.0.0", 5*time.Second)
+	require.NoError(t, err)
+
+	var got *Identity
+	handler := requireAuthentication(authenticator, identities, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = IdentityFromContext(r.Context())
+	}))
+	serve := func(t *testing.T, r *http.Request) *Identity {
+		t.Helper()
+		got = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, got)
+		return got
+	}
+
+	t.Run("Token Identity", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.Header.Set("Authorization", "Bearer ci-token")
+		id := serve(t, r)
+		assert.Equal(t, AuthMethodToken, id.Method)
+		assert.True(t, id.Allows(RoleChainResolver))
+	})
+
+	t.Run("Certificate Named Like Token Identity", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.TLS = &tls.ConnectionState{PeerCertificates: []*x509.Certificate{ciCert.Leaf}}
+		id := serve(t, r)
+		assert.Equal(t, "ci", id.Name)
+		assert.Equal(t, AuthMethodClientCert, id.Method)
+		assert.False(t, id.Allows(RoleChainResolver))
+	})
+
+	t.Run("Legacy Token", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+		r.Header.Set("Authorization", "Bearer legacy-token")
+		assert.True(t, serve(t, r).Allows(RoleChainResolver))
+		assert.Contains(t, identities, "token:"+legacyTokenIdentity)
+	})
+
+	for _, key := range []string{"ci", "oauth:ci", "token:"} {
+		_, _, err := newAuthenticator(AuthConfig{Identities: map[string][]string{key: {RoleAll}}}, "", "1.0.0", time.Second)
+		assert.ErrorContains(t, err, "invalid identity", key)
+	}
+}
+
+func TestToolAuthorization(t *testing.T) {
+	tools, toolsWithConfig := createTools()
+	mcpServer, err := NewServerBuilder().
+		WithConfig(&Config{}).
+		WithVersion("1.0.0").
+		WithTools(tools...).
+		WithToolsWithConfig(toolsWithConfig...).
+		Build()
+	require.NoError(t, err)
+
+	ca, aliceCert := issueClientCert(t, "alice")
+	dir := t.TempDir()
+	certFile, keyFile, pool := writeLoopbackKeyPair(t, dir)
+
+	authenticator, identities, err := newAuthenticator(AuthConfig{
+		Tokens: []TokenCredential{
+			{Identity: "ci", Token: "ci-token"},
+			{Identity: "guest", Token: "guest-token"},
+		},
+		ClientCA: writeCAFile(t, dir, ca),
+		Identities: map[string][]string{
+			"token:ci":   {RoleChainResolver, RoleChainValidator},
+			"mtls:alice": {RoleAll},
+		},
+	}, "", "1.0.0", 5*time.Second)
+	require.NoError(t, err)
+
+	opts := TransportOptions{
+		Transport:     TransportHTTP,
+		Listen:        closedLoopbackAddr(t),
+		TLSCertFile:   certFile,
+		TLSKeyFile:    keyFile,
+		Authenticator: authenticator,
+		Identities:    identities,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- serveNetworkTransport(ctx, mcpServer, opts, logger.NewMCPLogger(io.Discard, true)) }()
+	defer func() {
+		cancel()
+		assert.NoError(t, <-done)
+	}()
+
+	url := "https://" + opts.Listen + "/mcp"
+	connect := func(t *testing.T, tlsConfig *tls.Config, headers map[string]string) *client.Client {
+		t.Helper()
+		tlsConfig.RootCAs = pool
+		c, err := client.NewStreamableHttpClient(url,
+			transport.WithHTTPHeaders(headers),
+			transport.WithHTTPBasicClient(&http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}))
+		require.NoError(t, err)
+		t.Cleanup(func() { c.Close() })
+
+		initRequest := mcp.InitializeRequest{}
+		initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+		initRequest.Params.ClientInfo = mcp.Implementation{Name: "auth-test", Version: "1.0.0"}
+		require.Eventually(t, func() bool {
+			_, err := c.Initialize(context.Background(), initRequest)
+			return err == nil
+		}, 5*time.Second, 20*time.Millisecond)
+		return c
+	}
+	toolNames := func(t *testing.T, c *client.Client) []string {
+		t.Helper()
+		result, err := c.ListTools(context.Background(), mcp.ListToolsRequest{})
+		require.NoError(t, err)
+		var names []string
+		for _, tool := range result.Tools {
+			names = append(names, tool.Name)
+		}
+		return names
+	}
+
+	t.Run("Token Allowlist", func(t *testing.T) {
+		c := connect(t, &tls.Config{}, map[string]string{"Authorization": "Bearer ci-token"})
+		assert.ElementsMatch(t, []string{ToolResolveCertChain, ToolValidateCertChain}, toolNames(t, c))
+
+		call := mcp.CallToolRequest{}
+		call.Params.Name = ToolFetchRemoteCert
+		call.Params.Arguments = map[string]any{"hostname": "127.0.0.1"}
+		_, err := c.CallTool(context.Background(), call)
+		assert.ErrorContains(t, err, ErrToolForbidden.Error())
+	})
+
+	t.Run("Identity Without Allowlist", func(t *testing.T) {
+		c := connect(t, &tls.Config{}, map[string]string{"Authorization": "Bearer guest-token"})
+		assert.Empty(t, toolNames(t, c))
+	})
+
+	t.Run("Client Certificate", func(t *testing.T) {
+		c := connect(t, &tls.Config{Certificates: []tls.Certificate{aliceCert}}, nil)
+		assert.Len(t, toolNames(t, c), len(tools)+len(toolsWithConfig))
+	})
+
+	t.Run("Anonymous Rejected", func(t *testing.T) {
+		httpClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+		resp, err := httpClient.Post(url, "application/json", nil)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
@@ -13,13 +13,13 @@ import (
 	"strings"
 	"syscall"
 	"text/template"
+	"time"
 
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/helper/posix"
 	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/mcp-server/templates"
 	"github.com/mark3labs/mcp-go/client"
-	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/cobra"
 )
 
@@ -57,6 +57,7 @@ type cliHelpData struct {
 //   - [Gopls-style] --instructions flag for displaying certificate operation workflows
 //   - Configuration file support via --config flag or MCP_X509_CONFIG_FILE environment variable
 //   - Default MCP server startup when no arguments are provided
+//   - Stdio, SSE, and Streamable HTTP transports via the --transport flag
 //   - Graceful shutdown handling with signal interception
 //
 // Fields:
@@ -80,6 +81,8 @@ type cliHelpData struct {
 //     Resources like documentation or status information accessible via MCP protocol.
 //   - resourcesWithEmbed: List of resources that require embedded filesystem access.
 //     Resources that need to load templates or documentation from embedded files.
+//   - resourceTemplates: List of resource templates for resources addressed at runtime.
+//     Templates for certificates and chains resolved by earlier tool calls.
 //   - prompts: List of predefined prompts for guided workflows.
 //     Prompts for certificate analysis, expiry monitoring, security audits, etc.
 //   - promptsWithEmbed: List of prompts that require embedded filesystem access.
@@ -90,6 +93,8 @@ type cliHelpData struct {
 //     Instructions sent during MCP initialization handshake.
 //   - populateCache: Whether to populate metadata cache for resource handlers.
 //     When enabled, resource handlers can access cached tool/prompt/resource metadata.
+//   - transport: Transport, listen address, TLS, and bearer token options.
+//     Set via the --transport, --listen, --tls-cert, --tls-key, and --auth-token flags.
 //
 // This struct enables seamless integration between CLI and MCP server operations,
 // providing both traditional command-line usage and modern MCP protocol support.
@@ -106,11 +111,13 @@ type CLIFramework struct {
 	toolsWithConfig    []ToolDefinitionWithConfig
 	resources          []ServerResource
 	resourcesWithEmbed []ServerResourceWithEmbed
+	resourceTemplates  []ServerResourceTemplate
 	prompts            []ServerPrompt
 	promptsWithEmbed   []ServerPromptWithEmbed
 	samplingHandler    client.SamplingHandler
 	instructions       string
 	populateCache      bool
+	transport          TransportOptions
 }
 
 // NewCLIFramework creates a new CLI framework instance with MCP server integration.
@@ -162,6 +169,7 @@ func NewCLIFramework(configFile string, deps ServerDependencies) *CLIFramework {
 		toolsWithConfig:    deps.ToolsWithConfig,
 		resources:          deps.Resources,
 		resourcesWithEmbed: deps.ResourcesWithEmbed,
+		resourceTemplates:  deps.ResourceTemplates,
 		prompts:            deps.Prompts,
 		promptsWithEmbed:   deps.PromptsWithEmbed,
 		samplingHandler:    deps.SamplingHandler,
@@ -230,6 +238,9 @@ func (cf *CLIFramework) BuildRootCommand() *cobra.Command {
 	// Allows configuration override via CLI flag while supporting environment variables
 	rootCmd.PersistentFlags().StringVarP(&cf.configFile, "config", "c", cf.configFile, "path to MCP server configuration file")
 
+	// Add transport flags so one shared server instance can be reached over the network
+	cf.addTransportFlags(rootCmd)
+
 	// Extract flag names for template processing
 	instructionsFlagName, configFlagName, helpFlagName := extractFlagNames(rootCmd)
 
@@ -254,6 +265,9 @@ func (cf *CLIFramework) BuildRootCommand() *cobra.Command {
 	originalRunE := rootCmd.RunE
 	rootCmd.RunE = cf.createRootCommandRunE(rootCmd, exeName, originalRunE)
 
+	// Prometheus exporter for certificate and resolver health
+	rootCmd.AddCommand(cf.newServeMetricsCommand())
+
 	return rootCmd
 }
 
@@ -430,8 +444,9 @@ func extractFlagNames(rootCmd *cobra.Command) (instructionsFlagName, configFlagN
 //  1. Loads configuration from file (with fallback to defaults)
 //  2. Builds MCP server using the ServerBuilder pattern
 //  3. Registers all tools, resources, prompts, and sampling handlers
-//  4. Starts stdio-based MCP server for protocol communication
-//  5. Implements graceful shutdown with signal handling
+//  4. Installs the egress policy, blocking private ranges by default on network transports
+//  5. Serves the MCP protocol over the selected transport (stdio, SSE, or Streamable HTTP)
+//  6. Implements graceful shutdown with signal handling
 //
 // Configuration loading:
 //   - Uses cf.configFile if set via --config flag
@@ -445,6 +460,7 @@ func extractFlagNames(rootCmd *cobra.Command) (instructionsFlagName, configFlagN
 //   - Adds resources (static and embedded filesystem variants)
 //   - Includes prompts (standard and embedded template variants)
 //   - Enables sampling for AI-powered certificate analysis
+//   - Wraps every tool, resource, and prompt handler with panic recovery
 //   - Populates metadata cache if requested
 //
 // MCP server capabilities:
@@ -456,6 +472,8 @@ func extractFlagNames(rootCmd *cobra.Command) (instructionsFlagName, configFlagN
 // Signal handling:
 //   - Intercepts SIGINT (Ctrl+C) and SIGTERM signals
 //   - Uses context cancellation for graceful shutdown
+//   - Network transports stop accepting connections and drain in-flight requests
+//   - Stops the CRL cache cleanup goroutine on exit
 //   - Provides user feedback during shutdown process
 //   - Waits for active operations to complete before exiting
 //
@@ -465,8 +483,8 @@ func extractFlagNames(rootCmd *cobra.Command) (instructionsFlagName, configFlagN
 //   - Wraps errors with context for debugging
 //   - Integrates with Cobra's error handling for appropriate CLI behavior
 //
-// The server runs indefinitely until interrupted, communicating via stdio
-// for MCP protocol messages. This enables integration with MCP clients and AI assistants.
+// The server runs indefinitely until interrupted, communicating via stdio by default,
+// or over HTTP with --transport sse|http so that one instance can serve many clients.
 //
 // Returns:
 //   - nil: When server shuts down gracefully due to signal interruption (successful operation)
@@ -480,6 +498,12 @@ func (cf *CLIFramework) startMCPServer() error {
 	l := logger.NewCLILogger()
 	l.SetOutput(os.Stderr)
 
+	// Validate transport options before doing any work
+	transport, err := cf.resolveTransportOptions()
+	if err != nil {
+		return err
+	}
+
 	// Load config based on the --config flag or environment variable fallback
 	// This allows users to override configuration without editing files
 	config, err := loadConfig(cf.configFile)
@@ -487,6 +511,11 @@ func (cf *CLIFramework) startMCPServer() error {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
+	// Restrict outbound connections before any tool can reach the network
+	if err := applyEgressPolicy(config.Egress, transport.Transport != TransportStdio); err != nil {
+		return fmt.Errorf("invalid egress policy: %w", err)
+	}
+
 	// Build MCP server using the ServerBuilder pattern for clean dependency management
 	// Each With* method adds specific capabilities to the server
 	builder := NewServerBuilder().
@@ -499,10 +528,15 @@ func (cf *CLIFramework) startMCPServer() error {
 		WithToolsWithConfig(cf.toolsWithConfig...).
 		WithResources(cf.resources...).
 		WithEmbeddedResources(cf.resourcesWithEmbed...).
+		WithResourceTemplates(cf.resourceTemplates...).
 		WithPrompts(cf.prompts...).
 		WithEmbeddedPrompts(cf.promptsWithEmbed...).
 		WithSampling(cf.samplingHandler).
-		WithInstructions(cf.instructions)
+		WithInstructions(cf.instructions).
+		// A panicking handler fails its own request instead of the whole server
+		WithMiddleware(RecoverToolPanics(l)).
+		WithResourceMiddleware(RecoverResourcePanics(l)).
+		WithPromptMiddleware(RecoverPromptPanics(l))
 
 	// Enable metadata cache population if requested
 	// This allows resource handlers to access cached tool/prompt/resource information
@@ -517,11 +551,6 @@ func (cf *CLIFramework) startMCPServer() error {
 		return fmt.Errorf("failed to build MCP server: %w", err)
 	}
 
-	// Start the MCP server with stdio transport for protocol communication
-	// Stdio transport enables integration with MCP clients via standard input/output
-	// The server will handle JSON-RPC messages over stdin/stdout
-	stdioServer := server.NewStdioServer(mcpServer)
-
 	// Implement graceful shutdown with context cancellation
 	// This ensures clean termination when signals are received
 	ctx, cancel := context.WithCancel(context.Background())
@@ -529,6 +558,7 @@ func (cf *CLIFramework) startMCPServer() error {
 
 	// Start CRL cache cleanup with cancellable context
 	x509chain.StartCRLCacheCleanup(ctx)
+	defer x509chain.StopCRLCacheCleanup()
 
 	// Handle SIGINT/SIGTERM signals for graceful shutdown
 	// Creates a goroutine that waits for termination signals
@@ -543,6 +573,21 @@ func (cf *CLIFramework) startMCPServer() error {
 		cancel()
 	}()
 
+	// Network transports share the same built server; shutdown is driven by ctx
+	if transport.Transport != TransportStdio {
+		timeout := time.Duration(config.Defaults.Timeout) * time.Second
+		transport.Authenticator, transport.Identities, err = newAuthenticator(config.Auth, transport.AuthToken, cf.version, timeout)
+		if err != nil {
+			return fmt.Errorf("failed to configure authentication: %w", err)
+		}
+		return serveNetworkTransport(ctx, mcpServer, transport, l)
+	}
+
+	// Start the MCP server with stdio transport for protocol communication
+	// Stdio transport enables integration with MCP clients via standard input/output
+	// The server will handle JSON-RPC messages over stdin/stdout, with
+	// resources/subscribe and resources/unsubscribe answered in front of it
+
 	// Start the server - this will block until context is cancelled
 	// The server listens for MCP protocol messages on stdin and responds on stdout
 	// All MCP tool calls, resource requests, and sampling operations are handled here
@@ -551,7 +596,7 @@ func (cf *CLIFramework) startMCPServer() error {
 	// Check if the error is due to context cancellation (graceful shutdown)
 	// Only user-initiated cancellation (signals) should be treated as graceful shutdown
 	// Timeout errors are operational issues that should be reported
-	if err = stdioServer.Listen(ctx, os.Stdin, os.Stdout); err != nil && err == context.Canceled {
+	if err = serveStdio(ctx, mcpServer, os.Stdin, os.Stdout); err != nil && err == context.Canceled {
 		return nil
 	}
 
@@ -0,0 +1,420 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// methodCompletionComplete asks for values completing a prompt argument or resource template variable.
+	methodCompletionComplete = "completion/complete"
+	// refTypePrompt identifies a prompt in a completion/complete reference.
+	refTypePrompt = "ref/prompt"
+	// refTypeResource identifies a resource template in a completion/complete reference.
+	refTypeResource = "ref/resource"
+	// maxCompletionValues is the most values a completion/complete result may carry.
+	maxCompletionValues = 100
+	// maxRecentHostnames bounds the hostnames remembered for completion.
+	maxRecentHostnames = 64
+)
+
+// completionRegistry maps each server built by [ServerBuilder.Build] to its
+// completions, for handlers and transports that only have the server at hand.
+var completionRegistry sync.Map // *server.MCPServer -> *completions
+
+// completions answers completion/complete for the prompts and resource
+// templates of a server.
+//
+// Thread Safety: Safe for concurrent use once all prompts and resource
+// templates are added.
+type completions struct {
+	// server: Server the completions are attached to
+	server *server.MCPServer
+	// sandbox: File sandbox whose configured and client roots bound completed paths
+	sandbox *fileSandbox
+	// resources: Certificate resources whose fingerprints are completed
+	resources *certificateResources
+	// prompts: Argument completions by prompt name and argument name
+	prompts map[string]map[string]ArgumentCompletion
+	// templates: Variable completions by URI template and variable name
+	templates map[string]map[string]ArgumentCompletion
+	// mu: Protects sessions and histories
+	mu sync.Mutex
+	// sessions: Registered sessions by session ID
+	sessions map[string]server.ClientSession
+	// histories: Recent tool results by session ID
+	histories map[string]*completionHistory
+}
+
+// completionHistory is what the tool calls of one session fetched and
+// resolved, offered when completing the arguments of the same session only.
+type completionHistory struct {
+	// hostnames: Hostnames of recent remote fetches, least recent first
+	hostnames []string
+	// leaves: Leaf fingerprints of recently resolved chains, least recent first
+	leaves []string
+}
+
+// newCompletions creates completions without prompts or resource templates.
+//
+// Parameters:
+//   - sandbox: File sandbox whose configured and client roots bound completed paths
+//   - resources: Certificate resources whose fingerprints are completed
+//
+// Returns:
+//   - *completions: Completions to attach to a server before use
+func newCompletions(sandbox *fileSandbox, resources *certificateResources) *completions {
+	return &completions{
+		sandbox:   sandbox,
+		resources: resources,
+		sessions:  make(map[string]server.ClientSession),
+		prompts:   make(map[string]map[string]ArgumentCompletion),
+		templates: make(map[string]map[string]ArgumentCompletion),
+		histories: make(map[string]*completionHistory),
+	}
+}
+
+// addPrompt registers the argument completions of a prompt.
+func (c *completions) addPrompt(name string, args map[string]ArgumentCompletion) {
+	c.prompts[name] = args
+}
+
+// addResourceTemplate registers the variable completions of a resource template.
+func (c *completions) addResourceTemplate(uriTemplate string, vars map[string]ArgumentCompletion) {
+	c.templates[uriTemplate] = vars
+}
+
+// attach binds c to s, so that [completionsOf] finds it.
+func (c *completions) attach(s *server.MCPServer) {
+	c.server = s
+	completionRegistry.Store(s, c)
+}
+
+// completionsOf returns the completions of s.
+//
+// Returns:
+//   - *completions: Completions, or nil if s was not built by [ServerBuilder.Build]
+func completionsOf(s *server.MCPServer) *completions {
+	if s == nil {
+		return nil
+	}
+	c, _ := completionRegistry.Load(s)
+	res, _ := c.(*completions)
+	return res
+}
+
+// history returns the history of the session of ctx, creating it if needed.
+//
+// Returns:
+//   - *completionHistory: History to update while holding c.mu, or nil if ctx carries no session
+func (c *completions) history(ctx context.Context) *completionHistory {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return nil
+	}
+	h, ok := c.histories[session.SessionID()]
+	if !ok {
+		h = &completionHistory{}
+		c.histories[session.SessionID()] = h
+	}
+	return h
+}
+
+// addSession records a registered session, whose client roots then bound
+// the paths completed for it.
+func (c *completions) addSession(ctx context.Context, session server.ClientSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sessions[session.SessionID()] = session
+}
+
+// dropSession forgets a session that ended, and its history.
+func (c *completions) dropSession(ctx context.Context, session server.ClientSession) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.sessions, session.SessionID())
+	delete(c.histories, session.SessionID())
+}
+
+// remember moves value to the end of recent, dropping the least recent
+// values beyond limit.
+func remember(recent []string, value string, limit int) []string {
+	recent = slices.DeleteFunc(recent, func(v string) bool { return strings.EqualFold(v, value) })
+	recent = append(recent, value)
+	if len(recent) > limit {
+		recent = slices.Delete(recent, 0, len(recent)-limit)
+	}
+	return recent
+}
+
+// recordHostname remembers a hostname fetched by a tool call, so that it is
+// offered when completing hostname arguments for the session of the call.
+//
+// Parameters:
+//   - ctx: Context of the tool call
+//   - hostname: Hostname that was fetched
+func recordHostname(ctx context.Context, hostname string) {
+	c := completionsOf(server.ServerFromContext(ctx))
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if h := c.history(ctx); h != nil {
+		h.hostnames = remember(h.hostnames, hostname, maxRecentHostnames)
+	}
+}
+
+// recordChain remembers a chain resolved by a tool call, so that its
+// fingerprints are offered when completing certificate and chain arguments
+// for the session of the call.
+//
+// Parameters:
+//   - ctx: Context of the tool call
+//   - leaf: Fingerprint of the leaf of the chain
+func (c *completions) recordChain(ctx context.Context, leaf string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if h := c.history(ctx); h != nil {
+		h.leaves = remember(h.leaves, leaf, maxCachedChains)
+	}
+}
+
+// handleCompletionMessage answers a completion/complete request.
+//
+// mcp-go does not route this method, so it is answered by [unroutedMethodsOf]
+// in front of the server.
+//
+// Parameters:
+//   - session: ID of the session that sent the message
+//   - message: Raw JSON-RPC message
+//
+// Returns:
+//   - mcp.JSONRPCMessage: Response to send back to the client
+//   - bool: Whether the message was a completion request; if false it must be forwarded to the server
+func (c *completions) handleCompletionMessage(session string, message []byte) (mcp.JSONRPCMessage, bool) {
+	// Quick check before parsing every message
+	if !bytes.Contains(message, []byte(methodCompletionComplete)) {
+		return nil, false
+	}
+	var request struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			Ref struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+				URI  string `json:"uri"`
+			} `json:"ref"`
+			Argument struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"argument"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil || request.Method != methodCompletionComplete {
+		return nil, false
+	}
+
+	id := mcp.NewRequestId(request.ID)
+	var args map[string]ArgumentCompletion
+	switch ref := request.Params.Ref; ref.Type {
+	case refTypePrompt:
+		var ok bool
+		if args, ok = c.prompts[ref.Name]; !ok {
+			return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, fmt.Sprintf("unknown prompt %q", ref.Name), nil), true
+		}
+	case refTypeResource:
+		var ok bool
+		if args, ok = c.templates[ref.URI]; !ok {
+			return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, fmt.Sprintf("unknown resource template %q", ref.URI), nil), true
+		}
+	default:
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, fmt.Sprintf("unsupported reference type %q", ref.Type), nil), true
+	}
+
+	// Arguments without a completion have no suggestions
+	values := []string{}
+	if completion, ok := args[request.Params.Argument.Name]; ok {
+		values = append(values, c.values(session, completion, request.Params.Argument.Value)...)
+	}
+
+	var result mcp.CompleteResult
+	result.Completion.Total = len(values)
+	if len(values) > maxCompletionValues {
+		values = values[:maxCompletionValues]
+		result.Completion.HasMore = true
+	}
+	result.Completion.Values = values
+	return mcp.NewJSONRPCResultResponse(id, result), true
+}
+
+// values returns the values of a completion starting with value, for session.
+func (c *completions) values(session string, completion ArgumentCompletion, value string) []string {
+	switch completion.Source {
+	case CompletionSourcePath:
+		return c.paths(c.pathRoots(session), value)
+	case CompletionSourceHostname:
+		hostnames, _ := c.recent(session)
+		return withPrefix(hostnames, value)
+	case CompletionSourceCertificate:
+		_, leaves := c.recent(session)
+		var fingerprints []string
+		for _, leaf := range leaves {
+			chain, _ := c.resources.chainFingerprints(leaf)
+			fingerprints = append(fingerprints, chain...)
+		}
+		slices.Sort(fingerprints)
+		return withPrefix(slices.Compact(fingerprints), value)
+	case CompletionSourceChain:
+		_, leaves := c.recent(session)
+		// Chains evicted from the resources can no longer be read
+		leaves = slices.DeleteFunc(leaves, func(leaf string) bool {
+			_, ok := c.resources.chainFingerprints(leaf)
+			return !ok
+		})
+		return withPrefix(leaves, value)
+	default:
+		return withPrefix(completion.Values, value)
+	}
+}
+
+// recent returns the hostnames and leaf fingerprints recorded for session,
+// most recent first.
+func (c *completions) recent(session string) (hostnames, leaves []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	h, ok := c.histories[session]
+	if !ok {
+		return nil, nil
+	}
+	hostnames, leaves = slices.Clone(h.hostnames), slices.Clone(h.leaves)
+	slices.Reverse(hostnames)
+	slices.Reverse(leaves)
+	return hostnames, leaves
+}
+
+// withPrefix returns the candidates starting with prefix, ignoring case.
+func withPrefix(candidates []string, prefix string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if len(candidate) >= len(prefix) && strings.EqualFold(candidate[:len(prefix)], prefix) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+// pathRoots returns the directories within which paths are completed for
+// session: the configured roots, narrowed to the roots listed by the client
+// if it declared the roots capability. Sessions that are not registered get
+// none, as their client roots cannot be known.
+//
+// The client is not waited for, as the transport may be reading its messages
+// on this goroutine; until its roots are known, they are requested in the
+// background and no directory is offered.
+//
+// Parameters:
+//   - session: ID of the session asking for completions
+//
+// Returns:
+//   - []string: Resolved directories
+func (c *completions) pathRoots(session string) []string {
+	c.mu.Lock()
+	cs, ok := c.sessions[session]
+	c.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	clientRoots, declared, known := c.sandbox.knownSessionRoots(cs)
+	switch {
+	case !declared:
+		return c.sandbox.roots
+	case !known:
+		c.sandbox.prefetchSessionRoots(c.server.WithContext(context.Background(), cs))
+		return nil
+	default:
+		return intersectRoots(c.sandbox.roots, clientRoots)
+	}
+}
+
+// paths completes a file system path within roots.
+//
+// The entries of the directory named by value, up to its last separator, are
+// offered if that directory lies within a root; directories end with a
+// separator so that they can be completed further. Relative values are
+// resolved against the working directory, as the tools read them. Roots
+// themselves are offered when value names no directory within a root, so an
+// empty value lists the roots.
+//
+// Parameters:
+//   - roots: Resolved directories within which paths are completed
+//   - value: Path typed so far
+//
+// Returns:
+//   - []string: Completed paths, in the form value was typed in
+func (c *completions) paths(roots []string, value string) []string {
+	if value == "" {
+		return rootPaths(roots, "")
+	}
+	typedDir, prefix := filepath.Split(value)
+	dir, err := filepath.Abs(typedDir)
+	if err != nil {
+		return nil
+	}
+	if resolved, err := filepath.EvalSymlinks(dir); err != nil || !withinRoots(resolved, roots) {
+		abs, err := filepath.Abs(value)
+		if err != nil {
+			return nil
+		}
+		return rootPaths(roots, abs)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		// Hidden entries are only offered once a dot is typed
+		if !strings.HasPrefix(name, prefix) || (strings.HasPrefix(name, ".") && !strings.HasPrefix(prefix, ".")) {
+			continue
+		}
+		path := typedDir + name
+		if isDir(filepath.Join(dir, name)) {
+			path += string(filepath.Separator)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+// rootPaths returns the roots starting with prefix, each ending with a separator.
+func rootPaths(roots []string, prefix string) []string {
+	var paths []string
+	for _, root := range roots {
+		if strings.HasPrefix(root, prefix) {
+			paths = append(paths, root+string(filepath.Separator))
+		}
+	}
+	return paths
+}
@@ -0,0 +1,272 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// complete sends a completion/complete request from the session "completer"
+// through the unrouted method handler of handle.
+func complete(t *testing.T, handle unroutedMethodHandler, ref, argument, value string) mcp.JSONRPCMessage {
+	t.Helper()
+	return completeAs(t, handle, "completer", ref, argument, value)
+}
+
+// completeAs sends a completion/complete request from session through the unrouted method handler of handle.
+func completeAs(t *testing.T, handle unroutedMethodHandler, session, ref, argument, value string) mcp.JSONRPCMessage {
+	t.Helper()
+	response, ok := handle(session, fmt.Appendf(nil,
+		`{"jsonrpc":"2.0","id":1,"method":"completion/complete","params":{"ref":%s,"argument":{"name":%q,"value":%q}}}`,
+		ref, argument, value))
+	require.True(t, ok)
+	return response
+}
+
+// completionValues returns the values of a successful completion/complete response.
+func completionValues(t *testing.T, response mcp.JSONRPCMessage) []string {
+	t.Helper()
+	result, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected result response, got %#v", response)
+	return result.Result.(mcp.CompleteResult).Completion.Values
+}
+
+func TestCompletionComplete(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "certs"), 0o755))
+	for _, name := range []string{"certs/leaf.pem", "certs/leaf.key", "chain.pem", ".hidden.pem"} {
+		require.NoError(t, os.WriteFile(filepath.Join(root, name), nil, 0o600))
+	}
+	outside := t.TempDir()
+	require.NoError(t, os.Symlink(outside, filepath.Join(root, "escape")))
+
+	config := &Config{}
+	config.Files.Roots = []string{root}
+	tools, toolsWithConfig := createTools()
+	prompts, promptsWithEmbed := createPrompts()
+	s, err := NewServerBuilder().WithConfig(config).WithVersion("1.0.0").
+		WithTools(tools...).WithToolsWithConfig(toolsWithConfig...).
+		WithPrompts(prompts...).WithEmbeddedPrompts(promptsWithEmbed...).
+		WithResourceTemplates(createResourceTemplates()...).Build()
+	require.NoError(t, err)
+	handle := unroutedMethodsOf(s)
+	require.NotNil(t, handle)
+	require.NoError(t, s.RegisterSession(t.Context(), newTestSession("completer")))
+	// Roots are reported with symlinks resolved
+	root, err = filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	sep := string(filepath.Separator)
+
+	troubleshooting := `{"type":"ref/prompt","name":"troubleshooting"}`
+	audit := `{"type":"ref/prompt","name":"security-audit"}`
+
+	t.Run("fixed values", func(t *testing.T) {
+		assert.Equal(t, []string{"chain", "connection"}, completionValues(t, complete(t, handle, troubleshooting, "issue_type", "C")))
+		assert.Equal(t, []string{"443"}, completionValues(t, complete(t, handle, audit, "port", "44")))
+		assert.Equal(t, []string{"der"}, completionValues(t, complete(t, handle, `{"type":"ref/resource","uri":"cert://sha256/{+fingerprint}{?format}"}`, "format", "d")))
+
+		// Arguments without a completion have an empty list of values
+		values := completionValues(t, complete(t, handle, `{"type":"ref/prompt","name":"certificate-analysis"}`, "unknown", ""))
+		assert.NotNil(t, values)
+		assert.Empty(t, values)
+	})
+
+	t.Run("paths", func(t *testing.T) {
+		assert.Equal(t, []string{root + sep}, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", "")))
+		assert.Equal(t, []string{root + sep}, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root[:len(root)-2])))
+		assert.Equal(t, []string{root + sep + "certs" + sep, root + sep + "chain.pem", root + sep + "escape" + sep},
+			completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root+sep)))
+		assert.Equal(t, []string{root + sep + ".hidden.pem"}, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root+sep+".")))
+		assert.Equal(t, []string{root + sep + "certs" + sep + "leaf.key", root + sep + "certs" + sep + "leaf.pem"},
+			completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root+sep+"certs"+sep+"l")))
+
+		// Directories outside the roots, including through symlinks, are not listed
+		assert.Empty(t, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root+sep+"escape"+sep)))
+		assert.Empty(t, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", outside+sep)))
+		// Above the roots, only the roots themselves are offered
+		assert.Equal(t, []string{root + sep}, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", root+sep+".."+sep)))
+	})
+
+	t.Run("hostnames", func(t *testing.T) {
+		assert.Empty(t, completionValues(t, complete(t, handle, audit, "hostname", "")))
+
+		remote := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		// The tool hangs up after the handshake
+		remote.Config.ErrorLog = log.New(io.Discard, "", 0)
+		remote.StartTLS()
+		defer remote.Close()
+		host, port, err := net.SplitHostPort(remote.Listener.Addr().String())
+		require.NoError(t, err)
+		response := sendMessage(t.Context(), s, newTestSession("completer"), fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":{"hostname":%q,"port":%s}}}`,
+			ToolFetchRemoteCert, host, port))
+		require.False(t, response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult).IsError)
+
+		assert.Equal(t, []string{host}, completionValues(t, complete(t, handle, audit, "hostname", host[:3])))
+		assert.Empty(t, completionValues(t, complete(t, handle, audit, "hostname", "example")))
+		// Other sessions are not offered the hostnames fetched by this one
+		assert.Empty(t, completionValues(t, completeAs(t, handle, "other", audit, "hostname", "")))
+	})
+
+	t.Run("fingerprints", func(t *testing.T) {
+		leaf := newTestCertificate(t, "completion.example.com")
+		ca := newTestCertificate(t, "Completion CA")
+		certificateResourcesOf(s).recordChain([]*x509.Certificate{leaf, ca})
+		leafFP := certificateFingerprint(leaf)
+		chainRef := `{"type":"ref/resource","uri":"chain://sha256/{+fingerprint}{?format}"}`
+
+		// Chains are only offered to the sessions that resolved them
+		assert.NotContains(t, completionValues(t, complete(t, handle, chainRef, "fingerprint", "")), leafFP)
+		session := newTestSession("completer")
+		completionsOf(s).recordChain(s.WithContext(t.Context(), session), leafFP)
+
+		values := completionValues(t, complete(t, handle, chainRef, "fingerprint", ""))
+		assert.Contains(t, values, leafFP)
+		assert.NotContains(t, values, certificateFingerprint(ca))
+
+		values = completionValues(t, complete(t, handle, `{"type":"ref/resource","uri":"cert://sha256/{+fingerprint}{?format}"}`, "fingerprint", strings.ToUpper(leafFP[:8])))
+		assert.Equal(t, []string{leafFP}, values)
+		assert.Empty(t, completionValues(t, completeAs(t, handle, "other", chainRef, "fingerprint", "")))
+
+		// Ended sessions are forgotten
+		completionsOf(s).dropSession(t.Context(), session)
+		assert.NotContains(t, completionValues(t, complete(t, handle, chainRef, "fingerprint", "")), leafFP)
+	})
+
+	t.Run("invalid references", func(t *testing.T) {
+		for _, ref := range []string{
+			`{"type":"ref/prompt","name":"unknown"}`,
+			`{"type":"ref/resource","uri":"unknown://{id}"}`,
+			`{"type":"ref/tool","name":"resolve_cert_chain"}`,
+		} {
+			rpcErr, ok := complete(t, handle, ref, "x", "").(mcp.JSONRPCError)
+			require.True(t, ok, ref)
+			assert.Equal(t, mcp.INVALID_PARAMS, rpcErr.Error.Code)
+		}
+	})
+}
+
+func TestCompletionLimit(t *testing.T) {
+	values := make([]string, maxCompletionValues+5)
+	for i := range values {
+		values[i] = fmt.Sprintf("v%03d", i)
+	}
+	s, err := NewServerBuilder().WithVersion("1.0.0").WithPrompts(ServerPrompt{
+		Prompt:      mcp.NewPrompt("many", mcp.WithArgument("value")),
+		Completions: map[string]ArgumentCompletion{"value": {Values: values}},
+	}).Build()
+	require.NoError(t, err)
+
+	response := complete(t, unroutedMethodsOf(s), `{"type":"ref/prompt","name":"many"}`, "value", "v")
+	data, err := json.Marshal(response)
+	require.NoError(t, err)
+	var decoded struct {
+		Result struct {
+			Completion struct {
+				Values  []string `json:"values"`
+				Total   int      `json:"total"`
+				HasMore bool     `json:"hasMore"`
+			} `json:"completion"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Len(t, decoded.Result.Completion.Values, maxCompletionValues)
+	assert.Equal(t, len(values), decoded.Result.Completion.Total)
+	assert.True(t, decoded.Result.Completion.HasMore)
+
+	// Other requests are left to the server
+	_, ok := unroutedMethodsOf(s)("completer", []byte(`{"jsonrpc":"2.0","id":1,"method":"prompts/list"}`))
+	assert.False(t, ok)
+}
+
+func TestCompletionClientRoots(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"certs", "other"} {
+		require.NoError(t, os.Mkdir(filepath.Join(root, dir), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, dir, "ca.pem"), nil, 0o600))
+	}
+	root, err := filepath.EvalSymlinks(root)
+	require.NoError(t, err)
+	sep := string(filepath.Separator)
+
+	config := &Config{}
+	config.Files.Roots = []string{root}
+	prompts, promptsWithEmbed := createPrompts()
+	s, err := NewServerBuilder().WithConfig(config).WithVersion("1.0.0").
+		WithPrompts(prompts...).WithEmbeddedPrompts(promptsWithEmbed...).Build()
+	require.NoError(t, err)
+	handle := unroutedMethodsOf(s)
+	require.NotNil(t, handle)
+
+	roots := &testRoots{}
+	roots.set(filepath.Join(root, "certs"), t.TempDir())
+	c := client.NewClient(transport.NewInProcessTransportWithOptions(s, transport.WithRootsHandler(roots)), client.WithRootsHandler(roots))
+	t.Cleanup(func() { c.Close() })
+	require.NoError(t, c.Start(t.Context()))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "roots-test", Version: "1.0.0"}
+	_, err = c.Initialize(t.Context(), initRequest)
+	require.NoError(t, err)
+
+	// The in-process transport does not report its session ID
+	completions := completionsOf(s)
+	completions.mu.Lock()
+	require.Len(t, completions.sessions, 1)
+	var session string
+	for id := range completions.sessions {
+		session = id
+	}
+	completions.mu.Unlock()
+
+	troubleshooting := `{"type":"ref/prompt","name":"troubleshooting"}`
+	paths := func(value string) []string {
+		return completionValues(t, completeAs(t, handle, session, troubleshooting, "certificate_path", value))
+	}
+
+	// Client roots are requested in the background, so nothing is offered until they are known
+	assert.Eventually(t, func() bool {
+		return slices.Equal(paths(""), []string{root + sep + "certs" + sep})
+	}, 5*time.Second, 10*time.Millisecond)
+	// Configured roots are narrowed to the client roots
+	assert.Equal(t, []string{root + sep + "certs" + sep}, paths(root+sep))
+	assert.Equal(t, []string{root + sep + "certs" + sep + "ca.pem"}, paths(root+sep+"certs"+sep))
+	assert.Empty(t, paths(root+sep+"other"+sep))
+
+	// Changed roots are requested again after notifications/roots/list_changed
+	roots.set(filepath.Join(root, "other"))
+	require.NoError(t, c.RootListChanges(t.Context()))
+	assert.Eventually(t, func() bool {
+		return slices.Equal(paths(""), []string{root + sep + "other" + sep})
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Sessions without client roots complete within the configured roots
+	require.NoError(t, s.RegisterSession(t.Context(), newTestSession("completer")))
+	assert.Equal(t, []string{root + sep}, completionValues(t, complete(t, handle, troubleshooting, "certificate_path", "")))
+
+	// Forged session IDs complete no paths
+	for _, value := range []string{"", root + sep, root + sep + "other" + sep} {
+		assert.Empty(t, completionValues(t, completeAs(t, handle, "forged", troubleshooting, "certificate_path", value)), value)
+	}
+}
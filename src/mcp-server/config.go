@@ -10,8 +10,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -57,6 +60,18 @@ type Config struct {
 		// Temperature: Sampling temperature for AI responses (0.0 to 1.0)
 		Temperature float64 `json:"temperature,omitempty" yaml:"temperature,omitempty"`
 	} `json:"ai" yaml:"ai"`
+
+	// Auth: Authentication and per-identity tool allowlists for the sse and http transports
+	Auth AuthConfig `json:"auth" yaml:"auth"`
+
+	// Egress: Restrictions on outbound connections for remote fetches and AIA, OCSP, and CRL downloads
+	Egress x509chain.EgressPolicy `json:"egress" yaml:"egress"`
+
+	// Limits: Rate limits and concurrency quotas for tool calls and outbound requests per host
+	Limits LimitsConfig `json:"limits" yaml:"limits"`
+
+	// Files: Directories on the local file system that tools read certificates from and complete paths within
+	Files FilesConfig `json:"files" yaml:"files"`
 }
 
 // detectConfigFormat determines the configuration file format based on file extension.
@@ -182,3 +197,27 @@ func loadConfig(configPath string) (*Config, error) {
 
 	return config, nil
 }
+
+// applyEgressPolicy installs the configured egress policy for outbound connections.
+//
+// Network transports expose the resolver to remote clients, so private and
+// loopback ranges are blocked there unless the configuration sets
+// egress.blockPrivate explicitly. Stdio keeps unrestricted egress unless an
+// egress section is configured.
+//
+// Parameters:
+//   - policy: Egress section of the configuration
+//   - serverMode: Whether the server is exposed over a network transport
+//
+// Returns:
+//   - error: Invalid CIDR, host pattern, or scheme in the policy
+func applyEgressPolicy(policy x509chain.EgressPolicy, serverMode bool) error {
+	if serverMode && policy.BlockPrivate == nil {
+		blockPrivate := true
+		policy.BlockPrivate = &blockPrivate
+	}
+	if !serverMode && reflect.ValueOf(policy).IsZero() {
+		return x509chain.SetEgressPolicy(nil)
+	}
+	return x509chain.SetEgressPolicy(&policy)
+}
@@ -0,0 +1,453 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// defaultMaxFileSize is the size limit of files read by tools when none is configured.
+	defaultMaxFileSize = x509inventory.DefaultMaxFileSize
+	// rootsRequestTimeout bounds the wait for a client to answer roots/list.
+	rootsRequestTimeout = 10 * time.Second
+)
+
+var (
+	// errOutsideRoots indicates a path outside the directories tools may read.
+	errOutsideRoots = errors.New("file path is outside the allowed roots")
+	// errNotRegularFile indicates a path naming a directory, device, or other non-regular file.
+	errNotRegularFile = errors.New("file path does not name a regular file")
+	// errFileTooLarge indicates a file larger than the configured size limit.
+	errFileTooLarge = errors.New("file exceeds the size limit")
+	// errRootsUnavailable indicates a client that declared roots but did not list them.
+	errRootsUnavailable = errors.New("failed to list the client's roots")
+)
+
+// FilesConfig configures the directories the server works with on the local file system.
+type FilesConfig struct {
+	// Roots: Directories within which tools read files and paths are completed; defaults to the working directory
+	Roots []string `json:"roots,omitempty" yaml:"roots,omitempty"`
+	// MaxFileSize: Size limit in bytes of files read by tools (default: 1 MiB)
+	MaxFileSize int64 `json:"maxFileSize,omitempty" yaml:"maxFileSize,omitempty"`
+}
+
+// rootDirs returns the configured roots as absolute paths with symlinks resolved.
+//
+// Roots that do not exist are skipped. Without configured roots, the working
+// directory is the only root.
+//
+// Returns:
+//   - []string: Resolved root directories
+func (c FilesConfig) rootDirs() []string {
+	return resolveDirs(c.configuredRoots())
+}
+
+// configuredRoots returns the configured roots as given, or the working directory
+// if there are none.
+func (c FilesConfig) configuredRoots() []string {
+	if len(c.Roots) == 0 {
+		return []string{"."}
+	}
+	return c.Roots
+}
+
+// absDirs returns dirs as absolute paths, without resolving symlinks.
+func absDirs(dirs []string) []string {
+	var abs []string
+	for _, dir := range dirs {
+		if path, err := filepath.Abs(dir); err == nil {
+			abs = append(abs, path)
+		}
+	}
+	return abs
+}
+
+// resolveDirs returns dirs as absolute paths with symlinks resolved, skipping
+// those that do not exist.
+func resolveDirs(dirs []string) []string {
+	var resolved []string
+	for _, dir := range dirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			continue
+		}
+		path, err := filepath.EvalSymlinks(abs)
+		if err != nil {
+			continue
+		}
+		resolved = append(resolved, path)
+	}
+	return resolved
+}
+
+// withinRoots reports whether path, absolute with symlinks resolved, is one of
+// roots or lies beneath one of them.
+func withinRoots(path string, roots []string) bool {
+	return rootOf(path, roots) != ""
+}
+
+// rootOf returns the first of roots that path, absolute with symlinks
+// resolved, is or lies beneath, or "" if there is none.
+func rootOf(path string, roots []string) string {
+	for _, root := range roots {
+		rel, err := filepath.Rel(root, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return root
+		}
+	}
+	return ""
+}
+
+// isDir reports whether path names a directory, following symlinks.
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// fileSandboxRegistry maps each server built by [ServerBuilder.Build] to its
+// file sandbox, for handlers that only have the server at hand.
+var fileSandboxRegistry sync.Map // *server.MCPServer -> *fileSandbox
+
+// fileSandbox confines the files tools read to the configured roots and, for
+// clients declaring the roots capability, to the roots the client lists.
+//
+// A path must lie within both, so a client can narrow file access but never
+// widen it beyond what the operator configured. Client roots are requested on
+// first use and requested again after notifications/roots/list_changed.
+//
+// Thread Safety: Safe for concurrent use.
+type fileSandbox struct {
+	// roots: Resolved directories configured by the operator
+	roots []string
+	// lexicalRoots: Directories configured by the operator as absolute paths, before symlinks are resolved
+	lexicalRoots []string
+	// maxFileSize: Size limit in bytes of files read
+	maxFileSize int64
+	// mu: Protects clientRoots and pending
+	mu sync.Mutex
+	// clientRoots: Resolved roots listed by each session's client, by session ID
+	clientRoots map[string][]string
+	// pending: IDs of the sessions whose roots are being requested by [fileSandbox.prefetchSessionRoots]
+	pending map[string]struct{}
+}
+
+// newFileSandbox creates a file sandbox from the file settings.
+//
+// Parameters:
+//   - files: File settings providing the roots and size limit
+//
+// Returns:
+//   - *fileSandbox: Sandbox to attach to a server before use
+func newFileSandbox(files FilesConfig) *fileSandbox {
+	maxFileSize := files.MaxFileSize
+	if maxFileSize <= 0 {
+		maxFileSize = defaultMaxFileSize
+	}
+	return &fileSandbox{
+		roots:        files.rootDirs(),
+		lexicalRoots: absDirs(files.configuredRoots()),
+		maxFileSize:  maxFileSize,
+		clientRoots:  make(map[string][]string),
+		pending:      make(map[string]struct{}),
+	}
+}
+
+// attach binds f to s, so that [fileSandboxOf] finds it, and forgets the
+// roots of clients announcing that their roots changed.
+func (f *fileSandbox) attach(s *server.MCPServer) {
+	fileSandboxRegistry.Store(s, f)
+	s.AddNotificationHandler(mcp.MethodNotificationRootsListChanged, f.handleRootsListChanged)
+}
+
+// fileSandboxOf returns the file sandbox of s.
+//
+// Returns:
+//   - *fileSandbox: Sandbox, or nil if s was not built by [ServerBuilder.Build]
+func fileSandboxOf(s *server.MCPServer) *fileSandbox {
+	if s == nil {
+		return nil
+	}
+	f, _ := fileSandboxRegistry.Load(s)
+	res, _ := f.(*fileSandbox)
+	return res
+}
+
+// sandboxFromContext returns the file sandbox of the server handling the
+// request in ctx, or one confined to the working directory if there is none.
+func sandboxFromContext(ctx context.Context) *fileSandbox {
+	if f := fileSandboxOf(server.ServerFromContext(ctx)); f != nil {
+		return f
+	}
+	return newFileSandbox(FilesConfig{})
+}
+
+// handleRootsListChanged forgets the roots of the notifying session, so that
+// they are requested again on the next file access. Without a session in ctx,
+// the roots of every session are forgotten.
+func (f *fileSandbox) handleRootsListChanged(ctx context.Context, notification mcp.JSONRPCNotification) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		delete(f.clientRoots, session.SessionID())
+		return
+	}
+	clear(f.clientRoots)
+}
+
+// dropSession forgets the roots of a session that has ended.
+func (f *fileSandbox) dropSession(ctx context.Context, session server.ClientSession) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.clientRoots, session.SessionID())
+}
+
+// sessionRoots returns the roots listed by the client of the session in ctx.
+//
+// Parameters:
+//   - ctx: Context of the request
+//
+// Returns:
+//   - []string: Resolved client roots
+//   - bool: Whether the client declared the roots capability; if false, only the configured roots apply
+//   - error: errRootsUnavailable if the client did not answer roots/list
+func (f *fileSandbox) sessionRoots(ctx context.Context) ([]string, bool, error) {
+	session := server.ClientSessionFromContext(ctx)
+	lister, ok := rootsLister(session)
+	if !ok {
+		return nil, false, nil
+	}
+
+	f.mu.Lock()
+	roots, ok := f.clientRoots[session.SessionID()]
+	f.mu.Unlock()
+	if ok {
+		return roots, true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, rootsRequestTimeout)
+	defer cancel()
+	result, err := lister.ListRoots(ctx, mcp.ListRootsRequest{})
+	if err != nil {
+		return nil, true, fmt.Errorf("%w: %v", errRootsUnavailable, err)
+	}
+	var dirs []string
+	for _, root := range result.Roots {
+		if dir, ok := fileURIPath(root.URI); ok {
+			dirs = append(dirs, dir)
+		}
+	}
+	roots = resolveDirs(dirs)
+
+	f.mu.Lock()
+	f.clientRoots[session.SessionID()] = roots
+	f.mu.Unlock()
+	return roots, true, nil
+}
+
+// rootsLister returns session as a session that can be asked for its
+// roots, if its client declared the roots capability.
+func rootsLister(session server.ClientSession) (server.SessionWithRoots, bool) {
+	info, ok := session.(server.SessionWithClientInfo)
+	if !ok || info.GetClientCapabilities().Roots == nil {
+		return nil, false
+	}
+	lister, ok := session.(server.SessionWithRoots)
+	return lister, ok
+}
+
+// knownSessionRoots returns the roots listed by the client of session
+// without asking the client.
+//
+// Parameters:
+//   - session: Session whose roots apply
+//
+// Returns:
+//   - []string: Resolved client roots
+//   - bool: Whether the client declared the roots capability; if false, only the configured roots apply
+//   - bool: Whether the roots are known; if false, [fileSandbox.prefetchSessionRoots] can request them
+func (f *fileSandbox) knownSessionRoots(session server.ClientSession) (roots []string, declared, known bool) {
+	if _, ok := rootsLister(session); !ok {
+		return nil, false, false
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	roots, known = f.clientRoots[session.SessionID()]
+	return roots, true, known
+}
+
+// prefetchSessionRoots requests the roots of the client of the session in
+// ctx in the background, for callers that must not wait for the client, such
+// as a transport reading the client's messages. Nothing is requested while
+// an earlier request for the session is outstanding.
+//
+// Parameters:
+//   - ctx: Context carrying the session, detached from any request
+func (f *fileSandbox) prefetchSessionRoots(ctx context.Context) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return
+	}
+	f.mu.Lock()
+	if _, ok := f.pending[session.SessionID()]; ok {
+		f.mu.Unlock()
+		return
+	}
+	f.pending[session.SessionID()] = struct{}{}
+	f.mu.Unlock()
+
+	go func() {
+		// Errors are reported when a tool reads a file; completion offers nothing meanwhile
+		_, _, _ = f.sessionRoots(ctx)
+		f.mu.Lock()
+		delete(f.pending, session.SessionID())
+		f.mu.Unlock()
+	}()
+}
+
+// intersectRoots returns the directories lying within both a and b: each
+// directory of either that is one of the other or lies beneath one of them.
+func intersectRoots(a, b []string) []string {
+	var roots []string
+	for _, dir := range a {
+		if withinRoots(dir, b) {
+			roots = append(roots, dir)
+		}
+	}
+	for _, dir := range b {
+		if withinRoots(dir, a) && !slices.Contains(roots, dir) {
+			roots = append(roots, dir)
+		}
+	}
+	return roots
+}
+
+// fileURIPath returns the local path of a file:// root URI.
+func fileURIPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "file" || u.Path == "" {
+		return "", false
+	}
+	path := u.Path
+	// file:///C:/certs names C:/certs on Windows
+	if len(path) > 2 && path[0] == '/' && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path), true
+}
+
+// resolve returns path, absolute with symlinks resolved, and the configured
+// root it lies within.
+//
+// Paths outside the configured roots are refused before the file system is
+// consulted, so that the error is the same whether or not they exist; paths
+// within them are refused again if a symlink leads outside.
+//
+// Parameters:
+//   - ctx: Context of the request, carrying the session whose roots apply
+//   - path: Path as given by the client, relative to the working directory
+//
+// Returns:
+//   - resolved: Absolute path with symlinks resolved
+//   - root: Configured root containing resolved
+//   - error: errOutsideRoots, errRootsUnavailable, or the error resolving a path that does not exist
+func (f *fileSandbox) resolve(ctx context.Context, path string) (resolved, root string, err error) {
+	// An empty path would otherwise name the working directory
+	if path == "" {
+		return "", "", os.ErrNotExist
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", "", err
+	}
+	// Roots may be configured through symlinks, so either form of a root admits the path
+	if !withinRoots(abs, f.lexicalRoots) && !withinRoots(abs, f.roots) {
+		return "", "", errOutsideRoots
+	}
+	if resolved, err = filepath.EvalSymlinks(abs); err != nil {
+		return "", "", err
+	}
+	if root = rootOf(resolved, f.roots); root == "" {
+		return "", "", errOutsideRoots
+	}
+	clientRoots, declared, err := f.sessionRoots(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	if declared && !withinRoots(resolved, clientRoots) {
+		return "", "", errOutsideRoots
+	}
+	return resolved, root, nil
+}
+
+// readFile reads a regular file within the sandbox.
+//
+// The file is opened relative to its root with [os.OpenInRoot], so a symlink
+// swapped in after the path was checked cannot lead outside the root.
+//
+// Parameters:
+//   - ctx: Context of the request, carrying the session whose roots apply
+//   - path: Path as given by the client, relative to the working directory
+//
+// Returns:
+//   - []byte: File contents
+//   - error: A sandbox error (see [isSandboxError]), or the error opening or reading the file
+func (f *fileSandbox) readFile(ctx context.Context, path string) ([]byte, error) {
+	resolved, root, err := f.resolve(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.OpenInRoot(root, rel)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, errNotRegularFile
+	}
+	if info.Size() > f.maxFileSize {
+		return nil, fmt.Errorf("%w of %d bytes", errFileTooLarge, f.maxFileSize)
+	}
+	// The file may grow after Stat
+	data, err := io.ReadAll(io.LimitReader(file, f.maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > f.maxFileSize {
+		return nil, fmt.Errorf("%w of %d bytes", errFileTooLarge, f.maxFileSize)
+	}
+	return data, nil
+}
+
+// isSandboxError reports whether err denies access to a file that exists,
+// as opposed to an input that names no file at all.
+func isSandboxError(err error) bool {
+	return errors.Is(err, errOutsideRoots) || errors.Is(err, errNotRegularFile) ||
+		errors.Is(err, errFileTooLarge) || errors.Is(err, errRootsUnavailable)
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testRoots lists a changeable set of roots to the server.
+type testRoots struct {
+	// mu: Protects dirs
+	mu sync.Mutex
+	// dirs: Directories listed as roots
+	dirs []string
+}
+
+// ListRoots implements the client and server RootsHandler interfaces.
+func (r *testRoots) ListRoots(ctx context.Context, request mcp.ListRootsRequest) (*mcp.ListRootsResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := &mcp.ListRootsResult{Roots: []mcp.Root{}}
+	for _, dir := range r.dirs {
+		uri := url.URL{Scheme: "file", Path: filepath.ToSlash(dir)}
+		result.Roots = append(result.Roots, mcp.Root{URI: uri.String()})
+	}
+	return result, nil
+}
+
+// set replaces the listed roots.
+func (r *testRoots) set(dirs ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.dirs = dirs
+}
+
+// callTool calls a tool of s on a test session.
+func callTool(t *testing.T, s *server.MCPServer, name string, args map[string]any) mcp.CallToolResult {
+	t.Helper()
+	arguments, err := json.Marshal(args)
+	require.NoError(t, err)
+	response, ok := sendMessage(t.Context(), s, newTestSession("sandbox"), fmt.Sprintf(
+		`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":%s}}`, name, arguments)).(mcp.JSONRPCResponse)
+	require.True(t, ok)
+	return response.Result.(mcp.CallToolResult)
+}
+
+// sandboxFixture creates a root holding certs/ca.pem, a directory outside it
+// holding secret.pem, and a symlink from the root to that secret.
+func sandboxFixture(t *testing.T) (root, outside, caPEM string) {
+	t.Helper()
+	caPEM = newSelfSignedCA(t, "Sandbox CA")
+	root, outside = t.TempDir(), t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(root, "certs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "certs", "ca.pem"), []byte(caPEM), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(outside, "secret.pem"), []byte(caPEM), 0o600))
+	require.NoError(t, os.Symlink(filepath.Join(outside, "secret.pem"), filepath.Join(root, "escape.pem")))
+	return root, outside, caPEM
+}
+
+func TestFileSandboxReadFile(t *testing.T) {
+	root, outside, caPEM := sandboxFixture(t)
+	sandbox := newFileSandbox(FilesConfig{Roots: []string{root}})
+
+	data, err := sandbox.readFile(t.Context(), filepath.Join(root, "certs", "ca.pem"))
+	require.NoError(t, err)
+	assert.Equal(t, caPEM, string(data))
+
+	_, err = sandbox.readFile(t.Context(), filepath.Join(root, "certs", "..", "..", filepath.Base(outside), "secret.pem"))
+	assert.ErrorIs(t, err, errOutsideRoots)
+	_, err = sandbox.readFile(t.Context(), filepath.Join(outside, "secret.pem"))
+	assert.ErrorIs(t, err, errOutsideRoots)
+	// Paths outside the roots are refused alike whether or not they exist
+	_, err = sandbox.readFile(t.Context(), filepath.Join(outside, "missing.pem"))
+	assert.ErrorIs(t, err, errOutsideRoots)
+	_, err = sandbox.readFile(t.Context(), base64.StdEncoding.EncodeToString([]byte(caPEM)))
+	assert.ErrorIs(t, err, errOutsideRoots)
+	// Symlinks are resolved before the check
+	_, err = sandbox.readFile(t.Context(), filepath.Join(root, "escape.pem"))
+	assert.ErrorIs(t, err, errOutsideRoots)
+
+	_, err = sandbox.readFile(t.Context(), filepath.Join(root, "certs"))
+	assert.ErrorIs(t, err, errNotRegularFile)
+
+	// Inputs naming no file within the roots are not sandbox errors
+	for _, input := range []string{"", filepath.Join(root, "missing.pem")} {
+		_, err = sandbox.readFile(t.Context(), input)
+		require.Error(t, err)
+		assert.False(t, isSandboxError(err), "%q: %v", input, err)
+	}
+
+	// Roots configured through a symlink admit paths through either form
+	link := filepath.Join(t.TempDir(), "root")
+	require.NoError(t, os.Symlink(root, link))
+	linked := newFileSandbox(FilesConfig{Roots: []string{link}})
+	for _, dir := range []string{link, root} {
+		data, err = linked.readFile(t.Context(), filepath.Join(dir, "certs", "ca.pem"))
+		require.NoError(t, err, dir)
+		assert.Equal(t, caPEM, string(data))
+	}
+
+	small := newFileSandbox(FilesConfig{Roots: []string{root}, MaxFileSize: 64})
+	_, err = small.readFile(t.Context(), filepath.Join(root, "certs", "ca.pem"))
+	assert.ErrorIs(t, err, errFileTooLarge)
+}
+
+func TestReadCertificateDataSandbox(t *testing.T) {
+	root, outside, caPEM := sandboxFixture(t)
+	config := &Config{}
+	config.Files.Roots = []string{root}
+	tools, toolsWithConfig := createTools()
+	s, err := NewServerBuilder().WithConfig(config).WithVersion("1.0.0").
+		WithTools(tools...).WithToolsWithConfig(toolsWithConfig...).Build()
+	require.NoError(t, err)
+
+	inspect := func(t *testing.T, certificate string) mcp.CallToolResult {
+		t.Helper()
+		return callTool(t, s, ToolInspectCertificate, map[string]any{"certificate": certificate})
+	}
+
+	for name, input := range map[string]string{
+		"file":   filepath.Join(root, "certs", "ca.pem"),
+		"base64": base64.StdEncoding.EncodeToString([]byte(caPEM)),
+		"pem":    caPEM,
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.False(t, inspect(t, input).IsError)
+		})
+	}
+
+	t.Run("outside roots", func(t *testing.T) {
+		for _, input := range []string{filepath.Join(outside, "secret.pem"), filepath.Join(outside, "missing.pem"), filepath.Join(root, "escape.pem")} {
+			result := inspect(t, input)
+			require.True(t, result.IsError)
+			text := result.Content[0].(mcp.TextContent).Text
+			assert.Contains(t, text, errOutsideRoots.Error())
+			assert.NotContains(t, text, input)
+		}
+	})
+
+	t.Run("invalid input is not echoed", func(t *testing.T) {
+		result := inspect(t, "/no/such/secret-value.pem")
+		require.True(t, result.IsError)
+		assert.NotContains(t, result.Content[0].(mcp.TextContent).Text, "secret-value")
+	})
+
+	t.Run("diff and inventory", func(t *testing.T) {
+		for _, call := range []struct {
+			tool string
+			args map[string]any
+		}{
+			{ToolDiffCertChains, map[string]any{"old_certificate": filepath.Join(root, "certs", "ca.pem"), "new_certificate": filepath.Join(outside, "secret.pem")}},
+			{ToolScanCertificateInventory, map[string]any{"paths": outside}},
+		} {
+			result := callTool(t, s, call.tool, call.args)
+			require.True(t, result.IsError, call.tool)
+			assert.Contains(t, result.Content[0].(mcp.TextContent).Text, errOutsideRoots.Error(), call.tool)
+		}
+	})
+}
+
+func TestFileSandboxClientRoots(t *testing.T) {
+	root, _, _ := sandboxFixture(t)
+	other := filepath.Join(root, "other")
+	require.NoError(t, os.Mkdir(other, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(other, "ca.pem"), []byte(newSelfSignedCA(t, "Other CA")), 0o600))
+
+	config := &Config{}
+	config.Files.Roots = []string{root}
+	tools, toolsWithConfig := createTools()
+	s, err := NewServerBuilder().WithConfig(config).WithVersion("1.0.0").
+		WithTools(tools...).WithToolsWithConfig(toolsWithConfig...).Build()
+	require.NoError(t, err)
+
+	roots := &testRoots{}
+	roots.set(filepath.Join(root, "certs"), t.TempDir())
+	c := client.NewClient(transport.NewInProcessTransportWithOptions(s, transport.WithRootsHandler(roots)), client.WithRootsHandler(roots))
+	t.Cleanup(func() { c.Close() })
+	require.NoError(t, c.Start(t.Context()))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "roots-test", Version: "1.0.0"}
+	_, err = c.Initialize(t.Context(), initRequest)
+	require.NoError(t, err)
+
+	inspect := func(t *testing.T, path string) *mcp.CallToolResult {
+		t.Helper()
+		request := mcp.CallToolRequest{}
+		request.Params.Name = ToolInspectCertificate
+		request.Params.Arguments = map[string]any{"certificate": path}
+		result, err := c.CallTool(t.Context(), request)
+		require.NoError(t, err)
+		return result
+	}
+
+	// Client roots narrow the configured roots
+	assert.False(t, inspect(t, filepath.Join(root, "certs", "ca.pem")).IsError)
+	result := inspect(t, filepath.Join(other, "ca.pem"))
+	require.True(t, result.IsError)
+	assert.Contains(t, result.Content[0].(mcp.TextContent).Text, errOutsideRoots.Error())
+
+	// Changed roots are requested again after notifications/roots/list_changed
+	roots.set(other)
+	require.NoError(t, c.RootListChanges(t.Context()))
+	assert.False(t, inspect(t, filepath.Join(other, "ca.pem")).IsError)
+	assert.True(t, inspect(t, filepath.Join(root, "certs", "ca.pem")).IsError)
+
+	// Client roots cannot widen the configured roots
+	roots.set(filepath.Dir(root))
+	require.NoError(t, c.RootListChanges(t.Context()))
+	assert.True(t, inspect(t, filepath.Join(root, "escape.pem")).IsError)
+}
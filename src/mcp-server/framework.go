@@ -255,12 +255,61 @@ type ServerResourceWithEmbed struct {
 	Handler ResourceHandlerWithEmbed
 }
 
+// ServerResourceTemplate holds a resource template definition.
+// It pairs an MCP resource template specification with the handler serving every matching URI.
+//
+// Fields:
+//   - Template: The MCP resource template containing the URI template, name, description, and MIME type
+//   - Handler: The function that serves reads of URIs matching the template
+//   - Completions: How completion/complete completes values of the template's variables, by variable name
+//
+// This struct is used for families of resources whose URIs are only known at runtime,
+// such as certificates addressed by fingerprint.
+type ServerResourceTemplate struct {
+	// Template: The MCP resource template containing the URI template, name, description, and MIME type
+	Template mcp.ResourceTemplate
+	// Handler: The function that serves reads of URIs matching the template
+	Handler ResourceHandler
+	// Completions: How completion/complete completes values of the template's variables, by variable name
+	Completions map[string]ArgumentCompletion
+}
+
+// CompletionSource names a dynamic source of values for completion/complete.
+type CompletionSource string
+
+const (
+	// CompletionSourcePath completes file system paths within the configured file roots.
+	CompletionSourcePath CompletionSource = "path"
+	// CompletionSourceHostname completes hostnames fetched by recent tool calls.
+	CompletionSourceHostname CompletionSource = "hostname"
+	// CompletionSourceCertificate completes fingerprints of the certificates kept as resources.
+	CompletionSourceCertificate CompletionSource = "certificate"
+	// CompletionSourceChain completes leaf fingerprints of the chains kept as resources.
+	CompletionSourceChain CompletionSource = "chain"
+)
+
+// ArgumentCompletion describes how completion/complete completes values of a
+// prompt argument or resource template variable.
+//
+// Fields:
+//   - Source: Dynamic source of values; when empty, Values are offered instead
+//   - Values: Fixed values offered for the argument, such as the members of an enumeration
+//
+// Offered values are those starting with the value typed so far.
+type ArgumentCompletion struct {
+	// Source: Dynamic source of values; when empty, Values are offered instead
+	Source CompletionSource
+	// Values: Fixed values offered for the argument, such as the members of an enumeration
+	Values []string
+}
+
 // ServerPrompt holds a prompt definition that doesn't require embedded filesystem access.
 // It pairs an MCP prompt specification with its implementation function.
 //
 // Fields:
 //   - Prompt: The MCP prompt definition containing name, description, and arguments
 //   - Handler: The function that implements the prompt's logic
+//   - Completions: How completion/complete completes values of the prompt's arguments, by argument name
 //
 // This struct is used when registering prompts that don't need access to embedded templates.
 type ServerPrompt struct {
@@ -268,6 +317,8 @@ type ServerPrompt struct {
 	Prompt mcp.Prompt
 	// Handler: The function that implements the prompt's logic
 	Handler PromptHandler
+	// Completions: How completion/complete completes values of the prompt's arguments, by argument name
+	Completions map[string]ArgumentCompletion
 }
 
 // ServerPromptWithEmbed holds a prompt definition that requires embedded filesystem access.
@@ -276,6 +327,7 @@ type ServerPrompt struct {
 // Fields:
 //   - Prompt: The MCP prompt definition containing name, description, and arguments
 //   - Handler: The function that implements the prompt's logic with embed access
+//   - Completions: How completion/complete completes values of the prompt's arguments, by argument name
 //
 // This struct is used for prompts that need to access embedded templates for dynamic content generation.
 type ServerPromptWithEmbed struct {
@@ -283,6 +335,8 @@ type ServerPromptWithEmbed struct {
 	Prompt mcp.Prompt
 	// Handler: The function that implements the prompt's logic with embed access
 	Handler PromptHandlerWithEmbed
+	// Completions: How completion/complete completes values of the prompt's arguments, by argument name
+	Completions map[string]ArgumentCompletion
 }
 
 // ServerDependencies holds all dependencies needed to create the MCP server.
@@ -298,11 +352,15 @@ type ServerPromptWithEmbed struct {
 //   - ToolsWithConfig: List of tool definitions that need configuration access
 //   - Resources: List of static and dynamic resources provided by the server
 //   - ResourcesWithEmbed: List of resources that require embedded filesystem access
+//   - ResourceTemplates: List of resource templates for resources addressed at runtime
 //   - Prompts: List of predefined prompts for guided workflows
 //   - PromptsWithEmbed: List of prompts that require embedded filesystem access
 //   - SamplingHandler: Handler for bidirectional AI communication and streaming responses
 //   - Instructions: Server instructions for MCP clients describing capabilities and behavior
 //   - PopulateCache: Whether to populate metadata cache for resource handlers
+//   - ToolMiddlewares: Middlewares wrapping every tool handler, outermost first
+//   - ResourceMiddlewares: Middlewares wrapping every resource handler, outermost first
+//   - PromptMiddlewares: Middlewares wrapping every prompt handler, outermost first
 //
 // This struct is used internally by ServerBuilder and should not be instantiated directly.
 type ServerDependencies struct {
@@ -324,6 +382,8 @@ type ServerDependencies struct {
 	Resources []ServerResource
 	// ResourcesWithEmbed: List of resources that require embedded filesystem access
 	ResourcesWithEmbed []ServerResourceWithEmbed
+	// ResourceTemplates: List of resource templates for resources addressed at runtime
+	ResourceTemplates []ServerResourceTemplate
 	// Prompts: List of predefined prompts for guided workflows
 	Prompts []ServerPrompt
 	// PromptsWithEmbed: List of prompts that require embedded filesystem access
@@ -334,6 +394,12 @@ type ServerDependencies struct {
 	Instructions string
 	// PopulateCache: Whether to populate metadata cache for resource handlers
 	PopulateCache bool
+	// ToolMiddlewares: Middlewares wrapping every tool handler, outermost first
+	ToolMiddlewares []ToolMiddleware
+	// ResourceMiddlewares: Middlewares wrapping every resource handler, outermost first
+	ResourceMiddlewares []ResourceMiddleware
+	// PromptMiddlewares: Middlewares wrapping every prompt handler, outermost first
+	PromptMiddlewares []PromptMiddleware
 }
 
 // ServerBuilder helps construct the [MCP] server with proper dependencies using a fluent interface.
@@ -508,6 +574,22 @@ func (b *ServerBuilder) WithEmbeddedResources(resources ...ServerResourceWithEmb
 	return b
 }
 
+// WithResourceTemplates adds resource templates to the MCP server.
+// It registers handlers for families of resources addressed by URI templates.
+//
+// Parameters:
+//   - templates: Variable number of ServerResourceTemplate structs containing template specs and handlers
+//
+// Returns:
+//   - The ServerBuilder instance for method chaining
+//
+// Templates let clients read resources whose URIs are built at runtime, such as
+// "cert://sha256/{fingerprint}" for certificates resolved by earlier tool calls.
+func (b *ServerBuilder) WithResourceTemplates(templates ...ServerResourceTemplate) *ServerBuilder {
+	b.deps.ResourceTemplates = append(b.deps.ResourceTemplates, templates...)
+	return b
+}
+
 // WithPrompts adds predefined prompts to the MCP server for guided workflows.
 // It registers prompts that provide structured interactions for common tasks.
 //
@@ -604,6 +686,49 @@ func (b *ServerBuilder) WithPopulate() *ServerBuilder {
 	return b
 }
 
+// WithMiddleware adds middlewares that wrap every tool handler.
+// They apply uniformly to tools added with WithTools and WithToolsWithConfig.
+//
+// Parameters:
+//   - middlewares: Tool middlewares; the first one added is the outermost
+//
+// Returns:
+//   - The ServerBuilder instance for method chaining
+//
+// Middlewares are applied at Build() time, inside the server's own tool
+// authorization and rate limits, so they only see calls that were admitted.
+func (b *ServerBuilder) WithMiddleware(middlewares ...ToolMiddleware) *ServerBuilder {
+	b.deps.ToolMiddlewares = append(b.deps.ToolMiddlewares, middlewares...)
+	return b
+}
+
+// WithResourceMiddleware adds middlewares that wrap every resource handler.
+// They apply uniformly to resources added with WithResources, WithEmbeddedResources,
+// and WithResourceTemplates, and to the certificate resources registered at runtime.
+//
+// Parameters:
+//   - middlewares: Resource middlewares; the first one added is the outermost
+//
+// Returns:
+//   - The ServerBuilder instance for method chaining
+func (b *ServerBuilder) WithResourceMiddleware(middlewares ...ResourceMiddleware) *ServerBuilder {
+	b.deps.ResourceMiddlewares = append(b.deps.ResourceMiddlewares, middlewares...)
+	return b
+}
+
+// WithPromptMiddleware adds middlewares that wrap every prompt handler.
+// They apply uniformly to prompts added with WithPrompts and WithEmbeddedPrompts.
+//
+// Parameters:
+//   - middlewares: Prompt middlewares; the first one added is the outermost
+//
+// Returns:
+//   - The ServerBuilder instance for method chaining
+func (b *ServerBuilder) WithPromptMiddleware(middlewares ...PromptMiddleware) *ServerBuilder {
+	b.deps.PromptMiddlewares = append(b.deps.PromptMiddlewares, middlewares...)
+	return b
+}
+
 // BuildCLI creates a CLI framework with integrated MCP server capabilities.
 // It constructs a CLIFramework instance that provides both command-line interface
 // and MCP server functionality, allowing unified access to certificate operations.
@@ -640,19 +765,88 @@ func (b *ServerBuilder) BuildCLI() (*CLIFramework, error) {
 //   - An error if the configuration is invalid or server creation fails
 //
 // The method enables sampling if a sampling handler was provided, registers all tools,
-// resources, and prompts, and returns a ready-to-use server. The server will handle
+// resources, and prompts wrapped in the middlewares added with WithMiddleware,
+// WithResourceMiddleware, and WithPromptMiddleware, and returns a ready-to-use server. The server will handle
 // MCP protocol communication and route requests to the appropriate handlers.
 //
+// Tools are listed and called subject to the tool role allowlist of the
+// authenticated [Identity] in the request context, if any, and then to the
+// rate limits and concurrency quotas in the Limits section of the config.
+// Per-host limits are installed for every outbound request of the process.
+//
+// Chains resolved by tool calls are kept as cert://sha256/{fingerprint} and
+// chain://sha256/{fingerprint} resources, listed in pages of resourcePageSize
+// entries. The stdio, SSE, and streamable HTTP transports served by this
+// package answer resources/subscribe for them, and completion/complete for
+// the Completions of prompts and resource templates; other transports, such
+// as the ADK transport bridge, do not.
+//
+// Tool calls that carry a progress token in their _meta receive progress
+// notifications for each network step, and a notifications/cancelled from the
+// calling session cancels the context of the named call, aborting its
+// outstanding network I/O.
+//
+// Tools read files only within the roots and size limit of the Files section
+// of the config and, for clients declaring the roots capability, within the
+// roots the client lists.
+//
 // [MCP]: https://modelcontextprotocol.io/docs/getting-started/intro
 func (b *ServerBuilder) Build() (*server.MCPServer, error) {
-	s := server.NewMCPServer(
-		"X.509 Certificate Chain Resolver",
-		b.deps.Version,
+	toolRoles := toolRoleIndex(b.deps.Tools, b.deps.ToolsWithConfig)
+	calls := newCallTracker()
+	hooks := &server.Hooks{}
+	hooks.AddBeforeCallTool(stampRequestID)
+	certResources := newCertificateResources(b.deps.ResourceMiddlewares)
+	hooks.AddOnRegisterSession(certResources.addSession)
+	hooks.AddOnUnregisterSession(certResources.dropSession)
+	var files FilesConfig
+	if b.deps.Config != nil {
+		files = b.deps.Config.Files
+	}
+	sandbox := newFileSandbox(files)
+	hooks.AddOnUnregisterSession(sandbox.dropSession)
+	completions := newCompletions(sandbox, certResources)
+	hooks.AddOnRegisterSession(completions.addSession)
+	hooks.AddOnUnregisterSession(completions.dropSession)
+	opts := []server.ServerOption{
 		server.WithToolCapabilities(true),
 		server.WithResourceCapabilities(true, true),
 		server.WithPromptCapabilities(true),
 		server.WithInstructions(b.deps.Instructions),
-	)
+		server.WithPaginationLimit(resourcePageSize),
+		// Outermost, so progress reporting and notifications/cancelled cover the whole call
+		server.WithHooks(hooks),
+		server.WithToolHandlerMiddleware(calls.middleware()),
+		// Per-identity tool allowlists; requests without an identity (stdio) are unrestricted
+		server.WithToolFilter(authorizedToolFilter(toolRoles)),
+		server.WithToolHandlerMiddleware(authorizeToolCall(toolRoles)),
+	}
+
+	// Rate limits run after authorization so refused callers do not consume tokens
+	var limiter *toolLimiter
+	if b.deps.Config != nil {
+		var err error
+		if limiter, err = newToolLimiter(b.deps.Config.Limits); err != nil {
+			return nil, err
+		}
+	}
+	if limiter != nil {
+		opts = append(opts, server.WithToolHandlerMiddleware(limitToolCalls(limiter)))
+	}
+	// The host limiter and status are process-wide, so a server without limits
+	// also clears those of a previously built one
+	if limiter != nil && limiter.config.Hosts.Rate > 0 {
+		x509chain.SetHostLimiter(limiter)
+	} else {
+		x509chain.SetHostLimiter(nil)
+	}
+	activeLimiter.Store(limiter)
+
+	s := server.NewMCPServer("X.509 Certificate Chain Resolver", b.deps.Version, opts...)
+	s.AddNotificationHandler(methodNotificationCancelled, calls.handleCancelled)
+	certResources.attach(s)
+	sandbox.attach(s)
+	completions.attach(s)
 
 	// Enable sampling for bidirectional AI communication if handler provided
 	if b.deps.SamplingHandler != nil {
@@ -663,7 +857,7 @@ func (b *ServerBuilder) Build() (*server.MCPServer, error) {
 
 	// Add tools
 	for _, tool := range b.deps.Tools {
-		s.AddTool(tool.Tool, tool.Handler)
+		s.AddTool(tool.Tool, chainMiddleware(tool.Handler, b.deps.ToolMiddlewares))
 	}
 
 	// Add tools that need config (wrap the handler)
@@ -671,35 +865,41 @@ func (b *ServerBuilder) Build() (*server.MCPServer, error) {
 		handler := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			return tool.Handler(ctx, request, b.deps.Config)
 		}
-		s.AddTool(tool.Tool, handler)
+		s.AddTool(tool.Tool, chainMiddleware(handler, b.deps.ToolMiddlewares))
 	}
 
 	// Add resources
 	for _, resource := range b.deps.Resources {
-		s.AddResource(resource.Resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-			return resource.Handler(ctx, request)
-		})
+		s.AddResource(resource.Resource, chainMiddleware(resource.Handler, b.deps.ResourceMiddlewares))
 	}
 
 	// Add resources that need embed access (dependency injection passing Magic embedded filesystem)
 	for _, resource := range b.deps.ResourcesWithEmbed {
-		s.AddResource(resource.Resource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		handler := func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 			return resource.Handler(ctx, request, b.deps.Embed)
-		})
+		}
+		s.AddResource(resource.Resource, chainMiddleware(handler, b.deps.ResourceMiddlewares))
+	}
+
+	// Add resource templates
+	for _, tmpl := range b.deps.ResourceTemplates {
+		s.AddResourceTemplate(tmpl.Template, chainMiddleware(tmpl.Handler, b.deps.ResourceMiddlewares))
+		completions.addResourceTemplate(tmpl.Template.URITemplate.Raw(), tmpl.Completions)
 	}
 
 	// Add prompts
 	for _, prompt := range b.deps.Prompts {
-		s.AddPrompt(prompt.Prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
-			return prompt.Handler(ctx, request)
-		})
+		s.AddPrompt(prompt.Prompt, chainMiddleware(prompt.Handler, b.deps.PromptMiddlewares))
+		completions.addPrompt(prompt.Prompt.Name, prompt.Completions)
 	}
 
 	// Add prompts that need embed access (dependency injection passing Magic embedded filesystem)
 	for _, prompt := range b.deps.PromptsWithEmbed {
-		s.AddPrompt(prompt.Prompt, func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		handler := func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
 			return prompt.Handler(ctx, request, b.deps.Embed)
-		})
+		}
+		s.AddPrompt(prompt.Prompt, chainMiddleware(handler, b.deps.PromptMiddlewares))
+		completions.addPrompt(prompt.Prompt.Name, prompt.Completions)
 	}
 
 	// Populate metadata cache for resource handlers if requested
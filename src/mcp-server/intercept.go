@@ -0,0 +1,222 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// stdioSessionID is the ID mcp-go gives the single session of the stdio transport.
+const stdioSessionID = "stdio"
+
+// maxRequestBodySize bounds the HTTP request bodies read by [interceptUnroutedMethods]
+// and [interceptSSEUnroutedMethods].
+const maxRequestBodySize = 8 << 20
+
+// unroutedMethodHandler answers the JSON-RPC requests that mcp-go does not
+// route: resources/subscribe, resources/unsubscribe, and completion/complete.
+//
+// Parameters:
+//   - session: ID of the session the message arrived on
+//   - message: Raw JSON-RPC message
+//
+// Returns:
+//   - mcp.JSONRPCMessage: Response to send back to the client
+//   - bool: Whether the message was answered; if false it must be forwarded to the server
+type unroutedMethodHandler func(session string, message []byte) (mcp.JSONRPCMessage, bool)
+
+// unroutedMethodsOf returns the handler answering the requests mcp-go does not route for s.
+//
+// Returns:
+//   - unroutedMethodHandler: Handler, or nil if s was not built by [ServerBuilder.Build]
+func unroutedMethodsOf(s *server.MCPServer) unroutedMethodHandler {
+	resources, completions := certificateResourcesOf(s), completionsOf(s)
+	if resources == nil || completions == nil {
+		return nil
+	}
+	return func(session string, message []byte) (mcp.JSONRPCMessage, bool) {
+		if response, ok := resources.handleSubscriptionMessage(session, message); ok {
+			return response, true
+		}
+		return completions.handleCompletionMessage(session, message)
+	}
+}
+
+// syncWriter serializes writes so that messages written by different
+// goroutines are not interleaved.
+type syncWriter struct {
+	// mu: Serializes writes to w
+	mu sync.Mutex
+	// w: Underlying writer
+	w io.Writer
+}
+
+// Write implements io.Writer.
+func (w *syncWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.w.Write(p)
+}
+
+// serveStdio serves s over stdin and stdout, answering the requests mcp-go
+// does not route itself.
+//
+// Every message mcp-go writes is a single Write call, so answers to
+// intercepted requests are written between them without interleaving.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the server
+//   - s: Server returned by ServerBuilder.Build
+//   - in: Input stream of newline-delimited JSON-RPC messages
+//   - out: Output stream for responses and notifications
+//
+// Returns:
+//   - error: Error returned by the stdio server
+func serveStdio(ctx context.Context, s *server.MCPServer, in io.Reader, out io.Writer) error {
+	handle := unroutedMethodsOf(s)
+	if handle == nil {
+		return server.NewStdioServer(s).Listen(ctx, in, out)
+	}
+
+	w := &syncWriter{w: out}
+	pr, pw := io.Pipe()
+	go func() {
+		reader := bufio.NewReader(in)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(line) > 0 {
+				if response, ok := handle(stdioSessionID, line); ok {
+					if data, mErr := json.Marshal(response); mErr == nil {
+						w.Write(append(data, '\n'))
+					}
+				} else if _, wErr := pw.Write(line); wErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	// Unblock the reader goroutine once the server stops
+	defer pr.Close()
+
+	return server.NewStdioServer(s).Listen(ctx, pr, w)
+}
+
+// interceptUnroutedMethods wraps the streamable HTTP handler of s so that
+// the requests mcp-go does not route are answered for the session named by
+// the Mcp-Session-Id header. Request bodies larger than maxRequestBodySize
+// are refused.
+//
+// Parameters:
+//   - s: Server returned by ServerBuilder.Build
+//   - next: Streamable HTTP handler serving s
+//
+// Returns:
+//   - http.Handler: Handler answering intercepted requests and forwarding everything else
+func interceptUnroutedMethods(s *server.MCPServer, next http.Handler) http.Handler {
+	handle := unroutedMethodsOf(s)
+	if handle == nil {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Body == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, ok := readRequestBody(w, r)
+		if !ok {
+			return
+		}
+
+		session := r.Header.Get(server.HeaderKeySessionID)
+		if response, ok := handle(session, body); ok {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		next.ServeHTTP(w, r)
+	})
+}
+
+// interceptSSEUnroutedMethods wraps the SSE server of s so that the requests
+// mcp-go does not route are answered for the session named by the sessionId
+// query parameter of the message endpoint.
+//
+// As for every request on this transport, the POST is accepted and the
+// response is delivered on the session's event stream.
+//
+// Parameters:
+//   - s: Server returned by ServerBuilder.Build
+//   - sse: SSE server serving s
+//
+// Returns:
+//   - http.Handler: Handler answering intercepted requests and forwarding everything else to sse
+func interceptSSEUnroutedMethods(s *server.MCPServer, sse *server.SSEServer) http.Handler {
+	handle := unroutedMethodsOf(s)
+	if handle == nil {
+		return sse
+	}
+	messagePath := sse.CompleteMessagePath()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != messagePath || r.Body == nil {
+			sse.ServeHTTP(w, r)
+			return
+		}
+		body, ok := readRequestBody(w, r)
+		if !ok {
+			return
+		}
+
+		session := r.URL.Query().Get("sessionId")
+		if response, ok := handle(session, body); ok {
+			if err := sse.SendEventToSession(session, response); err != nil {
+				http.Error(w, "invalid session", http.StatusBadRequest)
+				return
+			}
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		sse.ServeHTTP(w, r)
+	})
+}
+
+// readRequestBody reads a request body of at most maxRequestBodySize bytes,
+// answering the request with an error if it cannot.
+//
+// Returns:
+//   - []byte: Request body
+//   - bool: Whether the body was read; if false the request has been answered
+func readRequestBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+	r.Body.Close()
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return nil, false
+		}
+		http.Error(w, "failed to read request body", http.StatusBadRequest)
+		return nil, false
+	}
+	return body, true
+}
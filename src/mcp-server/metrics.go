@@ -0,0 +1,468 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/helper/posix"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/spf13/cobra"
+)
+
+// metricsContentType is the [Prometheus text exposition format] content type.
+//
+// [Prometheus text exposition format]: https://prometheus.io/docs/instrumenting/exposition_formats/#text-based-format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Defaults for the serve-metrics command.
+const (
+	// DefaultMetricsListen is the default listen address of the metrics endpoint.
+	DefaultMetricsListen = "127.0.0.1:9560"
+	// DefaultMetricsInterval is the default time between endpoint scans.
+	DefaultMetricsInterval = 5 * time.Minute
+)
+
+// resourceMetric maps a [CollectResourceUsage] value to a Prometheus metric.
+type resourceMetric struct {
+	// group: Section of ResourceUsageData holding the value
+	group func(*ResourceUsageData) map[string]any
+	// key: Key of the value in the section
+	key string
+	// name: Prometheus metric name
+	name string
+	// help: Metric description
+	help string
+	// typ: Prometheus metric type
+	typ string
+	// scale: Multiplier applied to the value, such as MiB to bytes
+	scale float64
+}
+
+// resourceMetrics are the runtime statistics exported from CollectResourceUsage.
+var resourceMetrics = []resourceMetric{
+	{memoryUsage, "heap_alloc_mb", "x509_resolver_heap_alloc_bytes", "Bytes of allocated heap objects.", "gauge", 1 << 20},
+	{memoryUsage, "heap_inuse_mb", "x509_resolver_heap_inuse_bytes", "Bytes in in-use heap spans.", "gauge", 1 << 20},
+	{memoryUsage, "heap_sys_mb", "x509_resolver_heap_sys_bytes", "Bytes of heap memory obtained from the OS.", "gauge", 1 << 20},
+	{memoryUsage, "heap_objects", "x509_resolver_heap_objects", "Number of allocated heap objects.", "gauge", 1},
+	{memoryUsage, "stack_inuse_mb", "x509_resolver_stack_inuse_bytes", "Bytes in stack spans.", "gauge", 1 << 20},
+	{gcStats, "num_gc", "x509_resolver_gc_cycles_total", "Number of completed GC cycles.", "counter", 1},
+	{gcStats, "gc_cpu_fraction", "x509_resolver_gc_cpu_fraction", "Fraction of CPU time used by the GC since the program started.", "gauge", 1},
+	{systemInfo, "num_goroutine", "x509_resolver_goroutines", "Number of goroutines.", "gauge", 1},
+}
+
+// memoryUsage, gcStats, and systemInfo select a section of ResourceUsageData.
+func memoryUsage(d *ResourceUsageData) map[string]any { return d.MemoryUsage }
+func gcStats(d *ResourceUsageData) map[string]any     { return d.GCStats }
+func systemInfo(d *ResourceUsageData) map[string]any  { return d.SystemInfo }
+
+// MetricsExporter serves certificate and resolver health as Prometheus metrics.
+//
+// Endpoints are scanned in the background by [MetricsExporter.Run] rather than
+// on every scrape, so scrapes stay fast regardless of how many targets are
+// monitored; each scrape reports the most recent scan together with the CRL
+// cache and runtime statistics.
+type MetricsExporter struct {
+	// targets: Endpoints as "host:port"
+	targets []string
+	// opts: Endpoint scan options
+	opts x509inventory.EndpointOptions
+
+	// mu: Guards the fields below
+	mu sync.RWMutex
+	// report: Result of the most recent scan, nil before the first scan
+	report *x509inventory.EndpointReport
+	// scannedAt: Completion time of the most recent scan
+	scannedAt time.Time
+	// scanDuration: Duration of the most recent scan
+	scanDuration time.Duration
+	// scans: Number of completed scans
+	scans int64
+}
+
+// NewMetricsExporter creates an exporter for the given endpoints.
+//
+// Parameters:
+//   - targets: Endpoints as "host:port"; may be empty to export only resolver metrics
+//   - opts: Endpoint scan options
+//
+// Returns:
+//   - *MetricsExporter: Exporter without scan results until Refresh or Run is called
+func NewMetricsExporter(targets []string, opts x509inventory.EndpointOptions) *MetricsExporter {
+	return &MetricsExporter{targets: targets, opts: opts}
+}
+
+// Refresh scans every endpoint once and replaces the exported results.
+//
+// Parameters:
+//   - ctx: Context for cancellation
+//
+// Returns:
+//   - error: Error if ctx is cancelled during the scan; the previous results are kept
+//
+// Thread Safety: Safe for concurrent use with ServeHTTP.
+func (e *MetricsExporter) Refresh(ctx context.Context) error {
+	if len(e.targets) == 0 {
+		return nil
+	}
+
+	start := time.Now()
+	report, err := x509inventory.ScanEndpoints(ctx, e.targets, e.opts, nil)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.report = report
+	e.scannedAt = time.Now()
+	e.scanDuration = e.scannedAt.Sub(start)
+	e.scans++
+	return nil
+}
+
+// Run refreshes the results immediately and then once per interval until ctx is cancelled.
+//
+// Parameters:
+//   - ctx: Context whose cancellation stops the refresh loop
+//   - interval: Time between scans
+//   - log: Logger for scan failures
+func (e *MetricsExporter) Run(ctx context.Context, interval time.Duration, log logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.Refresh(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Metrics scan failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ServeHTTP writes all metrics in the Prometheus text exposition format.
+//
+// Thread Safety: Safe for concurrent use.
+func (e *MetricsExporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", metricsContentType)
+	fmt.Fprint(w, e.Render())
+}
+
+// Render returns all metrics in the Prometheus text exposition format.
+//
+// Returns:
+//   - string: Exposition text
+//
+// Thread Safety: Safe for concurrent use.
+func (e *MetricsExporter) Render() string {
+	var m metricsWriter
+
+	e.mu.RLock()
+	if e.report != nil {
+		e.renderEndpoints(&m)
+		m.family("x509_scan_last_timestamp_seconds", "Completion time of the most recent endpoint scan.", "gauge")
+		m.sample("x509_scan_last_timestamp_seconds", float64(e.scannedAt.UnixMilli())/1000)
+		m.family("x509_scan_duration_seconds", "Duration of the most recent endpoint scan.", "gauge")
+		m.sample("x509_scan_duration_seconds", e.scanDuration.Seconds())
+	}
+	m.family("x509_scans_total", "Number of completed endpoint scans.", "counter")
+	m.sample("x509_scans_total", float64(e.scans))
+	e.mu.RUnlock()
+
+	renderCRLCacheMetrics(&m)
+	renderResourceMetrics(&m)
+	return m.b.String()
+}
+
+// renderEndpoints writes the per-endpoint and per-certificate metrics of the
+// most recent scan. The caller must hold e.mu.
+func (e *MetricsExporter) renderEndpoints(m *metricsWriter) {
+	results := e.report.Results
+
+	endpointLabels := func(r *x509inventory.EndpointResult) []string {
+		host, port, err := net.SplitHostPort(r.Target)
+		if err != nil {
+			host = r.Target
+		}
+		return []string{"host", host, "port", port}
+	}
+	boolValue := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	m.family("x509_probe_success", "Whether the TLS handshake with the endpoint succeeded.", "gauge")
+	for i := range results {
+		m.sample("x509_probe_success", boolValue(results[i].Health != x509inventory.HealthUnreachable), endpointLabels(&results[i])...)
+	}
+	m.family("x509_probe_duration_seconds", "Time spent fetching, completing, and checking the endpoint chain.", "gauge")
+	for i := range results {
+		m.sample("x509_probe_duration_seconds", float64(results[i].DurationMS)/1000, endpointLabels(&results[i])...)
+	}
+	m.family("x509_chain_complete", "Whether the completed chain ends at a self-signed root.", "gauge")
+	for i := range results {
+		m.sample("x509_chain_complete", boolValue(results[i].Complete), endpointLabels(&results[i])...)
+	}
+	m.family("x509_chain_verified", "Whether every signature in the chain verifies.", "gauge")
+	for i := range results {
+		m.sample("x509_chain_verified", boolValue(results[i].Verified), endpointLabels(&results[i])...)
+	}
+	m.family("x509_chain_health", "Worst health state of the endpoint; the series with value 1 is the current state.", "gauge")
+	for i := range results {
+		m.sample("x509_chain_health", 1, append(endpointLabels(&results[i]), "health", results[i].Health)...)
+	}
+
+	m.family("x509_cert_not_after_seconds", "Expiry of each certificate in the chain as a Unix timestamp.", "gauge")
+	for i := range results {
+		for _, cert := range results[i].Chain {
+			labels := append(endpointLabels(&results[i]), "subject", cert.Subject, "role", cert.Role, "serial", cert.SerialNumber)
+			m.sample("x509_cert_not_after_seconds", float64(cert.NotAfter.Unix()), labels...)
+		}
+	}
+	m.family("x509_cert_not_before_seconds", "Start of validity of each certificate in the chain as a Unix timestamp.", "gauge")
+	for i := range results {
+		for _, cert := range results[i].Chain {
+			labels := append(endpointLabels(&results[i]), "subject", cert.Subject, "role", cert.Role, "serial", cert.SerialNumber)
+			m.sample("x509_cert_not_before_seconds", float64(cert.NotBefore.Unix()), labels...)
+		}
+	}
+
+	m.family("x509_cert_revocation_status", "OCSP/CRL status of each non-root certificate; the series with value 1 is the current status.", "gauge")
+	for i := range results {
+		for _, rev := range results[i].Revocation {
+			role := x509inventory.RoleIntermediate
+			for _, cert := range results[i].Chain {
+				if cert.SerialNumber == serialHex(rev.SerialNumber) && cert.Subject == rev.Subject {
+					role = cert.Role
+				}
+			}
+			labels := append(endpointLabels(&results[i]),
+				"subject", rev.Subject, "role", role, "serial", serialHex(rev.SerialNumber), "status", strings.ToLower(rev.Status))
+			m.sample("x509_cert_revocation_status", 1, labels...)
+		}
+	}
+}
+
+// serialHex converts a decimal serial number to the hexadecimal form used in
+// [x509inventory.ChainCertificate], returning other values unchanged.
+func serialHex(serial string) string {
+	n, ok := new(big.Int).SetString(serial, 10)
+	if !ok {
+		return serial
+	}
+	return n.Text(16)
+}
+
+// renderCRLCacheMetrics writes the CRL cache statistics.
+func renderCRLCacheMetrics(m *metricsWriter) {
+	metrics := x509chain.GetCRLCacheMetrics()
+	config := x509chain.GetCRLCacheConfig()
+
+	m.family("x509_crl_cache_entries", "Number of CRLs in the cache.", "gauge")
+	m.sample("x509_crl_cache_entries", float64(metrics.Size))
+	m.family("x509_crl_cache_max_entries", "Maximum number of CRLs in the cache.", "gauge")
+	m.sample("x509_crl_cache_max_entries", float64(config.MaxSize))
+	m.family("x509_crl_cache_memory_bytes", "Approximate memory used by cached CRLs.", "gauge")
+	m.sample("x509_crl_cache_memory_bytes", float64(metrics.TotalMemory))
+	m.family("x509_crl_cache_hits_total", "CRL cache hits.", "counter")
+	m.sample("x509_crl_cache_hits_total", float64(metrics.Hits))
+	m.family("x509_crl_cache_misses_total", "CRL cache misses.", "counter")
+	m.sample("x509_crl_cache_misses_total", float64(metrics.Misses))
+	m.family("x509_crl_cache_evictions_total", "CRL cache LRU evictions.", "counter")
+	m.sample("x509_crl_cache_evictions_total", float64(metrics.Evictions))
+	m.family("x509_crl_cache_cleanups_total", "Expired CRLs removed from the cache.", "counter")
+	m.sample("x509_crl_cache_cleanups_total", float64(metrics.Cleanups))
+}
+
+// renderResourceMetrics writes the runtime statistics gathered by CollectResourceUsage.
+func renderResourceMetrics(m *metricsWriter) {
+	usage := CollectResourceUsage(false)
+	for _, metric := range resourceMetrics {
+		value, ok := metricValue(metric.group(usage)[metric.key])
+		if !ok {
+			continue
+		}
+		m.family(metric.name, metric.help, metric.typ)
+		m.sample(metric.name, value*metric.scale)
+	}
+}
+
+// metricValue converts a numeric resource usage value to float64.
+func metricValue(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// metricsWriter builds a Prometheus text exposition.
+type metricsWriter struct {
+	b strings.Builder
+}
+
+// family writes the HELP and TYPE lines of a metric family.
+func (m *metricsWriter) family(name, help, typ string) {
+	fmt.Fprintf(&m.b, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// sample writes one sample; labels alternate between names and values.
+func (m *metricsWriter) sample(name string, value float64, labels ...string) {
+	m.b.WriteString(name)
+	if len(labels) > 0 {
+		m.b.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				m.b.WriteByte(',')
+			}
+			m.b.WriteString(labels[i])
+			m.b.WriteString(`="`)
+			m.b.WriteString(labelEscaper.Replace(labels[i+1]))
+			m.b.WriteByte('"')
+		}
+		m.b.WriteByte('}')
+	}
+	m.b.WriteByte(' ')
+	m.b.WriteString(strconv.FormatFloat(value, 'g', -1, 64))
+	m.b.WriteByte('\n')
+}
+
+// labelEscaper escapes label values as required by the exposition format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// newServeMetricsCommand creates the serve-metrics subcommand.
+//
+// The command serves GET /metrics in the Prometheus text exposition format,
+// exporting certificate expiry, chain completeness, verification, and
+// revocation status for the endpoints listed in the targets file, plus the
+// CRL cache and runtime statistics. Scan timeouts, warning days, and
+// concurrency come from the MCP server configuration defaults.
+//
+// Returns:
+//   - *cobra.Command: Configured serve-metrics command
+func (cf *CLIFramework) newServeMetricsCommand() *cobra.Command {
+	exeName := posix.GetExecutableName()
+	var (
+		listen      string
+		targetsFile string
+		interval    time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve-metrics",
+		Short: "Serve Prometheus metrics for TLS endpoint certificates and resolver health",
+		Example: fmt.Sprintf(`  %s serve-metrics --targets targets.txt
+  %s serve-metrics --targets targets.txt --listen :9560 --interval 10m`, exeName, exeName),
+		Args:         cobra.NoArgs,
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			l := logger.NewCLILogger()
+			l.SetOutput(os.Stderr)
+
+			config, err := loadConfig(cf.configFile)
+			if err != nil {
+				return fmt.Errorf("failed to load config: %w", err)
+			}
+			if interval <= 0 {
+				return fmt.Errorf("invalid interval %s, must be positive", interval)
+			}
+			// Targets are chosen by the operator, so private ranges stay reachable unless configured
+			if err := applyEgressPolicy(config.Egress, false); err != nil {
+				return fmt.Errorf("invalid egress policy: %w", err)
+			}
+
+			var targets []string
+			if targetsFile != "" {
+				f, err := os.Open(targetsFile)
+				if err != nil {
+					return fmt.Errorf("failed to open targets file: %w", err)
+				}
+				targets, err = x509inventory.ParseTargets(f)
+				f.Close()
+				if err != nil {
+					return fmt.Errorf("failed to read targets file: %w", err)
+				}
+			}
+
+			exporter := NewMetricsExporter(targets, x509inventory.EndpointOptions{
+				Version:     cf.version,
+				Timeout:     time.Duration(config.Defaults.Timeout) * time.Second,
+				WarnDays:    config.Defaults.WarnDays,
+				Concurrency: config.Defaults.BatchConcurrency,
+			})
+			return serveMetrics(cmd.Context(), listen, interval, exporter, l)
+		},
+	}
+
+	cmd.Flags().StringVar(&listen, "listen", DefaultMetricsListen, "listen address of the metrics endpoint")
+	cmd.Flags().StringVar(&targetsFile, "targets", "", "file listing host:port endpoints to monitor, one per line")
+	cmd.Flags().DurationVar(&interval, "interval", DefaultMetricsInterval, "time between endpoint scans")
+
+	return cmd
+}
+
+// serveMetrics serves the exporter on listen until ctx is cancelled or a
+// termination signal is received, refreshing scan results every interval.
+func serveMetrics(ctx context.Context, listen string, interval time.Duration, exporter *MetricsExporter, l logger.Logger) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	listener, err := net.Listen("tcp", listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", listen, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", exporter)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	x509chain.StartCRLCacheCleanup(ctx)
+	defer x509chain.StopCRLCacheCleanup()
+
+	var wg sync.WaitGroup
+	wg.Go(func() { exporter.Run(ctx, interval, l) })
+	wg.Go(func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	})
+
+	l.Printf("Serving metrics on http://%s/metrics", listener.Addr())
+	err = srv.Serve(listener)
+	stop()
+	wg.Wait()
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startMetricsTLSServer serves a leaf issued by a generated root on a loopback address.
+func startMetricsTLSServer(t *testing.T) string {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	rootTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Metrics Root CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().AddDate(1, 0, 0),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootDER, err := x509.CreateCertificate(rand.Reader, rootTmpl, rootTmpl, &rootKey.PublicKey, rootKey)
+	require.NoError(t, err)
+	root, err := x509.ParseCertificate(rootDER)
+	require.NoError(t, err)
+
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	leafTmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(0xbeef),
+		Subject:      pkix.Name{CommonName: `metrics "quoted" example`},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 3, 0),
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTmpl, root, &leafKey.PublicKey, rootKey)
+	require.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Config.ErrorLog = log.New(io.Discard, "", 0)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{leafDER, rootDER}, PrivateKey: leafKey}}}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String()
+}
+
+// closedLoopbackAddr returns a loopback address with no listener.
+func closedLoopbackAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := listener.Addr().String()
+	require.NoError(t, listener.Close())
+	return addr
+}
+
+func TestMetricsExporter(t *testing.T) {
+	healthy := startMetricsTLSServer(t)
+	closed := closedLoopbackAddr(t)
+	_, healthyPort, _ := net.SplitHostPort(healthy)
+	_, closedPort, _ := net.SplitHostPort(closed)
+
+	exporter := NewMetricsExporter([]string{healthy, closed}, x509inventory.EndpointOptions{Version: "1.0.0", Timeout: 5 * time.Second})
+
+	t.Run("Before First Scan", func(t *testing.T) {
+		output := exporter.Render()
+		assert.NotContains(t, output, "x509_probe_success")
+		assert.Contains(t, output, "x509_scans_total 0\n")
+		assert.Contains(t, output, "# TYPE x509_crl_cache_hits_total counter\n")
+		assert.Contains(t, output, "# TYPE x509_resolver_heap_alloc_bytes gauge\n")
+		assert.Contains(t, output, "x509_resolver_goroutines ")
+	})
+
+	require.NoError(t, exporter.Refresh(context.Background()))
+
+	recorder := httptest.NewRecorder()
+	exporter.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, metricsContentType, recorder.Header().Get("Content-Type"))
+	output := recorder.Body.String()
+
+	healthyLabels := fmt.Sprintf(`host="127.0.0.1",port="%s"`, healthyPort)
+	closedLabels := fmt.Sprintf(`host="127.0.0.1",port="%s"`, closedPort)
+	// The DN string already escapes the quotes, and the exposition format escapes them again
+	leafLabels := healthyLabels + `,subject="CN=metrics \\\"quoted\\\" example",role="leaf",serial="beef"`
+
+	for _, want := range []string{
+		"x509_probe_success{" + healthyLabels + "} 1\n",
+		"x509_probe_success{" + closedLabels + "} 0\n",
+		"x509_chain_complete{" + healthyLabels + "} 1\n",
+		"x509_chain_verified{" + healthyLabels + "} 1\n",
+		"x509_chain_health{" + healthyLabels + `,health="ok"} 1` + "\n",
+		"x509_chain_health{" + closedLabels + `,health="unreachable"} 1` + "\n",
+		"x509_cert_not_after_seconds{" + leafLabels + "} ",
+		"x509_cert_not_after_seconds{" + healthyLabels + `,subject="CN=Metrics Root CA",role="root",serial="1"} `,
+		"x509_cert_revocation_status{" + leafLabels + `,status="unknown"} 1` + "\n",
+		"x509_scans_total 1\n",
+		"# TYPE x509_cert_not_after_seconds gauge\n",
+	} {
+		assert.Contains(t, output, want)
+	}
+
+	// Every family is declared exactly once
+	for _, line := range strings.Split(output, "\n") {
+		if strings.HasPrefix(line, "# TYPE ") {
+			assert.Equal(t, 1, strings.Count(output, line+"\n"), line)
+		}
+	}
+}
+
+func TestServeMetrics(t *testing.T) {
+	addr := closedLoopbackAddr(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- serveMetrics(ctx, addr, time.Hour, NewMetricsExporter(nil, x509inventory.EndpointOptions{}), logger.NewMCPLogger(io.Discard, true))
+	}()
+
+	var resp *http.Response
+	require.Eventually(t, func() bool {
+		var err error
+		resp, err = http.Get("http://" + addr + "/metrics")
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, string(body), "x509_crl_cache_entries ")
+
+	resp, err = http.Post("http://"+addr+"/metrics", "text/plain", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("serveMetrics did not shut down")
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// ErrInputTooLarge indicates tool arguments larger than the limit set with [LimitToolInput].
+var ErrInputTooLarge = errors.New("tool input too large")
+
+// ToolMiddleware wraps a [ToolHandler] with cross-cutting behavior such as
+// timing, logging, panic recovery, input limits, or result caching.
+//
+// Middlewares registered with [ServerBuilder.WithMiddleware] apply to both
+// Tools and ToolsWithConfig; a ToolHandlerWithConfig is wrapped after the
+// config has been bound, so every middleware sees the same signature.
+//
+// Example:
+//
+//	timing := func(next ToolHandler) ToolHandler {
+//	    return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+//	        start := time.Now()
+//	        defer func() { log.Printf("%s took %s", request.Params.Name, time.Since(start)) }()
+//	        return next(ctx, request)
+//	    }
+//	}
+//	builder.WithMiddleware(timing)
+type ToolMiddleware = func(next ToolHandler) ToolHandler
+
+// ResourceMiddleware wraps a [ResourceHandler]; middlewares registered with
+// [ServerBuilder.WithResourceMiddleware] apply to Resources and ResourcesWithEmbed.
+type ResourceMiddleware = func(next ResourceHandler) ResourceHandler
+
+// PromptMiddleware wraps a [PromptHandler]; middlewares registered with
+// [ServerBuilder.WithPromptMiddleware] apply to Prompts and PromptsWithEmbed.
+type PromptMiddleware = func(next PromptHandler) PromptHandler
+
+// chainMiddleware applies middlewares to handler so that the first middleware
+// is the outermost, i.e. the first to see a request and the last to see its result.
+func chainMiddleware[H any](handler H, middlewares []func(H) H) H {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](handler)
+	}
+	return handler
+}
+
+// RecoverToolPanics returns middleware that converts a panicking tool handler
+// into an error, so one faulty tool cannot take down the server. The panic
+// value and stack are logged, not returned, so clients learn nothing about
+// the server's internals.
+//
+// Parameters:
+//   - l: Logger receiving the panic value and stack; use a stderr logger with stdio
+//
+// Returns:
+//   - ToolMiddleware: Middleware failing the request with a generic internal error
+func RecoverToolPanics(l logger.Logger) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					l.Printf("tool %s panicked: %v\n%s", request.Params.Name, r, debug.Stack())
+					result, err = nil, fmt.Errorf("tool %s failed: internal error", request.Params.Name)
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// RecoverResourcePanics returns middleware that converts a panicking resource
+// handler into an error.
+//
+// Parameters:
+//   - l: Logger receiving the panic value and stack; use a stderr logger with stdio
+//
+// Returns:
+//   - ResourceMiddleware: Middleware failing the request with a generic internal error
+func RecoverResourcePanics(l logger.Logger) ResourceMiddleware {
+	return func(next ResourceHandler) ResourceHandler {
+		return func(ctx context.Context, request mcp.ReadResourceRequest) (contents []mcp.ResourceContents, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					l.Printf("resource %s panicked: %v\n%s", request.Params.URI, r, debug.Stack())
+					contents, err = nil, fmt.Errorf("resource %s failed: internal error", request.Params.URI)
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// RecoverPromptPanics returns middleware that converts a panicking prompt
+// handler into an error.
+//
+// Parameters:
+//   - l: Logger receiving the panic value and stack; use a stderr logger with stdio
+//
+// Returns:
+//   - PromptMiddleware: Middleware failing the request with a generic internal error
+func RecoverPromptPanics(l logger.Logger) PromptMiddleware {
+	return func(next PromptHandler) PromptHandler {
+		return func(ctx context.Context, request mcp.GetPromptRequest) (result *mcp.GetPromptResult, err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					l.Printf("prompt %s panicked: %v\n%s", request.Params.Name, r, debug.Stack())
+					result, err = nil, fmt.Errorf("prompt %s failed: internal error", request.Params.Name)
+				}
+			}()
+			return next(ctx, request)
+		}
+	}
+}
+
+// LogToolCalls returns middleware that logs the name, duration, and outcome
+// of every tool call.
+//
+// Parameters:
+//   - l: Logger receiving one line per call; use a stderr logger with stdio
+//
+// Returns:
+//   - ToolMiddleware: Timing and logging middleware
+func LogToolCalls(l logger.Logger) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, request)
+			elapsed := time.Since(start).Round(time.Microsecond)
+			switch {
+			case err != nil:
+				l.Printf("tool %s failed after %s: %v", request.Params.Name, elapsed, err)
+			case result != nil && result.IsError:
+				l.Printf("tool %s returned an error result after %s", request.Params.Name, elapsed)
+			default:
+				l.Printf("tool %s completed in %s", request.Params.Name, elapsed)
+			}
+			return result, err
+		}
+	}
+}
+
+// LimitToolInput returns middleware that rejects tool calls whose arguments
+// exceed maxBytes when encoded as JSON, before the handler decodes them.
+//
+// Parameters:
+//   - maxBytes: Maximum encoded argument size; 0 or less disables the limit
+//
+// Returns:
+//   - ToolMiddleware: Middleware failing oversized calls with [ErrInputTooLarge]
+func LimitToolInput(maxBytes int) ToolMiddleware {
+	return func(next ToolHandler) ToolHandler {
+		if maxBytes <= 0 {
+			return next
+		}
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			data, err := json.Marshal(request.Params.Arguments)
+			if err != nil {
+				return nil, fmt.Errorf("failed to encode arguments of %s: %w", request.Params.Name, err)
+			}
+			if len(data) > maxBytes {
+				return nil, fmt.Errorf("%w: %s arguments are %d bytes, limit is %d", ErrInputTooLarge, request.Params.Name, len(data), maxBytes)
+			}
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/mcp-server/templates"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startInProcessClient builds a server and returns an initialized in-process client for it.
+func startInProcessClient(t *testing.T, builder *ServerBuilder) *client.Client {
+	t.Helper()
+
+	mcpServer, err := builder.Build()
+	require.NoError(t, err)
+	c, err := client.NewInProcessClient(mcpServer)
+	require.NoError(t, err)
+	t.Cleanup(func() { c.Close() })
+
+	require.NoError(t, c.Start(t.Context()))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "middleware-test", Version: "1.0.0"}
+	_, err = c.Initialize(t.Context(), initRequest)
+	require.NoError(t, err)
+	return c
+}
+
+// recordingMiddleware returns tool, resource, and prompt middlewares that
+// append name to trace when they see a request.
+func recordingMiddleware(mu *sync.Mutex, trace *[]string, name string) (ToolMiddleware, ResourceMiddleware, PromptMiddleware) {
+	record := func(target string) {
+		mu.Lock()
+		defer mu.Unlock()
+		*trace = append(*trace, name+":"+target)
+	}
+	return func(next ToolHandler) ToolHandler {
+			return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				record(request.Params.Name)
+				return next(ctx, request)
+			}
+		}, func(next ResourceHandler) ResourceHandler {
+			return func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				record(request.Params.URI)
+				return next(ctx, request)
+			}
+		}, func(next PromptHandler) PromptHandler {
+			return func(ctx context.Context, request mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+				record(request.Params.Name)
+				return next(ctx, request)
+			}
+		}
+}
+
+func TestServerBuilder_WithMiddleware(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		trace []string
+	)
+	outerTool, outerResource, outerPrompt := recordingMiddleware(&mu, &trace, "outer")
+	innerTool, innerResource, innerPrompt := recordingMiddleware(&mu, &trace, "inner")
+
+	var panics bytes.Buffer
+	l := logger.NewMCPLogger(&panics, false)
+
+	config := &Config{}
+	config.Defaults.WarnDays = 42
+	builder := NewServerBuilder().
+		WithConfig(config).
+		WithVersion("1.0.0").
+		WithEmbed(templates.MagicEmbed).
+		WithTools(ToolDefinition{
+			Tool: mcp.NewTool("plain"),
+			Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return mcp.NewToolResultText("plain"), nil
+			},
+		}).
+		WithToolsWithConfig(ToolDefinitionWithConfig{
+			Tool: mcp.NewTool("configured"),
+			Handler: func(ctx context.Context, request mcp.CallToolRequest, config *Config) (*mcp.CallToolResult, error) {
+				if config.Defaults.WarnDays != 42 {
+					panic("config not bound")
+				}
+				panic("boom")
+			},
+		}).
+		WithResources(ServerResource{
+			Resource: mcp.NewResource("test://plain", "plain"),
+			Handler: func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+				return []mcp.ResourceContents{mcp.TextResourceContents{URI: request.Params.URI, Text: "plain"}}, nil
+			},
+		}).
+		WithEmbeddedResources(ServerResourceWithEmbed{
+			Resource: mcp.NewResource("test://embed", "embed"),
+			Handler: func(ctx context.Context, request mcp.ReadResourceRequest, embed templates.EmbedFS) ([]mcp.ResourceContents, error) {
+				panic("boom")
+			},
+		}).
+		WithEmbeddedPrompts(ServerPromptWithEmbed{
+			Prompt: mcp.NewPrompt("embedded"),
+			Handler: func(ctx context.Context, request mcp.GetPromptRequest, embed templates.EmbedFS) (*mcp.GetPromptResult, error) {
+				panic("boom")
+			},
+		}).
+		WithMiddleware(RecoverToolPanics(l), outerTool).
+		WithMiddleware(innerTool).
+		WithResourceMiddleware(RecoverResourcePanics(l), outerResource, innerResource).
+		WithPromptMiddleware(RecoverPromptPanics(l), outerPrompt, innerPrompt)
+	c := startInProcessClient(t, builder)
+	ctx := t.Context()
+
+	call := mcp.CallToolRequest{}
+	call.Params.Name = "plain"
+	_, err := c.CallTool(ctx, call)
+	require.NoError(t, err)
+
+	// ToolsWithConfig are wrapped after the config is bound
+	call.Params.Name = "configured"
+	_, err = c.CallTool(ctx, call)
+	assert.ErrorContains(t, err, "tool configured failed: internal error")
+	assert.NotContains(t, err.Error(), "boom")
+
+	read := mcp.ReadResourceRequest{}
+	read.Params.URI = "test://plain"
+	_, err = c.ReadResource(ctx, read)
+	require.NoError(t, err)
+	read.Params.URI = "test://embed"
+	_, err = c.ReadResource(ctx, read)
+	assert.ErrorContains(t, err, "resource test://embed failed: internal error")
+
+	get := mcp.GetPromptRequest{}
+	get.Params.Name = "embedded"
+	_, err = c.GetPrompt(ctx, get)
+	assert.ErrorContains(t, err, "prompt embedded failed: internal error")
+
+	// The panic value and stack stay in the server log
+	for _, want := range []string{"tool configured panicked: boom", "resource test://embed panicked: boom", "prompt embedded panicked: boom", "goroutine"} {
+		assert.Contains(t, panics.String(), want)
+	}
+
+	assert.Equal(t, []string{
+		"outer:plain", "inner:plain",
+		"outer:configured", "inner:configured",
+		"outer:test://plain", "inner:test://plain",
+		"outer:test://embed", "inner:test://embed",
+		"outer:embedded", "inner:embedded",
+	}, trace)
+}
+
+func TestLogToolCalls(t *testing.T) {
+	var buf bytes.Buffer
+	handler := LogToolCalls(logger.NewMCPLogger(&buf, false))(func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if request.Params.Name == "failing" {
+			return mcp.NewToolResultError("bad input"), nil
+		}
+		return mcp.NewToolResultText("ok"), nil
+	})
+
+	call := mcp.CallToolRequest{}
+	call.Params.Name = "working"
+	_, err := handler(t.Context(), call)
+	require.NoError(t, err)
+	call.Params.Name = "failing"
+	_, err = handler(t.Context(), call)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "tool working completed in")
+	assert.Contains(t, lines[1], "tool failing returned an error result after")
+}
+
+func TestLimitToolInput(t *testing.T) {
+	next := func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return mcp.NewToolResultText("ok"), nil
+	}
+
+	call := mcp.CallToolRequest{}
+	call.Params.Name = ToolResolveCertChain
+	call.Params.Arguments = map[string]any{"certificate": strings.Repeat("A", 64)}
+
+	_, err := LimitToolInput(128)(next)(t.Context(), call)
+	assert.NoError(t, err)
+
+	_, err = LimitToolInput(32)(next)(t.Context(), call)
+	assert.ErrorIs(t, err, ErrInputTooLarge)
+	assert.ErrorContains(t, err, "limit is 32")
+
+	// A non-positive limit disables the check
+	_, err = LimitToolInput(0)(next)(t.Context(), call)
+	assert.NoError(t, err)
+}
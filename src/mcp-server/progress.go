@@ -0,0 +1,187 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// methodNotificationProgress reports progress of a request that carried a progress token.
+	methodNotificationProgress = "notifications/progress"
+	// methodNotificationCancelled asks the server to abandon an in-flight request.
+	methodNotificationCancelled = "notifications/cancelled"
+	// requestIDMetaKey is the _meta field under which the JSON-RPC ID of a tool
+	// call is passed from the before-call hook to the tool middleware.
+	requestIDMetaKey = "tls-cert-chain-resolver/requestId"
+)
+
+// progressReporter sends MCP progress notifications for a single tool call.
+//
+// Thread Safety: Safe for concurrent use; notifications are serialized so
+// that the progress value increases with every notification.
+type progressReporter struct {
+	// ctx: Context of the tool call, carrying the calling session
+	ctx context.Context
+	// server: Server used to deliver notifications to the calling session
+	server *server.MCPServer
+	// token: Progress token supplied by the client in the request's _meta
+	token mcp.ProgressToken
+	// mu: Serializes notifications and protects progress
+	mu sync.Mutex
+	// progress: Number of steps reported so far
+	progress float64
+}
+
+// progressReporterKey is the context key under which the progressReporter is stored.
+type progressReporterKey struct{}
+
+// progressFromContext returns the progress reporter of a tool call.
+//
+// Parameters:
+//   - ctx: Context of the tool call
+//
+// Returns:
+//   - *progressReporter: Reporter, or nil if the client did not ask for progress
+func progressFromContext(ctx context.Context) *progressReporter {
+	p, _ := ctx.Value(progressReporterKey{}).(*progressReporter)
+	return p
+}
+
+// report sends a progress notification for one more completed step.
+//
+// Delivery is best effort: a notification that cannot be delivered, for
+// example because the session has gone away, is dropped.
+//
+// Parameters:
+//   - total: Total number of steps, or 0 when unknown
+//   - message: Human-readable description of the step
+func (p *progressReporter) report(total int, message string) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.progress++
+	params := map[string]any{
+		"progressToken": p.token,
+		"progress":      p.progress,
+		"message":       message,
+	}
+	if total > 0 {
+		params["total"] = float64(total)
+	}
+	_ = p.server.SendNotificationToClient(p.ctx, methodNotificationProgress, params)
+}
+
+// trackedCall identifies an in-flight tool call within the server.
+type trackedCall struct {
+	// session: ID of the client session that made the call
+	session string
+	// id: JSON-RPC request ID of the call, formatted with its type so that 1 and "1" differ
+	id string
+}
+
+// callTracker cancels in-flight tool calls named by notifications/cancelled.
+//
+// Thread Safety: Safe for concurrent use.
+type callTracker struct {
+	// mu: Protects calls
+	mu sync.Mutex
+	// calls: Cancel functions of the in-flight tool calls
+	calls map[trackedCall]context.CancelFunc
+}
+
+// newCallTracker creates an empty call tracker.
+func newCallTracker() *callTracker {
+	return &callTracker{calls: make(map[trackedCall]context.CancelFunc)}
+}
+
+// trackedCallFor builds the key of a request ID in the session of ctx.
+func trackedCallFor(ctx context.Context, id any) trackedCall {
+	var session string
+	if s := server.ClientSessionFromContext(ctx); s != nil {
+		session = s.SessionID()
+	}
+	return trackedCall{session: session, id: fmt.Sprintf("%T:%v", id, id)}
+}
+
+// stampRequestID records the JSON-RPC ID of a tool call in its _meta so that
+// [callTracker.middleware] can register the call under it.
+//
+// It is installed as a before-call-tool hook, the only place where mcp-go
+// exposes the request ID.
+func stampRequestID(ctx context.Context, id any, request *mcp.CallToolRequest) {
+	if id == nil {
+		return
+	}
+	if request.Params.Meta == nil {
+		request.Params.Meta = &mcp.Meta{}
+	}
+	if request.Params.Meta.AdditionalFields == nil {
+		request.Params.Meta.AdditionalFields = make(map[string]any)
+	}
+	request.Params.Meta.AdditionalFields[requestIDMetaKey] = id
+}
+
+// middleware returns tool middleware that makes each call cancellable by its
+// request ID and, when the client sent a progress token, reports progress of
+// the network steps taken by the x509chain package.
+func (t *callTracker) middleware() server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			if meta := request.Params.Meta; meta != nil {
+				if id, ok := meta.AdditionalFields[requestIDMetaKey]; ok {
+					key := trackedCallFor(ctx, id)
+					t.mu.Lock()
+					t.calls[key] = cancel
+					t.mu.Unlock()
+					defer func() {
+						t.mu.Lock()
+						delete(t.calls, key)
+						t.mu.Unlock()
+					}()
+				}
+
+				if srv := server.ServerFromContext(ctx); meta.ProgressToken != nil && srv != nil {
+					p := &progressReporter{ctx: ctx, server: srv, token: meta.ProgressToken}
+					ctx = context.WithValue(ctx, progressReporterKey{}, p)
+					ctx = x509chain.WithProgress(ctx, func(message string) { p.report(0, message) })
+				}
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// handleCancelled cancels the tool call named by a notifications/cancelled
+// notification, aborting its outstanding network I/O.
+//
+// Notifications for unknown or already completed requests are ignored, as
+// the MCP specification requires.
+func (t *callTracker) handleCancelled(ctx context.Context, notification mcp.JSONRPCNotification) {
+	id, ok := notification.Params.AdditionalFields["requestId"]
+	if !ok || id == nil {
+		return
+	}
+	key := trackedCallFor(ctx, id)
+	t.mu.Lock()
+	cancel, ok := t.calls[key]
+	t.mu.Unlock()
+	if ok {
+		cancel()
+	}
+}
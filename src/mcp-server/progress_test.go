@@ -0,0 +1,138 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSession is an initialized client session that buffers the notifications sent to it.
+type testSession struct {
+	id            string
+	notifications chan mcp.JSONRPCNotification
+}
+
+func newTestSession(id string) *testSession {
+	return &testSession{id: id, notifications: make(chan mcp.JSONRPCNotification, 16)}
+}
+
+func (s *testSession) Initialize()                                         {}
+func (s *testSession) Initialized() bool                                   { return true }
+func (s *testSession) NotificationChannel() chan<- mcp.JSONRPCNotification { return s.notifications }
+func (s *testSession) SessionID() string                                   { return s.id }
+
+// drain returns the notifications received so far.
+func (s *testSession) drain() []mcp.JSONRPCNotification {
+	var received []mcp.JSONRPCNotification
+	for {
+		select {
+		case n := <-s.notifications:
+			received = append(received, n)
+		default:
+			return received
+		}
+	}
+}
+
+// sendMessage delivers a raw JSON-RPC message to s as if it came from session.
+func sendMessage(ctx context.Context, s *server.MCPServer, session server.ClientSession, message string) mcp.JSONRPCMessage {
+	return s.HandleMessage(s.WithContext(ctx, session), json.RawMessage(message))
+}
+
+func TestBatchProgressNotifications(t *testing.T) {
+	config := &Config{}
+	config.Defaults.BatchConcurrency = 2
+	_, toolsWithConfig := createTools()
+	s, err := NewServerBuilder().WithConfig(config).WithVersion("1.0.0").WithToolsWithConfig(toolsWithConfig...).Build()
+	require.NoError(t, err)
+
+	certs := base64.StdEncoding.EncodeToString([]byte(newSelfSignedCA(t, "Progress CA 1"))) + "," +
+		base64.StdEncoding.EncodeToString([]byte(newSelfSignedCA(t, "Progress CA 2")))
+	session := newTestSession("progress")
+
+	// Without a progress token no notifications are sent
+	sendMessage(t.Context(), s, session, fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":{"certificates":%q}}}`,
+		ToolBatchResolveCertChain, certs))
+	assert.Empty(t, session.drain())
+
+	sendMessage(t.Context(), s, session, fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"tools/call","params":{"name":%q,"arguments":{"certificates":%q},"_meta":{"progressToken":"batch"}}}`,
+		ToolBatchResolveCertChain, certs))
+	received := session.drain()
+	require.Len(t, received, 2)
+	for i, n := range received {
+		assert.Equal(t, methodNotificationProgress, n.Method)
+		assert.Equal(t, "batch", n.Params.AdditionalFields["progressToken"])
+		assert.Equal(t, float64(i+1), n.Params.AdditionalFields["progress"])
+		assert.Equal(t, float64(2), n.Params.AdditionalFields["total"])
+		assert.Regexp(t, `^processed certificate [12] of 2$`, n.Params.AdditionalFields["message"])
+	}
+}
+
+func TestToolCallCancellation(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	started := make(chan struct{})
+	s, err := NewServerBuilder().WithVersion("1.0.0").WithTools(ToolDefinition{
+		Tool: mcp.NewTool("slow"),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			// Network steps of the x509chain package are reported as progress
+			x509chain.FetchRemoteChain(ctx, "127.0.0.1", port, time.Second, "1.0.0")
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}).Build()
+	require.NoError(t, err)
+
+	session := newTestSession("caller")
+	done := make(chan mcp.JSONRPCMessage, 1)
+	go func() {
+		done <- sendMessage(context.Background(), s, session,
+			`{"jsonrpc":"2.0","id":7,"method":"tools/call","params":{"name":"slow","_meta":{"progressToken":7}}}`)
+	}()
+	<-started
+
+	received := session.drain()
+	require.Len(t, received, 1)
+	assert.Equal(t, float64(7), received[0].Params.AdditionalFields["progressToken"])
+	assert.Equal(t, "connecting to 127.0.0.1:"+strconv.Itoa(port), received[0].Params.AdditionalFields["message"])
+	assert.NotContains(t, received[0].Params.AdditionalFields, "total")
+
+	// Cancellations naming another request, or coming from another session, are ignored
+	sendMessage(t.Context(), s, session, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":"7"}}`)
+	sendMessage(t.Context(), s, newTestSession("other"), `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7}}`)
+	select {
+	case <-done:
+		t.Fatal("tool call cancelled by an unrelated notification")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	sendMessage(t.Context(), s, session, `{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":7,"reason":"user aborted"}}`)
+	select {
+	case response := <-done:
+		rpcErr, ok := response.(mcp.JSONRPCError)
+		require.True(t, ok, "expected error response, got %T", response)
+		assert.Contains(t, rpcErr.Error.Message, context.Canceled.Error())
+	case <-time.After(5 * time.Second):
+		t.Fatal("tool call not cancelled")
+	}
+}
@@ -36,6 +36,7 @@ import (
 //   - Comprehensive descriptions for user interface display
 //   - Metadata tags for categorization and discovery
 //   - Proper handler function bindings for prompt execution
+//   - Completion sources or fixed values for arguments that support completion/complete
 func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 	// Prompts that don't need embed access
 	prompts := []ServerPrompt{}
@@ -57,6 +58,9 @@ func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 				return prompt
 			}(),
 			Handler: handleCertificateAnalysisPrompt,
+			Completions: map[string]ArgumentCompletion{
+				"certificate_path": {Source: CompletionSourcePath},
+			},
 		},
 		{
 			Prompt: func() mcp.Prompt {
@@ -77,6 +81,10 @@ func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 				return prompt
 			}(),
 			Handler: handleExpiryMonitoringPrompt,
+			Completions: map[string]ArgumentCompletion{
+				"certificate_path": {Source: CompletionSourcePath},
+				"alert_days":       {Values: []string{"7", "14", "30", "60", "90"}},
+			},
 		},
 		{
 			Prompt: func() mcp.Prompt {
@@ -97,6 +105,10 @@ func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 				return prompt
 			}(),
 			Handler: handleSecurityAuditPrompt,
+			Completions: map[string]ArgumentCompletion{
+				"hostname": {Source: CompletionSourceHostname},
+				"port":     {Values: []string{"443", "8443", "465", "636", "993", "995"}},
+			},
 		},
 		{
 			Prompt: func() mcp.Prompt {
@@ -121,6 +133,11 @@ func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 				return prompt
 			}(),
 			Handler: handleTroubleshootingPrompt,
+			Completions: map[string]ArgumentCompletion{
+				"issue_type":       {Values: []string{"chain", "validation", "expiry", "connection"}},
+				"certificate_path": {Source: CompletionSourcePath},
+				"hostname":         {Source: CompletionSourceHostname},
+			},
 		},
 		{
 			Prompt: func() mcp.Prompt {
@@ -140,6 +157,10 @@ func createPrompts() ([]ServerPrompt, []ServerPromptWithEmbed) {
 				return prompt
 			}(),
 			Handler: handleResourceMonitoringPrompt,
+			Completions: map[string]ArgumentCompletion{
+				"monitoring_context": {Values: []string{"debugging", "optimization", "routine", "troubleshooting"}},
+				"format_preference":  {Values: []string{"json", "markdown"}},
+			},
 		},
 	}
 
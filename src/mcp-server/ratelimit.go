@@ -0,0 +1,418 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// ToolLimitDefault is the key in [LimitsConfig.Tools] whose limit applies to
+// every tool without its own entry. Each tool still gets its own bucket.
+const ToolLimitDefault = "*"
+
+// maxTrackedHosts bounds the per-host bucket map; idle buckets are dropped
+// beyond it, and the fullest bucket when none is idle.
+const maxTrackedHosts = 1024
+
+// ErrRateLimited indicates a tool call or outbound request refused by a rate limit or quota.
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+// activeLimiter holds the limiter of the most recently built server for
+// status://server-status; nil when no limits are configured.
+var activeLimiter atomic.Pointer[toolLimiter]
+
+// LimitsConfig configures rate limits and concurrency quotas for tool calls
+// and the outbound requests they make.
+//
+// Limits apply to every transport. A zero LimitsConfig imposes no limits.
+type LimitsConfig struct {
+	// MaxInFlight: Maximum tool calls executing at once across all tools; 0 is unlimited
+	MaxInFlight int `json:"maxInFlight,omitempty" yaml:"maxInFlight,omitempty"`
+	// Tools: Limits per tool name; the "*" entry applies to tools without their own entry
+	Tools map[string]RateLimit `json:"tools,omitempty" yaml:"tools,omitempty"`
+	// Hosts: Limit applied to each destination host of remote fetches and AIA, OCSP, and CRL downloads
+	Hosts RateLimit `json:"hosts,omitzero" yaml:"hosts,omitempty"`
+}
+
+// RateLimit is a token bucket with an optional concurrency quota.
+type RateLimit struct {
+	// Rate: Sustained requests per second; 0 disables the token bucket
+	Rate float64 `json:"ratePerSecond,omitempty" yaml:"ratePerSecond,omitempty"`
+	// Burst: Requests allowed back to back before Rate applies; defaults to Rate rounded up, at least 1
+	Burst int `json:"burst,omitempty" yaml:"burst,omitempty"`
+	// MaxConcurrent: Calls of one tool executing at once; 0 is unlimited (not used for hosts)
+	MaxConcurrent int `json:"maxConcurrent,omitempty" yaml:"maxConcurrent,omitempty"`
+}
+
+// burst returns the configured burst or its default.
+func (r RateLimit) burst() float64 {
+	if r.Burst > 0 {
+		return float64(r.Burst)
+	}
+	return max(1, math.Ceil(r.Rate))
+}
+
+// tokenBucket is a token bucket refilled continuously at rate tokens per second.
+// It is not safe for concurrent use; toolLimiter serializes access.
+type tokenBucket struct {
+	// rate: Tokens added per second
+	rate float64
+	// burst: Bucket capacity
+	burst float64
+	// tokens: Tokens available at last; negative while reservations are outstanding
+	tokens float64
+	// last: Time tokens was last updated
+	last time.Time
+}
+
+// newTokenBucket returns a full bucket for limit, or nil when limit has no rate.
+func newTokenBucket(limit RateLimit, now time.Time) *tokenBucket {
+	if limit.Rate <= 0 {
+		return nil
+	}
+	return &tokenBucket{rate: limit.Rate, burst: limit.burst(), tokens: limit.burst(), last: now}
+}
+
+// refill adds the tokens accrued since the last update.
+func (b *tokenBucket) refill(now time.Time) {
+	if elapsed := now.Sub(b.last).Seconds(); elapsed > 0 {
+		b.tokens = min(b.burst, b.tokens+elapsed*b.rate)
+		b.last = now
+	}
+}
+
+// allow takes a token if one is available.
+//
+// Returns:
+//   - bool: Whether a token was taken
+//   - time.Duration: Time until the next token when none was available
+func (b *tokenBucket) allow(now time.Time) (bool, time.Duration) {
+	b.refill(now)
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// reserve takes a token, going into debt if necessary, and returns how long
+// the caller must wait before using it.
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// toolQuota tracks the limit and usage of a single tool.
+type toolQuota struct {
+	// limit: Limit applied to the tool
+	limit RateLimit
+	// bucket: Token bucket, nil when the limit has no rate
+	bucket *tokenBucket
+	// inFlight: Calls currently executing
+	inFlight int
+	// calls: Calls admitted
+	calls uint64
+	// rejected: Calls refused by the rate limit or concurrency quota
+	rejected uint64
+}
+
+// toolLimiter enforces a [LimitsConfig] on tool calls and, as an
+// x509chain.HostLimiter, on outbound requests per destination host.
+type toolLimiter struct {
+	// config: Limits being enforced
+	config LimitsConfig
+	// mu: Guards every field below
+	mu sync.Mutex
+	// inFlight: Tool calls currently executing across all tools
+	inFlight int
+	// rejected: Tool calls refused by MaxInFlight
+	rejected uint64
+	// tools: Quotas of limited tools, created on first call
+	tools map[string]*toolQuota
+	// hosts: Token buckets per destination host
+	hosts map[string]*tokenBucket
+	// hostWaits: Outbound requests delayed by a host bucket
+	hostWaits uint64
+	// now: Clock, replaceable in tests
+	now func() time.Time
+}
+
+// newToolLimiter creates a limiter for config.
+//
+// Parameters:
+//   - config: Limits to enforce
+//
+// Returns:
+//   - *toolLimiter: Limiter, or nil when config imposes no limits
+//   - error: Negative limits
+func newToolLimiter(config LimitsConfig) (*toolLimiter, error) {
+	if config.MaxInFlight < 0 {
+		return nil, fmt.Errorf("invalid limits.maxInFlight %d, must not be negative", config.MaxInFlight)
+	}
+	for name, limit := range config.Tools {
+		if limit.Rate < 0 || limit.Burst < 0 || limit.MaxConcurrent < 0 {
+			return nil, fmt.Errorf("invalid limits for tool %q, values must not be negative", name)
+		}
+	}
+	if config.Hosts.Rate < 0 || config.Hosts.Burst < 0 {
+		return nil, errors.New("invalid limits.hosts, values must not be negative")
+	}
+	if config.MaxInFlight == 0 && len(config.Tools) == 0 && config.Hosts.Rate == 0 {
+		return nil, nil
+	}
+	return &toolLimiter{
+		config: config,
+		tools:  make(map[string]*toolQuota),
+		hosts:  make(map[string]*tokenBucket),
+		now:    time.Now,
+	}, nil
+}
+
+// quota returns the quota of tool, or nil when the tool is unlimited.
+// The caller must hold l.mu.
+func (l *toolLimiter) quota(tool string) *toolQuota {
+	if q, ok := l.tools[tool]; ok {
+		return q
+	}
+	limit, ok := l.config.Tools[tool]
+	if !ok {
+		limit, ok = l.config.Tools[ToolLimitDefault]
+	}
+	var q *toolQuota
+	if ok {
+		q = &toolQuota{limit: limit, bucket: newTokenBucket(limit, l.now())}
+	}
+	l.tools[tool] = q
+	return q
+}
+
+// acquire admits a call to tool or reports which limit refused it.
+//
+// Returns:
+//   - func(): Releases the in-flight slots taken by the call
+//   - error: [ErrRateLimited] wrapped with the limit that refused the call
+func (l *toolLimiter) acquire(tool string) (func(), error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.config.MaxInFlight > 0 && l.inFlight >= l.config.MaxInFlight {
+		l.rejected++
+		return nil, fmt.Errorf("%w: %d tool calls already in flight, retry later", ErrRateLimited, l.inFlight)
+	}
+	q := l.quota(tool)
+	if q != nil {
+		if q.limit.MaxConcurrent > 0 && q.inFlight >= q.limit.MaxConcurrent {
+			q.rejected++
+			return nil, fmt.Errorf("%w: %d calls of %s already in flight, retry later", ErrRateLimited, q.inFlight, tool)
+		}
+		if q.bucket != nil {
+			if ok, retry := q.bucket.allow(l.now()); !ok {
+				q.rejected++
+				return nil, fmt.Errorf("%w: %s allows %g calls per second, retry in %s", ErrRateLimited, tool, q.limit.Rate, retry.Round(time.Millisecond))
+			}
+		}
+		q.inFlight++
+		q.calls++
+	}
+	l.inFlight++
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.inFlight--
+		if q != nil {
+			q.inFlight--
+		}
+	}, nil
+}
+
+// Wait blocks until the per-host bucket admits a request to host.
+//
+// Parameters:
+//   - ctx: Context bounding the wait
+//   - host: Destination hostname or IP address
+//
+// Returns:
+//   - error: [ErrRateLimited] wrapped with ctx.Err() when ctx ends before the request is admitted
+//
+// Thread Safety: Safe for concurrent use.
+func (l *toolLimiter) Wait(ctx context.Context, host string) error {
+	if l.config.Hosts.Rate <= 0 {
+		return nil
+	}
+
+	l.mu.Lock()
+	now := l.now()
+	b, ok := l.hosts[host]
+	if !ok {
+		if len(l.hosts) >= maxTrackedHosts {
+			// A refilled bucket behaves like a new one, so dropping it loses nothing
+			maps.DeleteFunc(l.hosts, func(_ string, b *tokenBucket) bool {
+				b.refill(now)
+				return b.tokens >= b.burst
+			})
+		}
+		if len(l.hosts) >= maxTrackedHosts {
+			// Every bucket is in use; forget the one closest to refilled
+			fullest := ""
+			for h, b := range l.hosts {
+				if fullest == "" || b.tokens > l.hosts[fullest].tokens {
+					fullest = h
+				}
+			}
+			delete(l.hosts, fullest)
+		}
+		b = newTokenBucket(l.config.Hosts, now)
+		l.hosts[host] = b
+	}
+	delay := b.reserve(now)
+	if delay > 0 {
+		l.hostWaits++
+	}
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		// Return the unused reservation
+		l.mu.Lock()
+		b.tokens = min(b.burst, b.tokens+1)
+		l.mu.Unlock()
+		return fmt.Errorf("%w: requests to %s are limited to %g per second: %w", ErrRateLimited, host, l.config.Hosts.Rate, ctx.Err())
+	}
+}
+
+// limitStatus is the limits section of status://server-status.
+type limitStatus struct {
+	// MaxInFlight: Configured global in-flight limit; 0 is unlimited
+	MaxInFlight int `json:"maxInFlight"`
+	// InFlight: Tool calls currently executing
+	InFlight int `json:"inFlight"`
+	// Rejected: Tool calls refused by MaxInFlight
+	Rejected uint64 `json:"rejected"`
+	// Tools: Status of every limited tool called so far
+	Tools map[string]toolLimitStatus `json:"tools,omitempty"`
+	// Hosts: Status of the per-host limit, when configured
+	Hosts *hostLimitStatus `json:"hosts,omitempty"`
+}
+
+// toolLimitStatus is the status of a single tool quota.
+type toolLimitStatus struct {
+	// RatePerSecond: Configured rate; 0 when only concurrency is limited
+	RatePerSecond float64 `json:"ratePerSecond,omitempty"`
+	// Burst: Bucket capacity
+	Burst int `json:"burst,omitempty"`
+	// TokensAvailable: Calls that may start immediately under the rate limit
+	TokensAvailable float64 `json:"tokensAvailable,omitempty"`
+	// MaxConcurrent: Configured concurrency quota; 0 is unlimited
+	MaxConcurrent int `json:"maxConcurrent,omitempty"`
+	// InFlight: Calls currently executing
+	InFlight int `json:"inFlight"`
+	// Calls: Calls admitted
+	Calls uint64 `json:"calls"`
+	// Rejected: Calls refused
+	Rejected uint64 `json:"rejected"`
+}
+
+// hostLimitStatus is the status of the per-host limit.
+type hostLimitStatus struct {
+	// RatePerSecond: Configured rate per host
+	RatePerSecond float64 `json:"ratePerSecond"`
+	// Burst: Bucket capacity per host
+	Burst int `json:"burst"`
+	// TrackedHosts: Hosts with a bucket
+	TrackedHosts int `json:"trackedHosts"`
+	// Throttled: Hosts whose bucket is currently empty
+	Throttled []string `json:"throttled,omitempty"`
+	// Waits: Requests delayed by a host bucket
+	Waits uint64 `json:"waits"`
+}
+
+// status returns a snapshot of the limits and their usage.
+func (l *toolLimiter) status() limitStatus {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	st := limitStatus{
+		MaxInFlight: l.config.MaxInFlight,
+		InFlight:    l.inFlight,
+		Rejected:    l.rejected,
+		Tools:       make(map[string]toolLimitStatus),
+	}
+	for name, q := range l.tools {
+		if q == nil {
+			continue
+		}
+		ts := toolLimitStatus{
+			MaxConcurrent: q.limit.MaxConcurrent,
+			InFlight:      q.inFlight,
+			Calls:         q.calls,
+			Rejected:      q.rejected,
+		}
+		if q.bucket != nil {
+			q.bucket.refill(now)
+			ts.RatePerSecond = q.limit.Rate
+			ts.Burst = int(q.bucket.burst)
+			ts.TokensAvailable = math.Floor(max(q.bucket.tokens, 0)*100) / 100
+		}
+		st.Tools[name] = ts
+	}
+	if l.config.Hosts.Rate > 0 {
+		hs := &hostLimitStatus{
+			RatePerSecond: l.config.Hosts.Rate,
+			Burst:         int(l.config.Hosts.burst()),
+			TrackedHosts:  len(l.hosts),
+			Waits:         l.hostWaits,
+		}
+		for host, b := range l.hosts {
+			if b.refill(now); b.tokens < 1 {
+				hs.Throttled = append(hs.Throttled, host)
+			}
+		}
+		slices.Sort(hs.Throttled)
+		st.Hosts = hs
+	}
+	return st
+}
+
+// limitToolCalls returns middleware that admits each tool call through l.
+//
+// Refused calls fail with [ErrRateLimited] before the tool handler runs, so a
+// client in a retry loop cannot reach remote hosts faster than the limits allow.
+func limitToolCalls(l *toolLimiter) server.ToolHandlerMiddleware {
+	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+		return func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			release, err := l.acquire(request.Params.Name)
+			if err != nil {
+				return nil, err
+			}
+			defer release()
+			return next(ctx, request)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenBucket(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 3}, now)
+
+	for range 3 {
+		ok, _ := b.allow(now)
+		assert.True(t, ok)
+	}
+	ok, retry := b.allow(now)
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, retry)
+
+	// Refill never exceeds the burst
+	now = now.Add(time.Minute)
+	assert.Zero(t, b.reserve(now))
+	assert.Zero(t, b.reserve(now))
+	assert.Zero(t, b.reserve(now))
+	assert.Equal(t, 500*time.Millisecond, b.reserve(now))
+	assert.Equal(t, time.Second, b.reserve(now))
+
+	assert.Nil(t, newTokenBucket(RateLimit{MaxConcurrent: 1}, now))
+	assert.Equal(t, float64(1), RateLimit{Rate: 0.1}.burst())
+	assert.Equal(t, float64(3), RateLimit{Rate: 2.5}.burst())
+}
+
+func TestNewToolLimiter(t *testing.T) {
+	limiter, err := newToolLimiter(LimitsConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, limiter)
+
+	_, err = newToolLimiter(LimitsConfig{MaxInFlight: -1})
+	assert.ErrorContains(t, err, "maxInFlight")
+	_, err = newToolLimiter(LimitsConfig{Tools: map[string]RateLimit{ToolFetchRemoteCert: {Rate: -1}}})
+	assert.ErrorContains(t, err, ToolFetchRemoteCert)
+	_, err = newToolLimiter(LimitsConfig{Hosts: RateLimit{Burst: -1}})
+	assert.ErrorContains(t, err, "limits.hosts")
+}
+
+func TestToolLimiter_Acquire(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	limiter, err := newToolLimiter(LimitsConfig{
+		MaxInFlight: 4,
+		Tools: map[string]RateLimit{
+			ToolFetchRemoteCert: {Rate: 1, Burst: 2},
+			ToolLimitDefault:    {MaxConcurrent: 1},
+		},
+	})
+	require.NoError(t, err)
+	limiter.now = func() time.Time { return now }
+
+	// Token bucket
+	release1, err := limiter.acquire(ToolFetchRemoteCert)
+	require.NoError(t, err)
+	release1()
+	release2, err := limiter.acquire(ToolFetchRemoteCert)
+	require.NoError(t, err)
+	_, err = limiter.acquire(ToolFetchRemoteCert)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.ErrorContains(t, err, "retry in 1s")
+	now = now.Add(time.Second)
+	release3, err := limiter.acquire(ToolFetchRemoteCert)
+	require.NoError(t, err)
+
+	// Default concurrency quota, tracked per tool
+	releaseA, err := limiter.acquire(ToolResolveCertChain)
+	require.NoError(t, err)
+	_, err = limiter.acquire(ToolResolveCertChain)
+	assert.ErrorContains(t, err, "1 calls of resolve_cert_chain already in flight")
+
+	// Global in-flight limit
+	releaseB, err := limiter.acquire(ToolInspectCSR)
+	require.NoError(t, err)
+	_, err = limiter.acquire(ToolLintCertificate)
+	assert.ErrorContains(t, err, "4 tool calls already in flight")
+
+	status := limiter.status()
+	assert.Equal(t, 4, status.InFlight)
+	assert.Equal(t, uint64(1), status.Rejected)
+	assert.Equal(t, uint64(3), status.Tools[ToolFetchRemoteCert].Calls)
+	assert.Equal(t, uint64(1), status.Tools[ToolFetchRemoteCert].Rejected)
+	assert.Equal(t, 1, status.Tools[ToolResolveCertChain].InFlight)
+	assert.Nil(t, status.Hosts)
+
+	releaseA()
+	releaseB()
+	release2()
+	release3()
+	release, err := limiter.acquire(ToolResolveCertChain)
+	require.NoError(t, err)
+	release()
+	assert.Zero(t, limiter.status().InFlight)
+}
+
+func TestToolLimiter_Wait(t *testing.T) {
+	limiter, err := newToolLimiter(LimitsConfig{Hosts: RateLimit{Rate: 20, Burst: 1}})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	// Other hosts have their own bucket
+	require.NoError(t, limiter.Wait(ctx, "ocsp.example.com"))
+	// Timed from before the first token, so a slow scheduler cannot shorten the measured wait
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx, "ca.example.com"))
+	require.NoError(t, limiter.Wait(ctx, "ca.example.com"))
+	assert.GreaterOrEqual(t, time.Since(start), 45*time.Millisecond)
+	// Pin the clock to when ocsp.example.com has refilled but ca.example.com has not
+	pinned := start.Add(50 * time.Millisecond)
+	limiter.now = func() time.Time { return pinned }
+
+	// A cancelled wait returns its reservation
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	err = limiter.Wait(cancelled, "ca.example.com")
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	status := limiter.status()
+	require.NotNil(t, status.Hosts)
+	assert.Equal(t, 2, status.Hosts.TrackedHosts)
+	assert.Equal(t, uint64(2), status.Hosts.Waits)
+	assert.Equal(t, []string{"ca.example.com"}, status.Hosts.Throttled)
+
+	t.Run("Tracked Hosts Bounded", func(t *testing.T) {
+		limiter, err := newToolLimiter(LimitsConfig{Hosts: RateLimit{Rate: 1, Burst: 1}})
+		require.NoError(t, err)
+		// A frozen clock keeps every bucket empty, so none is idle
+		now := time.Now()
+		limiter.now = func() time.Time { return now }
+		for i := range maxTrackedHosts + 10 {
+			require.NoError(t, limiter.Wait(ctx, fmt.Sprintf("host-%d.example.com", i)))
+		}
+		assert.Len(t, limiter.hosts, maxTrackedHosts)
+		assert.Contains(t, limiter.hosts, fmt.Sprintf("host-%d.example.com", maxTrackedHosts+9))
+	})
+}
+
+func TestBuildResetsLimits(t *testing.T) {
+	t.Cleanup(func() {
+		activeLimiter.Store(nil)
+		x509chain.SetHostLimiter(nil)
+	})
+
+	limited := &Config{}
+	limited.Limits = LimitsConfig{Hosts: RateLimit{Rate: 0.001, Burst: 1}}
+	_, err := NewServerBuilder().WithConfig(limited).WithVersion("1.0.0").Build()
+	require.NoError(t, err)
+	limiter := activeLimiter.Load()
+	require.NotNil(t, limiter)
+	// Drain the bucket, so a still-installed host limiter would hold the next request
+	require.NoError(t, limiter.Wait(t.Context(), "127.0.0.1"))
+
+	_, err = NewServerBuilder().WithConfig(&Config{}).WithVersion("1.0.0").Build()
+	require.NoError(t, err)
+	assert.Nil(t, activeLimiter.Load())
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+	// Port 1 is closed, so only a dial error is expected once the limiter is gone
+	_, _, err = x509chain.FetchRemoteChain(ctx, "127.0.0.1", 1, time.Second, "1.0.0")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ErrRateLimited)
+}
+
+func TestLimitToolCalls(t *testing.T) {
+	t.Cleanup(func() {
+		activeLimiter.Store(nil)
+		x509chain.SetHostLimiter(nil)
+	})
+
+	tools, toolsWithConfig := createTools()
+	resources, _ := createResources()
+	config := &Config{}
+	config.Limits = LimitsConfig{
+		Tools: map[string]RateLimit{ToolGetResourceUsage: {Rate: 0.001, Burst: 1}},
+		Hosts: RateLimit{Rate: 100},
+	}
+	mcpServer, err := NewServerBuilder().
+		WithConfig(config).
+		WithVersion("1.0.0").
+		WithTools(tools...).
+		WithToolsWithConfig(toolsWithConfig...).
+		WithResources(resources...).
+		Build()
+	require.NoError(t, err)
+
+	c, err := client.NewInProcessClient(mcpServer)
+	require.NoError(t, err)
+	defer c.Close()
+	ctx := t.Context()
+	require.NoError(t, c.Start(ctx))
+	initRequest := mcp.InitializeRequest{}
+	initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+	initRequest.Params.ClientInfo = mcp.Implementation{Name: "ratelimit-test", Version: "1.0.0"}
+	_, err = c.Initialize(ctx, initRequest)
+	require.NoError(t, err)
+
+	call := mcp.CallToolRequest{}
+	call.Params.Name = ToolGetResourceUsage
+	_, err = c.CallTool(ctx, call)
+	require.NoError(t, err)
+	_, err = c.CallTool(ctx, call)
+	assert.ErrorContains(t, err, "rate limit exceeded")
+
+	// Concurrent calls to unlimited tools are unaffected
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Go(func() {
+			call := mcp.CallToolRequest{}
+			call.Params.Name = ToolInspectCSR
+			call.Params.Arguments = map[string]any{"csr": "invalid"}
+			_, err := c.CallTool(ctx, call)
+			assert.NoError(t, err)
+		})
+	}
+	wg.Wait()
+
+	read := mcp.ReadResourceRequest{}
+	read.Params.URI = "status://server-status"
+	result, err := c.ReadResource(ctx, read)
+	require.NoError(t, err)
+	require.Len(t, result.Contents, 1)
+	text, ok := result.Contents[0].(mcp.TextResourceContents)
+	require.True(t, ok)
+
+	var status struct {
+		Limits limitStatus `json:"limits"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text.Text), &status))
+	usage := status.Limits.Tools[ToolGetResourceUsage]
+	assert.Equal(t, uint64(1), usage.Calls)
+	assert.Equal(t, uint64(1), usage.Rejected)
+	require.NotNil(t, status.Limits.Hosts)
+	assert.Equal(t, float64(100), status.Limits.Hosts.RatePerSecond)
+}
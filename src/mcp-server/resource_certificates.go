@@ -0,0 +1,401 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const (
+	// certResourcePrefix prefixes the URI of a certificate resource, followed by its SHA-256 fingerprint.
+	certResourcePrefix = "cert://sha256/"
+	// chainResourcePrefix prefixes the URI of a chain resource, followed by the SHA-256 fingerprint of its leaf.
+	chainResourcePrefix = "chain://sha256/"
+	// maxCachedChains bounds the chains kept as resources; the least recently resolved is evicted first.
+	maxCachedChains = 256
+	// resourcePageSize is the number of entries returned per page of resources/list and the other list methods.
+	resourcePageSize = 50
+	// methodNotificationResourceUpdated tells a subscribed client that a resource's content changed.
+	methodNotificationResourceUpdated = "notifications/resources/updated"
+)
+
+// certificateResourceRegistry maps each server built by [ServerBuilder.Build]
+// to its certificate resources, for handlers and transports that only have
+// the server at hand.
+var certificateResourceRegistry sync.Map // *server.MCPServer -> *certificateResources
+
+// cachedChain is a chain kept by certificateResources.
+type cachedChain struct {
+	// certs: Certificates of the chain, leaf first
+	certs []*x509.Certificate
+	// fingerprints: SHA-256 fingerprints of certs, in the same order
+	fingerprints []string
+}
+
+// cachedCertificate is a certificate kept by certificateResources.
+type cachedCertificate struct {
+	// cert: The certificate
+	cert *x509.Certificate
+	// refs: Number of cached chains containing the certificate
+	refs int
+}
+
+// certificateResources keeps the chains resolved by tool calls and exposes
+// each chain, and each certificate in it, as an MCP resource.
+//
+// Chains are served under chain://sha256/{leaf fingerprint} and certificates
+// under cert://sha256/{fingerprint}. At most maxCachedChains chains are kept;
+// a certificate resource is removed once no kept chain contains it. Sessions
+// subscribed to a chain resource are notified when the chain resolved for
+// the same leaf changes.
+//
+// Thread Safety: Safe for concurrent use.
+type certificateResources struct {
+	// server: Server the resources are registered with
+	server *server.MCPServer
+	// middlewares: Resource middlewares applied to the handlers of registered resources
+	middlewares []ResourceMiddleware
+	// mu: Protects the fields below and orders resource registration with the cache
+	mu sync.Mutex
+	// chains: Kept chains by leaf fingerprint
+	chains map[string]*cachedChain
+	// order: Leaf fingerprints of kept chains, least recently resolved first
+	order []string
+	// certs: Certificates of the kept chains by fingerprint
+	certs map[string]*cachedCertificate
+	// subscriptions: Subscribed session IDs by resource URI
+	subscriptions map[string]map[string]struct{}
+	// sessions: IDs of the sessions currently registered with server
+	sessions map[string]struct{}
+}
+
+// newCertificateResources creates empty certificate resources.
+//
+// Parameters:
+//   - middlewares: Resource middlewares applied to every registered resource
+//
+// Returns:
+//   - *certificateResources: Resources to attach to a server before use
+func newCertificateResources(middlewares []ResourceMiddleware) *certificateResources {
+	return &certificateResources{
+		middlewares:   middlewares,
+		chains:        make(map[string]*cachedChain),
+		certs:         make(map[string]*cachedCertificate),
+		subscriptions: make(map[string]map[string]struct{}),
+		sessions:      make(map[string]struct{}),
+	}
+}
+
+// attach binds r to s, so that chains recorded while handling requests of s
+// are registered with s and [certificateResourcesFromContext] finds r.
+func (r *certificateResources) attach(s *server.MCPServer) {
+	r.server = s
+	certificateResourceRegistry.Store(s, r)
+}
+
+// certificateResourcesOf returns the certificate resources of s.
+//
+// Returns:
+//   - *certificateResources: Resources, or nil if s was not built by [ServerBuilder.Build]
+func certificateResourcesOf(s *server.MCPServer) *certificateResources {
+	if s == nil {
+		return nil
+	}
+	r, _ := certificateResourceRegistry.Load(s)
+	res, _ := r.(*certificateResources)
+	return res
+}
+
+// certificateResourcesFromContext returns the certificate resources of the server handling a request.
+//
+// Returns:
+//   - *certificateResources: Resources, or nil when the handler was called outside a server
+func certificateResourcesFromContext(ctx context.Context) *certificateResources {
+	return certificateResourcesOf(server.ServerFromContext(ctx))
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint used in resource URIs:
+// lowercase hex without separators.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// fingerprintSeparators strips the separators of colon-hex fingerprints, such as
+// the sha256Fingerprint values in tool results.
+var fingerprintSeparators = strings.NewReplacer(":", "", " ", "")
+
+// normalizeFingerprint converts a fingerprint from a resource URI, in upper or
+// lower case and with or without colon or space separators, to the form used
+// as cache key.
+//
+// Returns:
+//   - string: Normalized fingerprint
+//   - error: Error if the input is not a SHA-256 fingerprint
+func normalizeFingerprint(fingerprint string) (string, error) {
+	fp := strings.ToLower(fingerprintSeparators.Replace(fingerprint))
+	if decoded, err := hex.DecodeString(fp); err != nil || len(decoded) != sha256.Size {
+		return "", fmt.Errorf("invalid SHA-256 fingerprint %q", fingerprint)
+	}
+	return fp, nil
+}
+
+// recordChain keeps a resolved chain and registers resources for it and for
+// each of its certificates.
+//
+// Recording a chain for a leaf already kept replaces the previous chain; if
+// the certificates differ, subscribers of the chain resource are notified.
+//
+// Parameters:
+//   - certs: Resolved chain, leaf first; it is copied
+//
+// Thread Safety: Safe for concurrent use; nil receivers are ignored.
+func (r *certificateResources) recordChain(certs []*x509.Certificate) {
+	if r == nil || len(certs) == 0 {
+		return
+	}
+	chain := &cachedChain{certs: slices.Clone(certs), fingerprints: make([]string, len(certs))}
+	for i, cert := range certs {
+		chain.fingerprints[i] = certificateFingerprint(cert)
+	}
+	leaf := chain.fingerprints[0]
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var (
+		added   []server.ServerResource
+		removed []string
+		changed bool
+	)
+	if previous, ok := r.chains[leaf]; ok {
+		r.order = slices.DeleteFunc(r.order, func(fp string) bool { return fp == leaf })
+		if slices.Equal(previous.fingerprints, chain.fingerprints) {
+			// Same chain resolved again; only its recency changes
+			r.order = append(r.order, leaf)
+			return
+		}
+		removed = append(removed, r.release(previous)...)
+		changed = true
+	} else {
+		added = append(added, r.chainResource(leaf, chain))
+	}
+	r.chains[leaf] = chain
+	r.order = append(r.order, leaf)
+
+	for i, fp := range chain.fingerprints {
+		if c, ok := r.certs[fp]; ok {
+			c.refs++
+			continue
+		}
+		r.certs[fp] = &cachedCertificate{cert: chain.certs[i], refs: 1}
+		if j := slices.Index(removed, certResourcePrefix+fp); j >= 0 {
+			// Released by the previous chain and still in use; keep the resource registered
+			removed = slices.Delete(removed, j, j+1)
+			continue
+		}
+		added = append(added, r.certResource(fp, chain.certs[i]))
+	}
+
+	for len(r.order) > maxCachedChains {
+		evicted := r.order[0]
+		r.order = r.order[1:]
+		removed = append(removed, chainResourcePrefix+evicted)
+		removed = append(removed, r.release(r.chains[evicted])...)
+		delete(r.chains, evicted)
+	}
+
+	if len(added) > 0 {
+		r.server.AddResources(added...)
+	}
+	if len(removed) > 0 {
+		r.server.DeleteResources(removed...)
+	}
+	if changed {
+		r.notifyUpdated(chainResourcePrefix + leaf)
+	}
+}
+
+// release drops the references of chain to its certificates.
+//
+// Returns:
+//   - []string: URIs of the certificate resources no longer referenced by any kept chain
+func (r *certificateResources) release(chain *cachedChain) []string {
+	var removed []string
+	for _, fp := range chain.fingerprints {
+		c := r.certs[fp]
+		if c.refs--; c.refs == 0 {
+			delete(r.certs, fp)
+			removed = append(removed, certResourcePrefix+fp)
+		}
+	}
+	return removed
+}
+
+// certResource builds the listed resource of a certificate.
+func (r *certificateResources) certResource(fp string, cert *x509.Certificate) server.ServerResource {
+	return server.ServerResource{
+		Resource: mcp.NewResource(
+			certResourcePrefix+fp,
+			"Certificate: "+certificateDisplayName(cert),
+			mcp.WithResourceDescription(fmt.Sprintf("Certificate %s issued by %s; add ?format=der or ?format=json for other views", cert.Subject, cert.Issuer)),
+			mcp.WithMIMEType(mimeTypePEM),
+		),
+		Handler: chainMiddleware(handleCertificateResource, r.middlewares),
+	}
+}
+
+// chainResource builds the listed resource of a chain.
+func (r *certificateResources) chainResource(leaf string, chain *cachedChain) server.ServerResource {
+	return server.ServerResource{
+		Resource: mcp.NewResource(
+			chainResourcePrefix+leaf,
+			"Certificate Chain: "+certificateDisplayName(chain.certs[0]),
+			mcp.WithResourceDescription(fmt.Sprintf("Certificate chain resolved for %s; add ?format=der or ?format=json for other views", chain.certs[0].Subject)),
+			mcp.WithMIMEType(mimeTypePEM),
+		),
+		Handler: chainMiddleware(handleChainResource, r.middlewares),
+	}
+}
+
+// certificateDisplayName returns the subject common name of cert, or its full subject when it has none.
+func certificateDisplayName(cert *x509.Certificate) string {
+	if cert.Subject.CommonName != "" {
+		return cert.Subject.CommonName
+	}
+	return cert.Subject.String()
+}
+
+// certificate returns a kept certificate by normalized fingerprint.
+func (r *certificateResources) certificate(fp string) (*x509.Certificate, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.certs[fp]
+	if !ok {
+		return nil, false
+	}
+	return c.cert, true
+}
+
+// chain returns a kept chain by normalized leaf fingerprint.
+func (r *certificateResources) chain(fp string) ([]*x509.Certificate, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.chains[fp]
+	if !ok {
+		return nil, false
+	}
+	return c.certs, true
+}
+
+// chainFingerprints returns the fingerprints of the certificates of a kept
+// chain by normalized leaf fingerprint, leaf first.
+func (r *certificateResources) chainFingerprints(fp string) ([]string, bool) {
+	if r == nil {
+		return nil, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	c, ok := r.chains[fp]
+	if !ok {
+		return nil, false
+	}
+	return slices.Clone(c.fingerprints), true
+}
+
+// subscribe records that session wants notifications/resources/updated for uri.
+func (r *certificateResources) subscribe(session, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	sessions, ok := r.subscriptions[uri]
+	if !ok {
+		sessions = make(map[string]struct{})
+		r.subscriptions[uri] = sessions
+	}
+	sessions[session] = struct{}{}
+}
+
+// unsubscribe removes a subscription recorded by subscribe.
+func (r *certificateResources) unsubscribe(session, uri string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.subscriptions[uri], session)
+	if len(r.subscriptions[uri]) == 0 {
+		delete(r.subscriptions, uri)
+	}
+}
+
+// addSession records a session that may subscribe to resources.
+//
+// It is installed as a register-session hook.
+func (r *certificateResources) addSession(ctx context.Context, session server.ClientSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.SessionID()] = struct{}{}
+}
+
+// hasSession reports whether session is currently registered.
+func (r *certificateResources) hasSession(session string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.sessions[session]
+	return ok
+}
+
+// dropSession removes a session that has ended and every subscription of it.
+//
+// It is installed as an unregister-session hook.
+func (r *certificateResources) dropSession(ctx context.Context, session server.ClientSession) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.sessions, session.SessionID())
+	for uri, sessions := range r.subscriptions {
+		delete(sessions, session.SessionID())
+		if len(sessions) == 0 {
+			delete(r.subscriptions, uri)
+		}
+	}
+}
+
+// notifyUpdated sends notifications/resources/updated for uri to its subscribers.
+// The caller must hold r.mu.
+func (r *certificateResources) notifyUpdated(uri string) {
+	for session := range r.subscriptions[uri] {
+		// Delivery is best effort; sessions without an open stream miss the update
+		_ = r.server.SendNotificationToSpecificClient(session, methodNotificationResourceUpdated, map[string]any{"uri": uri})
+	}
+}
+
+// recordResolvedChain keeps a chain resolved by a tool call as resources of
+// the server handling the call, if any, and offers its fingerprints for
+// completion to the session of the call.
+//
+// Parameters:
+//   - ctx: Context of the tool call
+//   - certs: Resolved chain, leaf first
+func recordResolvedChain(ctx context.Context, certs []*x509.Certificate) {
+	if len(certs) == 0 {
+		return
+	}
+	certificateResourcesFromContext(ctx).recordChain(certs)
+	completionsOf(server.ServerFromContext(ctx)).recordChain(ctx, certificateFingerprint(certs[0]))
+}
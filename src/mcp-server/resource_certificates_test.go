@@ -0,0 +1,374 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCertificate parses a fresh self-signed CA certificate.
+func newTestCertificate(t *testing.T, commonName string) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode([]byte(newSelfSignedCA(t, commonName)))
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+// newCertificateResourceServer builds a server serving the generated resource templates.
+func newCertificateResourceServer(t *testing.T) (*server.MCPServer, *certificateResources) {
+	t.Helper()
+	s, err := NewServerBuilder().WithVersion("1.0.0").WithResourceTemplates(createResourceTemplates()...).Build()
+	require.NoError(t, err)
+	r := certificateResourcesOf(s)
+	require.NotNil(t, r)
+	return s, r
+}
+
+// readResource reads uri from s and returns its single content.
+func readResource(t *testing.T, s *server.MCPServer, uri string) mcp.ResourceContents {
+	t.Helper()
+	response := sendMessage(t.Context(), s, newTestSession("reader"),
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":%q}}`, uri))
+	result, ok := response.(mcp.JSONRPCResponse)
+	require.True(t, ok, "expected result response, got %#v", response)
+	read, ok := result.Result.(mcp.ReadResourceResult)
+	require.True(t, ok)
+	require.Len(t, read.Contents, 1)
+	return read.Contents[0]
+}
+
+// notificationsOf returns the notifications with the given method.
+func notificationsOf(received []mcp.JSONRPCNotification, method string) []mcp.JSONRPCNotification {
+	var matching []mcp.JSONRPCNotification
+	for _, n := range received {
+		if n.Method == method {
+			matching = append(matching, n)
+		}
+	}
+	return matching
+}
+
+func TestCertificateResources(t *testing.T) {
+	s, r := newCertificateResourceServer(t)
+	leaf := newTestCertificate(t, "leaf.example.com")
+	ca := newTestCertificate(t, "Resource Test CA")
+	leafFP, caFP := certificateFingerprint(leaf), certificateFingerprint(ca)
+
+	session := newTestSession("subscriber")
+	require.NoError(t, s.RegisterSession(t.Context(), session))
+	chainURI := chainResourcePrefix + leafFP
+	_, ok := r.handleSubscriptionMessage(session.SessionID(), []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":%q}}`, chainURI)))
+	require.True(t, ok)
+
+	r.recordChain([]*x509.Certificate{leaf, ca})
+	assert.Len(t, notificationsOf(session.drain(), "notifications/resources/list_changed"), 1)
+
+	response := sendMessage(t.Context(), s, session, `{"jsonrpc":"2.0","id":1,"method":"resources/list"}`)
+	list := response.(mcp.JSONRPCResponse).Result.(mcp.ListResourcesResult)
+	var uris []string
+	for _, resource := range list.Resources {
+		uris = append(uris, resource.URI)
+	}
+	assert.ElementsMatch(t, []string{chainURI, certResourcePrefix + leafFP, certResourcePrefix + caFP}, uris)
+
+	t.Run("certificate views", func(t *testing.T) {
+		// Fingerprints are accepted in either case
+		text, ok := readResource(t, s, certResourcePrefix+strings.ToUpper(caFP)).(mcp.TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, mimeTypePEM, text.MIMEType)
+		block, _ := pem.Decode([]byte(text.Text))
+		require.NotNil(t, block)
+		assert.Equal(t, ca.Raw, block.Bytes)
+
+		blob, ok := readResource(t, s, certResourcePrefix+caFP+"?format=der").(mcp.BlobResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, mimeTypeCertDER, blob.MIMEType)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(ca.Raw), blob.Blob)
+
+		text, ok = readResource(t, s, certResourcePrefix+leafFP+"?format=json").(mcp.TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, mimeTypeJSON, text.MIMEType)
+		assert.Contains(t, text.Text, "leaf.example.com")
+	})
+
+	t.Run("chain views", func(t *testing.T) {
+		text, ok := readResource(t, s, chainURI).(mcp.TextResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, 2, strings.Count(text.Text, "-----BEGIN CERTIFICATE-----"))
+
+		blob, ok := readResource(t, s, chainURI+"?format=der").(mcp.BlobResourceContents)
+		require.True(t, ok)
+		assert.Equal(t, mimeTypeChainDER, blob.MIMEType)
+		assert.Equal(t, base64.StdEncoding.EncodeToString(append(bytes.Clone(leaf.Raw), ca.Raw...)), blob.Blob)
+
+		text, ok = readResource(t, s, chainURI+"?format=json").(mcp.TextResourceContents)
+		require.True(t, ok)
+		var view chainResourceView
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &view))
+		assert.Equal(t, leafFP, view.LeafFingerprint)
+		assert.Len(t, view.Certificates, 2)
+	})
+
+	t.Run("invalid reads", func(t *testing.T) {
+		for _, uri := range []string{
+			certResourcePrefix + strings.Repeat("0", 64),
+			certResourcePrefix + "not-a-fingerprint",
+			chainResourcePrefix + leafFP + "?format=p12",
+		} {
+			response := sendMessage(t.Context(), s, session,
+				fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"resources/read","params":{"uri":%q}}`, uri))
+			_, isError := response.(mcp.JSONRPCError)
+			assert.True(t, isError, uri)
+		}
+	})
+
+	// Resolving the same chain again does not notify subscribers
+	r.recordChain([]*x509.Certificate{leaf, ca})
+	assert.Empty(t, session.drain())
+
+	// A different chain for the same leaf replaces the old one and notifies subscribers
+	other := newTestCertificate(t, "Resource Test CA 2")
+	r.recordChain([]*x509.Certificate{leaf, other})
+	received := session.drain()
+	updated := notificationsOf(received, methodNotificationResourceUpdated)
+	require.Len(t, updated, 1)
+	assert.Equal(t, chainURI, updated[0].Params.AdditionalFields["uri"])
+	_, ok = r.certificate(caFP)
+	assert.False(t, ok, "certificate of the replaced chain should be released")
+	_, ok = r.certificate(certificateFingerprint(other))
+	assert.True(t, ok)
+
+	// Unsubscribed and ended sessions receive no updates
+	_, ok = r.handleSubscriptionMessage(session.SessionID(), []byte(fmt.Sprintf(`{"jsonrpc":"2.0","id":2,"method":"resources/unsubscribe","params":{"uri":%q}}`, chainURI)))
+	require.True(t, ok)
+	r.recordChain([]*x509.Certificate{leaf, ca})
+	assert.Empty(t, notificationsOf(session.drain(), methodNotificationResourceUpdated))
+
+	r.subscribe(session.SessionID(), chainURI)
+	s.UnregisterSession(t.Context(), session.SessionID())
+	r.mu.Lock()
+	assert.Empty(t, r.subscriptions)
+	r.mu.Unlock()
+}
+
+func TestCertificateResourceEviction(t *testing.T) {
+	s, r := newCertificateResourceServer(t)
+	ca := newTestCertificate(t, "Shared CA")
+	leaves := make([]*x509.Certificate, maxCachedChains+1)
+	for i := range leaves {
+		leaves[i] = newTestCertificate(t, fmt.Sprintf("leaf-%d.example.com", i))
+		r.recordChain([]*x509.Certificate{leaves[i], ca})
+	}
+
+	_, ok := r.chain(certificateFingerprint(leaves[0]))
+	assert.False(t, ok, "least recently resolved chain should be evicted")
+	_, ok = r.certificate(certificateFingerprint(leaves[0]))
+	assert.False(t, ok)
+	_, ok = r.chain(certificateFingerprint(leaves[maxCachedChains]))
+	assert.True(t, ok)
+	_, ok = r.certificate(certificateFingerprint(ca))
+	assert.True(t, ok, "certificate shared with kept chains should stay")
+
+	// Listing is paginated
+	var (
+		listed int
+		cursor string
+		pages  int
+	)
+	for {
+		params := ""
+		if cursor != "" {
+			params = fmt.Sprintf(`,"params":{"cursor":%q}`, cursor)
+		}
+		response := sendMessage(t.Context(), s, newTestSession("lister"), `{"jsonrpc":"2.0","id":1,"method":"resources/list"`+params+`}`)
+		list := response.(mcp.JSONRPCResponse).Result.(mcp.ListResourcesResult)
+		assert.LessOrEqual(t, len(list.Resources), resourcePageSize)
+		listed += len(list.Resources)
+		pages++
+		if list.NextCursor == "" {
+			break
+		}
+		cursor = string(list.NextCursor)
+	}
+	// One chain and one leaf per kept chain, plus the shared CA
+	assert.Equal(t, 2*maxCachedChains+1, listed)
+	assert.Greater(t, pages, 1)
+}
+
+func TestHandleSubscriptionMessage(t *testing.T) {
+	r := newCertificateResources(nil)
+
+	_, ok := r.handleSubscriptionMessage("s", []byte(`{"jsonrpc":"2.0","id":1,"method":"resources/list"}`))
+	assert.False(t, ok)
+	_, ok = r.handleSubscriptionMessage("s", []byte(`{"jsonrpc":"2.0","method":"resources/subscribe","params":{"uri":"x"}}`))
+	assert.False(t, ok, "notifications are not requests")
+
+	response, ok := r.handleSubscriptionMessage("s", []byte(`{"jsonrpc":"2.0","id":"a","method":"resources/subscribe","params":{}}`))
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_PARAMS, response.(mcp.JSONRPCError).Error.Code)
+
+	response, ok = r.handleSubscriptionMessage("", []byte(`{"jsonrpc":"2.0","id":"a","method":"resources/subscribe","params":{"uri":"x"}}`))
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, response.(mcp.JSONRPCError).Error.Code)
+
+	// Session IDs the server never registered are refused
+	response, ok = r.handleSubscriptionMessage("s", []byte(`{"jsonrpc":"2.0","id":"a","method":"resources/subscribe","params":{"uri":"x"}}`))
+	require.True(t, ok)
+	assert.Equal(t, mcp.INVALID_REQUEST, response.(mcp.JSONRPCError).Error.Code)
+	assert.Empty(t, r.subscriptions)
+
+	r.addSession(t.Context(), newTestSession("s"))
+	response, ok = r.handleSubscriptionMessage("s", []byte(`{"jsonrpc":"2.0","id":"a","method":"resources/subscribe","params":{"uri":"x"}}`))
+	require.True(t, ok)
+	assert.Equal(t, mcp.NewRequestId("a"), response.(mcp.JSONRPCResponse).ID)
+	assert.Contains(t, r.subscriptions["x"], "s")
+
+	r.dropSession(t.Context(), newTestSession("s"))
+	assert.False(t, r.hasSession("s"))
+	assert.Empty(t, r.subscriptions)
+}
+
+func TestServeStdioSubscriptions(t *testing.T) {
+	s, r := newCertificateResourceServer(t)
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan error, 1)
+	go func() { done <- serveStdio(t.Context(), s, inR, outW) }()
+
+	lines := bufio.NewScanner(outR)
+	readLine := func() map[string]any {
+		t.Helper()
+		require.True(t, lines.Scan())
+		var message map[string]any
+		require.NoError(t, json.Unmarshal(lines.Bytes(), &message))
+		return message
+	}
+
+	// Other messages reach the server, which registers the stdio session before reading them
+	fmt.Fprintln(inW, `{"jsonrpc":"2.0","id":1,"method":"ping"}`)
+	message := readLine()
+	assert.Equal(t, float64(1), message["id"])
+
+	fmt.Fprintln(inW, `{"jsonrpc":"2.0","id":2,"method":"resources/subscribe","params":{"uri":"chain://sha256/abc"}}`)
+	message = readLine()
+	assert.Equal(t, float64(2), message["id"])
+	assert.Contains(t, message, "result")
+
+	r.mu.Lock()
+	assert.Contains(t, r.subscriptions["chain://sha256/abc"], stdioSessionID)
+	r.mu.Unlock()
+
+	inW.Close()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stdio server did not stop at end of input")
+	}
+}
+
+func TestInterceptUnroutedMethods(t *testing.T) {
+	s, r := newCertificateResourceServer(t)
+	var forwarded []byte
+	handler := interceptUnroutedMethods(s, http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		forwarded, _ = io.ReadAll(req.Body)
+	}))
+
+	subscribe := func(session, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body))
+		req.Header.Set(server.HeaderKeySessionID, session)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	body := `{"jsonrpc":"2.0","id":1,"method":"resources/subscribe","params":{"uri":"cert://sha256/abc"}}`
+	rec := subscribe("forged-session", body)
+	assert.Contains(t, rec.Body.String(), "unknown session")
+	r.mu.Lock()
+	assert.Empty(t, r.subscriptions)
+	r.mu.Unlock()
+
+	require.NoError(t, s.RegisterSession(t.Context(), newTestSession("http-session")))
+	rec = subscribe("http-session", body)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `"result"`)
+	assert.Nil(t, forwarded)
+	r.mu.Lock()
+	assert.Contains(t, r.subscriptions["cert://sha256/abc"], "http-session")
+	r.mu.Unlock()
+
+	body = `{"jsonrpc":"2.0","id":2,"method":"ping"}`
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/mcp", strings.NewReader(body)))
+	assert.Equal(t, body, string(forwarded))
+
+	forwarded = nil
+	rec = subscribe("http-session", strings.Repeat(" ", maxRequestBodySize+1))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.Nil(t, forwarded)
+}
+
+func TestResolvedChainsBecomeResources(t *testing.T) {
+	tools, _ := createTools()
+	s, err := NewServerBuilder().WithVersion("1.0.0").WithTools(tools...).WithResourceTemplates(createResourceTemplates()...).Build()
+	require.NoError(t, err)
+
+	caPEM := newSelfSignedCA(t, "Tool Resolved CA")
+	response := sendMessage(t.Context(), s, newTestSession("caller"), fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":%q,"arguments":{"certificate":%q}}}`,
+		ToolResolveCertChain, base64.StdEncoding.EncodeToString([]byte(caPEM))))
+	require.False(t, response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult).IsError)
+
+	block, _ := pem.Decode([]byte(caPEM))
+	sum := certificateFingerprint(&x509.Certificate{Raw: block.Bytes})
+	text, ok := readResource(t, s, chainResourcePrefix+sum).(mcp.TextResourceContents)
+	require.True(t, ok)
+	assert.Equal(t, caPEM, text.Text)
+
+	t.Run("fingerprint from tool result", func(t *testing.T) {
+		data, err := json.Marshal(response.(mcp.JSONRPCResponse).Result.(mcp.CallToolResult).StructuredContent)
+		require.NoError(t, err)
+		var structured struct {
+			Certificates []struct {
+				SHA256 string `json:"sha256Fingerprint"`
+			} `json:"certificates"`
+		}
+		require.NoError(t, json.Unmarshal(data, &structured))
+		require.NotEmpty(t, structured.Certificates)
+		fp := structured.Certificates[0].SHA256
+		require.Contains(t, fp, ":")
+
+		for _, uri := range []string{
+			certResourcePrefix + fp,
+			chainResourcePrefix + fp,
+			chainResourcePrefix + url.PathEscape(fp),
+			certResourcePrefix + url.PathEscape(strings.ReplaceAll(fp, ":", " ")),
+		} {
+			text, ok := readResource(t, s, uri).(mcp.TextResourceContents)
+			require.True(t, ok, uri)
+			assert.Equal(t, caPEM, text.Text, uri)
+		}
+	})
+}
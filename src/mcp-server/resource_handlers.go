@@ -7,15 +7,33 @@ package mcpserver
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/mcp-server/templates"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/version"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
+// MIME types of the certificate and chain resource views.
+const (
+	// mimeTypePEM is used for PEM certificates and PEM bundles.
+	mimeTypePEM = "application/x-pem-file"
+	// mimeTypeCertDER is used for a single DER certificate.
+	mimeTypeCertDER = "application/pkix-cert"
+	// mimeTypeChainDER is used for concatenated DER certificates.
+	mimeTypeChainDER = "application/octet-stream"
+	// mimeTypeJSON is used for the JSON certificate summaries.
+	mimeTypeJSON = "application/json"
+)
+
 // handleConfigResource handles requests for the configuration template resource.
 // It provides a JSON template showing the expected configuration structure for the MCP server.
 //
@@ -90,7 +108,7 @@ func handleVersionResource(ctx context.Context, request mcp.ReadResourceRequest)
 			"resources": resources,            // Loaded from config with meta
 			"prompts":   prompts,              // Loaded from config with meta
 		},
-		"supportedFormats": []string{"pem", "der", "json"},
+		"supportedFormats": []string{"pem", "der", "json", "jks", "pkcs12"},
 	}
 
 	jsonData, err := json.MarshalIndent(versionInfo, "", "  ")
@@ -148,7 +166,8 @@ func handleCertificateFormatsResource(ctx context.Context, request mcp.ReadResou
 //   - An error if JSON marshaling fails
 //
 // The status includes server health, timestamp, version, and available capabilities
-// (tools, resources, prompts with full metadata from config, supported formats).
+// (tools, resources, prompts with full metadata from config, supported formats),
+// plus rate limit and quota usage when limits are configured.
 // All capabilities are loaded dynamically from codegen config files with their meta information.
 func handleStatusResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
 	// Load configurations dynamically
@@ -177,7 +196,12 @@ func handleStatusResource(ctx context.Context, request mcp.ReadResourceRequest)
 			"resources": resources,            // Loaded from config with meta
 			"prompts":   prompts,              // Loaded from config with meta
 		},
-		"supportedFormats": []string{"pem", "der", "json"},
+		"supportedFormats": []string{"pem", "der", "json", "jks", "pkcs12"},
+	}
+
+	// Rate limit and quota usage, when limits are configured
+	if limiter := activeLimiter.Load(); limiter != nil {
+		statusInfo["limits"] = limiter.status()
 	}
 
 	jsonData, err := json.MarshalIndent(statusInfo, "", "  ")
@@ -193,3 +217,144 @@ func handleStatusResource(ctx context.Context, request mcp.ReadResourceRequest)
 		},
 	}, nil
 }
+
+// parseCertificateResourceURI extracts the fingerprint and view of a
+// cert:// or chain:// resource URI.
+//
+// Parameters:
+//   - uri: Resource URI such as cert://sha256/{fingerprint}?format=der
+//   - prefix: Expected URI prefix, certResourcePrefix or chainResourcePrefix
+//
+// Returns:
+//   - fingerprint: Normalized SHA-256 fingerprint
+//   - format: Requested view: pem (the default), der, or json
+//   - error: Error if the URI is malformed or the view is unsupported
+func parseCertificateResourceURI(uri, prefix string) (fingerprint, format string, err error) {
+	rest, ok := strings.CutPrefix(uri, prefix)
+	if !ok {
+		return "", "", fmt.Errorf("unsupported resource URI %q: expected %s{fingerprint}", uri, prefix)
+	}
+	rawFingerprint, rawQuery, _ := strings.Cut(rest, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid resource URI %q: %w", uri, err)
+	}
+	format = strings.ToLower(query.Get("format"))
+	switch format {
+	case "":
+		format = "pem"
+	case "pem", "der", "json":
+	default:
+		return "", "", fmt.Errorf("unsupported format %q: must be pem, der, or json", format)
+	}
+	// Separators may arrive percent-encoded, as in %3A or %20
+	if unescaped, uErr := url.PathUnescape(rawFingerprint); uErr == nil {
+		rawFingerprint = unescaped
+	}
+	if fingerprint, err = normalizeFingerprint(rawFingerprint); err != nil {
+		return "", "", err
+	}
+	return fingerprint, format, nil
+}
+
+// handleCertificateResource handles reads of cert://sha256/{fingerprint} resources.
+// It serves a certificate that a tool call resolved earlier on the same server.
+//
+// Parameters:
+//   - ctx: Context of the read, carrying the server
+//   - request: MCP resource read request with a URI matching the certificate template
+//
+// Returns:
+//   - A slice containing the certificate as PEM text, a base64 DER blob, or a JSON summary
+//   - An error if the URI is invalid or the certificate is not cached
+func handleCertificateResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := request.Params.URI
+	fingerprint, format, err := parseCertificateResourceURI(uri, certResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+	cert, ok := certificateResourcesFromContext(ctx).certificate(fingerprint)
+	if !ok {
+		return nil, fmt.Errorf("certificate %s not found: resolve a chain containing it first", fingerprint)
+	}
+
+	certManager := x509certs.New()
+	switch format {
+	case "der":
+		return []mcp.ResourceContents{
+			mcp.BlobResourceContents{URI: uri, MIMEType: mimeTypeCertDER, Blob: base64.StdEncoding.EncodeToString(certManager.EncodeDER(cert))},
+		}, nil
+	case "json":
+		jsonData, err := json.MarshalIndent(summarizeCertificate(cert), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal certificate: %w", err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: mimeTypeJSON, Text: string(jsonData)},
+		}, nil
+	default:
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: mimeTypePEM, Text: string(certManager.EncodePEM(cert))},
+		}, nil
+	}
+}
+
+// chainResourceView is the JSON view of a chain://sha256/{fingerprint} resource.
+type chainResourceView struct {
+	// LeafFingerprint: SHA-256 fingerprint of the leaf, as used in the resource URI
+	LeafFingerprint string `json:"leafFingerprint"`
+	// Certificates: Summaries of the chain's certificates, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+}
+
+// handleChainResource handles reads of chain://sha256/{fingerprint} resources.
+// It serves the chain that a tool call most recently resolved for the leaf on the same server.
+//
+// Parameters:
+//   - ctx: Context of the read, carrying the server
+//   - request: MCP resource read request with a URI matching the chain template
+//
+// Returns:
+//   - A slice containing the chain as a PEM bundle, a base64 blob of concatenated DER, or a JSON summary
+//   - An error if the URI is invalid or the chain is not cached
+func handleChainResource(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	uri := request.Params.URI
+	fingerprint, format, err := parseCertificateResourceURI(uri, chainResourcePrefix)
+	if err != nil {
+		return nil, err
+	}
+	certs, ok := certificateResourcesFromContext(ctx).chain(fingerprint)
+	if !ok {
+		return nil, fmt.Errorf("chain for leaf %s not found: resolve it with a tool first", fingerprint)
+	}
+
+	certManager := x509certs.New()
+	switch format {
+	case "der":
+		return []mcp.ResourceContents{
+			mcp.BlobResourceContents{URI: uri, MIMEType: mimeTypeChainDER, Blob: base64.StdEncoding.EncodeToString(certManager.EncodeMultipleDER(certs))},
+		}, nil
+	case "json":
+		jsonData, err := json.MarshalIndent(chainResourceView{
+			LeafFingerprint: fingerprint,
+			Certificates:    summarizeChain(chainOf(certs)),
+		}, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal chain: %w", err)
+		}
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: mimeTypeJSON, Text: string(jsonData)},
+		}, nil
+	default:
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{URI: uri, MIMEType: mimeTypePEM, Text: string(certManager.EncodeMultiplePEM(certs))},
+		}, nil
+	}
+}
+
+// chainOf wraps resolved certificates, leaf first, in a chain for role detection.
+func chainOf(certs []*x509.Certificate) *x509chain.Chain {
+	chain := x509chain.New(certs[0], version.Version)
+	chain.Certs = certs
+	return chain
+}
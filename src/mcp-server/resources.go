@@ -120,3 +120,69 @@ func createResources() ([]ServerResource, []ServerResourceWithEmbed) {
 
 	return resources, resourcesWithEmbed
 }
+
+// createResourceTemplates creates and returns all MCP resource templates without adding them to a server.
+// Each template addresses a family of resources through an RFC 6570 URI template, so clients
+// can construct resource URIs for content the server produces at runtime.
+//
+// Returns:
+//   - []ServerResourceTemplate: Slice of server resource template definitions
+//
+// The function defines the following resource templates:
+//   - cert://sha256/{+fingerprint}{?format}: Certificate by Fingerprint
+//   - chain://sha256/{+fingerprint}{?format}: Certificate Chain by Leaf Fingerprint
+//
+// Each resource template definition includes:
+//   - URI template used to match resource URIs and extract their variables
+//   - Human-readable name and description for user interfaces
+//   - MIME type specification when it is shared by all matching resources
+//   - Audience restrictions and priority settings for access control
+//   - Metadata tags for categorization and discovery
+//   - Handler functions that serve the matching resources
+//   - Completion sources or fixed values for template variables that support completion/complete
+func createResourceTemplates() []ServerResourceTemplate {
+	return []ServerResourceTemplate{
+		{
+			Template: func() mcp.ResourceTemplate {
+				tmpl := mcp.NewResourceTemplate(
+					"cert://sha256/{+fingerprint}{?format}",
+					"Certificate by Fingerprint",
+					mcp.WithTemplateDescription("A certificate the server has resolved, addressed by its SHA-256 fingerprint (hex, colons optional), as PEM (default), DER, or JSON with format=pem|der|json"),
+					mcp.WithTemplateAnnotations(
+						[]mcp.Role{
+							mcp.RoleUser,
+							mcp.RoleAssistant,
+						}, 0.6),
+				)
+				tmpl.Meta = mcp.NewMetaFromMap(map[string]any{"category": "certificates", "readOnly": true})
+				return tmpl
+			}(),
+			Handler: handleCertificateResource,
+			Completions: map[string]ArgumentCompletion{
+				"fingerprint": {Source: CompletionSourceCertificate},
+				"format":      {Values: []string{"pem", "der", "json"}},
+			},
+		},
+		{
+			Template: func() mcp.ResourceTemplate {
+				tmpl := mcp.NewResourceTemplate(
+					"chain://sha256/{+fingerprint}{?format}",
+					"Certificate Chain by Leaf Fingerprint",
+					mcp.WithTemplateDescription("A certificate chain the server has resolved, addressed by the SHA-256 fingerprint of its leaf, as a PEM bundle (default), concatenated DER, or JSON with format=pem|der|json"),
+					mcp.WithTemplateAnnotations(
+						[]mcp.Role{
+							mcp.RoleUser,
+							mcp.RoleAssistant,
+						}, 0.6),
+				)
+				tmpl.Meta = mcp.NewMetaFromMap(map[string]any{"category": "certificates", "readOnly": true})
+				return tmpl
+			}(),
+			Handler: handleChainResource,
+			Completions: map[string]ArgumentCompletion{
+				"fingerprint": {Source: CompletionSourceChain},
+				"format":      {Values: []string{"pem", "der", "json"}},
+			},
+		},
+	}
+}
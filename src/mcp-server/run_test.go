@@ -8,6 +8,7 @@ package mcpserver
 import (
 	"context"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
@@ -38,6 +39,7 @@ import (
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/helper/posix"
 	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
 	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/mcp-server/templates"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/version"
 )
@@ -376,6 +378,28 @@ func TestMCPTools(t *testing.T) {
 			expectError:    false,
 			expectContains: []string{}, // DER is binary, no text to check
 		},
+		{
+			name:     "resolve_cert_chain with jks format",
+			toolName: "resolve_cert_chain",
+			args: map[string]any{
+				"certificate":    certData,
+				"format":         "jks",
+				"store_password": "changeit",
+			},
+			expectError:    false,
+			expectContains: []string{"Certificate chain resolved successfully"},
+		},
+		{
+			name:     "fetch_remote_cert with pkcs12 format",
+			toolName: "fetch_remote_cert",
+			args: map[string]any{
+				"hostname": "example.com",
+				"port":     443,
+				"format":   "pkcs12",
+			},
+			expectError:    false,
+			expectContains: []string{"PKCS12 truststore", "Alias:"},
+		},
 		{
 			name:     "resolve_cert_chain with include_system_root",
 			toolName: "resolve_cert_chain",
@@ -1451,12 +1475,47 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestApplyEgressPolicy(t *testing.T) {
+	t.Cleanup(func() { x509chain.SetEgressPolicy(nil) })
+
+	// Stdio without an egress section stays unrestricted
+	require.NoError(t, applyEgressPolicy(x509chain.EgressPolicy{}, false))
+	assert.Nil(t, x509chain.GetEgressPolicy())
+
+	// Network transports block private ranges unless configured otherwise
+	require.NoError(t, applyEgressPolicy(x509chain.EgressPolicy{}, true))
+	policy := x509chain.GetEgressPolicy()
+	require.NotNil(t, policy)
+	require.NotNil(t, policy.BlockPrivate)
+	assert.True(t, *policy.BlockPrivate)
+
+	blockPrivate := false
+	require.NoError(t, applyEgressPolicy(x509chain.EgressPolicy{BlockPrivate: &blockPrivate}, true))
+	assert.False(t, *x509chain.GetEgressPolicy().BlockPrivate)
+
+	assert.Error(t, applyEgressPolicy(x509chain.EgressPolicy{AllowCIDRs: []string{"not-a-cidr"}}, false))
+
+	// Policy violations surface as tool errors
+	require.NoError(t, applyEgressPolicy(x509chain.EgressPolicy{}, true))
+	config, err := loadConfig("")
+	require.NoError(t, err)
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = map[string]any{"hostname": "127.0.0.1", "port": float64(443)}
+	result, err := handleFetchRemoteCert(t.Context(), req, config)
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "egress policy denied 127.0.0.1")
+}
+
 func TestCreateTools(t *testing.T) {
 	tools, toolsWithConfig := createTools()
 
 	// Verify we get the expected number of tools
-	assert.Len(t, tools, 4, "Expected 4 regular tools")
-	assert.Len(t, toolsWithConfig, 4, "Expected 4 config tools")
+	assert.Len(t, tools, 7, "Expected 7 regular tools")
+	assert.Len(t, toolsWithConfig, 6, "Expected 6 config tools")
 
 	// Verify tool names
 	expectedToolNames := []string{
@@ -1468,6 +1527,11 @@ func TestCreateTools(t *testing.T) {
 		"fetch_remote_cert",
 		"analyze_certificate_with_ai",
 		"visualize_cert_chain",
+		"inspect_csr",
+		"inspect_certificate",
+		"lint_certificate",
+		"diff_cert_chains",
+		"scan_certificate_inventory",
 	}
 
 	foundTools := make(map[string]bool)
@@ -1888,6 +1952,15 @@ func TestFormatJSON(t *testing.T) {
 	// Check structure
 	assert.Equal(t, "X.509 Certificate Chain", jsonResult["title"], "Expected title 'X.509 Certificate Chain'")
 	assert.Equal(t, float64(1), jsonResult["totalChained"], "Expected totalChained 1")
+
+	list, ok := jsonResult["listCertificates"].([]any)
+	require.True(t, ok, "Expected listCertificates array")
+	require.Len(t, list, 1)
+	entry := list[0].(map[string]any)
+	fp := x509certs.CertificateFingerprints(cert)
+	assert.Equal(t, fp.SHA256, entry["sha256Fingerprint"], "Expected SHA-256 fingerprint")
+	assert.Equal(t, fp.SHA1, entry["sha1Fingerprint"], "Expected SHA-1 fingerprint")
+	assert.Equal(t, fp.SPKISHA256, entry["spkiSha256"], "Expected SPKI pin")
 }
 
 func TestServerBuilder_Build_WithoutTools(t *testing.T) {
@@ -2021,6 +2094,10 @@ func TestGetKeySize(t *testing.T) {
 	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
 	require.NoError(t, err, "Failed to generate ECDSA key")
 
+	// Test Ed25519 key
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err, "Failed to generate Ed25519 key")
+
 	tests := []struct {
 		name     string
 		cert     *x509.Certificate
@@ -2042,6 +2119,14 @@ func TestGetKeySize(t *testing.T) {
 			},
 			expected: 256,
 		},
+		{
+			name: "Ed25519 key",
+			cert: &x509.Certificate{
+				PublicKey:          edKey,
+				PublicKeyAlgorithm: x509.Ed25519,
+			},
+			expected: 256,
+		},
 		{
 			name: "unsupported key type",
 			cert: &x509.Certificate{
@@ -4613,6 +4698,418 @@ func TestBatchConcurrencyLimit(t *testing.T) {
 
 	// Verify all results have the expected format
 	for i, result := range results {
-		assert.Contains(t, result, fmt.Sprintf("Certificate %d:", i+1), "Result %d missing expected format", i)
+		assert.Contains(t, result.text, fmt.Sprintf("Certificate %d:", i+1), "Result %d missing expected format", i)
+	}
+}
+
+func TestFormatChainOutput_TrustStore(t *testing.T) {
+	certManager := x509certs.New()
+	cert, err := certManager.Decode([]byte(testCertPEM))
+	require.NoError(t, err)
+	certs := []*x509.Certificate{cert}
+
+	t.Run("jks", func(t *testing.T) {
+		output, err := formatChainOutput(certs, resolveChainOptions{format: "jks", storePassword: "changeit"}, certManager)
+		require.NoError(t, err)
+
+		data, err := base64.StdEncoding.DecodeString(output)
+		require.NoError(t, err)
+
+		entries, err := certManager.DecodeJKS(data, "changeit")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, x509certs.TrustStoreAlias(cert), entries[0].Alias)
+		assert.True(t, cert.Equal(entries[0].Certificate))
+	})
+
+	t.Run("pkcs12", func(t *testing.T) {
+		output, err := formatChainOutput(certs, resolveChainOptions{format: "pkcs12", storePassword: "secret"}, certManager)
+		require.NoError(t, err)
+
+		data, err := base64.StdEncoding.DecodeString(output)
+		require.NoError(t, err)
+
+		decoded, err := certManager.DecodePKCS12TrustStore(data, "secret")
+		require.NoError(t, err)
+		require.Len(t, decoded, 1)
+		assert.True(t, cert.Equal(decoded[0]))
+	})
+
+	t.Run("empty chain", func(t *testing.T) {
+		_, err := formatChainOutput(nil, resolveChainOptions{format: "jks"}, certManager)
+		assert.ErrorIs(t, err, x509certs.ErrNoCertificates)
+	})
+}
+
+func TestHandleInspectCSR(t *testing.T) {
+	ctx := t.Context()
+
+	newCSR := func(t *testing.T, tmpl *x509.CertificateRequest) string {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		der, err := x509.CreateCertificateRequest(rand.Reader, tmpl, key)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}))
+	}
+
+	callTool := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handleInspectCSR(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "inspect_csr", Arguments: args},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result
+	}
+
+	t.Run("passing request", func(t *testing.T) {
+		result := callTool(t, map[string]any{"csr": newCSR(t, &x509.CertificateRequest{
+			Subject:  pkix.Name{CommonName: "www.example.com"},
+			DNSNames: []string{"www.example.com"},
+		})})
+		require.False(t, result.IsError)
+
+		report, ok := result.StructuredContent.(*x509certs.CSRReport)
+		require.True(t, ok, "expected structured CSR report, got %T", result.StructuredContent)
+		assert.True(t, report.Passed)
+		assert.Equal(t, "ECDSA P-256", report.PublicKeyAlgorithm)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &decoded))
+		assert.Equal(t, true, decoded["passed"])
+	})
+
+	t.Run("request without SAN", func(t *testing.T) {
+		result := callTool(t, map[string]any{"csr": newCSR(t, &x509.CertificateRequest{
+			Subject: pkix.Name{CommonName: "example.com"},
+		})})
+		require.False(t, result.IsError, "failed checks are reported, not raised")
+
+		report := result.StructuredContent.(*x509certs.CSRReport)
+		assert.False(t, report.Passed)
+		require.Len(t, report.Findings, 1)
+		assert.Equal(t, x509certs.CheckSANPresent, report.Findings[0].Check)
+	})
+
+	t.Run("missing csr parameter", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{}).IsError)
+	})
+
+	t.Run("certificate instead of request", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"csr": pemToBase64(testCertPEM)}).IsError)
+	})
+}
+
+func TestHandleInspectCertificate(t *testing.T) {
+	ctx := t.Context()
+
+	callTool := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handleInspectCertificate(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "inspect_certificate", Arguments: args},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result
 	}
+
+	t.Run("text format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM)})
+		require.False(t, result.IsError)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "Subject: CN=www.google.com")
+		assert.Contains(t, text.Text, "CT Precertificate SCTs:")
+		assert.Contains(t, text.Text, "OCSP - URI:http://o.pki.goog/wr2")
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM), "format": "json"})
+		require.False(t, result.IsError)
+
+		structured, ok := result.StructuredContent.(inspectCertificateResult)
+		require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+		require.Len(t, structured.Certificates, 1)
+		assert.Equal(t, "CN=www.google.com", structured.Certificates[0].Subject)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &decoded))
+		assert.Contains(t, decoded, "certificates")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM), "format": "yaml"}).IsError)
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"certificate": "invalid-cert-data"}).IsError)
+	})
+}
+
+func TestHandleLintCertificate(t *testing.T) {
+	ctx := t.Context()
+
+	callTool := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handleLintCertificate(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "lint_certificate", Arguments: args},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result
+	}
+
+	t.Run("text format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM)})
+		require.False(t, result.IsError)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "Subject:         CN=www.google.com")
+		assert.Contains(t, text.Text, "Type:            subscriber")
+		assert.Contains(t, text.Text, "Result: ")
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM), "format": "json"})
+		require.False(t, result.IsError)
+
+		structured, ok := result.StructuredContent.(lintCertificateResult)
+		require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+		require.Len(t, structured.Certificates, 1)
+		assert.Equal(t, x509certs.CertificateTypeSubscriber, structured.Certificates[0].Type)
+		assert.Positive(t, structured.Certificates[0].RulesEvaluated)
+		for _, f := range structured.Certificates[0].Findings {
+			assert.NotEmpty(t, f.Citation)
+		}
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &decoded))
+		assert.Contains(t, decoded, "certificates")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"certificate": pemToBase64(testCertPEM), "format": "yaml"}).IsError)
+	})
+
+	t.Run("invalid certificate", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"certificate": "invalid-cert-data"}).IsError)
+	})
+}
+
+func TestBuildValidationResult_Verbose(t *testing.T) {
+	block, _ := pem.Decode([]byte(testCertPEM))
+	require.NotNil(t, block, "Should decode test certificate")
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err, "Should parse certificate")
+
+	chain := x509chain.New(cert, version.Version)
+
+	t.Run("verbose parameter", func(t *testing.T) {
+		_, _, verbose, err := validateValidateParams(mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"certificate": "cert.pem"}},
+		})
+		require.NoError(t, err)
+		assert.False(t, verbose, "verbose defaults to false")
+
+		_, _, verbose, err = validateValidateParams(mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Arguments: map[string]any{"certificate": "cert.pem", "verbose": true}},
+		})
+		require.NoError(t, err)
+		assert.True(t, verbose)
+	})
+
+	t.Run("without verbose", func(t *testing.T) {
+		result := buildValidationResult(chain, "Revocation: skipped", false)
+		assert.Contains(t, result, "Validation: PASSED")
+		assert.NotContains(t, result, "Constraints Evaluation:")
+	})
+
+	t.Run("with verbose", func(t *testing.T) {
+		result := buildValidationResult(chain, "Revocation: skipped", true)
+		assert.Contains(t, result, "Constraints Evaluation:")
+		assert.Contains(t, result, "Name Constraints:")
+		assert.Contains(t, result, "No CA in the chain carries name constraints.")
+		assert.Contains(t, result, "Certificate Policies:")
+		assert.Contains(t, result, "2.23.140.1.2.1 (CA/B Domain Validated)")
+		assert.Contains(t, result, "Policy Processing: VALID")
+	})
+}
+
+func TestHandleDiffCertChains(t *testing.T) {
+	ctx := t.Context()
+	config := &Config{}
+	config.Defaults.Timeout = 5
+
+	selfSigned := func(t *testing.T) string {
+		t.Helper()
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber: big.NewInt(time.Now().UnixNano()),
+			Subject:      pkix.Name{CommonName: "diff.example.com"},
+			DNSNames:     []string{"diff.example.com"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(24 * time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(der)
+	}
+	oldCert, newCert := selfSigned(t), selfSigned(t)
+
+	callTool := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handleDiffCertChains(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "diff_cert_chains", Arguments: args},
+		}, config)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result
+	}
+
+	t.Run("text format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"old_certificate": oldCert, "new_certificate": oldCert})
+		require.False(t, result.IsError)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "[unchanged]")
+		assert.Contains(t, text.Text, "Result: IDENTICAL")
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"old_certificate": oldCert, "new_certificate": newCert, "format": "json"})
+		require.False(t, result.IsError)
+
+		structured, ok := result.StructuredContent.(*x509chain.ChainDiff)
+		require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+		assert.False(t, structured.Identical)
+		require.Len(t, structured.Certificates, 1)
+		assert.Equal(t, x509chain.DiffChanged, structured.Certificates[0].Status)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		var decoded map[string]any
+		require.NoError(t, json.Unmarshal([]byte(text.Text), &decoded))
+		assert.Contains(t, decoded, "certificates")
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"old_certificate": oldCert, "new_certificate": newCert, "format": "yaml"}).IsError)
+	})
+
+	t.Run("missing parameter", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"old_certificate": oldCert}).IsError)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		result := callTool(t, map[string]any{"old_certificate": oldCert, "new_certificate": "invalid cert data!"})
+		require.True(t, result.IsError)
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "failed to resolve new chain")
+		assert.NotContains(t, text.Text, "invalid cert data")
+	})
+
+	t.Run("host:port", func(t *testing.T) {
+		remote := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		defer remote.Close()
+		result := callTool(t, map[string]any{"old_certificate": remote.Listener.Addr().String(), "new_certificate": oldCert})
+		require.False(t, result.IsError, "%v", result.Content)
+		structured, ok := result.StructuredContent.(*x509chain.ChainDiff)
+		require.True(t, ok)
+		assert.False(t, structured.Identical)
+	})
+}
+
+func TestHandleScanCertificateInventory(t *testing.T) {
+	ctx := t.Context()
+	config := &Config{}
+	config.Defaults.Timeout = 5
+	config.Defaults.WarnDays = 30
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "inventory.example.com"},
+		DNSNames:     []string{"inventory.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().AddDate(0, 0, 90),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	secret := "kind: Secret\ntype: kubernetes.io/tls\nmetadata:\n  name: web\ndata:\n  tls.crt: " + base64.StdEncoding.EncodeToString(der) + "\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.yaml"), []byte(secret), 0644))
+	// Without a server, files are read within the working directory
+	t.Chdir(dir)
+
+	callTool := func(t *testing.T, args map[string]any) *mcp.CallToolResult {
+		t.Helper()
+		result, err := handleScanCertificateInventory(ctx, mcp.CallToolRequest{
+			Params: mcp.CallToolParams{Name: "scan_certificate_inventory", Arguments: args},
+		}, config)
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		return result
+	}
+
+	t.Run("markdown format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"paths": dir})
+		require.False(t, result.IsError)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		assert.Contains(t, text.Text, "Certificates: 2 (ok: 2,")
+		assert.Contains(t, text.Text, "default/web")
+	})
+
+	t.Run("csv format with warn_days", func(t *testing.T) {
+		result := callTool(t, map[string]any{"paths": dir + ", " + dir, "format": "csv", "warn_days": 120})
+		require.False(t, result.IsError)
+
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		lines := strings.Split(strings.TrimSpace(text.Text), "\n")
+		assert.Len(t, lines, 5)
+		assert.Contains(t, text.Text, ",expiring,")
+	})
+
+	t.Run("json format", func(t *testing.T) {
+		result := callTool(t, map[string]any{"paths": dir, "format": "json"})
+		require.False(t, result.IsError)
+
+		structured, ok := result.StructuredContent.(*x509inventory.Report)
+		require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+		require.Len(t, structured.Entries, 2)
+		assert.Equal(t, x509inventory.SourceKubernetesSecret, structured.Entries[0].Source)
+		assert.Equal(t, x509inventory.SourceFile, structured.Entries[1].Source)
+	})
+
+	t.Run("unsupported format", func(t *testing.T) {
+		assert.True(t, callTool(t, map[string]any{"paths": dir, "format": "xml"}).IsError)
+	})
+
+	t.Run("missing path", func(t *testing.T) {
+		result := callTool(t, map[string]any{"paths": filepath.Join(dir, "missing-secret")})
+		require.True(t, result.IsError)
+		text, ok := result.Content[0].(mcp.TextContent)
+		require.True(t, ok)
+		// Errors are reported without the path
+		assert.Equal(t, "failed to scan certificates: path does not exist", text.Text)
+		assert.True(t, callTool(t, map[string]any{"paths": " , "}).IsError)
+		assert.True(t, callTool(t, map[string]any{}).IsError)
+	})
 }
@@ -91,6 +91,7 @@ func Run(version string, configFile string) error {
 	// Create tools for CLI framework
 	tools, toolsWithConfig := createTools()
 	resources, resourcesWithEmbed := createResources()
+	resourceTemplates := createResourceTemplates()
 	prompts, promptsWithEmbed := createPrompts()
 
 	// Generate instructions dynamically
@@ -113,6 +114,7 @@ func Run(version string, configFile string) error {
 		ToolsWithConfig:    toolsWithConfig,
 		Resources:          resources,
 		ResourcesWithEmbed: resourcesWithEmbed,
+		ResourceTemplates:  resourceTemplates,
 		Prompts:            prompts,
 		PromptsWithEmbed:   promptsWithEmbed,
 		SamplingHandler:    NewDefaultSamplingHandler(config, version),
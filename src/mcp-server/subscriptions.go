@@ -0,0 +1,72 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+const (
+	// methodResourcesSubscribe asks for notifications/resources/updated of a resource.
+	methodResourcesSubscribe = "resources/subscribe"
+	// methodResourcesUnsubscribe cancels a resources/subscribe.
+	methodResourcesUnsubscribe = "resources/unsubscribe"
+)
+
+// handleSubscriptionMessage answers a resources/subscribe or
+// resources/unsubscribe request.
+//
+// mcp-go advertises the subscribe capability but does not route these
+// methods, so they are answered by [unroutedMethodsOf] in front of the server.
+//
+// Parameters:
+//   - session: ID of the session the message arrived on
+//   - message: Raw JSON-RPC message
+//
+// Returns:
+//   - mcp.JSONRPCMessage: Response to send back to the client
+//   - bool: Whether the message was a subscription request; if false it must be forwarded to the server
+func (r *certificateResources) handleSubscriptionMessage(session string, message []byte) (mcp.JSONRPCMessage, bool) {
+	// Quick check before parsing every message
+	if !bytes.Contains(message, []byte("resources/")) || !bytes.Contains(message, []byte("subscribe")) {
+		return nil, false
+	}
+	var request struct {
+		ID     any    `json:"id"`
+		Method string `json:"method"`
+		Params struct {
+			URI string `json:"uri"`
+		} `json:"params"`
+	}
+	if err := json.Unmarshal(message, &request); err != nil || request.ID == nil {
+		return nil, false
+	}
+	if request.Method != methodResourcesSubscribe && request.Method != methodResourcesUnsubscribe {
+		return nil, false
+	}
+
+	id := mcp.NewRequestId(request.ID)
+	if request.Params.URI == "" {
+		return mcp.NewJSONRPCError(id, mcp.INVALID_PARAMS, "uri is required", nil), true
+	}
+	if session == "" {
+		// Updates are delivered per session; without one there is nowhere to send them
+		return mcp.NewJSONRPCError(id, mcp.INVALID_REQUEST, "resource subscriptions require a session", nil), true
+	}
+	if !r.hasSession(session) {
+		// The session ID comes from the client; only sessions the server registered may subscribe
+		return mcp.NewJSONRPCError(id, mcp.INVALID_REQUEST, "unknown session", nil), true
+	}
+	if request.Method == methodResourcesSubscribe {
+		r.subscribe(session, request.Params.URI)
+	} else {
+		r.unsubscribe(session, request.Params.URI)
+	}
+	return mcp.NewJSONRPCResultResponse(id, mcp.EmptyResult{}), true
+}
@@ -45,6 +45,26 @@ const (
 	// ToolVisualizeCertChain provides certificate chain visualization in multiple formats.
 	// Supports ASCII tree, markdown table, and JSON output for better certificate chain analysis.
 	ToolVisualizeCertChain = "visualize_cert_chain"
+
+	// ToolInspectCSR inspects a PKCS#10 certificate signing request and runs pre-issuance checks.
+	// Catches weak keys, deprecated signature algorithms, and missing or malformed SANs before submission to a CA.
+	ToolInspectCSR = "inspect_csr"
+
+	// ToolInspectCertificate fully decodes certificates, comparable to openssl x509 -text.
+	// Renders every standard extension including all SAN types, name constraints, policies, AIA, CDP, SCTs, and TLS feature.
+	ToolInspectCertificate = "inspect_certificate"
+
+	// ToolLintCertificate lints certificates offline against the CA/Browser Forum Baseline Requirements.
+	// Deterministic rules cover validity limits, SANs, key sizes, algorithms, serial entropy, EKU/KU and AIA/CDP, each with a citation.
+	ToolLintCertificate = "lint_certificate"
+
+	// ToolDiffCertChains compares two certificates or chains and reports per-field differences.
+	// Both inputs are resolved through AIA and aligned, so renewals and CA migrations show up as field changes.
+	ToolDiffCertChains = "diff_cert_chains"
+
+	// ToolScanCertificateInventory builds an inventory of the certificates stored under directories and Kubernetes secret manifests.
+	// Every certificate found by content sniffing is resolved, verified, and checked for expiry.
+	ToolScanCertificateInventory = "scan_certificate_inventory"
 )
 
 // Tool roles as constants for consistency and type safety.
@@ -80,6 +100,26 @@ const (
 	// RoleChainVisualizer provides certificate chain visualization capabilities.
 	// Supports multiple output formats for enhanced certificate analysis and debugging.
 	RoleChainVisualizer = "chainVisualizer"
+
+	// RoleCSRInspector inspects certificate signing requests before issuance.
+	// Flags requests a publicly-trusted CA would reject or clients would fail to validate.
+	RoleCSRInspector = "csrInspector"
+
+	// RoleCertificateInspector decodes every certificate field and extension.
+	// Provides openssl-style text and a stable JSON schema for detailed certificate review.
+	RoleCertificateInspector = "certificateInspector"
+
+	// RoleCertificateLinter checks certificates against deterministic compliance rules.
+	// Provides an offline, reproducible alternative to AI-assisted compliance analysis.
+	RoleCertificateLinter = "certificateLinter"
+
+	// RoleChainComparer compares certificate chains across renewals and deployments.
+	// Highlights what changes before a new chain is rolled out.
+	RoleChainComparer = "chainComparer"
+
+	// RoleInventoryScanner discovers certificates across file systems and deployment manifests.
+	// Provides a fleet-wide view of chain health and upcoming expirations.
+	RoleInventoryScanner = "inventoryScanner"
 )
 
 // createTools creates and returns all MCP tool definitions with their handlers.
@@ -96,9 +136,10 @@ const (
 //
 // Tool Categories:
 //   - Standard tools ([]ToolDefinition): resolve_cert_chain, validate_cert_chain, get_resource_usage,
-//     visualize_cert_chain
+//     visualize_cert_chain, inspect_csr, inspect_certificate,
+//     lint_certificate
 //   - Config-dependent tools ([]ToolDefinitionWithConfig): batch_resolve_cert_chain, check_cert_expiry, fetch_remote_cert,
-//     analyze_certificate_with_ai
+//     analyze_certificate_with_ai, diff_cert_chains, scan_certificate_inventory
 //
 // The function defines the following tools:
 //   - resolve_cert_chain: Resolve X509 certificate chain from a certificate file or base64-encoded certificate data
@@ -109,11 +150,17 @@ const (
 //   - analyze_certificate_with_ai: Analyze certificate data using AI collaboration (requires bidirectional communication)
 //   - get_resource_usage: Get current resource usage statistics including memory, GC, and CPU information
 //   - visualize_cert_chain: Visualize certificate chain in multiple formats (ASCII tree, table, JSON)
+//   - inspect_csr: Inspect a certificate signing request (CSR) and run pre-issuance checks for key size, signature algorithm, and Subject Alternative Names
+//   - inspect_certificate: Fully decode certificates like 'openssl x509 -text', rendering every extension (all SAN types, name constraints, policies, policy mappings, AIA, CDP, SCT list, TLS feature/must-staple, unknown OIDs as hex)
+//   - lint_certificate: Lint certificates offline against the CA/Browser Forum Baseline Requirements (validity period limits, SAN requirements, key sizes, forbidden algorithms, serial entropy, EKU/KU consistency, AIA/CDP presence); every finding has a severity and citation
+//   - diff_cert_chains: Compare two certificates or chains (file paths, base64-encoded data, or host:port addresses), resolve and align both, and report per-field differences such as a new issuer, a different intermediate, added or removed SANs, a key algorithm change, or a new validity period
+//   - scan_certificate_inventory: Scan directories and files for certificates by content (PEM, DER, and Kubernetes TLS secret manifests), resolve and verify each chain, and report an inventory of subject, issuer, expiry, key, and chain health as Markdown, CSV, or JSON
 //
 // Each tool definition includes:
 //   - MCP parameter specifications with type validation and constraints
 //   - Comprehensive descriptions for user interface display
 //   - MCP annotations for tool behavior hints (read-only, destructive, etc.)
+//   - Output schema describing the structured content of successful results
 //   - Proper handler function bindings for tool execution
 func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 	// Tools that don't need config
@@ -134,11 +181,17 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 
 				mcp.WithString(
 					"format",
-					mcp.Description("Output format: 'pem', 'der', or 'json' (default: pem)"),
-					mcp.Enum("pem", "der", "json"),
+					mcp.Description("Output format: 'pem', 'der', 'json', or a base64-encoded Java truststore ('jks' or 'pkcs12') (default: pem)"),
+					mcp.Enum("pem", "der", "json", "jks", "pkcs12"),
 					mcp.DefaultString("pem"),
 				),
 
+				mcp.WithString(
+					"store_password",
+					mcp.Description("Truststore password used with 'jks' or 'pkcs12' format (default: changeit)"),
+					mcp.DefaultString("changeit"),
+				),
+
 				mcp.WithBoolean(
 					"include_system_root",
 					mcp.Description("Include system root CA in output (default: false)"),
@@ -150,6 +203,8 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Output only intermediate certificates (default: false)"),
 					mcp.DefaultBool(false),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Resolved certificates, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\"],\"type\":\"object\"},\"type\":\"array\"},\"format\":{\"description\":\"Requested output format\",\"type\":\"string\"},\"output\":{\"description\":\"Chain encoded in the requested format; base64 for der, jks, and pkcs12\",\"type\":\"string\"},\"total\":{\"description\":\"Number of certificates returned\",\"type\":\"integer\"}},\"required\":[\"certificates\",\"total\",\"format\",\"output\"],\"type\":\"object\"}")),
 			),
 			Handler: handleResolveCertChain,
 			Role:    RoleChainResolver,
@@ -170,6 +225,14 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Include system root CA for validation (default: true)"),
 					mcp.DefaultBool(true),
 				),
+
+				mcp.WithBoolean(
+					"verbose",
+					mcp.Description("Append a name constraints and certificate policy evaluation report (default: false)"),
+					mcp.DefaultBool(false),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Verified certificates, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"role\":{\"description\":\"Role of the certificate in the chain\",\"enum\":[\"leaf\",\"intermediate\",\"root\",\"self-signed\"],\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\",\"role\"],\"type\":\"object\"},\"type\":\"array\"},\"constraints\":{\"description\":\"Name constraints and certificate policy evaluation, when verbose is set\",\"type\":\"object\"},\"revocationStatus\":{\"description\":\"OCSP and CRL revocation check report\",\"type\":\"string\"},\"total\":{\"description\":\"Number of certificates in the chain\",\"type\":\"integer\"},\"valid\":{\"description\":\"Whether the chain verified\",\"type\":\"boolean\"}},\"required\":[\"valid\",\"certificates\",\"total\",\"revocationStatus\"],\"type\":\"object\"}")),
 			),
 			Handler: handleValidateCertChain,
 			Role:    RoleChainValidator,
@@ -191,6 +254,8 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Enum("json", "markdown"),
 					mcp.DefaultString("json"),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"crl_cache\":{\"description\":\"CRL cache metrics, when detailed is set\",\"type\":\"object\"},\"detailed_memory\":{\"description\":\"Memory breakdown, when detailed is set\",\"type\":\"object\"},\"gc_stats\":{\"description\":\"Garbage collector statistics\",\"type\":\"object\"},\"memory_usage\":{\"description\":\"Heap and allocation statistics in MB\",\"type\":\"object\"},\"system_info\":{\"description\":\"Go runtime and host information\",\"type\":\"object\"},\"timestamp\":{\"description\":\"Collection time\",\"format\":\"date-time\",\"type\":\"string\"}},\"required\":[\"timestamp\",\"memory_usage\",\"gc_stats\",\"system_info\"],\"type\":\"object\"}")),
 			),
 			Handler: handleGetResourceUsage,
 			Role:    RoleResourceMonitor,
@@ -215,10 +280,83 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Enum("ascii", "table", "json"),
 					mcp.DefaultString("ascii"),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Chain certificates, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"role\":{\"description\":\"Role of the certificate in the chain\",\"enum\":[\"leaf\",\"intermediate\",\"root\",\"self-signed\"],\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\",\"role\"],\"type\":\"object\"},\"type\":\"array\"},\"chainLength\":{\"description\":\"Number of certificates in the chain\",\"type\":\"integer\"},\"format\":{\"description\":\"Visualization format\",\"enum\":[\"ascii\",\"table\",\"json\"],\"type\":\"string\"},\"visualization\":{\"description\":\"Rendered visualization\",\"type\":\"string\"}},\"required\":[\"format\",\"chainLength\",\"certificates\",\"visualization\"],\"type\":\"object\"}")),
 			),
 			Handler: handleVisualizeCertChain,
 			Role:    RoleChainVisualizer,
 		},
+		{
+			Tool: mcp.NewTool(
+				ToolInspectCSR,
+				mcp.WithDescription("Inspect a certificate signing request (CSR) and run pre-issuance checks for key size, signature algorithm, and Subject Alternative Names"),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithIdempotentHintAnnotation(true),
+
+				mcp.WithString(
+					"csr",
+					mcp.Required(),
+					mcp.Description("CSR file path or base64-encoded CSR data (PEM or DER)"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"dnsNames\":{\"description\":\"Requested DNS Subject Alternative Names\",\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"emailAddresses\":{\"description\":\"Requested email Subject Alternative Names\",\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"findings\":{\"description\":\"Key-size, signature algorithm, and SAN findings\",\"items\":{\"properties\":{\"check\":{\"description\":\"Identifier of the check that produced the finding\",\"type\":\"string\"},\"citation\":{\"description\":\"Requirement the finding relates to\",\"type\":\"string\"},\"message\":{\"description\":\"Human-readable explanation\",\"type\":\"string\"},\"severity\":{\"description\":\"Finding severity\",\"enum\":[\"error\",\"warning\",\"info\"],\"type\":\"string\"}},\"required\":[\"check\",\"severity\",\"message\"],\"type\":\"object\"},\"type\":\"array\"},\"ipAddresses\":{\"description\":\"Requested IP address Subject Alternative Names\",\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"keyBits\":{\"description\":\"Public key size in bits\",\"type\":\"integer\"},\"passed\":{\"description\":\"True when no finding has error severity\",\"type\":\"boolean\"},\"publicKeyAlgorithm\":{\"description\":\"Public key algorithm\",\"type\":\"string\"},\"securityBits\":{\"description\":\"Equivalent security strength in bits\",\"type\":\"integer\"},\"signatureAlgorithm\":{\"description\":\"Algorithm used for the request self-signature\",\"type\":\"string\"},\"signatureValid\":{\"description\":\"Whether the request self-signature verifies\",\"type\":\"boolean\"},\"subject\":{\"description\":\"Full subject distinguished name\",\"type\":\"string\"},\"uris\":{\"description\":\"Requested URI Subject Alternative Names\",\"items\":{\"type\":\"string\"},\"type\":\"array\"}},\"required\":[\"subject\",\"publicKeyAlgorithm\",\"keyBits\",\"securityBits\",\"signatureAlgorithm\",\"signatureValid\",\"passed\",\"findings\"],\"type\":\"object\"}")),
+			),
+			Handler: handleInspectCSR,
+			Role:    RoleCSRInspector,
+		},
+		{
+			Tool: mcp.NewTool(
+				ToolInspectCertificate,
+				mcp.WithDescription("Fully decode certificates like 'openssl x509 -text', rendering every extension (all SAN types, name constraints, policies, policy mappings, AIA, CDP, SCT list, TLS feature/must-staple, unknown OIDs as hex)"),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithIdempotentHintAnnotation(true),
+
+				mcp.WithString(
+					"certificate",
+					mcp.Required(),
+					mcp.Description("Certificate file path or base64-encoded certificate data (PEM bundles decode every certificate)"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithString(
+					"format",
+					mcp.Description("Output format: 'text' (openssl-style) or 'json' (stable schema, also returned as structured content) (default: text)"),
+					mcp.Enum("text", "json"),
+					mcp.DefaultString("text"),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Full decode of every input certificate, in input order\",\"items\":{\"properties\":{\"extensions\":{\"description\":\"Every extension in certificate order\",\"items\":{\"type\":\"object\"},\"type\":\"array\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"publicKey\":{\"description\":\"Subject public key information\",\"type\":\"object\"},\"serialNumber\":{\"description\":\"Serial number as colon-separated hex\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"signature\":{\"description\":\"Issuer signature as colon-separated hex\",\"type\":\"string\"},\"signatureAlgorithm\":{\"description\":\"Algorithm the issuer used to sign the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"},\"version\":{\"description\":\"X.509 version\",\"type\":\"integer\"}},\"required\":[\"version\",\"serialNumber\",\"signatureAlgorithm\",\"issuer\",\"subject\",\"notBefore\",\"notAfter\",\"publicKey\",\"extensions\",\"signature\",\"sha256Fingerprint\"],\"type\":\"object\"},\"type\":\"array\"}},\"required\":[\"certificates\"],\"type\":\"object\"}")),
+			),
+			Handler: handleInspectCertificate,
+			Role:    RoleCertificateInspector,
+		},
+		{
+			Tool: mcp.NewTool(
+				ToolLintCertificate,
+				mcp.WithDescription("Lint certificates offline against the CA/Browser Forum Baseline Requirements (validity period limits, SAN requirements, key sizes, forbidden algorithms, serial entropy, EKU/KU consistency, AIA/CDP presence); every finding has a severity and citation"),
+				mcp.WithReadOnlyHintAnnotation(true),
+				mcp.WithIdempotentHintAnnotation(true),
+
+				mcp.WithString(
+					"certificate",
+					mcp.Required(),
+					mcp.Description("Certificate file path or base64-encoded certificate data (PEM bundles lint every certificate)"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithString(
+					"format",
+					mcp.Description("Output format: 'text' or 'json' (also returned as structured content) (default: text)"),
+					mcp.Enum("text", "json"),
+					mcp.DefaultString("text"),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Lint report for every input certificate, in input order\",\"items\":{\"properties\":{\"findings\":{\"description\":\"Rule violations with citations\",\"items\":{\"properties\":{\"check\":{\"description\":\"Identifier of the check that produced the finding\",\"type\":\"string\"},\"citation\":{\"description\":\"Requirement the finding relates to\",\"type\":\"string\"},\"message\":{\"description\":\"Human-readable explanation\",\"type\":\"string\"},\"severity\":{\"description\":\"Finding severity\",\"enum\":[\"error\",\"warning\",\"info\"],\"type\":\"string\"}},\"required\":[\"check\",\"severity\",\"message\"],\"type\":\"object\"},\"type\":\"array\"},\"passed\":{\"description\":\"True when there are no error-severity findings\",\"type\":\"boolean\"},\"rulesEvaluated\":{\"description\":\"Number of rules that applied to the certificate\",\"type\":\"integer\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"subject\":{\"description\":\"Certificate subject distinguished name\",\"type\":\"string\"},\"type\":{\"description\":\"Certificate type used to select rules\",\"enum\":[\"subscriber\",\"intermediate\",\"root\"],\"type\":\"string\"}},\"required\":[\"subject\",\"serialNumber\",\"type\",\"rulesEvaluated\",\"findings\",\"passed\"],\"type\":\"object\"},\"type\":\"array\"}},\"required\":[\"certificates\"],\"type\":\"object\"}")),
+			),
+			Handler: handleLintCertificate,
+			Role:    RoleCertificateLinter,
+		},
 	}
 
 	// Tools that need config
@@ -251,6 +389,8 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Output only intermediate certificates (default: false)"),
 					mcp.DefaultBool(false),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"processed\":{\"description\":\"Number of certificate inputs processed\",\"type\":\"integer\"},\"results\":{\"description\":\"Per-input results in input order\",\"items\":{\"properties\":{\"certificates\":{\"description\":\"Resolved certificates, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\"],\"type\":\"object\"},\"type\":\"array\"},\"error\":{\"description\":\"Reason the input could not be resolved\",\"type\":\"string\"},\"format\":{\"description\":\"Requested output format\",\"type\":\"string\"},\"index\":{\"description\":\"Position of the input in the batch, starting at 1\",\"minimum\":1,\"type\":\"integer\"},\"output\":{\"description\":\"Chain encoded in the requested format; base64 for der\",\"type\":\"string\"},\"warning\":{\"description\":\"Non-fatal problem, such as a failure to add the root CA\",\"type\":\"string\"}},\"required\":[\"index\"],\"type\":\"object\"},\"type\":\"array\"}},\"required\":[\"processed\",\"results\"],\"type\":\"object\"}")),
 			),
 			Handler: handleBatchResolveCertChain,
 			Role:    RoleBatchResolver,
@@ -268,6 +408,8 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Certificate file path or base64-encoded certificate data"),
 					mcp.MinLength(1),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"allValid\":{\"description\":\"True when no certificate is expired or expiring soon\",\"type\":\"boolean\"},\"certificates\":{\"description\":\"Checked certificates in input order\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"daysRemaining\":{\"description\":\"Whole days until expiry, negative once expired\",\"type\":\"integer\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"status\":{\"description\":\"Expiry status\",\"enum\":[\"expired\",\"expiring_soon\",\"valid\"],\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\",\"status\",\"daysRemaining\"],\"type\":\"object\"},\"type\":\"array\"},\"summary\":{\"description\":\"Certificate counts per status\",\"properties\":{\"expired\":{\"type\":\"integer\"},\"expiringSoon\":{\"type\":\"integer\"},\"total\":{\"type\":\"integer\"},\"valid\":{\"type\":\"integer\"}},\"required\":[\"total\",\"expired\",\"expiringSoon\",\"valid\"],\"type\":\"object\"},\"warnDays\":{\"description\":\"Warning window in days\",\"type\":\"integer\"}},\"required\":[\"warnDays\",\"certificates\",\"summary\",\"allValid\"],\"type\":\"object\"}")),
 			),
 			Handler: handleCheckCertExpiry,
 			Role:    RoleExpiryChecker,
@@ -292,10 +434,17 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 
 				mcp.WithString(
 					"format",
-					mcp.Description("Output format: 'pem', 'der', or 'json' (default: pem)"),
+					mcp.Description("Output format: 'pem', 'der', 'json', or a base64-encoded Java truststore ('jks' or 'pkcs12') (default: pem)"),
+					mcp.Enum("pem", "der", "json", "jks", "pkcs12"),
 					mcp.DefaultString("pem"),
 				),
 
+				mcp.WithString(
+					"store_password",
+					mcp.Description("Truststore password used with 'jks' or 'pkcs12' format (default: changeit)"),
+					mcp.DefaultString("changeit"),
+				),
+
 				mcp.WithBoolean(
 					"include_system_root",
 					mcp.Description("Include system root CA in output (default: false)"),
@@ -307,6 +456,8 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Output only intermediate certificates (default: false)"),
 					mcp.DefaultBool(false),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"aliases\":{\"description\":\"Truststore entry aliases, for jks and pkcs12\",\"items\":{\"type\":\"string\"},\"type\":\"array\"},\"certificates\":{\"description\":\"Certificates after filtering, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\"],\"type\":\"object\"},\"type\":\"array\"},\"certificatesReceived\":{\"description\":\"Number of certificates presented by the server\",\"type\":\"integer\"},\"format\":{\"description\":\"Requested output format\",\"type\":\"string\"},\"host\":{\"description\":\"Host that was connected to\",\"type\":\"string\"},\"output\":{\"description\":\"Chain encoded in the requested format; base64 for der, jks, and pkcs12\",\"type\":\"string\"},\"port\":{\"description\":\"Port that was connected to\",\"type\":\"integer\"},\"total\":{\"description\":\"Number of certificates after filtering\",\"type\":\"integer\"}},\"required\":[\"host\",\"port\",\"certificatesReceived\",\"certificates\",\"total\",\"format\",\"output\"],\"type\":\"object\"}")),
 			),
 			Handler: handleFetchRemoteCert,
 			Role:    RoleRemoteFetcher,
@@ -328,10 +479,75 @@ func createTools() ([]ToolDefinition, []ToolDefinitionWithConfig) {
 					mcp.Description("Type of analysis (required): 'security', 'compliance', 'general'"),
 					mcp.Enum("general", "security", "compliance"),
 				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"aiGenerated\":{\"description\":\"False when no AI API key is configured and the analysis holds the prepared context instead\",\"type\":\"boolean\"},\"analysis\":{\"description\":\"AI analysis, or the prepared analysis context\",\"type\":\"string\"},\"analysisType\":{\"description\":\"Requested type of analysis\",\"type\":\"string\"},\"certificates\":{\"description\":\"Analyzed certificates, leaf first\",\"items\":{\"properties\":{\"commonName\":{\"description\":\"Subject common name\",\"type\":\"string\"},\"isCA\":{\"description\":\"Whether the certificate is a CA\",\"type\":\"boolean\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"notAfter\":{\"description\":\"End of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"notBefore\":{\"description\":\"Start of the validity period\",\"format\":\"date-time\",\"type\":\"string\"},\"role\":{\"description\":\"Role of the certificate in the chain\",\"enum\":[\"leaf\",\"intermediate\",\"root\",\"self-signed\"],\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in decimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"subject\",\"commonName\",\"issuer\",\"serialNumber\",\"notBefore\",\"notAfter\",\"isCA\",\"sha256Fingerprint\",\"role\"],\"type\":\"object\"},\"type\":\"array\"},\"model\":{\"description\":\"Configured AI model, when AI analysis ran\",\"type\":\"string\"},\"revocationStatus\":{\"description\":\"OCSP and CRL revocation check report\",\"type\":\"string\"}},\"required\":[\"analysisType\",\"aiGenerated\",\"certificates\",\"revocationStatus\",\"analysis\"],\"type\":\"object\"}")),
 			),
 			Handler: handleAnalyzeCertificateWithAI,
 			Role:    RoleAIAnalyzer,
 		},
+		{
+			Tool: mcp.NewTool(
+				ToolDiffCertChains,
+				mcp.WithDescription("Compare two certificates or chains (file paths, base64-encoded data, or host:port addresses), resolve and align both, and report per-field differences such as a new issuer, a different intermediate, added or removed SANs, a key algorithm change, or a new validity period"),
+				mcp.WithReadOnlyHintAnnotation(true),
+
+				mcp.WithString(
+					"old_certificate",
+					mcp.Required(),
+					mcp.Description("Old certificate or chain: file path, base64-encoded certificate data, or host:port address"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithString(
+					"new_certificate",
+					mcp.Required(),
+					mcp.Description("New certificate or chain: file path, base64-encoded certificate data, or host:port address"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithString(
+					"format",
+					mcp.Description("Output format: 'text' or 'json' (also returned as structured content) (default: text)"),
+					mcp.Enum("text", "json"),
+					mcp.DefaultString("text"),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"certificates\":{\"description\":\"Aligned comparisons, in old chain order followed by added certificates\",\"items\":{\"properties\":{\"changes\":{\"description\":\"Field differences of aligned certificates\",\"items\":{\"properties\":{\"field\":{\"description\":\"Field or extension name\",\"type\":\"string\"},\"new\":{\"description\":\"Value in the new certificate\",\"type\":\"string\"},\"old\":{\"description\":\"Value in the old certificate\",\"type\":\"string\"}},\"required\":[\"field\"],\"type\":\"object\"},\"type\":\"array\"},\"newIndex\":{\"description\":\"Position in the new chain, or -1 when removed\",\"type\":\"integer\"},\"newSubject\":{\"description\":\"Subject in the new chain\",\"type\":\"string\"},\"oldIndex\":{\"description\":\"Position in the old chain, or -1 when added\",\"type\":\"integer\"},\"oldSubject\":{\"description\":\"Subject in the old chain\",\"type\":\"string\"},\"role\":{\"description\":\"Role of the certificate in its chain\",\"type\":\"string\"},\"status\":{\"description\":\"Comparison result\",\"enum\":[\"unchanged\",\"changed\",\"added\",\"removed\"],\"type\":\"string\"}},\"required\":[\"status\",\"role\",\"oldIndex\",\"newIndex\",\"changes\"],\"type\":\"object\"},\"type\":\"array\"},\"identical\":{\"description\":\"True when every certificate is unchanged\",\"type\":\"boolean\"},\"newLength\":{\"description\":\"Number of certificates in the new chain\",\"type\":\"integer\"},\"oldLength\":{\"description\":\"Number of certificates in the old chain\",\"type\":\"integer\"}},\"required\":[\"oldLength\",\"newLength\",\"certificates\",\"identical\"],\"type\":\"object\"}")),
+			),
+			Handler: handleDiffCertChains,
+			Role:    RoleChainComparer,
+		},
+		{
+			Tool: mcp.NewTool(
+				ToolScanCertificateInventory,
+				mcp.WithDescription("Scan directories and files for certificates by content (PEM, DER, and Kubernetes TLS secret manifests), resolve and verify each chain, and report an inventory of subject, issuer, expiry, key, and chain health as Markdown, CSV, or JSON"),
+				mcp.WithReadOnlyHintAnnotation(true),
+
+				mcp.WithString(
+					"paths",
+					mcp.Required(),
+					mcp.Description("Comma-separated list of directories and files to scan"),
+					mcp.MinLength(1),
+				),
+
+				mcp.WithString(
+					"format",
+					mcp.Description("Output format: 'markdown', 'csv', or 'json' (also returned as structured content) (default: markdown)"),
+					mcp.Enum("markdown", "csv", "json"),
+					mcp.DefaultString("markdown"),
+				),
+
+				mcp.WithNumber(
+					"warn_days",
+					mcp.Description("Report chains expiring within this many days (default: server warnDays setting)"),
+					mcp.Min(1),
+				),
+
+				mcp.WithRawOutputSchema([]byte("{\"properties\":{\"entries\":{\"description\":\"One entry per certificate found, in path order\",\"items\":{\"properties\":{\"chainLength\":{\"description\":\"Number of certificates in the resolved chain\",\"type\":\"integer\"},\"daysRemaining\":{\"description\":\"Whole days until expiry, negative once expired\",\"type\":\"integer\"},\"detail\":{\"description\":\"Reason for any health state other than ok\",\"type\":\"string\"},\"health\":{\"description\":\"Worst chain health state\",\"type\":\"string\"},\"issuer\":{\"description\":\"Issuer distinguished name\",\"type\":\"string\"},\"key\":{\"description\":\"Public key description\",\"type\":\"string\"},\"notAfter\":{\"description\":\"Expiry of the certificate\",\"format\":\"date-time\",\"type\":\"string\"},\"path\":{\"description\":\"File the certificate was found in\",\"type\":\"string\"},\"secret\":{\"description\":\"Kubernetes secret as namespace/name\",\"type\":\"string\"},\"serialNumber\":{\"description\":\"Serial number in hexadecimal\",\"type\":\"string\"},\"sha256Fingerprint\":{\"description\":\"SHA-256 fingerprint of the certificate\",\"type\":\"string\"},\"source\":{\"description\":\"Where in the file the certificate was found\",\"type\":\"string\"},\"subject\":{\"description\":\"Subject distinguished name\",\"type\":\"string\"}},\"required\":[\"path\",\"source\",\"subject\",\"issuer\",\"serialNumber\",\"notAfter\",\"daysRemaining\",\"key\",\"sha256Fingerprint\",\"chainLength\",\"health\"],\"type\":\"object\"},\"type\":\"array\"},\"errors\":{\"description\":\"Files that could not be read or decoded\",\"items\":{\"properties\":{\"error\":{\"type\":\"string\"},\"path\":{\"type\":\"string\"}},\"required\":[\"path\",\"error\"],\"type\":\"object\"},\"type\":\"array\"},\"filesScanned\":{\"description\":\"Number of files read\",\"type\":\"integer\"},\"filesSkipped\":{\"description\":\"Number of files over the size limit\",\"type\":\"integer\"},\"summary\":{\"description\":\"Entry counts per health state\",\"properties\":{\"expired\":{\"type\":\"integer\"},\"expiring\":{\"type\":\"integer\"},\"incomplete\":{\"type\":\"integer\"},\"invalid\":{\"type\":\"integer\"},\"ok\":{\"type\":\"integer\"},\"total\":{\"type\":\"integer\"},\"worst\":{\"description\":\"Worst health state counted\",\"type\":\"string\"}},\"required\":[\"total\",\"ok\",\"expiring\",\"incomplete\",\"invalid\",\"expired\"],\"type\":\"object\"}},\"required\":[\"filesScanned\",\"filesSkipped\",\"entries\",\"errors\",\"summary\"],\"type\":\"object\"}")),
+			),
+			Handler: handleScanCertificateInventory,
+			Role:    RoleInventoryScanner,
+		},
 	}
 
 	return tools, toolsWithConfig
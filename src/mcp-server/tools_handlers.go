@@ -10,56 +10,139 @@ import (
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
 	"net/http"
-	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
 	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+	x509inventory "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/inventory"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/mcp-server/templates"
 	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/version"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// readCertificateData reads certificate data from either a file path or base64-encoded string.
-// It first attempts to read as a file, then falls back to base64 decoding.
+// readCertificateData reads certificate data from a PEM literal, a file path, or a base64-encoded string.
+// PEM data is used as given; other input is first read as a file within the file sandbox of the
+// server handling the request, then decoded as base64.
 //
 // Parameters:
-//   - input: Certificate data as either a file path or base64-encoded string
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
+//   - input: Certificate data as PEM, a file path, or a base64-encoded string
 //
 // Returns:
 //   - []byte: The certificate data bytes
-//   - error: Error if both file reading and base64 decoding fail
+//   - error: Sandbox error for a file that may not be read, or an error if the input is none of the above
 //
-// The function provides flexible input handling for certificate data in multiple formats.
-// It prioritizes file reading for security and falls back to base64 for programmatic use.
-func readCertificateData(input string) ([]byte, error) {
+// The error never repeats the input, which may hold secrets or large encoded data.
+func readCertificateData(ctx context.Context, input string) ([]byte, error) {
+	if isPEMLiteral(input) {
+		return []byte(input), nil
+	}
+
 	// Try to read as file first
-	if fileData, err := os.ReadFile(input); err == nil {
+	fileData, err := sandboxFromContext(ctx).readFile(ctx, input)
+	if err == nil {
 		return fileData, nil
 	}
+	// Inputs outside the roots were never looked up, so they may still be data
+	if isSandboxError(err) && !errors.Is(err, errOutsideRoots) {
+		return nil, err
+	}
 
 	// Try to decode as base64
-	if decoded, err := base64.StdEncoding.DecodeString(input); err == nil {
+	if decoded, decodeErr := base64.StdEncoding.DecodeString(input); decodeErr == nil {
 		return decoded, nil
 	}
+	if errors.Is(err, errOutsideRoots) {
+		return nil, err
+	}
+
+	return nil, errors.New("certificate input is not PEM data, base64-encoded data, or a file path within the allowed roots")
+}
 
-	return nil, fmt.Errorf("certificate input '%s' is not a valid file path or base64-encoded data", input)
+// isPEMLiteral reports whether input holds PEM data rather than naming it.
+func isPEMLiteral(input string) bool {
+	return strings.Contains(input, "-----BEGIN ")
+}
+
+// resolveSandboxedInput builds a chain from a certificate file, PEM data,
+// base64-encoded data, or a host:port address, and completes it through AIA,
+// like [x509chain.ResolveInput] but reading files only within the file
+// sandbox.
+//
+// Parameters:
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
+//   - input: File path, PEM data, base64 data, or host:port address
+//   - timeout: Timeout for the TLS handshake and each AIA download
+//
+// Returns:
+//   - *x509chain.Chain: Resolved chain, leaf first
+//   - error: Sandbox error for a file that may not be read, or a decoding, connection, or download error
+func resolveSandboxedInput(ctx context.Context, input string, timeout time.Duration) (*x509chain.Chain, error) {
+	var data []byte
+	if isPEMLiteral(input) {
+		data = []byte(input)
+	} else if fileData, err := sandboxFromContext(ctx).readFile(ctx, input); err == nil {
+		data = fileData
+	} else if isSandboxError(err) && !errors.Is(err, errOutsideRoots) {
+		return nil, err
+	} else if host, port, ok := splitHostPort(input); ok {
+		remote, _, err := x509chain.FetchRemoteChain(ctx, host, port, timeout, version.Version)
+		if err != nil {
+			return nil, err
+		}
+		return x509chain.ResolveCertificates(ctx, remote.Certs, timeout, version.Version)
+	} else if decoded, decodeErr := base64.StdEncoding.DecodeString(input); decodeErr == nil {
+		data = decoded
+	} else if errors.Is(err, errOutsideRoots) {
+		return nil, err
+	} else {
+		return nil, errors.New("input is not PEM data, base64-encoded data, a file path within the allowed roots, or a host:port address")
+	}
+
+	certs, err := x509certs.New().DecodeMultiple(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, errors.New("failed to decode certificate: no certificates found")
+	}
+	return x509chain.ResolveCertificates(ctx, certs, timeout, version.Version)
+}
+
+// splitHostPort reports whether input is a host:port address with a numeric port.
+func splitHostPort(input string) (host string, port int, ok bool) {
+	host, portStr, err := net.SplitHostPort(input)
+	if err != nil || host == "" {
+		return "", 0, false
+	}
+	port, err = strconv.Atoi(portStr)
+	if err != nil {
+		return "", 0, false
+	}
+	return host, port, true
 }
 
 // resolveChainOptions contains configuration options for certificate chain resolution.
 // It groups related parameters to reduce function complexity and improve maintainability.
 //
 // Fields:
-//   - format: Output format ("pem", "der", or "json")
+//   - format: Output format ("pem", "der", "json", "jks", or "pkcs12")
+//   - storePassword: Truststore password for the "jks" and "pkcs12" formats
 //   - includeSystemRoot: Whether to include system root CA in the chain
 //   - intermediateOnly: Whether to return only intermediate certificates
 type resolveChainOptions struct {
-	// format: Output format ("pem", "der", or "json")
+	// format: Output format ("pem", "der", "json", "jks", or "pkcs12")
 	format string
+	// storePassword: Truststore password for the "jks" and "pkcs12" formats
+	storePassword string
 	// includeSystemRoot: Whether to include system root CA in the chain
 	includeSystemRoot bool
 	// intermediateOnly: Whether to return only intermediate certificates
@@ -84,6 +167,7 @@ func validateResolveParams(request mcp.CallToolRequest) (certInput string, opts
 
 	opts = resolveChainOptions{
 		format:            request.GetString("format", "pem"),
+		storePassword:     request.GetString("store_password", x509certs.DefaultTrustStorePassword),
 		includeSystemRoot: request.GetBool("include_system_root", false),
 		intermediateOnly:  request.GetBool("intermediate_only", false),
 	}
@@ -104,7 +188,7 @@ func validateResolveParams(request mcp.CallToolRequest) (certInput string, opts
 //   - error: Chain resolution error
 func resolveCertChain(ctx context.Context, certInput string, opts resolveChainOptions) ([]*x509.Certificate, error) {
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read certificate: %w", err)
 	}
@@ -128,6 +212,7 @@ func resolveCertChain(ctx context.Context, certInput string, opts resolveChainOp
 			return nil, fmt.Errorf("failed to add root CA: %w", err)
 		}
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	// Filter certificates if needed
 	certs := chain.Certs
@@ -139,26 +224,61 @@ func resolveCertChain(ctx context.Context, certInput string, opts resolveChainOp
 }
 
 // formatChainOutput formats the resolved certificate chain according to the specified format.
-// It handles PEM, DER, and JSON output formats with appropriate encoding.
+// It handles PEM, DER, JSON, and truststore (JKS, PKCS#12) output formats with appropriate encoding.
 //
 // Parameters:
 //   - certs: Certificate chain to format
-//   - format: Output format ("pem", "der", or "json")
+//   - opts: Resolution options carrying the output format and truststore password
 //   - certManager: Certificate manager for encoding operations
 //
 // Returns:
 //   - output: Formatted certificate data as string
-func formatChainOutput(certs []*x509.Certificate, format string, certManager *x509certs.Certificate) string {
-	switch format {
+//   - error: Truststore encoding error
+func formatChainOutput(certs []*x509.Certificate, opts resolveChainOptions, certManager *x509certs.Certificate) (string, error) {
+	switch opts.format {
 	case "der":
 		derData := certManager.EncodeMultipleDER(certs)
-		return base64.StdEncoding.EncodeToString(derData)
+		return base64.StdEncoding.EncodeToString(derData), nil
 	case "json":
-		return formatJSON(certs, certManager)
+		return formatJSON(certs, certManager), nil
+	case x509certs.TrustStoreJKS, x509certs.TrustStorePKCS12:
+		return encodeTrustStoreOutput(certs, opts.format, opts.storePassword, certManager)
 	default: // pem
 		pemData := certManager.EncodeMultiplePEM(certs)
-		return string(pemData)
+		return string(pemData), nil
+	}
+}
+
+// encodeTrustStoreOutput encodes certificates as a base64 Java truststore.
+// Each entry uses a stable alias derived from the subject CN and SHA-256 fingerprint.
+//
+// Parameters:
+//   - certs: Certificates to store as trusted entries
+//   - format: Truststore format ("jks" or "pkcs12")
+//   - password: Truststore password
+//   - certManager: Certificate manager for encoding operations
+//
+// Returns:
+//   - output: Base64-encoded truststore
+//   - error: Truststore encoding error
+func encodeTrustStoreOutput(certs []*x509.Certificate, format, password string, certManager *x509certs.Certificate) (string, error) {
+	data, err := certManager.EncodeTrustStore(format, certs, password)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode %s truststore: %w", format, err)
 	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// resolveChainResult is the structured content returned by the resolve_cert_chain tool.
+type resolveChainResult struct {
+	// Certificates: Resolved certificates, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+	// Total: Number of certificates returned
+	Total int `json:"total"`
+	// Format: Requested output format
+	Format string `json:"format"`
+	// Output: Chain encoded in the requested format
+	Output string `json:"output"`
 }
 
 // buildResolveResult creates the final formatted result for certificate chain resolution.
@@ -190,10 +310,10 @@ func buildResolveResult(certs []*x509.Certificate, output string) string {
 //   - request: MCP tool call request containing certificate input and format options
 //
 // Returns:
-//   - The tool execution result containing the resolved certificate chain
+//   - The tool execution result containing the resolved certificate chain as text and structured content
 //   - An error if certificate resolution or processing fails
 //
-// The function supports multiple input formats (file path or base64) and output formats (PEM, DER, JSON).
+// The function supports multiple input formats (file path or base64) and output formats (PEM, DER, JSON, JKS, PKCS#12).
 // It uses the x509chain package to fetch additional certificates from AIA URLs.
 func handleResolveCertChain(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Validate and extract parameters
@@ -210,11 +330,20 @@ func handleResolveCertChain(ctx context.Context, request mcp.CallToolRequest) (*
 
 	// Format output
 	certManager := x509certs.New()
-	output := formatChainOutput(certs, opts.format, certManager)
+	output, err := formatChainOutput(certs, opts, certManager)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
 
 	// Build and return result
 	result := buildResolveResult(certs, output)
-	return mcp.NewToolResultText(result), nil
+	structured := resolveChainResult{
+		Certificates: summarizeCertificates(certs),
+		Total:        len(certs),
+		Format:       opts.format,
+		Output:       output,
+	}
+	return mcp.NewToolResultStructured(structured, result), nil
 }
 
 // validateValidateParams validates and extracts parameters for certificate chain validation.
@@ -226,15 +355,17 @@ func handleResolveCertChain(ctx context.Context, request mcp.CallToolRequest) (*
 // Returns:
 //   - certInput: Certificate input as file path or base64 data
 //   - includeSystemRoot: Whether to include system root CA for validation
+//   - verbose: Whether to append the name and policy constraints report
 //   - error: Parameter validation error
-func validateValidateParams(request mcp.CallToolRequest) (certInput string, includeSystemRoot bool, err error) {
+func validateValidateParams(request mcp.CallToolRequest) (certInput string, includeSystemRoot, verbose bool, err error) {
 	certInput, err = request.RequireString("certificate")
 	if err != nil {
-		return "", false, fmt.Errorf("certificate parameter required: %w", err)
+		return "", false, false, fmt.Errorf("certificate parameter required: %w", err)
 	}
 
 	includeSystemRoot = request.GetBool("include_system_root", true)
-	return certInput, includeSystemRoot, nil
+	verbose = request.GetBool("verbose", false)
+	return certInput, includeSystemRoot, verbose, nil
 }
 
 // validateCertChain performs comprehensive certificate chain validation.
@@ -246,12 +377,12 @@ func validateValidateParams(request mcp.CallToolRequest) (certInput string, incl
 //   - includeSystemRoot: Whether to include system root CA for validation
 //
 // Returns:
-//   - chain: Validated certificate chain
+//   - chain: Validated certificate chain, or the certificates resolved so far when fetching or verification fails
 //   - revocationStatus: Revocation check results
 //   - error: Validation error
 func validateCertChain(ctx context.Context, certInput string, includeSystemRoot bool) (*x509chain.Chain, string, error) {
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
 		return nil, "", fmt.Errorf("failed to read certificate: %w", err)
 	}
@@ -266,7 +397,7 @@ func validateCertChain(ctx context.Context, certInput string, includeSystemRoot
 	// Create chain and fetch certificates
 	chain := x509chain.New(cert, version.Version)
 	if err := chain.FetchCertificate(ctx); err != nil {
-		return nil, "", fmt.Errorf("failed to fetch certificate chain: %w", err)
+		return chain, "", fmt.Errorf("failed to fetch certificate chain: %w", err)
 	}
 
 	// Add system root if requested
@@ -275,10 +406,11 @@ func validateCertChain(ctx context.Context, certInput string, includeSystemRoot
 			return nil, "", fmt.Errorf("failed to add root CA: %w", err)
 		}
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	// Validate the chain
 	if err := chain.VerifyChain(); err != nil {
-		return nil, "", fmt.Errorf("certificate chain validation failed: %w", err)
+		return chain, "", fmt.Errorf("certificate chain validation failed: %w", err)
 	}
 
 	// Check revocation status
@@ -291,16 +423,31 @@ func validateCertChain(ctx context.Context, certInput string, includeSystemRoot
 	return chain, revocationStatus, nil
 }
 
+// validateChainResult is the structured content returned by the validate_cert_chain tool.
+type validateChainResult struct {
+	// Valid: Whether the chain verified; failures are reported as tool errors
+	Valid bool `json:"valid"`
+	// Certificates: Verified certificates with their roles, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+	// Total: Number of certificates in the chain
+	Total int `json:"total"`
+	// RevocationStatus: OCSP and CRL revocation check report
+	RevocationStatus string `json:"revocationStatus"`
+	// Constraints: Name constraints and policy evaluation, when verbose is set
+	Constraints *x509chain.ConstraintsReport `json:"constraints,omitempty"`
+}
+
 // buildValidationResult creates the formatted result for certificate chain validation.
 // It includes chain details, certificate information, and validation status.
 //
 // Parameters:
 //   - chain: Validated certificate chain
 //   - revocationStatus: Revocation check results
+//   - verbose: Whether to append the name and policy constraints report
 //
 // Returns:
 //   - result: Formatted validation result string
-func buildValidationResult(chain *x509chain.Chain, revocationStatus string) string {
+func buildValidationResult(chain *x509chain.Chain, revocationStatus string, verbose bool) string {
 	var result strings.Builder
 	result.WriteString("Certificate chain validation successful!\n\n")
 	result.WriteString("Chain Details:\n")
@@ -319,6 +466,11 @@ func buildValidationResult(chain *x509chain.Chain, revocationStatus string) stri
 	result.WriteString("Validation: PASSED ✓\n\n")
 	result.WriteString(revocationStatus)
 
+	if verbose {
+		result.WriteString("\n\nConstraints Evaluation:\n")
+		result.WriteString(chain.EvaluateConstraints().RenderText())
+	}
+
 	return result.String()
 }
 
@@ -330,15 +482,16 @@ func buildValidationResult(chain *x509chain.Chain, revocationStatus string) stri
 //   - request: MCP tool call request containing certificate input and validation options
 //
 // Returns:
-//   - The tool execution result containing validation status and certificate details
+//   - The tool execution result containing validation status and certificate details as text and structured content
 //   - An error if certificate processing or validation fails
 //
 // The function performs comprehensive validation including chain integrity, trust verification,
 // and revocation status checking using OCSP/CRL. It provides detailed feedback on certificate roles
-// and validation outcomes.
+// and validation outcomes. With verbose set, it also reports the name constraints and certificate
+// policy processing of the chain, including when verification fails.
 func handleValidateCertChain(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	// Validate and extract parameters
-	certInput, includeSystemRoot, err := validateValidateParams(request)
+	certInput, includeSystemRoot, verbose, err := validateValidateParams(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
@@ -346,12 +499,25 @@ func handleValidateCertChain(ctx context.Context, request mcp.CallToolRequest) (
 	// Validate certificate chain
 	chain, revocationStatus, err := validateCertChain(ctx, certInput, includeSystemRoot)
 	if err != nil {
+		// Constraint violations are a common cause of failure, so show them when asked
+		if verbose && chain != nil && len(chain.Certs) > 0 {
+			return mcp.NewToolResultError(err.Error() + "\n\nConstraints Evaluation:\n" + chain.EvaluateConstraints().RenderText()), nil
+		}
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Build and return result
-	result := buildValidationResult(chain, revocationStatus)
-	return mcp.NewToolResultText(result), nil
+	result := buildValidationResult(chain, revocationStatus, verbose)
+	structured := validateChainResult{
+		Valid:            true,
+		Certificates:     summarizeChain(chain),
+		Total:            len(chain.Certs),
+		RevocationStatus: revocationStatus,
+	}
+	if verbose {
+		structured.Constraints = chain.EvaluateConstraints()
+	}
+	return mcp.NewToolResultStructured(structured, result), nil
 }
 
 // batchResolveOptions contains configuration options for batch certificate resolution operations.
@@ -370,7 +536,33 @@ type batchResolveOptions struct {
 	intermediateOnly bool
 }
 
-// processSingleCertificate processes a single certificate input and returns formatted result.
+// batchCertificateResult is the outcome of resolving one input of a batch.
+type batchCertificateResult struct {
+	// Index: Position of the input in the batch, starting at 1
+	Index int `json:"index"`
+	// Certificates: Resolved certificates, leaf first
+	Certificates []certificateSummary `json:"certificates,omitempty"`
+	// Format: Requested output format
+	Format string `json:"format,omitempty"`
+	// Output: Chain encoded in the requested format
+	Output string `json:"output,omitempty"`
+	// Warning: Non-fatal problem, such as a failure to add the root CA
+	Warning string `json:"warning,omitempty"`
+	// Error: Reason the input could not be resolved
+	Error string `json:"error,omitempty"`
+	// text: Human-readable rendering used for the text content
+	text string
+}
+
+// batchResolveResult is the structured content returned by the batch_resolve_cert_chain tool.
+type batchResolveResult struct {
+	// Processed: Number of certificate inputs processed
+	Processed int `json:"processed"`
+	// Results: Per-input results in input order
+	Results []batchCertificateResult `json:"results"`
+}
+
+// processSingleCertificate processes a single certificate input and returns its result.
 // It handles the complete certificate processing workflow including reading, decoding, chain resolution,
 // and formatting for a single certificate in a batch operation.
 //
@@ -381,64 +573,89 @@ type batchResolveOptions struct {
 //   - opts: Batch resolution options controlling output format and processing behavior
 //
 // Returns:
-//   - A formatted string containing the certificate processing result or error message
+//   - The certificate processing result, including its text rendering or error message
 //
 // The function performs all certificate operations (read, decode, fetch chain, format) for a single
 // certificate and returns a consistent result format suitable for batch processing.
-func processSingleCertificate(ctx context.Context, certInput string, index int, opts batchResolveOptions) string {
-	result := fmt.Sprintf("Certificate %d:\n", index+1)
+func processSingleCertificate(ctx context.Context, certInput string, index int, opts batchResolveOptions) batchCertificateResult {
+	result := batchCertificateResult{Index: index + 1}
+	text := fmt.Sprintf("Certificate %d:\n", index+1)
+	fail := func(err error) batchCertificateResult {
+		result.Error = err.Error()
+		result.text = text + fmt.Sprintf("  Error: %v\n", err)
+		return result
+	}
 
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
-		result += fmt.Sprintf("  Error: failed to read certificate: %v\n", err)
-		return result
+		return fail(fmt.Errorf("failed to read certificate: %w", err))
 	}
 
 	// Decode certificate
 	certManager := x509certs.New()
 	cert, err := certManager.Decode(certData)
 	if err != nil {
-		result += fmt.Sprintf("  Error: failed to decode certificate: %v\n", err)
-		return result
+		return fail(fmt.Errorf("failed to decode certificate: %w", err))
 	}
 
 	// Fetch certificate chain
 	chain := x509chain.New(cert, version.Version)
 	if err := chain.FetchCertificate(ctx); err != nil {
-		result += fmt.Sprintf("  Error: failed to fetch certificate chain: %v\n", err)
-		return result
+		return fail(fmt.Errorf("failed to fetch certificate chain: %w", err))
 	}
 
 	// Optionally add system root CA
 	if opts.includeSystemRoot {
 		if err := chain.AddRootCA(); err != nil {
-			result += fmt.Sprintf("  Warning: failed to add root CA: %v\n", err)
+			result.Warning = fmt.Sprintf("failed to add root CA: %v", err)
+			text += fmt.Sprintf("  Warning: %s\n", result.Warning)
 		}
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	// Filter certificates if needed
 	certs := chain.Certs
 	if opts.intermediateOnly {
 		certs = chain.FilterIntermediates()
 	}
+	result.Certificates = summarizeCertificates(certs)
+	result.Format = opts.format
 
 	// Format output
 	switch opts.format {
 	case "der":
 		derData := certManager.EncodeMultipleDER(certs)
-		result += fmt.Sprintf("  Format: DER (%d bytes)\n", len(derData))
+		result.Output = base64.StdEncoding.EncodeToString(derData)
+		text += fmt.Sprintf("  Format: DER (%d bytes)\n", len(derData))
 	case "json":
-		result += "  Format: JSON\n" + formatJSON(certs, certManager)
+		result.Output = formatJSON(certs, certManager)
+		text += "  Format: JSON\n" + result.Output
 	default: // pem
-		pemData := certManager.EncodeMultiplePEM(certs)
-		result += fmt.Sprintf("  Format: PEM\n%s", string(pemData))
+		result.Output = string(certManager.EncodeMultiplePEM(certs))
+		text += fmt.Sprintf("  Format: PEM\n%s", result.Output)
 	}
 
-	result += fmt.Sprintf("  Chain: %d certificate(s)\n", len(certs))
+	result.text = text + fmt.Sprintf("  Chain: %d certificate(s)\n", len(certs))
 	return result
 }
 
+// cancelledBatchResult reports a batch input that was not processed because the context ended.
+//
+// Parameters:
+//   - index: Certificate index in the batch (0-based)
+//   - err: Context error
+//
+// Returns:
+//   - The result carrying the cancellation as its error
+func cancelledBatchResult(index int, err error) batchCertificateResult {
+	return batchCertificateResult{
+		Index: index + 1,
+		Error: fmt.Sprintf("processing cancelled: %v", err),
+		text:  fmt.Sprintf("Certificate %d: Processing cancelled: %v", index+1, err),
+	}
+}
+
 // formatBatchResults combines individual certificate results into final batch output.
 // It creates a structured summary showing the total number of certificates processed
 // and concatenates all individual results with proper formatting.
@@ -452,10 +669,14 @@ func processSingleCertificate(ctx context.Context, certInput string, index int,
 //
 // The function provides consistent batch output formatting that matches the expected
 // user interface for batch certificate operations.
-func formatBatchResults(results []string, totalProcessed int) string {
+func formatBatchResults(results []batchCertificateResult, totalProcessed int) string {
+	texts := make([]string, len(results))
+	for i, result := range results {
+		texts[i] = result.text
+	}
 	finalResult := "Batch Certificate Chain Resolution Results:\n"
 	finalResult += fmt.Sprintf("Processed %d certificate(s)\n\n", totalProcessed)
-	finalResult += strings.Join(results, "\n")
+	finalResult += strings.Join(texts, "\n")
 	return finalResult
 }
 
@@ -505,7 +726,8 @@ func parseCertInputs(certInput string) []string {
 }
 
 // processBatchCertificates processes multiple certificates in batch.
-// It processes each certificate concurrently using goroutines for improved performance.
+// It processes each certificate concurrently using goroutines for improved performance,
+// and reports progress after each certificate when the client sent a progress token.
 //
 // Parameters:
 //   - ctx: Context for cancellation and timeout handling
@@ -515,8 +737,11 @@ func parseCertInputs(certInput string) []string {
 //
 // Returns:
 //   - results: List of processing results for each certificate
-func processBatchCertificates(ctx context.Context, certInputs []string, opts batchResolveOptions, maxConcurrent int) []string {
-	results := make([]string, len(certInputs))
+func processBatchCertificates(ctx context.Context, certInputs []string, opts batchResolveOptions, maxConcurrent int) []batchCertificateResult {
+	results := make([]batchCertificateResult, len(certInputs))
+	// Progress is reported per certificate rather than per network step
+	progress := progressFromContext(ctx)
+	ctx = x509chain.WithProgress(ctx, nil)
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, maxConcurrent) // Limit concurrent goroutines to configurable limit
 
@@ -530,20 +755,21 @@ func processBatchCertificates(ctx context.Context, certInputs []string, opts bat
 			case semaphore <- struct{}{}:
 				defer func() { <-semaphore }()
 			case <-ctx.Done():
-				results[index] = fmt.Sprintf("Certificate %d: Processing cancelled: %v", index+1, ctx.Err())
+				results[index] = cancelledBatchResult(index, ctx.Err())
 				return
 			}
 
 			// Check context again before processing
 			select {
 			case <-ctx.Done():
-				results[index] = fmt.Sprintf("Certificate %d: Processing cancelled: %v", index+1, ctx.Err())
+				results[index] = cancelledBatchResult(index, ctx.Err())
 				return
 			default:
 			}
 
 			result := processSingleCertificate(ctx, input, index, opts)
 			results[index] = result
+			progress.report(len(certInputs), fmt.Sprintf("processed certificate %d of %d", index+1, len(certInputs)))
 		}(i, certInput)
 	}
 
@@ -561,7 +787,7 @@ func processBatchCertificates(ctx context.Context, certInputs []string, opts bat
 //   - request: MCP tool call request containing comma-separated certificate inputs and format options
 //
 // Returns:
-//   - The tool execution result containing batch processing results for all certificates
+//   - The tool execution result containing batch processing results for all certificates as text and structured content
 //   - An error if any certificate processing fails critically
 //
 // The function handles multiple certificates efficiently, processing each one independently
@@ -582,7 +808,11 @@ func handleBatchResolveCertChain(ctx context.Context, request mcp.CallToolReques
 
 	// Combine and return results
 	finalResult := formatBatchResults(results, len(certInputs))
-	return mcp.NewToolResultText(finalResult), nil
+	structured := batchResolveResult{
+		Processed: len(certInputs),
+		Results:   results,
+	}
+	return mcp.NewToolResultStructured(structured, finalResult), nil
 }
 
 // validateRemoteParams validates and extracts parameters for remote certificate fetching.
@@ -594,22 +824,23 @@ func handleBatchResolveCertChain(ctx context.Context, request mcp.CallToolReques
 // Returns:
 //   - hostname: Target hostname to connect to
 //   - port: Port number for connection
-//   - format: Output format for certificates
-//   - includeSystemRoot: Whether to include system root CA
-//   - intermediateOnly: Whether to return only intermediate certificates
+//   - opts: Output options (format, truststore password, root and intermediate filtering)
 //   - error: Parameter validation error
-func validateRemoteParams(request mcp.CallToolRequest) (hostname string, port int, format string, includeSystemRoot, intermediateOnly bool, err error) {
+func validateRemoteParams(request mcp.CallToolRequest) (hostname string, port int, opts resolveChainOptions, err error) {
 	hostname, err = request.RequireString("hostname")
 	if err != nil {
-		return "", 0, "", false, false, fmt.Errorf("hostname parameter required: %w", err)
+		return "", 0, resolveChainOptions{}, fmt.Errorf("hostname parameter required: %w", err)
 	}
 
 	port = request.GetInt("port", 443)
-	format = request.GetString("format", "pem")
-	includeSystemRoot = request.GetBool("include_system_root", false)
-	intermediateOnly = request.GetBool("intermediate_only", false)
+	opts = resolveChainOptions{
+		format:            request.GetString("format", "pem"),
+		storePassword:     request.GetString("store_password", x509certs.DefaultTrustStorePassword),
+		includeSystemRoot: request.GetBool("include_system_root", false),
+		intermediateOnly:  request.GetBool("intermediate_only", false),
+	}
 
-	return hostname, port, format, includeSystemRoot, intermediateOnly, nil
+	return hostname, port, opts, nil
 }
 
 // fetchRemoteCertificates fetches certificate chain from a remote hostname and port.
@@ -646,6 +877,7 @@ func fetchRemoteCertificates(ctx context.Context, hostname string, port int, inc
 			return nil, nil, 0, fmt.Errorf("failed to add root CA: %w", err)
 		}
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	// Filter certificates if needed
 	filteredCerts := chain.Certs
@@ -656,6 +888,26 @@ func fetchRemoteCertificates(ctx context.Context, hostname string, port int, inc
 	return chain, filteredCerts, len(certs), nil
 }
 
+// remoteCertResult is the structured content returned by the fetch_remote_cert tool.
+type remoteCertResult struct {
+	// Host: Host that was connected to
+	Host string `json:"host"`
+	// Port: Port that was connected to
+	Port int `json:"port"`
+	// CertificatesReceived: Number of certificates presented by the server
+	CertificatesReceived int `json:"certificatesReceived"`
+	// Certificates: Certificates after filtering, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+	// Total: Number of certificates after filtering
+	Total int `json:"total"`
+	// Format: Requested output format
+	Format string `json:"format"`
+	// Output: Chain encoded in the requested format
+	Output string `json:"output"`
+	// Aliases: Truststore entry aliases, for the "jks" and "pkcs12" formats
+	Aliases []string `json:"aliases,omitempty"`
+}
+
 // buildRemoteResult creates the formatted result for remote certificate fetching.
 // It includes connection details, certificate counts, and formatted certificate data.
 //
@@ -664,12 +916,22 @@ func fetchRemoteCertificates(ctx context.Context, hostname string, port int, inc
 //   - port: Port number that was used
 //   - certCount: Number of certificates initially received
 //   - filteredCerts: Final list of certificates after filtering
-//   - format: Output format for certificates
+//   - opts: Output options carrying the format and truststore password
 //
 // Returns:
 //   - result: Formatted remote certificate fetch result string
-func buildRemoteResult(hostname string, port int, certCount int, filteredCerts []*x509.Certificate, format string) (string, error) {
+//   - structured: The same result as structured content
+//   - error: Truststore encoding error
+func buildRemoteResult(hostname string, port int, certCount int, filteredCerts []*x509.Certificate, opts resolveChainOptions) (string, *remoteCertResult, error) {
 	certManager := x509certs.New()
+	structured := &remoteCertResult{
+		Host:                 hostname,
+		Port:                 port,
+		CertificatesReceived: certCount,
+		Certificates:         summarizeCertificates(filteredCerts),
+		Total:                len(filteredCerts),
+		Format:               opts.format,
+	}
 
 	result := "Remote Certificate Fetch Results:\n"
 	result += fmt.Sprintf("Host: %s:%d\n", hostname, port)
@@ -677,7 +939,7 @@ func buildRemoteResult(hostname string, port int, certCount int, filteredCerts [
 	result += fmt.Sprintf("Certificates after filtering: %d\n\n", len(filteredCerts))
 
 	var output string
-	switch format {
+	switch opts.format {
 	case "der":
 		derData := certManager.EncodeMultipleDER(filteredCerts)
 		output = base64.StdEncoding.EncodeToString(derData)
@@ -685,6 +947,19 @@ func buildRemoteResult(hostname string, port int, certCount int, filteredCerts [
 	case "json":
 		output = formatJSON(filteredCerts, certManager)
 		result += "Format: JSON\n\n" + output
+	case x509certs.TrustStoreJKS, x509certs.TrustStorePKCS12:
+		encoded, err := encodeTrustStoreOutput(filteredCerts, opts.format, opts.storePassword, certManager)
+		if err != nil {
+			return "", nil, err
+		}
+		output = encoded
+		result += fmt.Sprintf("Format: %s truststore (base64 encoded)\n", strings.ToUpper(opts.format))
+		for _, cert := range filteredCerts {
+			alias := x509certs.TrustStoreAlias(cert)
+			structured.Aliases = append(structured.Aliases, alias)
+			result += fmt.Sprintf("Alias: %s\n", alias)
+		}
+		result += "\n" + output
 	default: // pem
 		pemData := certManager.EncodeMultiplePEM(filteredCerts)
 		output = string(pemData)
@@ -692,7 +967,8 @@ func buildRemoteResult(hostname string, port int, certCount int, filteredCerts [
 	}
 
 	result += fmt.Sprintf("\nTotal certificates in chain: %d", len(filteredCerts))
-	return result, nil
+	structured.Output = output
+	return result, structured, nil
 }
 
 // handleFetchRemoteCert fetches a certificate chain from a remote hostname and port.
@@ -704,7 +980,7 @@ func buildRemoteResult(hostname string, port int, certCount int, filteredCerts [
 //   - config: Server configuration containing timeout and format defaults
 //
 // Returns:
-//   - The tool execution result containing the fetched certificate chain
+//   - The tool execution result containing the fetched certificate chain as text and structured content
 //   - An error if connection or certificate retrieval fails
 //
 // The function uses the x509chain.FetchRemoteChain function to establish a TLS connection
@@ -712,24 +988,25 @@ func buildRemoteResult(hostname string, port int, certCount int, filteredCerts [
 // CA addition and certificate filtering.
 func handleFetchRemoteCert(ctx context.Context, request mcp.CallToolRequest, config *Config) (*mcp.CallToolResult, error) {
 	// Validate and extract parameters
-	hostname, port, format, includeSystemRoot, intermediateOnly, err := validateRemoteParams(request)
+	hostname, port, opts, err := validateRemoteParams(request)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Fetch remote certificates
-	_, filteredCerts, certCount, err := fetchRemoteCertificates(ctx, hostname, port, includeSystemRoot, intermediateOnly, config)
+	_, filteredCerts, certCount, err := fetchRemoteCertificates(ctx, hostname, port, opts.includeSystemRoot, opts.intermediateOnly, config)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
+	recordHostname(ctx, hostname)
 
 	// Build and return result
-	result, err := buildRemoteResult(hostname, port, certCount, filteredCerts, format)
+	result, structured, err := buildRemoteResult(hostname, port, certCount, filteredCerts, opts)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(result), nil
+	return mcp.NewToolResultStructured(structured, result), nil
 }
 
 // validateExpiryParams validates and extracts parameters for certificate expiry checking.
@@ -753,21 +1030,66 @@ func validateExpiryParams(request mcp.CallToolRequest, config *Config) (certInpu
 	return certInput, warnDays, nil
 }
 
+// Expiry statuses reported by the check_cert_expiry tool.
+const (
+	// expiryStatusExpired is a certificate past its NotAfter date.
+	expiryStatusExpired = "expired"
+	// expiryStatusExpiringSoon is a certificate expiring within the warning window.
+	expiryStatusExpiringSoon = "expiring_soon"
+	// expiryStatusValid is a certificate outside the warning window.
+	expiryStatusValid = "valid"
+)
+
+// expiryCertificate is the expiry status of one certificate.
+type expiryCertificate struct {
+	// certificateSummary: Certificate the status applies to
+	certificateSummary
+	// Status: expired, expiring_soon, or valid
+	Status string `json:"status"`
+	// DaysRemaining: Whole days until expiry, negative once expired
+	DaysRemaining int `json:"daysRemaining"`
+}
+
+// expirySummary counts the checked certificates per expiry status.
+type expirySummary struct {
+	// Total: Number of certificates checked
+	Total int `json:"total"`
+	// Expired: Number of expired certificates
+	Expired int `json:"expired"`
+	// ExpiringSoon: Number of certificates expiring within the warning window
+	ExpiringSoon int `json:"expiringSoon"`
+	// Valid: Number of certificates outside the warning window
+	Valid int `json:"valid"`
+}
+
+// expiryCheckResult is the structured content returned by the check_cert_expiry tool.
+type expiryCheckResult struct {
+	// WarnDays: Warning window in days
+	WarnDays int `json:"warnDays"`
+	// Certificates: Checked certificates in input order
+	Certificates []expiryCertificate `json:"certificates"`
+	// Summary: Certificate counts per status
+	Summary expirySummary `json:"summary"`
+	// AllValid: True when no certificate is expired or expiring soon
+	AllValid bool `json:"allValid"`
+}
+
 // checkCertificateExpiry performs expiry analysis on certificates.
 // It calculates days until expiry and categorizes certificates by status.
 //
 // Parameters:
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
 //   - certInput: Certificate input as file path or base64 data
 //   - warnDays: Number of days before expiry to show warnings
 //
 // Returns:
-//   - certs: Decoded certificates
+//   - certs: Expiry status of each certificate
 //   - expiryResults: List of expiry status for each certificate
 //   - summary: Summary statistics
 //   - error: Processing error
-func checkCertificateExpiry(certInput string, warnDays int) ([]*x509.Certificate, []string, map[string]int, error) {
+func checkCertificateExpiry(ctx context.Context, certInput string, warnDays int) ([]expiryCertificate, []string, map[string]int, error) {
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to read certificate: %w", err)
 	}
@@ -787,6 +1109,7 @@ func checkCertificateExpiry(certInput string, warnDays int) ([]*x509.Certificate
 	// Check expiry for each certificate
 	now := time.Now()
 	var expiryResults []string
+	expiryCerts := make([]expiryCertificate, len(certs))
 	expiredCount := 0
 	expiringSoonCount := 0
 
@@ -796,15 +1119,19 @@ func checkCertificateExpiry(certInput string, warnDays int) ([]*x509.Certificate
 		result += fmt.Sprintf("  Expires: %s\n", cert.NotAfter.Format("2006-01-02 15:04:05 MST"))
 
 		daysUntilExpiry := int(cert.NotAfter.Sub(now).Hours() / 24)
+		expiryCerts[i] = expiryCertificate{certificateSummary: summarizeCertificate(cert), DaysRemaining: daysUntilExpiry}
 
 		if now.After(cert.NotAfter) {
 			result += fmt.Sprintf("  Status: EXPIRED (%d days ago)\n", -daysUntilExpiry)
+			expiryCerts[i].Status = expiryStatusExpired
 			expiredCount++
 		} else if daysUntilExpiry <= warnDays {
 			result += fmt.Sprintf("  Status: EXPIRING SOON (%d days remaining)\n", daysUntilExpiry)
+			expiryCerts[i].Status = expiryStatusExpiringSoon
 			expiringSoonCount++
 		} else {
 			result += fmt.Sprintf("  Status: VALID (%d days remaining)\n", daysUntilExpiry)
+			expiryCerts[i].Status = expiryStatusValid
 		}
 		result += "\n"
 
@@ -818,7 +1145,7 @@ func checkCertificateExpiry(certInput string, warnDays int) ([]*x509.Certificate
 		"valid":        len(certs) - expiredCount - expiringSoonCount,
 	}
 
-	return certs, expiryResults, summary, nil
+	return expiryCerts, expiryResults, summary, nil
 }
 
 // buildExpiryResult creates the formatted result for certificate expiry checking.
@@ -866,7 +1193,7 @@ func buildExpiryResult(expiryResults []string, summary map[string]int, warnDays
 //   - config: Server configuration containing default warning days and other settings
 //
 // Returns:
-//   - The tool execution result containing expiry analysis and renewal recommendations
+//   - The tool execution result containing expiry analysis as text and structured content
 //   - An error if certificate processing fails
 //
 // The function supports both single certificates and certificate bundles, calculating days until expiry
@@ -880,14 +1207,25 @@ func handleCheckCertExpiry(ctx context.Context, request mcp.CallToolRequest, con
 	}
 
 	// Check certificate expiry
-	_, expiryResults, summary, err := checkCertificateExpiry(certInput, warnDays)
+	expiryCerts, expiryResults, summary, err := checkCertificateExpiry(ctx, certInput, warnDays)
 	if err != nil {
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
 	// Build and return result
 	result := buildExpiryResult(expiryResults, summary, warnDays)
-	return mcp.NewToolResultText(result), nil
+	structured := expiryCheckResult{
+		WarnDays:     warnDays,
+		Certificates: expiryCerts,
+		Summary: expirySummary{
+			Total:        summary["total"],
+			Expired:      summary["expired"],
+			ExpiringSoon: summary["expiringSoon"],
+			Valid:        summary["valid"],
+		},
+		AllValid: summary["expired"] == 0 && summary["expiringSoon"] == 0,
+	}
+	return mcp.NewToolResultStructured(structured, result), nil
 }
 
 // validateAIAnalysisParams validates and extracts parameters for AI certificate analysis.
@@ -923,7 +1261,7 @@ func validateAIAnalysisParams(request mcp.CallToolRequest) (certInput string, an
 //   - error: Certificate processing error
 func prepareCertificateForAnalysis(ctx context.Context, certInput string, config *Config) (*x509chain.Chain, error) {
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read certificate: %w", err)
 	}
@@ -958,6 +1296,7 @@ func prepareCertificateForAnalysis(ctx context.Context, certInput string, config
 		// Log error but continue with available certificates
 		// This is not a fatal error for AI analysis
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	return chain, nil
 }
@@ -1089,6 +1428,22 @@ func formatFallbackResult(analysisType, certificateContext, analysisPrompt strin
 	return result
 }
 
+// aiAnalysisResult is the structured content returned by the analyze_certificate_with_ai tool.
+type aiAnalysisResult struct {
+	// AnalysisType: Requested type of analysis
+	AnalysisType string `json:"analysisType"`
+	// AIGenerated: False when no AI API key is configured and Analysis holds the prepared context instead
+	AIGenerated bool `json:"aiGenerated"`
+	// Model: Configured AI model, when AI analysis ran
+	Model string `json:"model,omitempty"`
+	// Certificates: Analyzed certificates with their roles, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+	// RevocationStatus: OCSP and CRL revocation check report
+	RevocationStatus string `json:"revocationStatus"`
+	// Analysis: AI analysis, or the prepared analysis context
+	Analysis string `json:"analysis"`
+}
+
 // handleAnalyzeCertificateWithAI analyzes certificate data using AI collaboration through sampling.
 // It performs comprehensive security analysis including revocation status, cryptographic strength,
 // and compliance assessment using bidirectional AI communication.
@@ -1125,19 +1480,29 @@ func handleAnalyzeCertificateWithAI(ctx context.Context, request mcp.CallToolReq
 	// Build analysis prompt for AI
 	analysisPrompt := buildAIAnalysisPrompt(chain, revocationStatus, analysisType)
 
+	structured := aiAnalysisResult{
+		AnalysisType:     analysisType,
+		Certificates:     summarizeChain(chain),
+		RevocationStatus: revocationStatus,
+	}
+
 	// Try to get AI analysis if API key is configured
 	if config.AI.APIKey != "" {
 		result, err := executeAIAnalysis(ctx, analysisPrompt, analysisType, config)
 		if err != nil {
 			return mcp.NewToolResultError(err.Error()), nil
 		}
-		return mcp.NewToolResultText(result), nil
+		structured.AIGenerated = true
+		structured.Model = config.AI.Model
+		structured.Analysis = result
+		return mcp.NewToolResultStructured(structured, result), nil
 	}
 
 	// Fallback: Show what would be sent to AI (no API key configured)
 	certificateContext := buildCertificateContextWithRevocation(chain, revocationStatus, analysisType)
 	result := formatFallbackResult(analysisType, certificateContext, analysisPrompt)
-	return mcp.NewToolResultText(result), nil
+	structured.Analysis = result
+	return mcp.NewToolResultStructured(structured, result), nil
 }
 
 // validateResourceParams validates and extracts parameters for resource usage monitoring.
@@ -1156,7 +1521,7 @@ func validateResourceParams(request mcp.CallToolRequest) (detailed bool, format
 }
 
 // formatResourceResult formats resource usage data according to the specified format.
-// It handles both JSON and Markdown output formats with appropriate structured content.
+// Both formats carry the data as structured content; the format selects the text rendering.
 //
 // Parameters:
 //   - data: Resource usage data to format
@@ -1169,7 +1534,7 @@ func formatResourceResult(data *ResourceUsageData, format string) (*mcp.CallTool
 	switch format {
 	case "markdown":
 		markdown := FormatResourceUsageAsMarkdown(data)
-		return mcp.NewToolResultText(markdown), nil
+		return mcp.NewToolResultStructured(data, markdown), nil
 	case "json":
 		fallthrough
 	default:
@@ -1178,21 +1543,8 @@ func formatResourceResult(data *ResourceUsageData, format string) (*mcp.CallTool
 			return nil, fmt.Errorf("failed to format resource usage: %w", err)
 		}
 
-		// Parse the JSON string back to a map for structured content
-		var structuredData map[string]any
-		if err := json.Unmarshal([]byte(jsonData), &structuredData); err != nil {
-			// Fallback to text if parsing fails
-			return mcp.NewToolResultText(jsonData), nil
-		}
-
 		// Return structured JSON content for programmatic access
-		return &mcp.CallToolResult{
-			Content: []mcp.Content{
-				mcp.NewTextContent(jsonData),
-			},
-			StructuredContent: structuredData,
-			IsError:           false,
-		}, nil
+		return mcp.NewToolResultStructured(data, jsonData), nil
 	}
 }
 
@@ -1244,6 +1596,7 @@ func formatJSON(certs []*x509.Certificate, certManager *x509certs.Certificate) s
 		Serial             string `json:"serial"`
 		SignatureAlgorithm string `json:"signatureAlgorithm"`
 		PEM                string `json:"pem"`
+		x509certs.Fingerprints
 	}
 
 	certInfos := make([]CertInfo, len(certs))
@@ -1255,6 +1608,7 @@ func formatJSON(certs []*x509.Certificate, certManager *x509certs.Certificate) s
 			Serial:             cert.SerialNumber.String(),
 			SignatureAlgorithm: cert.SignatureAlgorithm.String(),
 			PEM:                string(pemData),
+			Fingerprints:       x509certs.CertificateFingerprints(cert),
 		}
 	}
 
@@ -1395,8 +1749,9 @@ func appendValidityInfo(context *strings.Builder, cert *x509.Certificate) {
 //   - chain: Certificate chain instance for key size calculation
 //   - cert: X.509 certificate to extract cryptographic information from
 //
-// The function extracts signature algorithm, public key algorithm, and key size
-// information for security analysis and compliance assessment.
+// The function extracts signature algorithm, public key algorithm, key size,
+// equivalent security strength, and post-quantum status for security analysis
+// and compliance assessment.
 func appendCryptoInfo(context *strings.Builder, chain *x509chain.Chain, cert *x509.Certificate) {
 	context.WriteString("CRYPTOGRAPHY:\n")
 	fmt.Fprintf(context, "  Signature Algorithm: %s\n", cert.SignatureAlgorithm.String())
@@ -1407,6 +1762,13 @@ func appendCryptoInfo(context *strings.Builder, chain *x509chain.Chain, cert *x5
 		keySize = chain.KeySize(cert)
 	}
 	fmt.Fprintf(context, "  Key Size: %d bits\n", keySize)
+
+	key := x509certs.DescribeKey(cert)
+	fmt.Fprintf(context, "  Key Type: %s\n", key.Algorithm)
+	if key.SecurityBits > 0 {
+		fmt.Fprintf(context, "  Security Strength: %d bits\n", key.SecurityBits)
+	}
+	fmt.Fprintf(context, "  Post-Quantum: %t\n", key.PostQuantum)
 }
 
 // appendCertProperties adds basic certificate properties to the context builder for AI analysis.
@@ -1668,6 +2030,18 @@ Provide actionable insights for certificate management and security.`
 	}
 }
 
+// visualizationResult is the structured content returned by the visualize_cert_chain tool.
+type visualizationResult struct {
+	// Format: Visualization format
+	Format string `json:"format"`
+	// ChainLength: Number of certificates in the chain
+	ChainLength int `json:"chainLength"`
+	// Certificates: Chain certificates with their roles, leaf first
+	Certificates []certificateSummary `json:"certificates"`
+	// Visualization: Rendered visualization, identical to the text content
+	Visualization string `json:"visualization"`
+}
+
 // validateVisualizeParams validates and extracts parameters for certificate chain visualization.
 // It ensures required parameters are present and validates the format option.
 //
@@ -1709,7 +2083,7 @@ func validateVisualizeParams(request mcp.CallToolRequest) (certInput, format str
 //   - error: Chain resolution error
 func resolveCertChainForVisualization(ctx context.Context, certInput string) (*x509chain.Chain, error) {
 	// Read certificate data
-	certData, err := readCertificateData(certInput)
+	certData, err := readCertificateData(ctx, certInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read certificate: %w", err)
 	}
@@ -1726,6 +2100,7 @@ func resolveCertChainForVisualization(ctx context.Context, certInput string) (*x
 	if err := chain.FetchCertificate(ctx); err != nil {
 		return nil, fmt.Errorf("failed to resolve certificate chain: %w", err)
 	}
+	recordResolvedChain(ctx, chain.Certs)
 
 	return chain, nil
 }
@@ -1795,5 +2170,468 @@ func handleVisualizeCertChain(ctx context.Context, request mcp.CallToolRequest)
 		return mcp.NewToolResultError(err.Error()), nil
 	}
 
-	return mcp.NewToolResultText(result), nil
+	structured := visualizationResult{
+		Format:        format,
+		ChainLength:   len(chain.Certs),
+		Certificates:  summarizeChain(chain),
+		Visualization: result,
+	}
+	return mcp.NewToolResultStructured(structured, result), nil
+}
+
+// validateInspectCSRParams validates and extracts parameters for CSR inspection.
+//
+// Parameters:
+//   - request: MCP tool call request containing the CSR input
+//
+// Returns:
+//   - csrInput: CSR input as file path or base64 data
+//   - error: Parameter validation error
+func validateInspectCSRParams(request mcp.CallToolRequest) (csrInput string, err error) {
+	csrInput, err = request.RequireString("csr")
+	if err != nil {
+		return "", fmt.Errorf("csr parameter required: %w", err)
+	}
+	return csrInput, nil
+}
+
+// inspectCSR reads, decodes, and runs pre-issuance checks on a certificate signing request.
+//
+// Parameters:
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
+//   - csrInput: CSR input as file path or base64 data (PEM or DER)
+//
+// Returns:
+//   - *x509certs.CSRReport: Request summary and findings
+//   - error: Reading or decoding error
+func inspectCSR(ctx context.Context, csrInput string) (*x509certs.CSRReport, error) {
+	csrData, err := readCertificateData(ctx, csrInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate request: %w", err)
+	}
+
+	certManager := x509certs.New()
+	csr, err := certManager.DecodeCSR(csrData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate request: %w", err)
+	}
+
+	return certManager.InspectCSR(csr), nil
+}
+
+// handleInspectCSR handles requests to inspect a certificate signing request before issuance.
+// It reports the requested subject, SANs, key and signature details, and flags weak keys,
+// deprecated signature algorithms, and missing or malformed SANs.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout handling
+//   - request: MCP tool call request containing the CSR input
+//
+// Returns:
+//   - The tool execution result containing the JSON report as text and structured content
+//   - An error if result encoding fails
+//
+// Findings with error severity do not make the tool call fail; callers inspect the
+// "passed" field of the report instead.
+func handleInspectCSR(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate and extract parameters
+	csrInput, err := validateInspectCSRParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Decode and inspect the request
+	report, err := inspectCSR(ctx, csrInput)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	jsonData, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode CSR report: %w", err)
+	}
+
+	return mcp.NewToolResultStructured(report, string(jsonData)), nil
+}
+
+// inspectCertificateResult is the structured content returned by the inspect_certificate tool.
+type inspectCertificateResult struct {
+	// Certificates: Full decode of every input certificate, in input order
+	Certificates []*x509certs.CertificateDetails `json:"certificates"`
+}
+
+// validateInspectCertificateParams validates and extracts parameters for certificate inspection.
+//
+// Parameters:
+//   - request: MCP tool call request containing certificate input and format options
+//
+// Returns:
+//   - certInput: Certificate input as file path or base64 data
+//   - format: Output format ("text" or "json")
+//   - error: Parameter validation error
+func validateInspectCertificateParams(request mcp.CallToolRequest) (certInput, format string, err error) {
+	certInput, err = request.RequireString("certificate")
+	if err != nil {
+		return "", "", fmt.Errorf("certificate parameter required: %w", err)
+	}
+
+	format = request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return "", "", fmt.Errorf("unsupported format '%s', supported formats: text, json", format)
+	}
+
+	return certInput, format, nil
+}
+
+// inspectCertificates reads and fully decodes every certificate in the input.
+//
+// Parameters:
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
+//   - certInput: Certificate input as file path or base64 data (PEM bundle or DER)
+//
+// Returns:
+//   - []*x509certs.CertificateDetails: Decoded certificates in input order
+//   - error: Reading or decoding error
+func inspectCertificates(ctx context.Context, certInput string) ([]*x509certs.CertificateDetails, error) {
+	certData, err := readCertificateData(ctx, certInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	certManager := x509certs.New()
+	certs, err := certManager.DecodeMultiple(certData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to decode certificate: %w", x509certs.ErrNoCertificates)
+	}
+
+	details := make([]*x509certs.CertificateDetails, len(certs))
+	for i, cert := range certs {
+		details[i] = certManager.Inspect(cert)
+	}
+	return details, nil
+}
+
+// handleInspectCertificate handles requests for a full certificate decode, comparable to `openssl x509 -text`.
+// Every standard extension is rendered, including all SAN types, name constraints, policies,
+// policy mappings, AIA, CRL distribution points, embedded SCTs, and the TLS feature extension.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout handling
+//   - request: MCP tool call request containing certificate input and format options
+//
+// Returns:
+//   - The tool execution result containing openssl-style or JSON text, with structured content
+//   - An error if result encoding fails
+func handleInspectCertificate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate and extract parameters
+	certInput, format, err := validateInspectCertificateParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Decode every certificate
+	details, err := inspectCertificates(ctx, certInput)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := inspectCertificateResult{Certificates: details}
+	if format == "text" {
+		var text strings.Builder
+		for _, d := range details {
+			text.WriteString(d.RenderText())
+		}
+		return mcp.NewToolResultStructured(result, text.String()), nil
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificate details: %w", err)
+	}
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// lintCertificateResult is the structured content returned by the lint_certificate tool.
+type lintCertificateResult struct {
+	// Certificates: Lint report for every input certificate, in input order
+	Certificates []*x509certs.LintReport `json:"certificates"`
+}
+
+// validateLintCertificateParams validates and extracts parameters for certificate linting.
+//
+// Parameters:
+//   - request: MCP tool call request containing certificate input and format options
+//
+// Returns:
+//   - certInput: Certificate input as file path or base64 data
+//   - format: Output format ("text" or "json")
+//   - error: Parameter validation error
+func validateLintCertificateParams(request mcp.CallToolRequest) (certInput, format string, err error) {
+	certInput, err = request.RequireString("certificate")
+	if err != nil {
+		return "", "", fmt.Errorf("certificate parameter required: %w", err)
+	}
+
+	format = request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return "", "", fmt.Errorf("unsupported format '%s', supported formats: text, json", format)
+	}
+
+	return certInput, format, nil
+}
+
+// lintCertificates reads and lints every certificate in the input.
+//
+// Parameters:
+//   - ctx: Context of the tool call, identifying the server and session whose file roots apply
+//   - certInput: Certificate input as file path or base64 data (PEM bundle or DER)
+//
+// Returns:
+//   - []*x509certs.LintReport: Lint reports in input order
+//   - error: Reading or decoding error
+func lintCertificates(ctx context.Context, certInput string) ([]*x509certs.LintReport, error) {
+	certData, err := readCertificateData(ctx, certInput)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	certManager := x509certs.New()
+	certs, err := certManager.DecodeMultiple(certData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode certificate: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("failed to decode certificate: %w", x509certs.ErrNoCertificates)
+	}
+
+	reports := make([]*x509certs.LintReport, len(certs))
+	for i, cert := range certs {
+		reports[i] = certManager.Lint(cert)
+	}
+	return reports, nil
+}
+
+// handleLintCertificate handles requests to lint certificates against the CA/Browser Forum Baseline Requirements.
+// Linting is deterministic and offline, so it complements the AI-assisted analyze_certificate_with_ai tool
+// with reproducible findings that each cite the requirement they enforce.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout handling
+//   - request: MCP tool call request containing certificate input and format options
+//
+// Returns:
+//   - The tool execution result containing text or JSON reports, with structured content
+//   - An error if result encoding fails
+func handleLintCertificate(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	// Validate and extract parameters
+	certInput, format, err := validateLintCertificateParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Lint every certificate
+	reports, err := lintCertificates(ctx, certInput)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	result := lintCertificateResult{Certificates: reports}
+	if format == "text" {
+		texts := make([]string, len(reports))
+		for i, report := range reports {
+			texts[i] = report.RenderText()
+		}
+		return mcp.NewToolResultStructured(result, strings.Join(texts, "\n")), nil
+	}
+
+	jsonData, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode lint reports: %w", err)
+	}
+
+	return mcp.NewToolResultStructured(result, string(jsonData)), nil
+}
+
+// validateDiffCertChainsParams validates and extracts parameters for chain comparison.
+//
+// Parameters:
+//   - request: MCP tool call request containing both inputs and the format option
+//
+// Returns:
+//   - oldInput: Old certificate input as file path, base64 data, or host:port
+//   - newInput: New certificate input as file path, base64 data, or host:port
+//   - format: Output format ("text" or "json")
+//   - error: Parameter validation error
+func validateDiffCertChainsParams(request mcp.CallToolRequest) (oldInput, newInput, format string, err error) {
+	oldInput, err = request.RequireString("old_certificate")
+	if err != nil {
+		return "", "", "", fmt.Errorf("old_certificate parameter required: %w", err)
+	}
+
+	newInput, err = request.RequireString("new_certificate")
+	if err != nil {
+		return "", "", "", fmt.Errorf("new_certificate parameter required: %w", err)
+	}
+
+	format = request.GetString("format", "text")
+	if format != "text" && format != "json" {
+		return "", "", "", fmt.Errorf("unsupported format '%s', supported formats: text, json", format)
+	}
+
+	return oldInput, newInput, format, nil
+}
+
+// handleDiffCertChains handles requests to compare two certificates or chains.
+// Both inputs are resolved through AIA without verification, so expired or
+// not-yet-trusted chains can still be compared before a rollout.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout handling
+//   - request: MCP tool call request containing both inputs and the format option
+//   - config: Server configuration providing the resolution timeout
+//
+// Returns:
+//   - The tool execution result containing the text or JSON diff, with structured content
+//   - An error if result encoding fails
+func handleDiffCertChains(ctx context.Context, request mcp.CallToolRequest, config *Config) (*mcp.CallToolResult, error) {
+	// Validate and extract parameters
+	oldInput, newInput, format, err := validateDiffCertChainsParams(request)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Resolve both chains, reading files within the sandbox
+	timeout := time.Duration(config.Defaults.Timeout) * time.Second
+	oldChain, err := resolveSandboxedInput(ctx, oldInput, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve old chain: %v", err)), nil
+	}
+	newChain, err := resolveSandboxedInput(ctx, newInput, timeout)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("failed to resolve new chain: %v", err)), nil
+	}
+
+	diff := x509chain.DiffChains(oldChain, newChain)
+
+	if format == "text" {
+		return mcp.NewToolResultStructured(diff, diff.RenderText()), nil
+	}
+
+	jsonData, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode chain diff: %w", err)
+	}
+
+	return mcp.NewToolResultStructured(diff, string(jsonData)), nil
+}
+
+// validateInventoryParams validates and extracts parameters for inventory scanning.
+//
+// Parameters:
+//   - request: MCP tool call request containing paths, format, and warning window
+//   - config: Server configuration containing default warning days
+//
+// Returns:
+//   - paths: Directories and files to scan
+//   - format: Output format ("markdown", "csv", or "json")
+//   - warnDays: Expiry warning window in days
+//   - error: Parameter validation error
+func validateInventoryParams(request mcp.CallToolRequest, config *Config) (paths []string, format string, warnDays int, err error) {
+	pathsInput, err := request.RequireString("paths")
+	if err != nil {
+		return nil, "", 0, fmt.Errorf("paths parameter required: %w", err)
+	}
+	paths = parseCertInputs(pathsInput)
+	if len(paths) == 0 {
+		return nil, "", 0, fmt.Errorf("paths parameter must list at least one path")
+	}
+
+	format = request.GetString("format", "markdown")
+	if format != "markdown" && format != "csv" && format != "json" {
+		return nil, "", 0, fmt.Errorf("unsupported format '%s', supported formats: markdown, csv, json", format)
+	}
+
+	warnDays = request.GetInt("warn_days", config.Defaults.WarnDays)
+	return paths, format, warnDays, nil
+}
+
+// handleScanCertificateInventory handles requests to scan directories and files for certificates.
+// Certificates are found by content, including Kubernetes TLS secret manifests, and every
+// chain is resolved, verified, and checked for expiry.
+//
+// Parameters:
+//   - ctx: Context for cancellation and timeout handling
+//   - request: MCP tool call request containing paths, format, and warning window
+//   - config: Server configuration providing the AIA timeout and default warning days
+//
+// Returns:
+//   - The tool execution result containing the Markdown, CSV, or JSON report, with structured content
+//   - An error if result encoding fails
+func handleScanCertificateInventory(ctx context.Context, request mcp.CallToolRequest, config *Config) (*mcp.CallToolResult, error) {
+	// Validate and extract parameters
+	paths, format, warnDays, err := validateInventoryParams(request, config)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	// Only paths within the file sandbox are scanned; symlinks below them are not followed
+	sandbox := sandboxFromContext(ctx)
+	for i, path := range paths {
+		if paths[i], _, err = sandbox.resolve(ctx, path); err != nil {
+			return mcp.NewToolResultError(inventoryErrorMessage(err)), nil
+		}
+	}
+
+	report, err := x509inventory.Scan(ctx, paths, x509inventory.Options{
+		Version:     version.Version,
+		Timeout:     time.Duration(config.Defaults.Timeout) * time.Second,
+		WarnDays:    warnDays,
+		MaxFileSize: sandbox.maxFileSize,
+	})
+	if err != nil {
+		return mcp.NewToolResultError(inventoryErrorMessage(err)), nil
+	}
+
+	switch format {
+	case "csv":
+		output, err := report.RenderCSV()
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode inventory: %w", err)
+		}
+		return mcp.NewToolResultStructured(report, output), nil
+	case "json":
+		jsonData, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode inventory: %w", err)
+		}
+		return mcp.NewToolResultStructured(report, string(jsonData)), nil
+	default:
+		return mcp.NewToolResultStructured(report, report.RenderMarkdown()), nil
+	}
+}
+
+// inventoryErrorMessage maps an error resolving or scanning inventory paths
+// to a fixed message, so that the result never repeats a path or the file
+// system error naming it.
+func inventoryErrorMessage(err error) string {
+	var reason string
+	switch {
+	case errors.Is(err, errOutsideRoots):
+		reason = errOutsideRoots.Error()
+	case errors.Is(err, errRootsUnavailable):
+		reason = errRootsUnavailable.Error()
+	case errors.Is(err, fs.ErrNotExist):
+		reason = "path does not exist"
+	case errors.Is(err, fs.ErrPermission):
+		reason = "permission denied"
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		reason = "scan cancelled"
+	default:
+		reason = "unreadable path"
+	}
+	return "failed to scan certificates: " + reason
 }
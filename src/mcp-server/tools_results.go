@@ -0,0 +1,110 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"crypto/x509"
+	"time"
+
+	x509certs "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/certs"
+	x509chain "github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/internal/x509/chain"
+)
+
+// Certificate roles reported in structured tool output.
+const (
+	// certRoleLeaf is the end-entity certificate a chain was resolved from.
+	certRoleLeaf = "leaf"
+	// certRoleIntermediate is a CA certificate between the leaf and the root.
+	certRoleIntermediate = "intermediate"
+	// certRoleRoot is the trust anchor at the top of a chain.
+	certRoleRoot = "root"
+	// certRoleSelfSigned is a self-signed end-entity certificate.
+	certRoleSelfSigned = "self-signed"
+)
+
+// certificateSummary describes one certificate in the structured content of the chain tools.
+//
+// The JSON field names match the certificate schema declared by each tool's
+// outputSchema in tools/codegen/config/tools.json.
+type certificateSummary struct {
+	// Subject: Subject distinguished name
+	Subject string `json:"subject"`
+	// CommonName: Subject common name
+	CommonName string `json:"commonName"`
+	// Issuer: Issuer distinguished name
+	Issuer string `json:"issuer"`
+	// SerialNumber: Serial number in decimal
+	SerialNumber string `json:"serialNumber"`
+	// NotBefore: Start of the validity period
+	NotBefore time.Time `json:"notBefore"`
+	// NotAfter: End of the validity period
+	NotAfter time.Time `json:"notAfter"`
+	// IsCA: Whether the certificate is a CA
+	IsCA bool `json:"isCA"`
+	// SHA256: SHA-256 fingerprint of the certificate
+	SHA256 string `json:"sha256Fingerprint"`
+	// Role: Role of the certificate in its chain, for tools that know the full chain
+	Role string `json:"role,omitempty"`
+}
+
+// summarizeCertificate builds the structured summary of a certificate.
+//
+// Parameters:
+//   - cert: Certificate to summarize
+//
+// Returns:
+//   - certificateSummary: Summary without a role
+func summarizeCertificate(cert *x509.Certificate) certificateSummary {
+	return certificateSummary{
+		Subject:      cert.Subject.String(),
+		CommonName:   cert.Subject.CommonName,
+		Issuer:       cert.Issuer.String(),
+		SerialNumber: cert.SerialNumber.String(),
+		NotBefore:    cert.NotBefore,
+		NotAfter:     cert.NotAfter,
+		IsCA:         cert.IsCA,
+		SHA256:       x509certs.CertificateFingerprints(cert).SHA256,
+	}
+}
+
+// summarizeCertificates builds the structured summaries of a list of certificates.
+//
+// Parameters:
+//   - certs: Certificates to summarize
+//
+// Returns:
+//   - []certificateSummary: Summaries in input order; never nil, so it encodes as a JSON array
+func summarizeCertificates(certs []*x509.Certificate) []certificateSummary {
+	summaries := make([]certificateSummary, len(certs))
+	for i, cert := range certs {
+		summaries[i] = summarizeCertificate(cert)
+	}
+	return summaries
+}
+
+// summarizeChain builds the structured summaries of a chain, including each certificate's role.
+//
+// Parameters:
+//   - chain: Resolved certificate chain, leaf first
+//
+// Returns:
+//   - []certificateSummary: Summaries in chain order
+func summarizeChain(chain *x509chain.Chain) []certificateSummary {
+	summaries := summarizeCertificates(chain.Certs)
+	for i, cert := range chain.Certs {
+		switch {
+		case chain.IsRootNode(cert) && cert.IsCA:
+			summaries[i].Role = certRoleRoot
+		case chain.IsSelfSigned(cert):
+			summaries[i].Role = certRoleSelfSigned
+		case i == 0:
+			summaries[i].Role = certRoleLeaf
+		default:
+			summaries[i].Role = certRoleIntermediate
+		}
+	}
+	return summaries
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// newSelfSignedCA returns a PEM-encoded self-signed CA certificate without AIA
+// extensions, so chain resolution and revocation checks complete offline.
+func newSelfSignedCA(t *testing.T, commonName string) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func TestToolOutputSchemas(t *testing.T) {
+	caPEM := newSelfSignedCA(t, "Output Schema Test CA")
+	ca := base64.StdEncoding.EncodeToString([]byte(caPEM))
+	other := base64.StdEncoding.EncodeToString([]byte(newSelfSignedCA(t, "Output Schema Test CA 2")))
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: "www.example.com"},
+		DNSNames: []string{"www.example.com"},
+	}, key)
+	require.NoError(t, err)
+	csr := base64.StdEncoding.EncodeToString(csrDER)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ca.pem"), []byte(caPEM), 0o600))
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(server.Close)
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(serverURL.Port())
+	require.NoError(t, err)
+
+	calls := map[string][]map[string]any{
+		ToolResolveCertChain: {
+			{"certificate": ca},
+			{"certificate": ca, "format": "jks"},
+		},
+		ToolValidateCertChain: {
+			{"certificate": ca, "include_system_root": false, "verbose": true},
+		},
+		ToolBatchResolveCertChain: {
+			{"certificates": ca + ",invalid", "format": "der"},
+		},
+		ToolCheckCertExpiry: {
+			{"certificate": ca},
+		},
+		ToolFetchRemoteCert: {
+			{"hostname": serverURL.Hostname(), "port": port},
+			{"hostname": serverURL.Hostname(), "port": port, "format": "pkcs12"},
+		},
+		ToolAnalyzeCertificateWithAI: {
+			{"certificate": ca, "analysis_type": "security"},
+		},
+		ToolGetResourceUsage: {
+			{"format": "json", "detailed": true},
+			{"format": "markdown"},
+		},
+		ToolVisualizeCertChain: {
+			{"certificate": ca, "format": "ascii"},
+			{"certificate": ca, "format": "json"},
+		},
+		ToolInspectCSR: {
+			{"csr": csr},
+		},
+		ToolInspectCertificate: {
+			{"certificate": ca},
+			{"certificate": ca, "format": "json"},
+		},
+		ToolLintCertificate: {
+			{"certificate": ca},
+		},
+		ToolDiffCertChains: {
+			{"old_certificate": ca, "new_certificate": other},
+		},
+		ToolScanCertificateInventory: {
+			{"paths": dir, "format": "csv"},
+		},
+	}
+
+	config := &Config{}
+	config.Defaults.Timeout = 5
+	config.Defaults.WarnDays = 30
+	config.Defaults.BatchConcurrency = 2
+	config.Files.Roots = []string{dir}
+	tools, toolsWithConfig := createTools()
+	schemas := make(map[string]json.RawMessage)
+	for _, tool := range tools {
+		schemas[tool.Tool.Name] = tool.Tool.RawOutputSchema
+	}
+	for _, tool := range toolsWithConfig {
+		schemas[tool.Tool.Name] = tool.Tool.RawOutputSchema
+	}
+
+	c := startInProcessClient(t, NewServerBuilder().
+		WithConfig(config).
+		WithVersion("1.0.0").
+		WithTools(tools...).
+		WithToolsWithConfig(toolsWithConfig...))
+
+	for name, schema := range schemas {
+		t.Run(name, func(t *testing.T) {
+			require.NotEmpty(t, schema, "tool declares no output schema")
+			require.NotEmpty(t, calls[name], "tool has no output schema test case")
+			compiled, err := gojsonschema.NewSchema(gojsonschema.NewBytesLoader(schema))
+			require.NoError(t, err)
+
+			for _, args := range calls[name] {
+				call := mcp.CallToolRequest{}
+				call.Params.Name = name
+				call.Params.Arguments = args
+				result, err := c.CallTool(t.Context(), call)
+				require.NoError(t, err)
+				require.False(t, result.IsError, "%v: %v", args, result.Content)
+				require.NotNil(t, result.StructuredContent, "%v: missing structured content", args)
+				require.NotEmpty(t, result.Content, "%v: missing text content", args)
+
+				validation, err := compiled.Validate(gojsonschema.NewGoLoader(result.StructuredContent))
+				require.NoError(t, err)
+				for _, e := range validation.Errors() {
+					t.Errorf("%v: %s", args, e)
+				}
+			}
+		})
+	}
+}
+
+func TestHandleBatchResolveCertChain_Structured(t *testing.T) {
+	config := &Config{}
+	config.Defaults.BatchConcurrency = 2
+	ca := base64.StdEncoding.EncodeToString([]byte(newSelfSignedCA(t, "Batch Test CA")))
+
+	result, err := handleBatchResolveCertChain(t.Context(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: ToolBatchResolveCertChain, Arguments: map[string]any{"certificates": ca + ", invalid"}},
+	}, config)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	structured, ok := result.StructuredContent.(batchResolveResult)
+	require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+	assert.Equal(t, 2, structured.Processed)
+	require.Len(t, structured.Results, 2)
+
+	assert.Equal(t, 1, structured.Results[0].Index)
+	assert.Empty(t, structured.Results[0].Error)
+	require.Len(t, structured.Results[0].Certificates, 1)
+	assert.Equal(t, "Batch Test CA", structured.Results[0].Certificates[0].CommonName)
+	assert.Contains(t, structured.Results[0].Output, "BEGIN CERTIFICATE")
+
+	assert.Equal(t, 2, structured.Results[1].Index)
+	assert.Contains(t, structured.Results[1].Error, "failed to read certificate")
+	assert.Empty(t, structured.Results[1].Certificates)
+
+	text, ok := result.Content[0].(mcp.TextContent)
+	require.True(t, ok)
+	assert.Contains(t, text.Text, "Processed 2 certificate(s)")
+	assert.Contains(t, text.Text, "Certificate 2:\n  Error: failed to read certificate")
+}
+
+func TestHandleCheckCertExpiry_Structured(t *testing.T) {
+	config := &Config{}
+	config.Defaults.WarnDays = 400
+	ca := base64.StdEncoding.EncodeToString([]byte(newSelfSignedCA(t, "Expiry Test CA")))
+
+	result, err := handleCheckCertExpiry(t.Context(), mcp.CallToolRequest{
+		Params: mcp.CallToolParams{Name: ToolCheckCertExpiry, Arguments: map[string]any{"certificate": ca}},
+	}, config)
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	structured, ok := result.StructuredContent.(expiryCheckResult)
+	require.True(t, ok, "expected structured result, got %T", result.StructuredContent)
+	assert.Equal(t, 400, structured.WarnDays)
+	require.Len(t, structured.Certificates, 1)
+	assert.Equal(t, expiryStatusExpiringSoon, structured.Certificates[0].Status)
+	assert.Equal(t, "Expiry Test CA", structured.Certificates[0].CommonName)
+	assert.True(t, structured.Certificates[0].IsCA)
+	assert.Equal(t, expirySummary{Total: 1, ExpiringSoon: 1}, structured.Summary)
+	assert.False(t, structured.AllValid)
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/cobra"
+)
+
+// Transport names accepted by the --transport flag.
+const (
+	// TransportStdio serves a single client over standard input and output
+	TransportStdio = "stdio"
+	// TransportSSE serves clients over the legacy HTTP+SSE transport (GET /sse, POST /message)
+	TransportSSE = "sse"
+	// TransportHTTP serves clients over the Streamable HTTP transport (/mcp)
+	TransportHTTP = "http"
+)
+
+const (
+	// DefaultTransportListen is the default listen address for the network transports.
+	// It is loopback-only so that exposing the server is always an explicit choice.
+	DefaultTransportListen = "127.0.0.1:8080"
+
+	// authTokenEnv names the environment variable holding the bearer token,
+	// which keeps the secret out of the process list.
+	authTokenEnv = "MCP_X509_AUTH_TOKEN"
+
+	// transportShutdownTimeout bounds how long in-flight requests may take to
+	// finish after a termination signal before connections are closed.
+	transportShutdownTimeout = 10 * time.Second
+)
+
+// transports lists the supported transport names in display order.
+var transports = []string{TransportStdio, TransportSSE, TransportHTTP}
+
+// TransportOptions configures how the MCP server is exposed to clients.
+//
+// Fields:
+//   - Transport: One of TransportStdio, TransportSSE, or TransportHTTP
+//   - Listen: TCP listen address for the network transports
+//   - TLSCertFile: PEM certificate file; enables HTTPS together with TLSKeyFile
+//   - TLSKeyFile: PEM private key file matching TLSCertFile
+//   - AuthToken: Bearer token granting full access; combined with the auth configuration
+//   - Authenticator: Authenticates network clients; nil leaves the transport open
+//   - Identities: Tool roles per "<method>:<name>" key of identities resolved by Authenticator
+type TransportOptions struct {
+	// Transport: Transport name (stdio, sse, or http)
+	Transport string
+	// Listen: Listen address for the sse and http transports
+	Listen string
+	// TLSCertFile: Certificate file used to serve HTTPS
+	TLSCertFile string
+	// TLSKeyFile: Private key file used to serve HTTPS
+	TLSKeyFile string
+	// AuthToken: Bearer token from --auth-token or MCP_X509_AUTH_TOKEN
+	AuthToken string
+	// Authenticator: Authenticator chain built from AuthToken and the auth configuration
+	Authenticator Authenticator
+	// Identities: Tool allowlists per identity key
+	Identities map[string][]string
+}
+
+// Validate checks that the options form a usable transport configuration.
+//
+// Returns:
+//   - error: Unknown transport, an incomplete TLS key pair, or network-only
+//     options combined with the stdio transport
+func (o TransportOptions) Validate() error {
+	if !slices.Contains(transports, o.Transport) {
+		return fmt.Errorf("unsupported transport %q, must be one of %v", o.Transport, transports)
+	}
+	if (o.TLSCertFile == "") != (o.TLSKeyFile == "") {
+		return errors.New("both --tls-cert and --tls-key must be provided to enable TLS")
+	}
+	if o.Transport == TransportStdio && (o.TLSCertFile != "" || o.AuthToken != "") {
+		return errors.New("TLS and bearer token options require --transport sse or http")
+	}
+	return nil
+}
+
+// addTransportFlags registers the transport flags on the root command.
+//
+// Parameters:
+//   - cmd: Root command receiving the flags
+func (cf *CLIFramework) addTransportFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&cf.transport.Transport, "transport", TransportStdio, "MCP transport: stdio, sse, or http")
+	flags.StringVar(&cf.transport.Listen, "listen", DefaultTransportListen, "listen address for the sse and http transports")
+	flags.StringVar(&cf.transport.TLSCertFile, "tls-cert", "", "TLS certificate file for serving HTTPS")
+	flags.StringVar(&cf.transport.TLSKeyFile, "tls-key", "", "TLS private key file for serving HTTPS")
+	flags.StringVar(&cf.transport.AuthToken, "auth-token", "", "bearer token granting network clients full access (defaults to "+authTokenEnv+")")
+}
+
+// serveNetworkTransport serves mcpServer over the SSE or Streamable HTTP
+// transport until ctx is cancelled, then shuts the listener down gracefully.
+//
+// Every transport wraps the same MCPServer built by ServerBuilder.Build, so
+// tools, resources, prompts, and sampling behave identically to stdio.
+//
+// Parameters:
+//   - ctx: Context whose cancellation triggers graceful shutdown
+//   - mcpServer: Server returned by ServerBuilder.Build
+//   - opts: Validated transport options with Transport set to sse or http;
+//     when opts.Authenticator is set every request must authenticate
+//   - l: Logger for startup and shutdown messages
+//
+// Returns:
+//   - error: Listen or serve failure; nil after a graceful shutdown
+func serveNetworkTransport(ctx context.Context, mcpServer *server.MCPServer, opts TransportOptions, l logger.Logger) error {
+	srv := &http.Server{ReadHeaderTimeout: 10 * time.Second}
+
+	// Both transports manage their own sessions; shutting them down through the
+	// transport closes open event streams that would otherwise never go idle.
+	var (
+		shutdown func(context.Context) error
+		path     string
+	)
+	switch opts.Transport {
+	case TransportSSE:
+		sse := server.NewSSEServer(mcpServer, server.WithHTTPServer(srv))
+		srv.Handler, shutdown, path = interceptSSEUnroutedMethods(mcpServer, sse), sse.Shutdown, sse.CompleteSsePath()
+	case TransportHTTP:
+		streamable := server.NewStreamableHTTPServer(mcpServer, server.WithStreamableHTTPServer(srv))
+		mux := http.NewServeMux()
+		mux.Handle("/mcp", interceptUnroutedMethods(mcpServer, streamable))
+		srv.Handler, shutdown, path = mux, streamable.Shutdown, "/mcp"
+	default:
+		return fmt.Errorf("transport %q is not a network transport", opts.Transport)
+	}
+	if opts.Authenticator != nil {
+		srv.Handler = requireAuthentication(opts.Authenticator, opts.Identities, srv.Handler)
+		if requestsClientCert(opts.Authenticator) {
+			if opts.TLSCertFile == "" {
+				return errors.New("client certificate authentication requires --tls-cert and --tls-key")
+			}
+			// The chain is verified by ClientCertAuthenticator, not by the TLS stack
+			srv.TLSConfig = &tls.Config{ClientAuth: tls.RequestClientCert}
+		}
+	}
+
+	listener, err := net.Listen("tcp", opts.Listen)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", opts.Listen, err)
+	}
+
+	scheme := "http"
+	if opts.TLSCertFile != "" {
+		scheme = "https"
+	}
+	if opts.Authenticator == nil && !isLoopback(listener.Addr()) {
+		l.Printf("Warning: serving on non-loopback address %s without authentication", listener.Addr())
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), transportShutdownTimeout)
+		defer cancel()
+		if err := shutdown(shutdownCtx); err != nil {
+			// Stragglers are cut off rather than holding the process open
+			srv.Close()
+		}
+	}()
+
+	l.Printf("X.509 Certificate Chain Resolver MCP server listening on %s://%s%s (%s transport)", scheme, listener.Addr(), path, opts.Transport)
+	if opts.TLSCertFile != "" {
+		err = srv.ServeTLS(listener, opts.TLSCertFile, opts.TLSKeyFile)
+	} else {
+		err = srv.Serve(listener)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		<-done
+		return nil
+	}
+	// Serving failed before shutdown was requested; release the shutdown goroutine
+	srv.Close()
+	return err
+}
+
+// requestsClientCert reports whether auth includes client certificate authentication.
+func requestsClientCert(auth Authenticator) bool {
+	switch a := auth.(type) {
+	case *ClientCertAuthenticator:
+		return true
+	case authenticatorChain:
+		return slices.ContainsFunc(a, requestsClientCert)
+	}
+	return false
+}
+
+// isLoopback reports whether addr is bound to a loopback interface.
+func isLoopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	return ok && tcpAddr.IP.IsLoopback()
+}
+
+// resolveTransportOptions applies environment fallbacks and validates the options.
+//
+// Returns:
+//   - TransportOptions: Options with the bearer token resolved from MCP_X509_AUTH_TOKEN when unset
+//   - error: Validation error
+func (cf *CLIFramework) resolveTransportOptions() (TransportOptions, error) {
+	opts := cf.transport
+	if opts.Transport == "" {
+		opts.Transport = TransportStdio
+	}
+	if opts.Listen == "" {
+		opts.Listen = DefaultTransportListen
+	}
+	if opts.AuthToken == "" && opts.Transport != TransportStdio {
+		opts.AuthToken = os.Getenv(authTokenEnv)
+	}
+	return opts, opts.Validate()
+}
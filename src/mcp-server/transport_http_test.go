@@ -0,0 +1,218 @@
+// Copyright (c) 2026 H0llyW00dzZ All rights reserved.
+//
+// By accessing or using this software, you agree to be bound by the terms
+// of the License Agreement, which you can find at LICENSE files.
+
+package mcpserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/H0llyW00dzZ/tls-cert-chain-resolver/src/logger"
+	"github.com/mark3labs/mcp-go/client"
+	"github.com/mark3labs/mcp-go/client/transport"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLoopbackKeyPair writes a self-signed 127.0.0.1 certificate and key to dir
+// and returns their paths with a pool trusting the certificate.
+func writeLoopbackKeyPair(t *testing.T, dir string) (certFile, keyFile string, pool *x509.CertPool) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "server.crt")
+	keyFile = filepath.Join(dir, "server.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0600))
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool = x509.NewCertPool()
+	pool.AddCert(cert)
+	return certFile, keyFile, pool
+}
+
+func TestServeNetworkTransport(t *testing.T) {
+	mcpServer, err := NewServerBuilder().
+		WithConfig(&Config{}).
+		WithVersion("1.0.0").
+		WithDefaultTools().
+		WithResourceTemplates(createResourceTemplates()...).
+		Build()
+	require.NoError(t, err)
+
+	certFile, keyFile, pool := writeLoopbackKeyPair(t, t.TempDir())
+	httpsClient := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+	auth := map[string]string{"Authorization": "Bearer s3cret"}
+	authenticator, identities, err := newAuthenticator(AuthConfig{}, "s3cret", "1.0.0", 5*time.Second)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		opts      TransportOptions
+		scheme    string
+		path      string
+		newClient func(url string) (*client.Client, error)
+	}{
+		{
+			name:   "Streamable HTTP Over TLS",
+			opts:   TransportOptions{Transport: TransportHTTP, TLSCertFile: certFile, TLSKeyFile: keyFile, Authenticator: authenticator, Identities: identities},
+			scheme: "https",
+			path:   "/mcp",
+			newClient: func(url string) (*client.Client, error) {
+				return client.NewStreamableHttpClient(url, transport.WithHTTPHeaders(auth), transport.WithHTTPBasicClient(httpsClient))
+			},
+		},
+		{
+			name:   "SSE",
+			opts:   TransportOptions{Transport: TransportSSE, Authenticator: authenticator, Identities: identities},
+			scheme: "http",
+			path:   "/sse",
+			newClient: func(url string) (*client.Client, error) {
+				return client.NewSSEMCPClient(url, transport.WithHeaders(auth))
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.opts.Listen = closedLoopbackAddr(t)
+			url := tt.scheme + "://" + tt.opts.Listen + tt.path
+
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error, 1)
+			go func() {
+				done <- serveNetworkTransport(ctx, mcpServer, tt.opts, logger.NewMCPLogger(io.Discard, true))
+			}()
+
+			// Requests without the bearer token are rejected
+			require.Eventually(t, func() bool {
+				resp, err := httpsClient.Post(url, "application/json", strings.NewReader("{}"))
+				if err != nil {
+					return false
+				}
+				resp.Body.Close()
+				return resp.StatusCode == http.StatusUnauthorized && resp.Header.Get("WWW-Authenticate") != ""
+			}, 5*time.Second, 20*time.Millisecond)
+
+			c, err := tt.newClient(url)
+			require.NoError(t, err)
+			clientCtx, clientCancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer clientCancel()
+			require.NoError(t, c.Start(clientCtx))
+
+			initRequest := mcp.InitializeRequest{}
+			initRequest.Params.ProtocolVersion = mcp.LATEST_PROTOCOL_VERSION
+			initRequest.Params.ClientInfo = mcp.Implementation{Name: "transport-test", Version: "1.0.0"}
+			_, err = c.Initialize(clientCtx, initRequest)
+			require.NoError(t, err)
+
+			tools, err := c.ListTools(clientCtx, mcp.ListToolsRequest{})
+			require.NoError(t, err)
+			assert.NotEmpty(t, tools.Tools)
+
+			// Methods mcp-go does not route are answered in front of it on both transports
+			subscribe := mcp.SubscribeRequest{}
+			subscribe.Params.URI = chainResourcePrefix + strings.Repeat("ab", 32)
+			require.NoError(t, c.Subscribe(clientCtx, subscribe))
+			complete := mcp.CompleteRequest{}
+			complete.Params.Ref = mcp.ResourceReference{Type: "ref/resource", URI: "cert://sha256/{+fingerprint}{?format}"}
+			complete.Params.Argument.Name = "format"
+			complete.Params.Argument.Value = "d"
+			completion, err := c.Complete(clientCtx, complete)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"der"}, completion.Completion.Values)
+
+			// Shutdown also closes the open SSE stream
+			cancel()
+			select {
+			case err := <-done:
+				assert.NoError(t, err)
+			case <-time.After(transportShutdownTimeout + 5*time.Second):
+				t.Fatal("serveNetworkTransport did not shut down")
+			}
+			c.Close()
+		})
+	}
+
+	t.Run("Listen Failure", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		err = serveNetworkTransport(context.Background(), mcpServer, TransportOptions{Transport: TransportHTTP, Listen: listener.Addr().String()}, logger.NewMCPLogger(io.Discard, true))
+		assert.ErrorContains(t, err, "failed to listen")
+	})
+}
+
+func TestTransportOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    TransportOptions
+		wantErr string
+	}{
+		{"Stdio", TransportOptions{Transport: TransportStdio}, ""},
+		{"HTTP With TLS", TransportOptions{Transport: TransportHTTP, TLSCertFile: "c", TLSKeyFile: "k", AuthToken: "t"}, ""},
+		{"Unknown Transport", TransportOptions{Transport: "websocket"}, "unsupported transport"},
+		{"Certificate Without Key", TransportOptions{Transport: TransportSSE, TLSCertFile: "c"}, "both --tls-cert and --tls-key"},
+		{"Token With Stdio", TransportOptions{Transport: TransportStdio, AuthToken: "t"}, "require --transport sse or http"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestResolveTransportOptions(t *testing.T) {
+	t.Setenv(authTokenEnv, "from-env")
+
+	cf := &CLIFramework{transport: TransportOptions{Transport: TransportHTTP}}
+	opts, err := cf.resolveTransportOptions()
+	require.NoError(t, err)
+	assert.Equal(t, "from-env", opts.AuthToken)
+	assert.Equal(t, DefaultTransportListen, opts.Listen)
+
+	// The environment token is not applied to stdio, where it would be rejected
+	cf = &CLIFramework{}
+	opts, err = cf.resolveTransportOptions()
+	require.NoError(t, err)
+	assert.Equal(t, TransportStdio, opts.Transport)
+	assert.Empty(t, opts.AuthToken)
+}
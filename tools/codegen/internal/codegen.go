@@ -14,6 +14,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,6 +22,7 @@ import (
 	"time"
 
 	"github.com/xeipuuv/gojsonschema"
+	"github.com/yosida95/uritemplate/v3"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -33,6 +35,8 @@ import (
 type Config struct {
 	// Resources contains definitions for MCP server resources to generate
 	Resources []ResourceDefinition `json:"resources"`
+	// ResourceTemplates contains definitions for MCP server resource templates to generate
+	ResourceTemplates []ResourceTemplateDefinition `json:"resourceTemplates"`
 	// Tools contains definitions for MCP server tools to generate
 	Tools []ToolDefinition `json:"tools"`
 	// Prompts contains definitions for MCP server prompts to generate
@@ -64,6 +68,69 @@ type ResourceDefinition struct {
 	Meta map[string]any `json:"meta,omitempty"`
 }
 
+// ResourceTemplateDefinition represents a resource template to be generated.
+//
+// It defines a family of MCP server resources addressed by an [RFC 6570] URI
+// template, with its metadata and the handler that serves every matching URI.
+//
+// [RFC 6570]: https://www.rfc-editor.org/rfc/rfc6570
+type ResourceTemplateDefinition struct {
+	// URITemplate is the URI template matched by the resources (e.g., "cert://sha256/{fingerprint}")
+	URITemplate string `json:"uriTemplate"`
+	// Name is the human-readable name of the resource family
+	Name string `json:"name"`
+	// Description describes what the matching resources provide
+	Description string `json:"description"`
+	// MIMEType specifies the content type shared by all matching resources, if any
+	MIMEType string `json:"mimeType,omitempty"`
+	// Handler specifies the Go function that handles reads of matching resources
+	Handler string `json:"handler"`
+	// Audience specifies which MCP roles can access the matching resources
+	Audience []string `json:"audience,omitempty"`
+	// Priority sets the resource priority for MCP ordering (0.0-10.0)
+	Priority *float64 `json:"priority,omitempty"`
+	// Meta contains additional metadata for the resource template
+	Meta map[string]any `json:"meta,omitempty"`
+	// Completions maps template variable names to how their values are completed
+	Completions map[string]CompletionDefinition `json:"completions,omitempty"`
+}
+
+// CompletionDefinition describes how values of a prompt argument or
+// resource template variable are completed by completion/complete.
+//
+// Exactly one of Source and Values is set.
+type CompletionDefinition struct {
+	// Source names a dynamic source of values: path, hostname, certificate, or chain
+	Source string `json:"source,omitempty"`
+	// Values lists the fixed values offered for the argument
+	Values []string `json:"values,omitempty"`
+}
+
+// GoLiteral returns the ArgumentCompletion composite literal, without its
+// type name, that the generated code uses for the completion.
+//
+// Returns:
+//   - string: Go literal such as {Source: CompletionSourcePath}
+func (c CompletionDefinition) GoLiteral() string {
+	if c.Source != "" {
+		return fmt.Sprintf("{Source: %s}", completionSources[c.Source])
+	}
+	values := make([]string, len(c.Values))
+	for i, value := range c.Values {
+		values[i] = strconv.Quote(value)
+	}
+	return fmt.Sprintf("{Values: []string{%s}}", strings.Join(values, ", "))
+}
+
+// completionSources maps each completion source accepted in configuration
+// to the CompletionSource constant generated for it.
+var completionSources = map[string]string{
+	"path":        "CompletionSourcePath",
+	"hostname":    "CompletionSourceHostname",
+	"certificate": "CompletionSourceCertificate",
+	"chain":       "CompletionSourceChain",
+}
+
 // ToolDefinition represents a tool to be generated.
 //
 // It defines an MCP server tool with its name, parameters, handler,
@@ -89,6 +156,8 @@ type ToolDefinition struct {
 	WithConfig bool `json:"withConfig"`
 	// Params defines the tool's input parameters
 	Params []ToolParam `json:"params"`
+	// OutputSchema is the JSON Schema of the tool's structured content; it must describe an object
+	OutputSchema map[string]any `json:"outputSchema,omitempty"`
 	// MCP annotations for LLM hints
 	// TitleAnnotation provides a descriptive title for the tool
 	TitleAnnotation string `json:"titleAnnotation,omitempty"`
@@ -161,6 +230,16 @@ type PromptDefinition struct {
 	Meta map[string]any `json:"meta,omitempty"`
 }
 
+// HasCompletions reports whether any argument of the prompt declares a completion.
+func (p PromptDefinition) HasCompletions() bool {
+	for _, arg := range p.Arguments {
+		if arg.Completion != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // PromptArgument represents an argument for a prompt.
 //
 // It defines a single input argument for an MCP prompt with
@@ -172,6 +251,8 @@ type PromptArgument struct {
 	Description string `json:"description"`
 	// Required indicates if the argument must be provided
 	Required bool `json:"required,omitempty"`
+	// Completion describes how values of the argument are completed, if at all
+	Completion *CompletionDefinition `json:"completion,omitempty"`
 }
 
 // getCodegenDir returns the path to the codegen directory.
@@ -224,12 +305,14 @@ func loadConfig() (*Config, error) {
 
 	// Load resources
 	var resourcesWrapper struct {
-		Resources []ResourceDefinition `json:"resources"`
+		Resources         []ResourceDefinition         `json:"resources"`
+		ResourceTemplates []ResourceTemplateDefinition `json:"resourceTemplates"`
 	}
 	if err := loadJSON("resources.json", &resourcesWrapper); err != nil {
 		return nil, err
 	}
 	config.Resources = resourcesWrapper.Resources
+	config.ResourceTemplates = resourcesWrapper.ResourceTemplates
 
 	// Load tools
 	var toolsWrapper struct {
@@ -341,6 +424,9 @@ func validateConfig(config *Config) error {
 	if err := validateResources(config.Resources); err != nil {
 		return err
 	}
+	if err := validateResourceTemplates(config.ResourceTemplates); err != nil {
+		return err
+	}
 	if err := validateTools(config.Tools); err != nil {
 		return err
 	}
@@ -397,6 +483,69 @@ func validateResources(resources []ResourceDefinition) error {
 	return nil
 }
 
+// validateResourceTemplates validates resource template definitions.
+//
+// It checks each template for required fields (URITemplate, Name, Handler),
+// ensures the URI template parses and is unique, validates audience roles,
+// checks priority values are within valid range (0.0-10.0), and validates
+// completions of the template's variables.
+//
+// Parameters:
+//   - templates: Slice of resource template definitions to validate
+//
+// Returns:
+//   - error: Error if any resource template validation fails
+func validateResourceTemplates(templates []ResourceTemplateDefinition) error {
+	uriTemplates := make(map[string]bool)
+	validRoles := map[string]bool{
+		"user":      true,
+		"assistant": true,
+	}
+	for i, tmpl := range templates {
+		if tmpl.URITemplate == "" {
+			return fmt.Errorf("resource template %d: URITemplate is required", i)
+		}
+		if tmpl.Name == "" {
+			return fmt.Errorf("resource template %d: Name is required", i)
+		}
+		if tmpl.Handler == "" {
+			return fmt.Errorf("resource template %d: Handler is required", i)
+		}
+		parsed, err := uritemplate.New(tmpl.URITemplate)
+		if err != nil {
+			return fmt.Errorf("resource template %d: invalid URI template '%s': %w", i, tmpl.URITemplate, err)
+		}
+		if uriTemplates[tmpl.URITemplate] {
+			return fmt.Errorf("resource template %d: duplicate URI template '%s'", i, tmpl.URITemplate)
+		}
+		uriTemplates[tmpl.URITemplate] = true
+
+		// Validate audience roles
+		for _, role := range tmpl.Audience {
+			if !validRoles[role] {
+				return fmt.Errorf("resource template %d: invalid audience role '%s', must be one of: user, assistant", i, role)
+			}
+		}
+
+		// Validate priority
+		if tmpl.Priority != nil && (*tmpl.Priority < 0.0 || *tmpl.Priority > 10.0) {
+			return fmt.Errorf("resource template %d: priority must be between 0.0 and 10.0, got %f", i, *tmpl.Priority)
+		}
+
+		// Validate completions against the template's variables
+		varnames := parsed.Varnames()
+		for name, completion := range tmpl.Completions {
+			if !slices.Contains(varnames, name) {
+				return fmt.Errorf("resource template %d: completion for unknown variable '%s'", i, name)
+			}
+			if err := validateCompletion(completion); err != nil {
+				return fmt.Errorf("resource template %d variable '%s': %w", i, name, err)
+			}
+		}
+	}
+	return nil
+}
+
 // validateTools validates tool definitions.
 //
 // It iterates through all tools and validates each one individually,
@@ -453,9 +602,34 @@ func validateTool(tool *ToolDefinition, index int, toolNames, roleNames map[stri
 	toolNames[tool.Name] = true
 	roleNames[tool.RoleName] = true
 
+	if err := validateOutputSchema(tool.OutputSchema, index); err != nil {
+		return err
+	}
 	return validateToolParams(tool.Params, index)
 }
 
+// validateOutputSchema validates a tool output schema.
+//
+// The MCP specification requires structured content to be a JSON object,
+// so a declared output schema must have type "object". Tools without an
+// output schema are valid and return unstructured content only.
+//
+// Parameters:
+//   - schema: Output schema to validate, or nil when the tool declares none
+//   - toolIndex: Index of the parent tool (for error messages)
+//
+// Returns:
+//   - error: Error if the schema does not describe an object
+func validateOutputSchema(schema map[string]any, toolIndex int) error {
+	if schema == nil {
+		return nil
+	}
+	if schema["type"] != "object" {
+		return fmt.Errorf("tool %d: outputSchema type must be 'object', got '%v'", toolIndex, schema["type"])
+	}
+	return nil
+}
+
 // validateToolParams validates tool parameters.
 //
 // It checks each parameter for required fields (Name, Type),
@@ -725,7 +899,7 @@ func validatePrompts(prompts []PromptDefinition) error {
 // validatePromptArguments validates prompt arguments.
 //
 // It checks each argument for required fields (Name) and ensures
-// argument name uniqueness within the prompt.
+// argument name uniqueness within the prompt, validating completions when present.
 //
 // Parameters:
 //   - args: Slice of prompt arguments to validate
@@ -743,14 +917,47 @@ func validatePromptArguments(args []PromptArgument, promptIndex int) error {
 			return fmt.Errorf("prompt %d arg %d: duplicate argument name '%s'", promptIndex, j, arg.Name)
 		}
 		argNames[arg.Name] = true
+		if arg.Completion != nil {
+			if err := validateCompletion(*arg.Completion); err != nil {
+				return fmt.Errorf("prompt %d arg %d: %w", promptIndex, j, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateCompletion validates a completion definition.
+//
+// It checks that exactly one of Source and Values is set, that Source
+// names a known completion source, and that Values has no empty entries.
+//
+// Parameters:
+//   - completion: Completion definition to validate
+//
+// Returns:
+//   - error: Error if the completion definition is invalid
+func validateCompletion(completion CompletionDefinition) error {
+	if (completion.Source == "") == (len(completion.Values) == 0) {
+		return fmt.Errorf("completion must set exactly one of source and values")
+	}
+	if completion.Source != "" {
+		if _, ok := completionSources[completion.Source]; !ok {
+			return fmt.Errorf("invalid completion source '%s', must be one of: path, hostname, certificate, chain", completion.Source)
+		}
+	}
+	for _, value := range completion.Values {
+		if value == "" {
+			return fmt.Errorf("completion values must not be empty")
+		}
 	}
 	return nil
 }
 
 // GenerateResources generates the resources.go file for the MCP server.
 //
-// It loads the configuration, processes resource definitions, and generates
-// Go code that implements MCP server resources with their handlers.
+// It loads the configuration, processes resource and resource template
+// definitions, and generates Go code that implements MCP server resources
+// with their handlers.
 //
 // Returns:
 //   - error: Error if configuration loading or file generation fails
@@ -343,6 +343,153 @@ func TestValidateResources(t *testing.T) {
 	}
 }
 
+func TestValidateResourceTemplates(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates []ResourceTemplateDefinition
+		wantErr   bool
+		errMsg    string
+	}{
+		{
+			name: "valid template with annotations",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "cert://sha256/{fingerprint}{?format}",
+					Name:        "Certificate",
+					Description: "Certificate by fingerprint",
+					MIMEType:    "application/x-pem-file",
+					Handler:     "handleCertificate",
+					Audience:    []string{"assistant"},
+					Priority:    &[]float64{0.5}[0],
+					Completions: map[string]CompletionDefinition{
+						"fingerprint": {Source: "certificate"},
+						"format":      {Values: []string{"pem", "der"}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing URI template",
+			templates: []ResourceTemplateDefinition{
+				{
+					Name:    "Test",
+					Handler: "handler",
+				},
+			},
+			wantErr: true,
+			errMsg:  "URITemplate is required",
+		},
+		{
+			name: "missing handler",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test",
+				},
+			},
+			wantErr: true,
+			errMsg:  "Handler is required",
+		},
+		{
+			name: "malformed URI template",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id",
+					Name:        "Test",
+					Handler:     "handler",
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid URI template",
+		},
+		{
+			name: "duplicate URI template",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test1",
+					Handler:     "handler1",
+				},
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test2",
+					Handler:     "handler2",
+				},
+			},
+			wantErr: true,
+			errMsg:  "duplicate URI template",
+		},
+		{
+			name: "invalid audience role",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test",
+					Handler:     "handler",
+					Audience:    []string{"invalid_role"},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid audience role",
+		},
+		{
+			name: "completion for unknown variable",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test",
+					Handler:     "handler",
+					Completions: map[string]CompletionDefinition{"name": {Values: []string{"a"}}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "completion for unknown variable 'name'",
+		},
+		{
+			name: "invalid variable completion",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}{?format}",
+					Name:        "Test",
+					Handler:     "handler",
+					Completions: map[string]CompletionDefinition{"format": {Source: "registry"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "invalid completion source",
+		},
+		{
+			name: "invalid priority range",
+			templates: []ResourceTemplateDefinition{
+				{
+					URITemplate: "test://{id}",
+					Name:        "Test",
+					Handler:     "handler",
+					Priority:    &[]float64{11.0}[0],
+				},
+			},
+			wantErr: true,
+			errMsg:  "priority must be between 0.0 and 10.0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateResourceTemplates(tt.templates)
+			if tt.wantErr {
+				assert.Error(t, err, "validateResourceTemplates() should return error")
+				if tt.errMsg != "" {
+					assert.Contains(t, err.Error(), tt.errMsg,
+						"validateResourceTemplates() error should contain expected message")
+				}
+			} else {
+				assert.NoError(t, err, "validateResourceTemplates() should not return error")
+			}
+		})
+	}
+}
+
 func TestValidatePrompts(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -714,6 +861,18 @@ func TestValidateTool(t *testing.T) {
 			tool:    ToolDefinition{Name: "test", ConstName: "Test", Handler: "handler", RoleName: "role", RoleComment: "comment", WithConfig: false},
 			wantErr: true,
 		},
+		{
+			name: "object output schema",
+			tool: ToolDefinition{Name: "test", ConstName: "Test", Handler: "handler", RoleConst: "Role", RoleName: "role",
+				OutputSchema: map[string]any{"type": "object", "properties": map[string]any{"total": map[string]any{"type": "integer"}}}},
+			wantErr: false,
+		},
+		{
+			name: "non-object output schema",
+			tool: ToolDefinition{Name: "test", ConstName: "Test", Handler: "handler", RoleConst: "Role", RoleName: "role",
+				OutputSchema: map[string]any{"type": "array"}},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -757,6 +916,42 @@ func TestValidatePromptArguments(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid completions",
+			arguments: []PromptArgument{
+				{Name: "path", Description: "Path", Completion: &CompletionDefinition{Source: "path"}},
+				{Name: "kind", Description: "Kind", Completion: &CompletionDefinition{Values: []string{"a", "b"}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unknown completion source",
+			arguments: []PromptArgument{
+				{Name: "arg", Description: "Arg", Completion: &CompletionDefinition{Source: "registry"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "completion with both source and values",
+			arguments: []PromptArgument{
+				{Name: "arg", Description: "Arg", Completion: &CompletionDefinition{Source: "path", Values: []string{"a"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty completion",
+			arguments: []PromptArgument{
+				{Name: "arg", Description: "Arg", Completion: &CompletionDefinition{}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "empty completion value",
+			arguments: []PromptArgument{
+				{Name: "arg", Description: "Arg", Completion: &CompletionDefinition{Values: []string{"a", ""}}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -771,6 +966,11 @@ func TestValidatePromptArguments(t *testing.T) {
 	}
 }
 
+func TestCompletionGoLiteral(t *testing.T) {
+	assert.Equal(t, "{Source: CompletionSourceHostname}", CompletionDefinition{Source: "hostname"}.GoLiteral())
+	assert.Equal(t, `{Values: []string{"chain", "say \"hi\""}}`, CompletionDefinition{Values: []string{"chain", `say "hi"`}}.GoLiteral())
+}
+
 func TestFormatGoValue(t *testing.T) {
 	tests := []struct {
 		name     string